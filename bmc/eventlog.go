@@ -0,0 +1,74 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+)
+
+type eventLogGetterProvider struct {
+	name string
+	devices.EventLogReader
+}
+
+// eventLog returns the device event log entries of the first provider answering,
+// the providers are read through the devices.EventLogReader interface
+func eventLog(ctx context.Context, generic []eventLogGetterProvider) (entries []devices.EventLogEntry, metadata Metadata, err error) {
+	var metadataLocal Metadata
+Loop:
+	for _, elem := range generic {
+		if elem.EventLogReader == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			err = multierror.Append(err, ctx.Err())
+			break Loop
+		default:
+			metadataLocal.ProvidersAttempted = append(metadataLocal.ProvidersAttempted, elem.name)
+			entries, vErr := elem.GetEventLog()
+			if vErr != nil {
+				err = multierror.Append(err, errors.WithMessagef(vErr, "provider: %v", elem.name))
+				err = multierror.Append(err, vErr)
+				continue
+
+			}
+			metadataLocal.SuccessfulProvider = elem.name
+			return entries, metadataLocal, nil
+		}
+	}
+
+	return entries, metadataLocal, multierror.Append(err, errors.New("failure to get device event log"))
+}
+
+// GetEventLogFromInterfaces is a pass through to library function
+func GetEventLogFromInterfaces(ctx context.Context, generic []interface{}) (entries []devices.EventLogEntry, metadata Metadata, err error) {
+	implementations := make([]eventLogGetterProvider, 0)
+	for _, elem := range generic {
+		temp := eventLogGetterProvider{name: getProviderName(elem)}
+		switch p := elem.(type) {
+		case devices.EventLogReader:
+			temp.EventLogReader = p
+			implementations = append(implementations, temp)
+		default:
+			e := fmt.Sprintf("not an EventLogReader implementation: %T", p)
+			err = multierror.Append(err, errors.New(e))
+		}
+	}
+	if len(implementations) == 0 {
+		return entries, metadata, multierror.Append(
+			err,
+			errors.Wrap(
+				bmclibErrs.ErrProviderImplementation,
+				("no EventLogReader implementations found"),
+			),
+		)
+	}
+
+	return eventLog(ctx, implementations)
+}
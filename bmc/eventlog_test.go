@@ -0,0 +1,105 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type eventLogGetterTester struct {
+	returnEntries []devices.EventLogEntry
+	returnError   error
+}
+
+func (f *eventLogGetterTester) GetEventLog() (entries []devices.EventLogEntry, err error) {
+	return f.returnEntries, f.returnError
+}
+
+func (f *eventLogGetterTester) ClearEventLog() (err error) {
+	return f.returnError
+}
+
+func (f *eventLogGetterTester) Name() string {
+	return "foo"
+}
+
+func TestEventLog(t *testing.T) {
+	entries := []devices.EventLogEntry{{ID: "1", Severity: devices.SeverityCritical, Message: "Power Supply AC lost"}}
+
+	testCases := []struct {
+		testName           string
+		returnEntries      []devices.EventLogEntry
+		returnError        error
+		ctxTimeout         time.Duration
+		providerName       string
+		providersAttempted int
+	}{
+		{"success with metadata", entries, nil, 5 * time.Second, "foo", 1},
+		{"failure with metadata", nil, bmclibErrs.ErrNon200Response, 5 * time.Second, "foo", 1},
+		{"failure with context timeout", nil, context.DeadlineExceeded, 1 * time.Nanosecond, "foo", 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			testImplementation := eventLogGetterTester{returnEntries: tc.returnEntries, returnError: tc.returnError}
+			ctx, cancel := context.WithTimeout(context.Background(), tc.ctxTimeout)
+			defer cancel()
+			got, metadata, err := eventLog(ctx, []eventLogGetterProvider{{tc.providerName, &testImplementation}})
+			if tc.returnError != nil {
+				assert.ErrorIs(t, err, tc.returnError)
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.returnEntries, got)
+			assert.Equal(t, tc.providerName, metadata.SuccessfulProvider)
+			assert.Equal(t, tc.providersAttempted, len(metadata.ProvidersAttempted))
+		})
+	}
+}
+
+func TestEventLogFromInterfaces(t *testing.T) {
+	entries := []devices.EventLogEntry{{ID: "1", Severity: devices.SeverityOK, Message: "Log area reset/cleared"}}
+
+	testCases := []struct {
+		testName          string
+		returnEntries     []devices.EventLogEntry
+		returnError       error
+		providerName      string
+		badImplementation bool
+	}{
+		{"success with metadata", entries, nil, "foo", false},
+		{"failure with bad implementation", nil, bmclibErrs.ErrProviderImplementation, "foo", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			var generic []interface{}
+			if tc.badImplementation {
+				badImplementation := struct{}{}
+				generic = []interface{}{&badImplementation}
+			} else {
+				testImplementation := &eventLogGetterTester{returnEntries: tc.returnEntries, returnError: tc.returnError}
+				generic = []interface{}{testImplementation}
+			}
+			got, metadata, err := GetEventLogFromInterfaces(context.Background(), generic)
+			if tc.returnError != nil {
+				assert.ErrorIs(t, err, tc.returnError)
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tc.returnEntries, got)
+			assert.Equal(t, tc.providerName, metadata.SuccessfulProvider)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package bmclib
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+)
+
+// SnapshotStatus is the outcome of the snapshot of a host taken by BulkSnapshot
+type SnapshotStatus string
+
+const (
+	// SnapshotSuccess is a snapshot collected without errors
+	SnapshotSuccess SnapshotStatus = "success"
+	// SnapshotPartial is a snapshot returned along with the errors of the fields it is missing
+	SnapshotPartial SnapshotStatus = "partial"
+	// SnapshotFailed is a host no snapshot could be collected from
+	SnapshotFailed SnapshotStatus = "failed"
+)
+
+// BulkOptions tunes BulkSnapshot
+type BulkOptions struct {
+	// Concurrency is the number of hosts snapshotted at once, 1 when unset
+	Concurrency int
+	// Timeout bounds the snapshot of each host, a host running over it is reported as failed
+	Timeout time.Duration
+}
+
+// BulkResult is the snapshot of a host taken by BulkSnapshot
+type BulkResult struct {
+	Host   string
+	Status SnapshotStatus
+	// Snapshot is the device returned by ServerSnapshot, it misses the fields in Errors when partial
+	Snapshot interface{}
+	// Errors holds the field failures of a partial snapshot or the error of a failed one
+	Errors    *bmclibErrs.MultiError
+	StartedAt time.Time
+	Duration  time.Duration
+}
+
+// BulkSummary counts the outcomes of a BulkSnapshot
+type BulkSummary struct {
+	Success  int
+	Partial  int
+	Failed   int
+	Duration time.Duration
+}
+
+// BulkSnapshot snapshots the targets like ScanHosts and returns a BulkResult per target, in order,
+// with a summary of the outcomes. A provider returns a partial snapshot by implementing
+// devices.PartialSnapshotter, or by returning the device along with an *errors.MultiError of its field
+// failures. The targets left when ctx is done are reported as failed with the context error, which is also returned.
+func BulkSnapshot(ctx context.Context, targets []Target, opts BulkOptions) ([]BulkResult, BulkSummary, error) {
+	start := time.Now()
+
+	results := make([]BulkResult, len(targets))
+	err := forEachHost(ctx, len(targets), opts.Concurrency,
+		func(i int) {
+			results[i] = bulkSnapshot(ctx, targets[i], opts.Timeout)
+		},
+		func(i int) {
+			results[i] = newBulkResult(Result{Host: targets[i].Host, Err: ctx.Err()}, time.Now())
+		},
+	)
+
+	summary := BulkSummary{Duration: time.Since(start)}
+	for _, result := range results {
+		switch result.Status {
+		case SnapshotSuccess:
+			summary.Success++
+		case SnapshotPartial:
+			summary.Partial++
+		default:
+			summary.Failed++
+		}
+	}
+
+	return results, summary, err
+}
+
+// bulkSnapshot takes the snapshot of the target, giving up after timeout when set. The worker
+// keeps its slot until a snapshot abandoned on the timeout exits, so the slow hosts don't push the
+// number of BMCs queried at once past the concurrency, unless ctx itself is done.
+func bulkSnapshot(ctx context.Context, target Target, timeout time.Duration) BulkResult {
+	hostCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		hostCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	startedAt := time.Now()
+	result, exited := scanHost(hostCtx, target)
+	bulk := newBulkResult(result, startedAt)
+
+	select {
+	case <-exited:
+	case <-ctx.Done():
+	}
+
+	return bulk
+}
+
+// newBulkResult classifies the snapshot of a host, a snapshot returned with
+// an *errors.MultiError is partial and any other error fails the host.
+func newBulkResult(result Result, startedAt time.Time) BulkResult {
+	bulk := BulkResult{
+		Host:      result.Host,
+		Status:    SnapshotSuccess,
+		Snapshot:  result.Snapshot,
+		StartedAt: startedAt,
+		Duration:  time.Since(startedAt),
+	}
+
+	if result.Err == nil {
+		return bulk
+	}
+
+	bulk.Errors = &bmclibErrs.MultiError{}
+
+	var fieldErrs *bmclibErrs.MultiError
+	if result.Snapshot != nil && errors.As(result.Err, &fieldErrs) {
+		bulk.Status = SnapshotPartial
+		bulk.Errors.Errors = append(bulk.Errors.Errors, fieldErrs.Errors...)
+		return bulk
+	}
+
+	bulk.Status = SnapshotFailed
+	bulk.Snapshot = nil
+	bulk.Errors.Append(result.Err)
+
+	return bulk
+}
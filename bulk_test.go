@@ -0,0 +1,129 @@
+package bmclib
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// partialTester returns a snapshot along with the failures of the fields it could not read
+type partialTester struct {
+	serial string
+	errs   []error
+}
+
+func (p *partialTester) ServerSnapshot() (interface{}, error) {
+	fieldErrs := &bmclibErrs.MultiError{}
+	for _, err := range p.errs {
+		fieldErrs.Append(err)
+	}
+
+	return &devices.Discrete{Serial: p.serial}, fieldErrs.ErrorOrNil()
+}
+
+func TestBulkSnapshot(t *testing.T) {
+	errUnreachable := errors.New("unreachable")
+	errPsus := errors.New("psus")
+	errDisks := errors.New("disks")
+	var running, peak int32
+
+	targets := []Target{
+		{Host: "ok", Provider: &snapshotTester{serial: "ok", delay: 50 * time.Millisecond, running: &running, peak: &peak}},
+		{Host: "partial", Provider: &partialTester{serial: "partial", errs: []error{errPsus, errDisks}}},
+		{Host: "failing", Provider: &snapshotTester{err: errUnreachable, running: &running, peak: &peak}},
+		{Host: "unsupported", Provider: struct{}{}},
+		{Host: "clean", Provider: &partialTester{serial: "clean"}},
+	}
+
+	results, summary, err := BulkSnapshot(context.Background(), targets, BulkOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+	assert.Equal(t, len(targets), len(results))
+	for i, result := range results {
+		assert.Equal(t, targets[i].Host, result.Host)
+		assert.False(t, result.StartedAt.IsZero())
+	}
+
+	assert.Equal(t, SnapshotSuccess, results[0].Status)
+	assert.Equal(t, &devices.Discrete{Serial: "ok"}, results[0].Snapshot)
+	assert.Nil(t, results[0].Errors)
+	assert.GreaterOrEqual(t, int64(results[0].Duration), int64(50*time.Millisecond))
+
+	assert.Equal(t, SnapshotPartial, results[1].Status)
+	assert.Equal(t, &devices.Discrete{Serial: "partial"}, results[1].Snapshot)
+	assert.Equal(t, []error{errPsus, errDisks}, results[1].Errors.Errors)
+
+	assert.Equal(t, SnapshotFailed, results[2].Status)
+	assert.Nil(t, results[2].Snapshot)
+	assert.ErrorIs(t, results[2].Errors, errUnreachable)
+
+	assert.Equal(t, SnapshotFailed, results[3].Status)
+	assert.ErrorIs(t, results[3].Errors, bmclibErrs.ErrProviderImplementation)
+
+	assert.Equal(t, SnapshotSuccess, results[4].Status)
+	assert.Nil(t, results[4].Errors)
+
+	assert.Equal(t, 2, summary.Success)
+	assert.Equal(t, 1, summary.Partial)
+	assert.Equal(t, 2, summary.Failed)
+	assert.GreaterOrEqual(t, int64(summary.Duration), int64(results[0].Duration))
+}
+
+func TestBulkSnapshotTimeout(t *testing.T) {
+	var running, peak int32
+	targets := []Target{
+		{Host: "slow", Provider: &snapshotTester{serial: "slow", delay: 300 * time.Millisecond, running: &running, peak: &peak}},
+		{Host: "fast", Provider: &snapshotTester{serial: "fast", running: &running, peak: &peak}},
+	}
+
+	start := time.Now()
+	results, summary, err := BulkSnapshot(context.Background(), targets, BulkOptions{Concurrency: 1, Timeout: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the abandoned snapshot holds the only worker until it exits, the BMCs are never queried two at once
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(300*time.Millisecond))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&peak))
+
+	// the timeout only fails the slow host, the next one gets a fresh deadline
+	assert.Equal(t, SnapshotFailed, results[0].Status)
+	assert.ErrorIs(t, results[0].Errors, context.DeadlineExceeded)
+	assert.Less(t, int64(results[0].Duration), int64(300*time.Millisecond))
+	assert.Equal(t, SnapshotSuccess, results[1].Status)
+	assert.Equal(t, BulkSummary{Success: 1, Failed: 1, Duration: summary.Duration}, summary)
+}
+
+func TestBulkSnapshotContextDone(t *testing.T) {
+	targets := []Target{
+		{Host: "fast", Provider: &snapshotTester{serial: "fast"}},
+		{Host: "slow", Provider: &snapshotTester{serial: "slow", delay: 5 * time.Second}},
+		{Host: "pending", Provider: &partialTester{serial: "pending"}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	results, summary, err := BulkSnapshot(ctx, targets, BulkOptions{Concurrency: 1})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+
+	assert.Equal(t, SnapshotSuccess, results[0].Status)
+	for _, result := range results[1:] {
+		assert.Equal(t, SnapshotFailed, result.Status)
+		assert.Nil(t, result.Snapshot)
+		assert.ErrorIs(t, result.Errors, context.DeadlineExceeded)
+	}
+	assert.Equal(t, 1, summary.Success)
+	assert.Equal(t, 2, summary.Failed)
+}
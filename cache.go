@@ -0,0 +1,99 @@
+package bmclib
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+// SnapshotCache wraps a provider to serve its last snapshot for a while
+// instead of querying the BMC on every call, it is safe for concurrent use.
+type SnapshotCache struct {
+	provider snapshotter
+	ttl      time.Duration
+	// now returns the current time, it is replaced by the tests
+	now func() time.Time
+
+	mu          sync.Mutex
+	snapshot    interface{}
+	collectedAt time.Time
+}
+
+// CachedSnapshotter returns a SnapshotCache serving the snapshot of provider
+// as long as it was collected less than ttl ago.
+func CachedSnapshotter(provider snapshotter, ttl time.Duration) *SnapshotCache {
+	return &SnapshotCache{provider: provider, ttl: ttl, now: time.Now}
+}
+
+// ServerSnapshot returns a copy of the cached snapshot when it is fresher than the ttl,
+// otherwise it takes a new snapshot. The concurrent callers wait for a single refresh.
+func (c *SnapshotCache) ServerSnapshot() (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.snapshot == nil || c.now().Sub(c.collectedAt) >= c.ttl {
+		if err := c.refresh(); err != nil {
+			return nil, err
+		}
+	}
+
+	return cloneSnapshot(c.snapshot), nil
+}
+
+// Refresh takes a new snapshot regardless of the age of the cached one and returns a copy of it
+func (c *SnapshotCache) Refresh() (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.refresh(); err != nil {
+		return nil, err
+	}
+
+	return cloneSnapshot(c.snapshot), nil
+}
+
+// refresh replaces the cached snapshot, keeping the previous one when the provider fails.
+// The age of the snapshot is its CollectedAt, or the time it was taken when it has none.
+func (c *SnapshotCache) refresh() error {
+	snapshot, err := c.provider.ServerSnapshot()
+	if err != nil {
+		return err
+	}
+
+	c.snapshot = snapshot
+	c.collectedAt = collectedAt(snapshot)
+	if c.collectedAt.IsZero() {
+		c.collectedAt = c.now()
+	}
+
+	return nil
+}
+
+// collectedAt returns the CollectedAt of the snapshot, or the zero time when it has none
+func collectedAt(snapshot interface{}) time.Time {
+	switch s := snapshot.(type) {
+	case *devices.Discrete:
+		return s.CollectedAt
+	case *devices.Blade:
+		return s.CollectedAt
+	case *devices.Chassis:
+		return s.CollectedAt
+	default:
+		return time.Time{}
+	}
+}
+
+// cloneSnapshot returns a deep copy of the snapshot, so the callers can't alter the cached one
+func cloneSnapshot(snapshot interface{}) interface{} {
+	switch s := snapshot.(type) {
+	case *devices.Discrete:
+		return s.Clone()
+	case *devices.Blade:
+		return s.Clone()
+	case *devices.Chassis:
+		return s.Clone()
+	default:
+		return snapshot
+	}
+}
@@ -0,0 +1,127 @@
+package bmclib
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingSnapshotter returns a new snapshot collected at the time of its clock on every call
+type countingSnapshotter struct {
+	calls int32
+	clock *time.Time
+	err   error
+}
+
+func (s *countingSnapshotter) ServerSnapshot() (interface{}, error) {
+	calls := atomic.AddInt32(&s.calls, 1)
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &devices.Discrete{
+		Serial:      "cached",
+		CollectedAt: *s.clock,
+		Nics:        []*devices.Nic{{Name: "eth0"}},
+		Memory:      int(calls),
+	}, nil
+}
+
+func newCache(ttl time.Duration) (*SnapshotCache, *countingSnapshotter, *time.Time) {
+	clock := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	provider := &countingSnapshotter{clock: &clock}
+	cache := CachedSnapshotter(provider, ttl)
+	cache.now = func() time.Time { return clock }
+
+	return cache, provider, &clock
+}
+
+func TestCachedSnapshotterHit(t *testing.T) {
+	cache, provider, clock := newCache(time.Minute)
+
+	first, err := cache.ServerSnapshot()
+	assert.Nil(t, err)
+
+	*clock = clock.Add(30 * time.Second)
+	second, err := cache.ServerSnapshot()
+	assert.Nil(t, err)
+
+	assert.Equal(t, int32(1), provider.calls)
+	assert.Equal(t, first, second)
+
+	// the callers get copies, altering one doesn't reach the cache
+	first.(*devices.Discrete).Nics[0].Name = "altered"
+	third, _ := cache.ServerSnapshot()
+	assert.Equal(t, "eth0", third.(*devices.Discrete).Nics[0].Name)
+}
+
+func TestCachedSnapshotterExpiry(t *testing.T) {
+	cache, provider, clock := newCache(time.Minute)
+
+	_, err := cache.ServerSnapshot()
+	assert.Nil(t, err)
+
+	*clock = clock.Add(time.Minute)
+	snapshot, err := cache.ServerSnapshot()
+	assert.Nil(t, err)
+
+	assert.Equal(t, int32(2), provider.calls)
+	assert.Equal(t, 2, snapshot.(*devices.Discrete).Memory)
+	assert.Equal(t, *clock, snapshot.(*devices.Discrete).CollectedAt)
+}
+
+func TestCachedSnapshotterRefresh(t *testing.T) {
+	cache, provider, _ := newCache(time.Hour)
+
+	_, err := cache.ServerSnapshot()
+	assert.Nil(t, err)
+
+	snapshot, err := cache.Refresh()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, snapshot.(*devices.Discrete).Memory)
+
+	snapshot, err = cache.ServerSnapshot()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, snapshot.(*devices.Discrete).Memory)
+	assert.Equal(t, int32(2), provider.calls)
+}
+
+func TestCachedSnapshotterMiss(t *testing.T) {
+	cache, provider, _ := newCache(time.Hour)
+	errUnreachable := errors.New("unreachable")
+	provider.err = errUnreachable
+
+	// a failed snapshot isn't cached, the next call tries again
+	_, err := cache.ServerSnapshot()
+	assert.ErrorIs(t, err, errUnreachable)
+	_, err = cache.ServerSnapshot()
+	assert.ErrorIs(t, err, errUnreachable)
+	assert.Equal(t, int32(2), provider.calls)
+
+	provider.err = nil
+	snapshot, err := cache.ServerSnapshot()
+	assert.Nil(t, err)
+	assert.Equal(t, "cached", snapshot.(*devices.Discrete).Serial)
+}
+
+func TestCachedSnapshotterConcurrent(t *testing.T) {
+	cache, provider, _ := newCache(time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := cache.ServerSnapshot()
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&provider.calls))
+}
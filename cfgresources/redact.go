@@ -0,0 +1,97 @@
+package cfgresources
+
+// RedactedValue replaces sensitive values in redacted configuration.
+const RedactedValue = "[REDACTED]"
+
+// redact returns RedactedValue for non empty secrets, so it's still visible a secret was set.
+func redact(secret string) string {
+	if secret == "" {
+		return secret
+	}
+
+	return RedactedValue
+}
+
+// Redacted returns a copy of the configuration with passwords and license keys blanked,
+// use it before logging or marshaling configuration for debugging.
+// Resources without secrets are shared with the original.
+func (r *ResourcesConfig) Redacted() *ResourcesConfig {
+	if r == nil {
+		return nil
+	}
+
+	redacted := *r
+	redacted.License = r.License.Redacted()
+	redacted.SetupChassis = r.SetupChassis.Redacted()
+
+	if r.User != nil {
+		redacted.User = make([]*User, len(r.User))
+		for idx, user := range r.User {
+			redacted.User[idx] = user.Redacted()
+		}
+	}
+
+	return &redacted
+}
+
+// Redacted returns a copy of the chassis setup with the blade BMC account passwords blanked.
+func (s *SetupChassis) Redacted() *SetupChassis {
+	if s == nil {
+		return nil
+	}
+
+	redacted := *s
+	redacted.AddBladeBmcAdmins = redactBladeBmcAccounts(s.AddBladeBmcAdmins)
+	redacted.RemoveBladeBmcUsers = redactBladeBmcAccounts(s.RemoveBladeBmcUsers)
+
+	return &redacted
+}
+
+func redactBladeBmcAccounts(accounts []*BladeBmcAccount) []*BladeBmcAccount {
+	if accounts == nil {
+		return nil
+	}
+
+	redacted := make([]*BladeBmcAccount, len(accounts))
+	for idx, account := range accounts {
+		redacted[idx] = account.Redacted()
+	}
+
+	return redacted
+}
+
+// Redacted returns a copy of the account with the password blanked.
+func (b *BladeBmcAccount) Redacted() *BladeBmcAccount {
+	if b == nil {
+		return nil
+	}
+
+	redacted := *b
+	redacted.Password = redact(b.Password)
+
+	return &redacted
+}
+
+// Redacted returns a copy of the user with the password blanked.
+func (u *User) Redacted() *User {
+	if u == nil {
+		return nil
+	}
+
+	redacted := *u
+	redacted.Password = redact(u.Password)
+
+	return &redacted
+}
+
+// Redacted returns a copy of the license with the key blanked.
+func (l *License) Redacted() *License {
+	if l == nil {
+		return nil
+	}
+
+	redacted := *l
+	redacted.Key = redact(l.Key)
+
+	return &redacted
+}
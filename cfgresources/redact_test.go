@@ -0,0 +1,44 @@
+package cfgresources
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestResourcesConfigRedacted(t *testing.T) {
+	secrets := []string{"userSecret", "otherUserSecret", "bladeAdminSecret", "bladeUserSecret", "3353M-XKMML-D7H3P-XV794-3DXMM"}
+
+	config := &ResourcesConfig{
+		User: []*User{
+			{Name: "Administrator", Password: "userSecret", Role: "admin"},
+			{Name: "operator", Password: "otherUserSecret", Role: "user"},
+		},
+		License: &License{Key: "3353M-XKMML-D7H3P-XV794-3DXMM"},
+		SetupChassis: &SetupChassis{
+			AddBladeBmcAdmins:   []*BladeBmcAccount{{Name: "admin", Password: "bladeAdminSecret"}},
+			RemoveBladeBmcUsers: []*BladeBmcAccount{{Name: "user", Password: "bladeUserSecret"}},
+		},
+		Syslog: &Syslog{Server: "10.0.0.1", Port: 514},
+	}
+
+	payload, err := json.Marshal(config.Redacted())
+	if err != nil {
+		t.Fatalf("Found errors marshaling the redacted config %v", err)
+	}
+
+	for _, secret := range secrets {
+		if strings.Contains(string(payload), secret) {
+			t.Errorf("Expected %s to be redacted: found %s", secret, payload)
+		}
+	}
+
+	if !strings.Contains(string(payload), "Administrator") || !strings.Contains(string(payload), "10.0.0.1") {
+		t.Errorf("Expected non sensitive values to be kept: found %s", payload)
+	}
+
+	if config.User[0].Password != "userSecret" || config.License.Key != "3353M-XKMML-D7H3P-XV794-3DXMM" ||
+		config.SetupChassis.AddBladeBmcAdmins[0].Password != "bladeAdminSecret" {
+		t.Errorf("Expected the original config to be left untouched: found %+v", config)
+	}
+}
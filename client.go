@@ -12,6 +12,7 @@ import (
 	"github.com/bmc-toolbox/bmclib/bmc"
 	"github.com/bmc-toolbox/bmclib/devices"
 	"github.com/bmc-toolbox/bmclib/internal/httpclient"
+	"github.com/bmc-toolbox/bmclib/providers"
 	"github.com/bmc-toolbox/bmclib/providers/asrockrack"
 	"github.com/bmc-toolbox/bmclib/providers/dell/idrac9"
 	"github.com/bmc-toolbox/bmclib/providers/ipmitool"
@@ -61,6 +62,45 @@ func WithSecureTLS(rootCAs *x509.CertPool) Option {
 	}
 }
 
+// WithInsecureTLS skips the verification of the BMC certificate, which is otherwise
+// verified against the system CAs. Use it for BMCs serving self-signed certificates.
+func WithInsecureTLS() Option {
+	return func(args *Client) {
+		args.httpClientSetupFuncs = append(args.httpClientSetupFuncs, httpclient.WithInsecureTLS())
+	}
+}
+
+// WithSecureTLSFromFile enforces trusted TLS connections using the CA certificates of a PEM file.
+// Requests fail with a loading error when the file is missing or holds no valid certificate.
+func WithSecureTLSFromFile(caCertFile string) Option {
+	return func(args *Client) {
+		args.httpClientSetupFuncs = append(args.httpClientSetupFuncs, httpclient.WithCACertFile(caCertFile))
+	}
+}
+
+// WithClientCert authenticates to the BMC with the given PEM encoded client certificate and key.
+func WithClientCert(certPEM, keyPEM []byte) Option {
+	return func(args *Client) {
+		args.httpClientSetupFuncs = append(args.httpClientSetupFuncs, httpclient.WithClientCert(certPEM, keyPEM))
+	}
+}
+
+// WithObserver calls observer after each HTTP round-trip made to the BMC,
+// to collect metrics or logs.
+func WithObserver(observer func(providers.RequestInfo)) Option {
+	return func(args *Client) {
+		args.httpClientSetupFuncs = append(args.httpClientSetupFuncs, httpclient.WithObserver(observer))
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the HTTP requests made to the BMC,
+// it defaults to bmclib/<version>.
+func WithUserAgent(ua string) Option {
+	return func(args *Client) {
+		args.httpClientSetupFuncs = append(args.httpClientSetupFuncs, httpclient.WithUserAgent(ua))
+	}
+}
+
 // WithHTTPClient sets an http client
 func WithHTTPClient(c *http.Client) Option {
 	return func(args *Client) {
@@ -257,3 +297,10 @@ func (c *Client) PostCode(ctx context.Context) (status string, code int, err err
 	c.setMetadata(metadata)
 	return status, code, err
 }
+
+// GetEventLog pass through library function to return the BMC event log (SEL)
+func (c *Client) GetEventLog(ctx context.Context) (entries []devices.EventLogEntry, err error) {
+	entries, metadata, err := bmc.GetEventLogFromInterfaces(ctx, c.Registry.GetDriverInterfaces())
+	c.setMetadata(metadata)
+	return entries, err
+}
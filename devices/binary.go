@@ -0,0 +1,118 @@
+package devices
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+)
+
+// Snapshot kinds stored in the binary encoding
+const (
+	snapshotKindBlade    = "blade"
+	snapshotKindDiscrete = "discrete"
+	snapshotKindChassis  = "chassis"
+)
+
+// binarySnapshot is the envelope the snapshots are encoded in, the kind tells
+// UnmarshalSnapshot which concrete type to decode the data into.
+// JSON is used for the data as it sorts map keys, making the encoding deterministic.
+type binarySnapshot struct {
+	Kind string          `json:"kind"`
+	Data json.RawMessage `json:"data"`
+}
+
+func init() {
+	// allow snapshots to be gob encoded behind the interface{} returned by ServerSnapshot
+	gob.Register(&Blade{})
+	gob.Register(&Discrete{})
+	gob.Register(&Chassis{})
+}
+
+// aliases without methods, so marshaling doesn't recurse into MarshalBinary
+type (
+	bladeData    Blade
+	discreteData Discrete
+	chassisData  Chassis
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (b *Blade) MarshalBinary() ([]byte, error) {
+	return marshalSnapshot(snapshotKindBlade, (*bladeData)(b))
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (b *Blade) UnmarshalBinary(data []byte) error {
+	return unmarshalSnapshot(data, snapshotKindBlade, (*bladeData)(b))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (d *Discrete) MarshalBinary() ([]byte, error) {
+	return marshalSnapshot(snapshotKindDiscrete, (*discreteData)(d))
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (d *Discrete) UnmarshalBinary(data []byte) error {
+	return unmarshalSnapshot(data, snapshotKindDiscrete, (*discreteData)(d))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (c *Chassis) MarshalBinary() ([]byte, error) {
+	return marshalSnapshot(snapshotKindChassis, (*chassisData)(c))
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (c *Chassis) UnmarshalBinary(data []byte) error {
+	return unmarshalSnapshot(data, snapshotKindChassis, (*chassisData)(c))
+}
+
+// UnmarshalSnapshot decodes data produced by the snapshots MarshalBinary
+// and returns a *Blade, *Discrete or *Chassis accordingly.
+func UnmarshalSnapshot(data []byte) (interface{}, error) {
+	envelope := &binarySnapshot{}
+	if err := json.Unmarshal(data, envelope); err != nil {
+		return nil, fmt.Errorf("unable to decode snapshot: %w", err)
+	}
+
+	var snapshot interface {
+		UnmarshalBinary([]byte) error
+	}
+
+	switch envelope.Kind {
+	case snapshotKindBlade:
+		snapshot = &Blade{}
+	case snapshotKindDiscrete:
+		snapshot = &Discrete{}
+	case snapshotKindChassis:
+		snapshot = &Chassis{}
+	default:
+		return nil, fmt.Errorf("unable to decode snapshot: unknown kind %q", envelope.Kind)
+	}
+
+	if err := snapshot.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+func marshalSnapshot(kind string, snapshot interface{}) ([]byte, error) {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&binarySnapshot{Kind: kind, Data: data})
+}
+
+func unmarshalSnapshot(data []byte, kind string, snapshot interface{}) error {
+	envelope := &binarySnapshot{}
+	if err := json.Unmarshal(data, envelope); err != nil {
+		return fmt.Errorf("unable to decode snapshot: %w", err)
+	}
+
+	if envelope.Kind != kind {
+		return fmt.Errorf("unable to decode a %s snapshot into a %s", envelope.Kind, kind)
+	}
+
+	return json.Unmarshal(envelope.Data, snapshot)
+}
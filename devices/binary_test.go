@@ -0,0 +1,79 @@
+package devices
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"testing"
+)
+
+func TestSnapshotBinaryRoundTrip(t *testing.T) {
+	blade := &Blade{
+		Serial:        "cz3629fy3a",
+		Vendor:        HP,
+		BladePosition: 3,
+		Disks:         []*Disk{{Serial: "s403crxk0000e7227365", Size: "1200 GB"}},
+		Nics:          []*Nic{{Name: "bmc", MacAddress: "fc:15:b4:17:e2:2a"}},
+		StorageControllers: []*StorageController{
+			{Model: "Smart Array P246br Controller", Metadata: map[string]string{"b": "2", "a": "1", "c": "3"}},
+		},
+	}
+
+	var snapshots []interface{}
+	snapshots = append(snapshots, blade, &Discrete{Serial: "h16z4m2", Psus: []*Psu{{Serial: "ps1", CapacityKw: 2}}}, &Chassis{Serial: "cz3629fy3a", Blades: []*Blade{blade}})
+
+	for _, snapshot := range snapshots {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(&snapshot); err != nil {
+			t.Fatalf("Found errors gob encoding %T: %v", snapshot, err)
+		}
+
+		var decoded interface{}
+		if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+			t.Fatalf("Found errors gob decoding %T: %v", snapshot, err)
+		}
+
+		if !reflect.DeepEqual(decoded, snapshot) {
+			t.Errorf("Expected answer %v: found %v", snapshot, decoded)
+		}
+
+		data, err := snapshot.(interface{ MarshalBinary() ([]byte, error) }).MarshalBinary()
+		if err != nil {
+			t.Fatalf("Found errors marshaling %T: %v", snapshot, err)
+		}
+
+		decoded, err = UnmarshalSnapshot(data)
+		if err != nil {
+			t.Fatalf("Found errors unmarshaling %T: %v", snapshot, err)
+		}
+
+		if !reflect.DeepEqual(decoded, snapshot) {
+			t.Errorf("Expected answer %v: found %v", snapshot, decoded)
+		}
+	}
+}
+
+func TestSnapshotBinaryDeterministic(t *testing.T) {
+	controller := &StorageController{Metadata: map[string]string{"b": "2", "a": "1", "c": "3", "d": "4"}}
+	discrete := &Discrete{Serial: "h16z4m2", StorageControllers: []*StorageController{controller}}
+
+	first, err := discrete.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Found errors marshaling %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		data, err := discrete.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Found errors marshaling %v", err)
+		}
+
+		if !bytes.Equal(first, data) {
+			t.Fatalf("Expected a stable encoding: found %s and %s", first, data)
+		}
+	}
+
+	if err := (&Blade{}).UnmarshalBinary(first); err == nil {
+		t.Errorf("Expected an error decoding a discrete snapshot into a blade")
+	}
+}
@@ -0,0 +1,220 @@
+package devices
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// BIOSSettings holds BIOS attributes by name, attributes keep the order they were set in
+// so the serialized form is stable and can be diffed across a fleet.
+type BIOSSettings struct {
+	names  []string
+	values map[string]string
+}
+
+// BIOSSettingDiff describes an attribute that differs between two BIOSSettings,
+// a missing attribute is reported with Present set to false on that side.
+type BIOSSettingDiff struct {
+	Name         string `json:"name"`
+	Value        string `json:"value"`
+	Present      bool   `json:"present"`
+	OtherValue   string `json:"other_value"`
+	OtherPresent bool   `json:"other_present"`
+}
+
+// NewBIOSSettings returns an empty *BIOSSettings
+func NewBIOSSettings() *BIOSSettings {
+	return &BIOSSettings{values: make(map[string]string)}
+}
+
+// Get returns the value of the attribute and whether it is set
+func (b *BIOSSettings) Get(name string) (value string, ok bool) {
+	value, ok = b.values[name]
+	return value, ok
+}
+
+// Set sets the attribute value, new attributes are appended after the existing ones
+func (b *BIOSSettings) Set(name, value string) {
+	if b.values == nil {
+		b.values = make(map[string]string)
+	}
+
+	if _, exists := b.values[name]; !exists {
+		b.names = append(b.names, name)
+	}
+
+	b.values[name] = value
+}
+
+// Names returns the attribute names in order
+func (b *BIOSSettings) Names() []string {
+	names := make([]string, len(b.names))
+	copy(names, b.names)
+
+	return names
+}
+
+// Len returns the number of attributes
+func (b *BIOSSettings) Len() int {
+	return len(b.names)
+}
+
+// Diff returns the attributes that differ between b and other,
+// in the order of b followed by the attributes only present in other.
+func (b *BIOSSettings) Diff(other *BIOSSettings) (diff []BIOSSettingDiff) {
+	if other == nil {
+		other = NewBIOSSettings()
+	}
+
+	for _, name := range b.names {
+		value := b.values[name]
+		otherValue, ok := other.values[name]
+		if ok && value == otherValue {
+			continue
+		}
+
+		diff = append(diff, BIOSSettingDiff{Name: name, Value: value, Present: true, OtherValue: otherValue, OtherPresent: ok})
+	}
+
+	for _, name := range other.names {
+		if _, ok := b.values[name]; ok {
+			continue
+		}
+
+		diff = append(diff, BIOSSettingDiff{Name: name, OtherValue: other.values[name], OtherPresent: true})
+	}
+
+	return diff
+}
+
+// BIOSSettingsFromAttributes returns the settings of Redfish BIOS attributes sorted by name,
+// the numbers and booleans are formatted as strings and null values as empty strings.
+func BIOSSettingsFromAttributes(attributes map[string]interface{}) *BIOSSettings {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	settings := NewBIOSSettings()
+	for _, name := range names {
+		switch value := attributes[name].(type) {
+		case nil:
+			settings.Set(name, "")
+		case string:
+			settings.Set(name, value)
+		case float64:
+			settings.Set(name, strconv.FormatFloat(value, 'f', -1, 64))
+		case bool:
+			settings.Set(name, strconv.FormatBool(value))
+		default:
+			settings.Set(name, fmt.Sprint(value))
+		}
+	}
+
+	return settings
+}
+
+// Attributes returns the settings as Redfish BIOS attributes, each value is converted to the type
+// of the attribute in current so the BMC receives numbers and booleans as such. An attribute
+// missing from current or a value not matching its type returns an errors.ErrInvalidBIOSAttribute.
+func (b *BIOSSettings) Attributes(current map[string]interface{}) (map[string]interface{}, error) {
+	attributes := make(map[string]interface{}, len(b.names))
+	for _, name := range b.names {
+		value := b.values[name]
+
+		currentValue, ok := current[name]
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown attribute: %w", name, errors.ErrInvalidBIOSAttribute)
+		}
+
+		switch currentValue.(type) {
+		case float64:
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: expected a number, got %q: %w", name, value, errors.ErrInvalidBIOSAttribute)
+			}
+			attributes[name] = number
+		case bool:
+			boolean, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: expected a boolean, got %q: %w", name, value, errors.ErrInvalidBIOSAttribute)
+			}
+			attributes[name] = boolean
+		default:
+			attributes[name] = value
+		}
+	}
+
+	return attributes, nil
+}
+
+// MarshalJSON encodes the settings as a JSON object keeping the attribute order
+func (b *BIOSSettings) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBufferString("{")
+	for idx, name := range b.names {
+		if idx > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(b.values[name])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object of string values keeping the attribute order
+func (b *BIOSSettings) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("BIOS settings: expected a JSON object, got %v", token)
+	}
+
+	settings := NewBIOSSettings()
+	for decoder.More() {
+		token, err = decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		name, ok := token.(string)
+		if !ok {
+			return fmt.Errorf("BIOS settings: unexpected key %v", token)
+		}
+
+		var value string
+		if err = decoder.Decode(&value); err != nil {
+			return fmt.Errorf("BIOS settings: attribute %s: %w", name, err)
+		}
+
+		settings.Set(name, value)
+	}
+
+	*b = *settings
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package devices
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+)
+
+func TestBIOSSettingsOrder(t *testing.T) {
+	settings := NewBIOSSettings()
+	settings.Set("SriovGlobalEnable", "Enabled")
+	settings.Set("BootMode", "Uefi")
+	settings.Set("LogicalProc", "Disabled")
+	settings.Set("BootMode", "Bios")
+
+	expectedAnswer := `{"SriovGlobalEnable":"Enabled","BootMode":"Bios","LogicalProc":"Disabled"}`
+
+	answer, err := json.Marshal(settings)
+	if err != nil {
+		t.Fatalf("Found errors marshaling the settings %v", err)
+	}
+
+	if string(answer) != expectedAnswer {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, string(answer))
+	}
+
+	decoded := NewBIOSSettings()
+	err = json.Unmarshal(answer, decoded)
+	if err != nil {
+		t.Fatalf("Found errors unmarshaling the settings %v", err)
+	}
+
+	if !reflect.DeepEqual(decoded.Names(), settings.Names()) {
+		t.Errorf("Expected answer %v: found %v", settings.Names(), decoded.Names())
+	}
+
+	if value, _ := decoded.Get("BootMode"); value != "Bios" {
+		t.Errorf("Expected answer %v: found %v", "Bios", value)
+	}
+}
+
+func TestBIOSSettingsDiff(t *testing.T) {
+	settings := NewBIOSSettings()
+	settings.Set("BootMode", "Uefi")
+	settings.Set("LogicalProc", "Enabled")
+	settings.Set("ProcVirtualization", "Enabled")
+
+	other := NewBIOSSettings()
+	other.Set("BootMode", "Uefi")
+	other.Set("LogicalProc", "Disabled")
+	other.Set("SriovGlobalEnable", "Enabled")
+
+	expectedAnswer := []BIOSSettingDiff{
+		{Name: "LogicalProc", Value: "Enabled", Present: true, OtherValue: "Disabled", OtherPresent: true},
+		{Name: "ProcVirtualization", Value: "Enabled", Present: true},
+		{Name: "SriovGlobalEnable", OtherValue: "Enabled", OtherPresent: true},
+	}
+
+	answer := settings.Diff(other)
+	if !reflect.DeepEqual(answer, expectedAnswer) {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, answer)
+	}
+
+	if diff := settings.Diff(settings); len(diff) != 0 {
+		t.Errorf("Expected no differences: found %v", diff)
+	}
+}
+
+func TestBIOSSettingsAttributes(t *testing.T) {
+	current := map[string]interface{}{
+		"BootMode":       "Uefi",
+		"NumLock":        true,
+		"SerialComm":     nil,
+		"SysProfile":     "PerfOptimized",
+		"PowerCycleTime": float64(30),
+	}
+
+	settings := BIOSSettingsFromAttributes(current)
+
+	expectedNames := []string{"BootMode", "NumLock", "PowerCycleTime", "SerialComm", "SysProfile"}
+	if !reflect.DeepEqual(settings.Names(), expectedNames) {
+		t.Errorf("Expected answer %v: found %v", expectedNames, settings.Names())
+	}
+
+	if value, _ := settings.Get("PowerCycleTime"); value != "30" {
+		t.Errorf("Expected answer %v: found %v", "30", value)
+	}
+
+	update := NewBIOSSettings()
+	update.Set("NumLock", "false")
+	update.Set("PowerCycleTime", "45")
+	update.Set("SerialComm", "OnConRedir")
+
+	expectedAnswer := map[string]interface{}{
+		"NumLock":        false,
+		"PowerCycleTime": float64(45),
+		"SerialComm":     "OnConRedir",
+	}
+
+	answer, err := update.Attributes(current)
+	if err != nil {
+		t.Fatalf("Found errors converting the attributes %v", err)
+	}
+
+	if !reflect.DeepEqual(answer, expectedAnswer) {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, answer)
+	}
+
+	for name, value := range map[string]string{"NumLock": "maybe", "PowerCycleTime": "soon", "Unknown": "Enabled"} {
+		invalid := NewBIOSSettings()
+		invalid.Set(name, value)
+
+		_, err = invalid.Attributes(current)
+		if !errors.Is(err, bmclibErrs.ErrInvalidBIOSAttribute) {
+			t.Errorf("Expected error %v for %s: found %v", bmclibErrs.ErrInvalidBIOSAttribute, name, err)
+		}
+	}
+}
@@ -1,8 +1,14 @@
 package devices
 
+import (
+	"fmt"
+	"time"
+)
+
 // Blade contains all the blade information we will expose across different vendors
 type Blade struct {
 	Serial               string
+	CollectedAt          time.Time // When the snapshot was collected
 	Name                 string
 	BiosVersion          string
 	BmcType              string
@@ -10,7 +16,9 @@ type Blade struct {
 	BmcVersion           string
 	BmcLicenceType       string
 	BmcLicenceStatus     string
+	BmcLicense           *License `json:",omitempty"` // nil when the BMC doesn't expose license details
 	Disks                []*Disk
+	StorageControllers   []*StorageController
 	Nics                 []*Nic
 	BladePosition        int
 	Model                string
@@ -24,7 +32,19 @@ type Blade struct {
 	ProcessorCount       int
 	ProcessorCoreCount   int
 	ProcessorThreadCount int
+	CPUs                 []*CPU `json:",omitempty"`
 	StorageBlade         StorageBlade
 	Memory               int
+	MemoryModules        []*MemoryModule `json:",omitempty"`
+	BootOrder            []BootEntry     `json:",omitempty"`
+	GPUs                 []*GPU          `json:",omitempty"`
+	TPM                  *TPM            `json:",omitempty"` // nil when not collected
+	FirmwareInventory    []Firmware      `json:",omitempty"`
 	FlexAddressEnabled   bool
 }
+
+// String returns a single line summary of the blade, useful for logging.
+// It only carries identifying fields, use JSON marshaling for the full detail.
+func (b *Blade) String() string {
+	return fmt.Sprintf("Blade %s %s: name=%s serial=%s bmc=%s position=%d", b.Vendor, b.Model, b.Name, b.Serial, b.BmcAddress, b.BladePosition)
+}
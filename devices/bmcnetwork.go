@@ -0,0 +1,14 @@
+package devices
+
+// BMCNetwork represents the network configuration of a bmc or chassis manager
+type BMCNetwork struct {
+	Name      string        `json:"name,omitempty"`
+	Position  int           `json:"position,omitempty"`
+	Role      string        `json:"role,omitempty"`
+	IPAddress string        `json:"ip_address,omitempty"`
+	Netmask   string        `json:"netmask,omitempty"`
+	Gateway   string        `json:"gateway,omitempty"`
+	DHCP      bool          `json:"dhcp"`
+	VirtualIP string        `json:"virtual_ip,omitempty"` // Address that follows the active manager, if configured
+	Peers     []*BMCNetwork `json:"peers,omitempty"`      // Standby managers of a redundant setup
+}
@@ -0,0 +1,8 @@
+package devices
+
+// BootEntry represents a device in the server boot order
+type BootEntry struct {
+	Device  string `json:"device"`
+	Enabled bool   `json:"enabled"`
+	Index   int    `json:"index"` // Position in the boot order, starting at 0
+}
@@ -0,0 +1,74 @@
+package devices
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// diskSizeUnits maps the unit suffixes found in Disk.Size to their size in bytes,
+// the disk vendors count in decimal units, a "1.6TB" disk holds 1.6e12 bytes, the
+// binary units are only taken when spelled as such, e.g. "1TiB".
+var diskSizeUnits = map[string]int64{
+	"":    1,
+	"b":   1,
+	"kb":  1e3,
+	"kib": 1 << 10,
+	"mb":  1e6,
+	"mib": 1 << 20,
+	"gb":  1e9,
+	"gib": 1 << 30,
+	"tb":  1e12,
+	"tib": 1 << 40,
+	"pb":  1e15,
+	"pib": 1 << 50,
+}
+
+// FormatDiskSize returns the Disk.Size of a disk holding the given number of bytes in decimal
+// gigabytes, e.g. "3840 GB", the providers reading a byte count use it so their sizes parse back
+// the same with DiskSizeBytes.
+func FormatDiskSize(bytes int64) string {
+	return fmt.Sprintf("%d GB", bytes/diskSizeUnits["gb"])
+}
+
+// DiskSizeBytes parses a Disk.Size value like "1200 GB" or "1.6TB" into bytes,
+// returning false when the value can't be parsed. Disk.Size is kept as the providers
+// report it, DiskSizeBytes is the canonical way to compare or sum the disk sizes.
+func DiskSizeBytes(size string) (int64, bool) {
+	s := strings.ToLower(strings.Join(strings.Fields(size), ""))
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i == -1 {
+		i = len(s)
+	}
+
+	value, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return 0, false
+	}
+
+	unit, ok := diskSizeUnits[s[i:]]
+	if !ok {
+		return 0, false
+	}
+
+	return int64(value * float64(unit)), true
+}
+
+// TotalDiskCapacity returns the total capacity in bytes of the given disks,
+// nil entries and disks with an unparseable Size are skipped.
+func TotalDiskCapacity(disks []*Disk) int64 {
+	var total int64
+	for _, disk := range disks {
+		if disk == nil {
+			continue
+		}
+
+		if size, ok := DiskSizeBytes(disk.Size); ok {
+			total += size
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,76 @@
+package devices
+
+import "testing"
+
+func TestDiskSizeBytes(t *testing.T) {
+	tests := map[string]int64{
+		"1200 GB":  1200e9,
+		"3840 GB":  3840e9,
+		"1.6TB":    1600e9,
+		"1.5 TB":   1500e9,
+		"1TiB":     1 << 40,
+		"447 GiB":  447 << 30,
+		"512 mb":   512e6,
+		"512 MiB":  512 << 20,
+		"4096":     4096,
+		" 480 GB ": 480e9,
+	}
+
+	for size, expectedAnswer := range tests {
+		answer, ok := DiskSizeBytes(size)
+		if !ok {
+			t.Fatalf("Found errors parsing %q", size)
+		}
+
+		if answer != expectedAnswer {
+			t.Errorf("%q: Expected answer %d: found %d", size, expectedAnswer, answer)
+		}
+	}
+
+	for _, size := range []string{"", "Unknown", "GB", "12 parsecs"} {
+		if _, ok := DiskSizeBytes(size); ok {
+			t.Errorf("%q: Expected size to be rejected", size)
+		}
+	}
+}
+
+func TestTotalDiskCapacity(t *testing.T) {
+	disks := []*Disk{
+		{Serial: "a", Size: "1200 GB"},
+		nil,
+		{Serial: "b", Size: "1 TB"},
+		{Serial: "c", Size: "Unknown"},
+		{Serial: "d", Size: "512 MB"},
+	}
+
+	expectedAnswer := int64(1200e9 + 1e12 + 512e6)
+	if answer := TotalDiskCapacity(disks); answer != expectedAnswer {
+		t.Errorf("Expected answer %d: found %d", expectedAnswer, answer)
+	}
+
+	if answer := TotalDiskCapacity(nil); answer != 0 {
+		t.Errorf("Expected answer 0: found %d", answer)
+	}
+}
+
+func TestFormatDiskSize(t *testing.T) {
+	tests := map[int64]string{
+		480103981056:  "480 GB",
+		3840755982336: "3840 GB",
+		1600321314816: "1600 GB",
+		0:             "0 GB",
+	}
+
+	for bytes, expectedAnswer := range tests {
+		answer := FormatDiskSize(bytes)
+		if answer != expectedAnswer {
+			t.Errorf("%d: Expected answer %s: found %s", bytes, expectedAnswer, answer)
+		}
+
+		// the formatted size parses back to the bytes, rounded down to the gigabyte
+		size, ok := DiskSizeBytes(answer)
+		if !ok || size != bytes/1e9*1e9 {
+			t.Errorf("%q: Expected answer %d: found %d", answer, bytes/1e9*1e9, size)
+		}
+	}
+}
@@ -1,22 +1,39 @@
 package devices
 
+import (
+	"fmt"
+	"time"
+)
+
 // Chassis contains all the chassis the information we will expose across different vendors
 type Chassis struct {
-	Serial            string
-	Name              string
-	BmcAddress        string
-	Blades            []*Blade
-	StorageBlades     []*StorageBlade
-	Fans              []*Fan
-	Nics              []*Nic
-	Psus              []*Psu
-	PsuRedundancyMode string
-	IsPsuRedundant    bool
-	TempC             int
-	PassThru          string
-	Status            string
-	PowerKw           float64
-	Model             string
-	Vendor            string
-	FwVersion         string
+	Serial             string
+	CollectedAt        time.Time // When the snapshot was collected
+	Name               string
+	BmcAddress         string
+	Blades             []*Blade
+	StorageBlades      []*StorageBlade
+	Interconnects      []*Interconnect `json:",omitempty"`
+	Fans               []*Fan
+	Nics               []*Nic
+	Psus               []*Psu
+	TemperatureSensors []*TemperatureSensor `json:",omitempty"`
+	PsuRedundancyMode  string
+	IsPsuRedundant     bool
+	TempC              int
+	PassThru           string
+	Status             string
+	PowerKw            float64
+	Model              string
+	Vendor             string
+	FwVersion          string
+	FirmwareInventory  []Firmware `json:",omitempty"`
+}
+
+// String returns a single line summary of the chassis, useful for logging
+func (c *Chassis) String() string {
+	return fmt.Sprintf(
+		"Chassis %s %s: name=%s serial=%s bmc=%s fw=%s blades=%d",
+		c.Vendor, c.Model, c.Name, c.Serial, c.BmcAddress, c.FwVersion, len(c.Blades),
+	)
 }
@@ -0,0 +1,27 @@
+package devices
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestChassisJSONKeys(t *testing.T) {
+	payload, err := json.Marshal(&Chassis{Serial: "cz3629fy3a", BmcAddress: "10.0.0.1", FwVersion: "4.60"})
+	if err != nil {
+		t.Fatalf("Found errors marshaling the chassis %v", err)
+	}
+
+	// the keys match Blade and Discrete, the fields added later are omitted when empty
+	for _, key := range []string{`"Serial":"cz3629fy3a"`, `"BmcAddress":"10.0.0.1"`, `"FwVersion":"4.60"`, `"StorageBlades":null`} {
+		if !strings.Contains(string(payload), key) {
+			t.Errorf("Expected answer %s: found %s", key, payload)
+		}
+	}
+
+	for _, key := range []string{"Interconnects", "TemperatureSensors", "FirmwareInventory"} {
+		if strings.Contains(string(payload), key) {
+			t.Errorf("Expected %s to be omitted: found %s", key, payload)
+		}
+	}
+}
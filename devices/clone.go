@@ -0,0 +1,335 @@
+package devices
+
+// Clone returns a deep copy of the blade, the copy shares no slices or pointers with the original.
+func (b *Blade) Clone() *Blade {
+	if b == nil {
+		return nil
+	}
+
+	clone := *b
+	disks := make(map[*Disk]*Disk)
+	clone.Disks = cloneDisks(b.Disks, disks)
+	clone.StorageControllers = cloneStorageControllers(b.StorageControllers, disks)
+	clone.Nics = cloneNics(b.Nics)
+	clone.BootOrder = cloneBootOrder(b.BootOrder)
+	clone.GPUs = cloneGPUs(b.GPUs)
+	clone.TPM = cloneTPM(b.TPM)
+	clone.BmcLicense = cloneLicense(b.BmcLicense)
+	clone.FirmwareInventory = cloneFirmwareInventory(b.FirmwareInventory)
+	clone.MemoryModules = cloneMemoryModules(b.MemoryModules)
+	clone.CPUs = cloneCPUs(b.CPUs)
+
+	return &clone
+}
+
+// Clone returns a deep copy of the discrete, the copy shares no slices or pointers with the original.
+func (d *Discrete) Clone() *Discrete {
+	if d == nil {
+		return nil
+	}
+
+	clone := *d
+	disks := make(map[*Disk]*Disk)
+	clone.Disks = cloneDisks(d.Disks, disks)
+	clone.StorageControllers = cloneStorageControllers(d.StorageControllers, disks)
+	clone.Nics = cloneNics(d.Nics)
+	clone.Psus = clonePsus(d.Psus)
+	clone.BootOrder = cloneBootOrder(d.BootOrder)
+	clone.GPUs = cloneGPUs(d.GPUs)
+	clone.TPM = cloneTPM(d.TPM)
+	clone.BmcLicense = cloneLicense(d.BmcLicense)
+	clone.FirmwareInventory = cloneFirmwareInventory(d.FirmwareInventory)
+	clone.MemoryModules = cloneMemoryModules(d.MemoryModules)
+	clone.CPUs = cloneCPUs(d.CPUs)
+
+	return &clone
+}
+
+// Clone returns a deep copy of the chassis and its blades, the copy shares no slices or pointers with the original.
+func (c *Chassis) Clone() *Chassis {
+	if c == nil {
+		return nil
+	}
+
+	clone := *c
+	if c.Blades != nil {
+		clone.Blades = make([]*Blade, len(c.Blades))
+		for idx, blade := range c.Blades {
+			clone.Blades[idx] = blade.Clone()
+		}
+	}
+
+	if c.StorageBlades != nil {
+		clone.StorageBlades = make([]*StorageBlade, len(c.StorageBlades))
+		for idx, storageBlade := range c.StorageBlades {
+			if storageBlade != nil {
+				s := *storageBlade
+				clone.StorageBlades[idx] = &s
+			}
+		}
+	}
+
+	if c.Fans != nil {
+		clone.Fans = make([]*Fan, len(c.Fans))
+		for idx, fan := range c.Fans {
+			if fan != nil {
+				f := *fan
+				clone.Fans[idx] = &f
+			}
+		}
+	}
+
+	if c.Interconnects != nil {
+		clone.Interconnects = make([]*Interconnect, len(c.Interconnects))
+		for idx, interconnect := range c.Interconnects {
+			if interconnect != nil {
+				i := *interconnect
+				clone.Interconnects[idx] = &i
+			}
+		}
+	}
+
+	if c.TemperatureSensors != nil {
+		clone.TemperatureSensors = make([]*TemperatureSensor, len(c.TemperatureSensors))
+		for idx, sensor := range c.TemperatureSensors {
+			if sensor != nil {
+				t := *sensor
+				clone.TemperatureSensors[idx] = &t
+			}
+		}
+	}
+
+	clone.Nics = cloneNics(c.Nics)
+	clone.Psus = clonePsus(c.Psus)
+	clone.FirmwareInventory = cloneFirmwareInventory(c.FirmwareInventory)
+
+	return &clone
+}
+
+// cloneDisks copies the disks, recording the copies in seen so disks
+// referenced from several places in a snapshot are copied only once.
+func cloneDisks(disks []*Disk, seen map[*Disk]*Disk) []*Disk {
+	if disks == nil {
+		return nil
+	}
+
+	clone := make([]*Disk, len(disks))
+	for idx, disk := range disks {
+		if disk == nil {
+			continue
+		}
+
+		if copied, ok := seen[disk]; ok {
+			clone[idx] = copied
+			continue
+		}
+
+		d := *disk
+		seen[disk] = &d
+		clone[idx] = &d
+	}
+
+	return clone
+}
+
+func cloneStorageControllers(controllers []*StorageController, seen map[*Disk]*Disk) []*StorageController {
+	if controllers == nil {
+		return nil
+	}
+
+	clone := make([]*StorageController, len(controllers))
+	for idx, controller := range controllers {
+		if controller == nil {
+			continue
+		}
+
+		c := *controller
+		c.Status = cloneStatus(controller.Status)
+		c.Metadata = cloneMetadata(controller.Metadata)
+		c.Firmware = cloneFirmware(controller.Firmware)
+		c.Disks = cloneDisks(controller.Disks, seen)
+		clone[idx] = &c
+	}
+
+	return clone
+}
+
+func cloneNics(nics []*Nic) []*Nic {
+	if nics == nil {
+		return nil
+	}
+
+	clone := make([]*Nic, len(nics))
+	for idx, nic := range nics {
+		if nic != nil {
+			n := *nic
+			clone[idx] = &n
+		}
+	}
+
+	return clone
+}
+
+func clonePsus(psus []*Psu) []*Psu {
+	if psus == nil {
+		return nil
+	}
+
+	clone := make([]*Psu, len(psus))
+	for idx, psu := range psus {
+		if psu != nil {
+			p := *psu
+			clone[idx] = &p
+		}
+	}
+
+	return clone
+}
+
+func cloneBootOrder(entries []BootEntry) []BootEntry {
+	if entries == nil {
+		return nil
+	}
+
+	clone := make([]BootEntry, len(entries))
+	copy(clone, entries)
+
+	return clone
+}
+
+func cloneGPUs(gpus []*GPU) []*GPU {
+	if gpus == nil {
+		return nil
+	}
+
+	clone := make([]*GPU, len(gpus))
+	for idx, gpu := range gpus {
+		if gpu != nil {
+			g := *gpu
+			g.Status = cloneStatus(gpu.Status)
+			g.Firmware = cloneFirmware(gpu.Firmware)
+			clone[idx] = &g
+		}
+	}
+
+	return clone
+}
+
+func cloneTPM(tpm *TPM) *TPM {
+	if tpm == nil {
+		return nil
+	}
+
+	t := *tpm
+	t.Status = cloneStatus(tpm.Status)
+	t.Firmware = cloneFirmware(tpm.Firmware)
+
+	return &t
+}
+
+func cloneLicense(license *License) *License {
+	if license == nil {
+		return nil
+	}
+
+	l := *license
+	if license.Keys != nil {
+		l.Keys = append([]string{}, license.Keys...)
+	}
+	if license.Features != nil {
+		l.Features = append([]string{}, license.Features...)
+	}
+	if license.ExpiresAt != nil {
+		expiresAt := *license.ExpiresAt
+		l.ExpiresAt = &expiresAt
+	}
+
+	return &l
+}
+
+func cloneMemoryModules(modules []*MemoryModule) []*MemoryModule {
+	if modules == nil {
+		return nil
+	}
+
+	clone := make([]*MemoryModule, len(modules))
+	for idx, module := range modules {
+		if module != nil {
+			m := *module
+			clone[idx] = &m
+		}
+	}
+
+	return clone
+}
+
+func cloneCPUs(cpus []*CPU) []*CPU {
+	if cpus == nil {
+		return nil
+	}
+
+	clone := make([]*CPU, len(cpus))
+	for idx, cpu := range cpus {
+		if cpu != nil {
+			c := *cpu
+			c.Status = cloneStatus(cpu.Status)
+			c.Firmware = cloneFirmware(cpu.Firmware)
+			clone[idx] = &c
+		}
+	}
+
+	return clone
+}
+
+func cloneStatus(status *Status) *Status {
+	if status == nil {
+		return nil
+	}
+
+	s := *status
+
+	return &s
+}
+
+func cloneFirmware(firmware *Firmware) *Firmware {
+	if firmware == nil {
+		return nil
+	}
+
+	f := *firmware
+	f.Metadata = cloneMetadata(firmware.Metadata)
+	if firmware.Previous != nil {
+		f.Previous = make([]*Firmware, len(firmware.Previous))
+		for idx, previous := range firmware.Previous {
+			f.Previous[idx] = cloneFirmware(previous)
+		}
+	}
+
+	return &f
+}
+
+func cloneFirmwareInventory(inventory []Firmware) []Firmware {
+	if inventory == nil {
+		return nil
+	}
+
+	clone := make([]Firmware, len(inventory))
+	for idx := range inventory {
+		clone[idx] = *cloneFirmware(&inventory[idx])
+	}
+
+	return clone
+}
+
+func cloneMetadata(metadata map[string]string) map[string]string {
+	if metadata == nil {
+		return nil
+	}
+
+	clone := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		clone[k] = v
+	}
+
+	return clone
+}
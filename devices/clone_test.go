@@ -0,0 +1,58 @@
+package devices
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBladeClone(t *testing.T) {
+	disk := &Disk{Serial: "s403crxk0000e7227365", Status: "OK"}
+	blade := &Blade{
+		Serial: "cz3629fy3a",
+		Disks:  []*Disk{disk},
+		StorageControllers: []*StorageController{
+			{Model: "Smart Array P246br Controller", Disks: []*Disk{disk}, Firmware: &Firmware{Installed: "5.52"}},
+		},
+		Nics: []*Nic{{Name: "bmc", MacAddress: "fc:15:b4:17:e2:2a"}},
+	}
+
+	clone := blade.Clone()
+	if !reflect.DeepEqual(blade, clone) {
+		t.Fatalf("Expected answer %v: found %v", blade, clone)
+	}
+
+	clone.Nics[0].MacAddress = "00:00:00:00:00:00"
+	clone.Nics = append(clone.Nics, &Nic{Name: "eth0"})
+	clone.Disks[0].Status = "FAILED"
+	clone.StorageControllers[0].Firmware.Installed = "6.00"
+
+	if blade.Nics[0].MacAddress != "fc:15:b4:17:e2:2a" || len(blade.Nics) != 1 {
+		t.Errorf("Expected the original nics to be untouched: found %v", blade.Nics)
+	}
+
+	if disk.Status != "OK" || blade.StorageControllers[0].Firmware.Installed != "5.52" {
+		t.Errorf("Expected the original disks and controllers to be untouched: found %v", blade.StorageControllers[0])
+	}
+
+	if clone.StorageControllers[0].Disks[0] != clone.Disks[0] {
+		t.Errorf("Expected the cloned controller to reference the cloned disk")
+	}
+}
+
+func TestChassisClone(t *testing.T) {
+	chassis := &Chassis{
+		Serial: "cz3629fy3a",
+		Blades: []*Blade{{Serial: "blade1", Disks: []*Disk{{Serial: "disk1"}}}},
+		Nics:   []*Nic{{Name: "OA1", MacAddress: "fc:15:b4:17:e2:2a"}},
+		Fans:   []*Fan{{Position: 1, Status: "OK"}},
+	}
+
+	clone := chassis.Clone()
+	clone.Blades[0].Disks[0].Serial = "disk2"
+	clone.Nics[0].Name = "OA2"
+	clone.Fans[0].Status = "FAILED"
+
+	if chassis.Blades[0].Disks[0].Serial != "disk1" || chassis.Nics[0].Name != "OA1" || chassis.Fans[0].Status != "OK" {
+		t.Errorf("Expected the original chassis to be untouched: found %v", chassis)
+	}
+}
@@ -0,0 +1,11 @@
+package devices
+
+// ConfigChange describes a configuration request that would be sent to a BMC,
+// as collected by the providers running in dry-run.
+type ConfigChange struct {
+	// Resource is the configuration resource, e.g. User, Network, Ntp, LdapGroups or Syslog
+	Resource string `json:"resource"`
+	Endpoint string `json:"endpoint"`
+	// Params are the parameters of the request, with the secrets redacted
+	Params map[string]string `json:"params,omitempty"`
+}
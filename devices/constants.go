@@ -14,6 +14,8 @@ const (
 	Dell = "Dell"
 	// Supermicro is the constant that defines the vendor Supermicro
 	Supermicro = "Supermicro"
+	// Lenovo is the constant that defines the vendor Lenovo
+	Lenovo = "Lenovo"
 	// Cloudline is the constant that defines the cloudlines
 	Cloudline = "Cloudline"
 	// Quanta is the contant to identify Quanta hardware
@@ -34,6 +36,22 @@ const (
 	// NoRedundancy describes the power redundancy mode we don't have redundancy
 	NoRedundancy = "NoRedundancy"
 
+	// Storage controller mode constants
+
+	// StorageControllerModeRAID describes a controller presenting logical RAID volumes
+	StorageControllerModeRAID = "RAID"
+
+	// StorageControllerModeHBA describes a controller passing its disks through to the host
+	StorageControllerModeHBA = "HBA"
+
+	// StorageControllerModeJBOD describes a controller exposing its disks as individual volumes
+	StorageControllerModeJBOD = "JBOD"
+
+	// Temperature unit constants
+
+	// TemperatureUnitCelsius is the unit temperature sensors report in
+	TemperatureUnitCelsius = "C"
+
 	// Hardware constants
 
 	// BladeHwType is the constant defining the blade hw type
@@ -119,7 +137,7 @@ const (
 
 // ListSupportedVendors  returns a list of supported vendors
 func ListSupportedVendors() []string {
-	return []string{HP, Dell, Supermicro}
+	return []string{HP, Dell, Supermicro, Lenovo}
 }
 
 // VendorFromProductName attempts to identify the vendor from the given productname
@@ -132,6 +150,8 @@ func VendorFromProductName(productName string) string {
 		return Dell
 	case strings.Contains(n, "supermicro"):
 		return Supermicro
+	case strings.Contains(n, "lenovo"):
+		return Lenovo
 	case strings.Contains(n, "cloudline"):
 		return Cloudline
 	case strings.Contains(n, "quanta"):
@@ -1,5 +1,7 @@
 package devices
 
+import "fmt"
+
 // Device type is composed of various components
 type Device struct {
 	Oem                bool                 `json:"oem"`
@@ -47,6 +49,8 @@ func NewDevice() *Device {
 
 // Firmware struct holds firmware attributes of a device component
 type Firmware struct {
+	Component  string            `json:"component,omitempty"` // Set on FirmwareInventory entries, e.g. BIOS, NIC.Integrated.1-1-1
+	Updatable  bool              `json:"updatable,omitempty"`
 	Installed  string            `json:"installed,omitempty"`
 	SoftwareID string            `json:"software_id,omitempty"`
 	Previous   []*Firmware       `json:"previous,omitempty"`
@@ -68,6 +72,14 @@ type Status struct {
 
 // GPU component
 type GPU struct {
+	Description string    `json:"description,omitempty"`
+	Vendor      string    `json:"vendor,omitempty"`
+	Model       string    `json:"model,omitempty"`
+	Serial      string    `json:"serial,omitempty"`
+	Slot        string    `json:"slot,omitempty"`
+	MemoryMB    int64     `json:"memory_mb,omitempty"`
+	Status      *Status   `json:"status,omitempty"`
+	Firmware    *Firmware `json:"firmware,omitempty"` // VBIOS version
 }
 
 // Enclosure component
@@ -84,6 +96,10 @@ type Enclosure struct {
 
 // TPM component
 type TPM struct {
+	Present       bool      `json:"present"`
+	Enabled       bool      `json:"enabled"`
+	Version       string    `json:"version,omitempty"` // 1.2, 2.0
+	Manufacturer  string    `json:"manufacturer,omitempty"`
 	InterfaceType string    `json:"interface_type,omitempty"`
 	Firmware      *Firmware `json:"firmware,omitempty"`
 	Status        *Status   `json:"status,omitempty"`
@@ -105,12 +121,29 @@ type PSU struct {
 	Vendor             string    `json:"vendor,omitempty"`
 	Model              string    `json:"model,omitempty"`
 	Serial             string    `json:"serial,omitempty"`
+	Position           int       `json:"position,omitempty"` // Bay the PSU is installed in
+	Present            bool      `json:"present"`
 	PowerCapacityWatts int64     `json:"power_capacity_watts,omitempty"`
+	InputVoltage       float64   `json:"input_voltage,omitempty"`
+	OutputWatts        int64     `json:"output_watts,omitempty"`
 	Oem                bool      `json:"oem"`
 	Status             *Status   `json:"status,omitempty"`
 	Firmware           *Firmware `json:"firmware,omitempty"`
 }
 
+// String returns a single line summary of the PSU, useful for logging
+func (p *PSU) String() string {
+	var health string
+	if p.Status != nil {
+		health = p.Status.Health
+	}
+
+	return fmt.Sprintf(
+		"PSU %d: model=%s serial=%s present=%t health=%s capacity=%dW output=%dW input=%.1fV",
+		p.Position, p.Model, p.Serial, p.Present, health, p.PowerCapacityWatts, p.OutputWatts, p.InputVoltage,
+	)
+}
+
 // BIOS component
 type BIOS struct {
 	Description   string    `json:"description,omitempty"`
@@ -139,7 +172,7 @@ type CPU struct {
 	Vendor       string    `json:"vendor,omitempty"`
 	Model        string    `json:"model,omitempty"`
 	Serial       string    `json:"serial,omitempty"`
-	Slot         string    `json:"slot,omitempty"`
+	Slot         string    `json:"slot,omitempty"` // Socket, e.g. CPU1
 	Architecture string    `json:"architecture,omitempty"`
 	ClockSpeedHz int64     `json:"clock_speeed_hz,omitempty"`
 	Cores        int       `json:"cores,omitempty"`
@@ -193,10 +226,12 @@ type StorageController struct {
 	SupportedRAIDTypes           string            `json:"supported_raid_types,omitempty"`
 	PhysicalID                   string            `json:"physid,omitempty"`
 	SpeedGbps                    int64             `json:"speed_gbps,omitempty"`
+	Mode                         string            `json:"mode,omitempty"` // RAID, HBA, JBOD
 	Oem                          bool              `json:"oem"`
 	Status                       *Status           `json:"status,omitempty"`
 	Metadata                     map[string]string `json:"metadata"`
 	Firmware                     *Firmware         `json:"firmware,omitempty"`
+	Disks                        []*Disk           `json:"disks,omitempty"` // Disks attached to the controller
 }
 
 // Mainboard component
@@ -0,0 +1,259 @@
+package devices
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// FieldChange describes a field that differs between two snapshots,
+// Old is nil for added elements and New is nil for removed ones.
+type FieldChange struct {
+	Path string      `json:"path"` // e.g. Nics[fc:15:b4:17:e2:2b].Speed or Fans[1].Status
+	Old  interface{} `json:"old"`
+	New  interface{} `json:"new"`
+}
+
+// DiffOption sets an option on Diff
+type DiffOption func(*diffConfig)
+
+type diffConfig struct {
+	includeVolatile bool
+}
+
+// DiffIncludeVolatile makes Diff also report fields that change on every read, like temperature and power draw
+func DiffIncludeVolatile() DiffOption {
+	return func(c *diffConfig) {
+		c.includeVolatile = true
+	}
+}
+
+// volatileFields are readings expected to change between two snapshots of a healthy host
+var volatileFields = map[string]bool{
+	"CollectedAt":  true,
+	"TempC":        true,
+	"PowerKw":      true,
+	"CurrentRPM":   true,
+	"Temperature":  true,
+	"PowerOnHours": true,
+	"InputVoltage": true,
+	"OutputWatts":  true,
+	"Reading":      true,
+}
+
+// Diff compares two snapshots of the same kind (Blade, Discrete or Chassis) and returns the fields
+// that changed between a and b, ordered by field declaration and slice index. The elements of the
+// component slices are matched by the keys Merge uses, like the MAC address of the NICs, so a reordered
+// slice isn't reported as changed, and their path holds the key instead of the index.
+func Diff(a, b interface{}, opts ...DiffOption) ([]FieldChange, error) {
+	cfg := &diffConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if a == nil || b == nil {
+		return nil, fmt.Errorf("unable to diff a nil snapshot, expected a Blade, Discrete or Chassis")
+	}
+
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return nil, fmt.Errorf("unable to diff %T against %T", a, b)
+	}
+
+	valueA, valueB := reflect.ValueOf(a), reflect.ValueOf(b)
+	if valueA.Kind() == reflect.Ptr {
+		if valueA.IsNil() || valueB.IsNil() {
+			return nil, fmt.Errorf("unable to diff a nil %T", a)
+		}
+		valueA, valueB = valueA.Elem(), valueB.Elem()
+	}
+
+	switch valueA.Interface().(type) {
+	case Blade, Discrete, Chassis:
+	default:
+		return nil, fmt.Errorf("unable to diff %T, expected a Blade, Discrete or Chassis", a)
+	}
+
+	changes := []FieldChange{}
+	diffValues("", valueA, valueB, cfg, &changes)
+
+	return changes, nil
+}
+
+func diffValues(path string, a, b reflect.Value, cfg *diffConfig, changes *[]FieldChange) {
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				*changes = append(*changes, FieldChange{Path: path, Old: valueOrNil(a), New: valueOrNil(b)})
+			}
+			return
+		}
+		diffValues(path, a.Elem(), b.Elem(), cfg, changes)
+	case reflect.Struct:
+		if leafStruct(a.Type()) {
+			if !equalLeaves(a.Interface(), b.Interface()) {
+				*changes = append(*changes, FieldChange{Path: path, Old: a.Interface(), New: b.Interface()})
+			}
+			return
+		}
+
+		for i := 0; i < a.NumField(); i++ {
+			field := a.Type().Field(i)
+			if field.PkgPath != "" || (!cfg.includeVolatile && volatileFields[field.Name]) {
+				continue
+			}
+
+			fieldPath := field.Name
+			if path != "" {
+				fieldPath = path + "." + field.Name
+			}
+			diffValues(fieldPath, a.Field(i), b.Field(i), cfg, changes)
+		}
+	case reflect.Slice, reflect.Array:
+		if keyA, keyB, ok := diffKeys(a, b); ok {
+			diffKeyedSlices(path, a, b, keyA, keyB, cfg, changes)
+			return
+		}
+
+		length := a.Len()
+		if b.Len() > length {
+			length = b.Len()
+		}
+
+		for i := 0; i < length; i++ {
+			elemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= a.Len():
+				*changes = append(*changes, FieldChange{Path: elemPath, New: valueOrNil(b.Index(i))})
+			case i >= b.Len():
+				*changes = append(*changes, FieldChange{Path: elemPath, Old: valueOrNil(a.Index(i))})
+			default:
+				diffValues(elemPath, a.Index(i), b.Index(i), cfg, changes)
+			}
+		}
+	case reflect.Map:
+		keys := map[string]reflect.Value{}
+		for _, key := range append(a.MapKeys(), b.MapKeys()...) {
+			keys[fmt.Sprint(key.Interface())] = key
+		}
+
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			elemPath := fmt.Sprintf("%s[%s]", path, name)
+			elemA, elemB := a.MapIndex(keys[name]), b.MapIndex(keys[name])
+			switch {
+			case !elemA.IsValid():
+				*changes = append(*changes, FieldChange{Path: elemPath, New: elemB.Interface()})
+			case !elemB.IsValid():
+				*changes = append(*changes, FieldChange{Path: elemPath, Old: elemA.Interface()})
+			default:
+				diffValues(elemPath, elemA, elemB, cfg, changes)
+			}
+		}
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			*changes = append(*changes, FieldChange{Path: path, Old: a.Interface(), New: b.Interface()})
+		}
+	}
+}
+
+// diffKeys returns the mergeKeys of the elements of the a and b slices, ok is false when the
+// elements have no key, or when one is missing or repeated, the slices are then compared by index.
+func diffKeys(a, b reflect.Value) (keyA, keyB []string, ok bool) {
+	key, found := mergeKeys[a.Type().Elem()]
+	if !found {
+		return nil, nil, false
+	}
+
+	keys := func(v reflect.Value) ([]string, bool) {
+		seen := make(map[string]bool, v.Len())
+		keys := make([]string, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if isNilPtr(v.Index(i)) {
+				return nil, false
+			}
+
+			keys[i] = key(v.Index(i))
+			if keys[i] == "" || seen[keys[i]] {
+				return nil, false
+			}
+			seen[keys[i]] = true
+		}
+
+		return keys, true
+	}
+
+	if keyA, ok = keys(a); !ok {
+		return nil, nil, false
+	}
+	if keyB, ok = keys(b); !ok {
+		return nil, nil, false
+	}
+
+	return keyA, keyB, true
+}
+
+// diffKeyedSlices compares the elements of a and b holding the same key, the elements
+// of a missing from b are reported as removed, then the ones of b missing from a as added.
+func diffKeyedSlices(path string, a, b reflect.Value, keyA, keyB []string, cfg *diffConfig, changes *[]FieldChange) {
+	positionsB := make(map[string]int, len(keyB))
+	for i, k := range keyB {
+		positionsB[k] = i
+	}
+
+	matched := make(map[string]bool, len(keyA))
+	for i, k := range keyA {
+		elemPath := fmt.Sprintf("%s[%s]", path, k)
+		j, ok := positionsB[k]
+		if !ok {
+			*changes = append(*changes, FieldChange{Path: elemPath, Old: valueOrNil(a.Index(i))})
+			continue
+		}
+
+		matched[k] = true
+		diffValues(elemPath, a.Index(i), b.Index(j), cfg, changes)
+	}
+
+	for j, k := range keyB {
+		if !matched[k] {
+			*changes = append(*changes, FieldChange{Path: fmt.Sprintf("%s[%s]", path, k), New: valueOrNil(b.Index(j))})
+		}
+	}
+}
+
+// leafStruct returns true for the structs compared as a whole, like time.Time,
+// their fields are all unexported so walking them would never report a change.
+func leafStruct(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equalLeaves compares two leaf structs, the times are compared with Equal as
+// the same instant may be held with a different location or monotonic reading.
+func equalLeaves(a, b interface{}) bool {
+	if timeA, ok := a.(time.Time); ok {
+		return timeA.Equal(b.(time.Time))
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// valueOrNil returns the value held by v, or nil for nil pointers
+func valueOrNil(v reflect.Value) interface{} {
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return nil
+	}
+
+	return v.Interface()
+}
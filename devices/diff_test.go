@@ -0,0 +1,207 @@
+package devices
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	before := &Discrete{
+		Serial:      "cz3629fy3a",
+		BiosVersion: "U30 v2.60",
+		BmcVersion:  "2.50",
+		TempC:       21,
+		Nics: []*Nic{
+			{Name: "bmc", MacAddress: "fc:15:b4:17:e2:2a"},
+			{Name: "eth0", MacAddress: "fc:15:b4:17:e2:2b"},
+		},
+	}
+
+	after := before.Clone()
+	after.BiosVersion = "U30 v2.72"
+	after.TempC = 25
+	after.Nics = append(after.Nics, &Nic{Name: "eth1", MacAddress: "fc:15:b4:17:e2:2c"})
+
+	expectedAnswer := []FieldChange{
+		{Path: "BiosVersion", Old: "U30 v2.60", New: "U30 v2.72"},
+		{Path: "Nics[fc:15:b4:17:e2:2c]", New: after.Nics[2]},
+	}
+
+	answer, err := Diff(before, after)
+	if err != nil {
+		t.Fatalf("Found errors calling Diff %v", err)
+	}
+
+	if !reflect.DeepEqual(answer, expectedAnswer) {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, answer)
+	}
+
+	// removing the nic is reported the other way around
+	answer, err = Diff(after, before)
+	if err != nil {
+		t.Fatalf("Found errors calling Diff %v", err)
+	}
+
+	if len(answer) != 2 || answer[1].Path != "Nics[fc:15:b4:17:e2:2c]" || answer[1].Old != after.Nics[2] || answer[1].New != nil {
+		t.Errorf("Expected the nic to be reported as removed: found %v", answer)
+	}
+
+	answer, err = Diff(before, after, DiffIncludeVolatile())
+	if err != nil {
+		t.Fatalf("Found errors calling Diff %v", err)
+	}
+
+	if len(answer) != 3 || answer[2] != (FieldChange{Path: "TempC", Old: 21, New: 25}) {
+		t.Errorf("Expected the temperature change to be reported: found %v", answer)
+	}
+}
+
+func TestDiffReorderedNics(t *testing.T) {
+	before := &Discrete{
+		Serial: "cz3629fy3a",
+		Nics: []*Nic{
+			{Name: "eth0", MacAddress: "fc:15:b4:17:e2:2b", Speed: "1G"},
+			{Name: "eth1", MacAddress: "fc:15:b4:17:e2:2c", Speed: "10G"},
+		},
+	}
+
+	after := before.Clone()
+	after.Nics[0], after.Nics[1] = after.Nics[1], after.Nics[0]
+
+	answer, err := Diff(before, after)
+	if err != nil {
+		t.Fatalf("Found errors calling Diff %v", err)
+	}
+
+	if len(answer) != 0 {
+		t.Errorf("Expected no changes for reordered nics: found %v", answer)
+	}
+
+	after.Nics[0].Speed = "25G"
+	expectedAnswer := []FieldChange{
+		{Path: "Nics[fc:15:b4:17:e2:2c].Speed", Old: "10G", New: "25G"},
+	}
+
+	answer, err = Diff(before, after)
+	if err != nil {
+		t.Fatalf("Found errors calling Diff %v", err)
+	}
+
+	if !reflect.DeepEqual(answer, expectedAnswer) {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, answer)
+	}
+}
+
+func TestDiffChassis(t *testing.T) {
+	before := &Chassis{
+		Serial:    "cz3629fy3a",
+		FwVersion: "4.60",
+		Blades:    []*Blade{{Serial: "blade1", BmcVersion: "2.50", PowerKw: 0.2}},
+	}
+
+	after := before.Clone()
+	after.FwVersion = "4.70"
+	after.Blades[0].BmcVersion = "2.55"
+	after.Blades[0].PowerKw = 0.3
+
+	expectedAnswer := []FieldChange{
+		{Path: "Blades[blade1].BmcVersion", Old: "2.50", New: "2.55"},
+		{Path: "FwVersion", Old: "4.60", New: "4.70"},
+	}
+
+	answer, err := Diff(before, after)
+	if err != nil {
+		t.Fatalf("Found errors calling Diff %v", err)
+	}
+
+	if !reflect.DeepEqual(answer, expectedAnswer) {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, answer)
+	}
+}
+
+func TestDiffSensorReadings(t *testing.T) {
+	before := &Chassis{
+		Serial:             "cz3629fy3a",
+		TemperatureSensors: []*TemperatureSensor{{Name: "Ambient", Location: "Inlet", Reading: 21.5}},
+	}
+
+	after := before.Clone()
+	after.TemperatureSensors[0].Reading = 23
+
+	answer, err := Diff(before, after)
+	if err != nil {
+		t.Fatalf("Found errors calling Diff %v", err)
+	}
+
+	if len(answer) != 0 {
+		t.Errorf("Expected no changes for the sensor readings: found %v", answer)
+	}
+
+	expectedAnswer := []FieldChange{
+		{Path: "TemperatureSensors[Ambient].Reading", Old: 21.5, New: float64(23)},
+	}
+
+	answer, err = Diff(before, after, DiffIncludeVolatile())
+	if err != nil {
+		t.Fatalf("Found errors calling Diff %v", err)
+	}
+
+	if !reflect.DeepEqual(answer, expectedAnswer) {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, answer)
+	}
+}
+
+func TestDiffTimes(t *testing.T) {
+	expiresAt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	renewedAt := expiresAt.AddDate(1, 0, 0)
+	collectedAt := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	before := &Discrete{
+		Serial:      "cz3629fy3a",
+		CollectedAt: collectedAt,
+		BmcLicense:  &License{Type: "Advanced", ExpiresAt: &expiresAt},
+	}
+
+	after := before.Clone()
+	after.BmcLicense.ExpiresAt = &renewedAt
+	// the same instant in another location isn't a change
+	after.CollectedAt = collectedAt.In(time.FixedZone("CEST", 2*60*60))
+
+	expectedAnswer := []FieldChange{
+		{Path: "BmcLicense.ExpiresAt", Old: expiresAt, New: renewedAt},
+	}
+
+	answer, err := Diff(before, after, DiffIncludeVolatile())
+	if err != nil {
+		t.Fatalf("Found errors calling Diff %v", err)
+	}
+
+	if !reflect.DeepEqual(answer, expectedAnswer) {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, answer)
+	}
+
+	after.CollectedAt = collectedAt.Add(time.Minute)
+	answer, err = Diff(before, after, DiffIncludeVolatile())
+	if err != nil {
+		t.Fatalf("Found errors calling Diff %v", err)
+	}
+
+	if len(answer) != 2 || answer[0] != (FieldChange{Path: "CollectedAt", Old: collectedAt, New: after.CollectedAt}) {
+		t.Errorf("Expected the collection time change to be reported: found %v", answer)
+	}
+}
+
+func TestDiffMismatchedTypes(t *testing.T) {
+	if _, err := Diff(nil, nil); err == nil {
+		t.Errorf("Expected an error diffing nil snapshots")
+	}
+
+	if _, err := Diff(&Blade{}, &Discrete{}); err == nil {
+		t.Errorf("Expected an error diffing a Blade against a Discrete")
+	}
+
+	if _, err := Diff(&Nic{}, &Nic{}); err == nil {
+		t.Errorf("Expected an error diffing a type that isn't a snapshot")
+	}
+}
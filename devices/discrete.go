@@ -1,8 +1,14 @@
 package devices
 
+import (
+	"fmt"
+	"time"
+)
+
 // Discrete contains all the blade information we will expose across different vendors
 type Discrete struct {
 	Serial               string
+	CollectedAt          time.Time // When the snapshot was collected
 	Name                 string
 	BiosVersion          string
 	BmcType              string
@@ -13,8 +19,10 @@ type Discrete struct {
 	BmcIpmiReachable     bool
 	BmcLicenceType       string
 	BmcLicenceStatus     string
+	BmcLicense           *License `json:",omitempty"` // nil when the BMC doesn't expose license details
 	BmcAuth              bool
 	Disks                []*Disk
+	StorageControllers   []*StorageController
 	Nics                 []*Nic
 	Psus                 []*Psu
 	Model                string
@@ -27,5 +35,17 @@ type Discrete struct {
 	ProcessorCount       int
 	ProcessorCoreCount   int
 	ProcessorThreadCount int
+	CPUs                 []*CPU `json:",omitempty"`
 	Memory               int
+	MemoryModules        []*MemoryModule `json:",omitempty"`
+	BootOrder            []BootEntry     `json:",omitempty"`
+	GPUs                 []*GPU          `json:",omitempty"`
+	TPM                  *TPM            `json:",omitempty"` // nil when not collected
+	FirmwareInventory    []Firmware      `json:",omitempty"`
+}
+
+// String returns a single line summary of the discrete, useful for logging.
+// It only carries identifying fields, use JSON marshaling for the full detail.
+func (d *Discrete) String() string {
+	return fmt.Sprintf("Discrete %s %s: name=%s serial=%s bmc=%s", d.Vendor, d.Model, d.Name, d.Serial, d.BmcAddress)
 }
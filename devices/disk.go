@@ -5,8 +5,17 @@ type Disk struct {
 	Status    string
 	Serial    string
 	Type      string
-	Size      string
+	Size      string // As reported by the bmc, e.g. "1200 GB", see DiskSizeBytes for its size in bytes
 	Model     string
 	Location  string
 	FwVersion string
+	// The fields below are optional, providers that can't read them leave them at the zero value
+	SmartStatus        string `json:",omitempty"` // SMART overall health as reported by the BMC
+	PowerOnHours       int    `json:",omitempty"`
+	Temperature        int    `json:",omitempty"` // Celsius
+	WearLevel          int    `json:",omitempty"` // Percentage of the rated write endurance consumed, SSDs only
+	MediaErrors        int    `json:",omitempty"`
+	ReallocatedSectors int    `json:",omitempty"`
+	WWN                string `json:",omitempty"` // World Wide Name, used to correlate drives across systems
+	Interface          string `json:",omitempty"` // SATA, SAS, NVMe
 }
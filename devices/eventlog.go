@@ -0,0 +1,44 @@
+package devices
+
+import (
+	"strings"
+	"time"
+)
+
+// Severity is the normalized severity of an event log entry
+type Severity string
+
+const (
+	// SeverityOK is an informational event
+	SeverityOK Severity = "OK"
+	// SeverityWarning is an event that needs attention
+	SeverityWarning Severity = "Warning"
+	// SeverityCritical is an event reporting a failure
+	SeverityCritical Severity = "Critical"
+	// SeverityUnknown is used when the vendor severity can't be mapped
+	SeverityUnknown Severity = "Unknown"
+)
+
+// EventLogEntry represents an entry of the BMC event log (SEL)
+type EventLogEntry struct {
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Sensor    string    `json:"sensor,omitempty"`
+	EventType string    `json:"event_type,omitempty"`
+	Severity  Severity  `json:"severity"`
+	Message   string    `json:"message"`
+}
+
+// NormalizeSeverity maps the severity strings used by the different vendors to a Severity
+func NormalizeSeverity(severity string) Severity {
+	switch strings.ToLower(strings.TrimSpace(severity)) {
+	case "ok", "info", "informational", "normal", "0":
+		return SeverityOK
+	case "warning", "warn", "minor", "non-critical", "noncritical", "1":
+		return SeverityWarning
+	case "critical", "major", "error", "fatal", "non-recoverable", "nonrecoverable", "2":
+		return SeverityCritical
+	default:
+		return SeverityUnknown
+	}
+}
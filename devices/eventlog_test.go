@@ -0,0 +1,23 @@
+package devices
+
+import "testing"
+
+func TestNormalizeSeverity(t *testing.T) {
+	tests := map[string]Severity{
+		"OK":              SeverityOK,
+		"Informational":   SeverityOK,
+		" info ":          SeverityOK,
+		"Warning":         SeverityWarning,
+		"Non-Critical":    SeverityWarning,
+		"Critical":        SeverityCritical,
+		"Non-Recoverable": SeverityCritical,
+		"":                SeverityUnknown,
+		"Degraded?":       SeverityUnknown,
+	}
+
+	for severity, expectedAnswer := range tests {
+		if answer := NormalizeSeverity(severity); answer != expectedAnswer {
+			t.Errorf("%q: Expected answer %v: found %v", severity, expectedAnswer, answer)
+		}
+	}
+}
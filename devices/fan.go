@@ -1,11 +1,23 @@
 package devices
 
-// Fan represents que current status a fan
+import "fmt"
+
+// Fan represents a fan device
 type Fan struct {
 	Serial     string
+	Name       string `json:",omitempty"`
 	Status     string
-	Position   int
+	Position   int  // Bay the fan is installed in
+	Present    bool `json:",omitempty"`
 	Model      string
 	CurrentRPM int64
 	PowerKw    float64
 }
+
+// String returns a single line summary of the fan, useful for logging
+func (f *Fan) String() string {
+	return fmt.Sprintf(
+		"Fan %d: name=%s model=%s serial=%s present=%t status=%s rpm=%d",
+		f.Position, f.Name, f.Model, f.Serial, f.Present, f.Status, f.CurrentRPM,
+	)
+}
@@ -0,0 +1,27 @@
+package devices
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFanJSONKeys(t *testing.T) {
+	payload, err := json.Marshal(&Fan{Serial: "1_fan", Position: 1, CurrentRPM: 4200})
+	if err != nil {
+		t.Fatalf("Found errors marshaling the fan %v", err)
+	}
+
+	// the keys match the ones the fans were always marshaled with, the fields added later are omitted when empty
+	for _, key := range []string{`"Serial":"1_fan"`, `"Position":1`, `"CurrentRPM":4200`, `"PowerKw":0`} {
+		if !strings.Contains(string(payload), key) {
+			t.Errorf("Expected answer %s: found %s", key, payload)
+		}
+	}
+
+	for _, key := range []string{"Name", "Present"} {
+		if strings.Contains(string(payload), key) {
+			t.Errorf("Expected %s to be omitted: found %s", key, payload)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package devices
+
+import "github.com/bmc-toolbox/bmclib/errors"
+
+// FirmwareProgress reports the progress of a firmware update
+type FirmwareProgress struct {
+	Phase errors.FirmwareUpdatePhase `json:"phase"`
+	// Percent is the completion of the phase, from 0 to 100
+	Percent int    `json:"percent"`
+	Message string `json:"message,omitempty"`
+}
+
+// ReportFirmwareProgress calls the progress callback of a firmware update, a nil callback is a no-op.
+func ReportFirmwareProgress(progress func(FirmwareProgress), phase errors.FirmwareUpdatePhase, percent int, message string) {
+	if progress == nil {
+		return
+	}
+
+	progress(FirmwareProgress{Phase: phase, Percent: percent, Message: message})
+}
@@ -0,0 +1,43 @@
+package devices
+
+import "strings"
+
+// Health is the normalized health of a device or component
+type Health string
+
+const (
+	// HealthOK means the device reports no issues
+	HealthOK Health = "OK"
+	// HealthWarning means the device is degraded but operational
+	HealthWarning Health = "Warning"
+	// HealthCritical means the device reports a failure
+	HealthCritical Health = "Critical"
+	// HealthUnknown is used when the vendor health can't be mapped
+	HealthUnknown Health = "Unknown"
+)
+
+// healthStates maps the lowercased vendor health strings to a Health
+var healthStates = map[string]Health{
+	"ok":                 HealthOK,
+	"healthy":            HealthOK,
+	"normal":             HealthOK,
+	"good":               HealthOK,
+	"op_status_ok":       HealthOK,
+	"warning":            HealthWarning,
+	"degraded":           HealthWarning,
+	"op_status_degraded": HealthWarning,
+	"critical":           HealthCritical,
+	"unhealthy":          HealthCritical,
+	"failed":             HealthCritical,
+	"error":              HealthCritical,
+	"op_status_failed":   HealthCritical,
+}
+
+// NormalizeHealth maps the health strings returned by the different vendors to a Health
+func NormalizeHealth(health string) Health {
+	if normalized, ok := healthStates[strings.ToLower(strings.TrimSpace(health))]; ok {
+		return normalized
+	}
+
+	return HealthUnknown
+}
@@ -0,0 +1,25 @@
+package devices
+
+import "testing"
+
+func TestNormalizeHealth(t *testing.T) {
+	tests := map[string]Health{
+		"OK":               HealthOK,
+		"OP_STATUS_OK":     HealthOK,
+		" Healthy ":        HealthOK,
+		"Degraded":         HealthWarning,
+		"Warning":          HealthWarning,
+		"Unhealthy":        HealthCritical,
+		"OP_STATUS_FAILED": HealthCritical,
+		"Critical":         HealthCritical,
+		"UNKNOWN":          HealthUnknown,
+		"":                 HealthUnknown,
+		"3":                HealthUnknown,
+	}
+
+	for health, expectedAnswer := range tests {
+		if answer := NormalizeHealth(health); answer != expectedAnswer {
+			t.Errorf("%q: Expected answer %v: found %v", health, expectedAnswer, answer)
+		}
+	}
+}
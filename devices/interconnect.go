@@ -0,0 +1,13 @@
+package devices
+
+// Interconnect represents a switch or pass-thru module installed in a chassis interconnect bay
+type Interconnect struct {
+	Position    int    `json:"position"` // Interconnect bay
+	Serial      string `json:"serial,omitempty"`
+	Model       string `json:"model,omitempty"`
+	PartNumber  string `json:"part_number,omitempty"`
+	Vendor      string `json:"vendor,omitempty"`
+	FabricType  string `json:"fabric_type,omitempty"` // e.g. Ethernet, FibreChannel
+	MgmtAddress string `json:"mgmt_address,omitempty"`
+	Status      string `json:"status,omitempty"`
+}
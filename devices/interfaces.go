@@ -3,8 +3,10 @@ package devices
 import (
 	"context"
 	"crypto/x509"
+	"time"
 
 	"github.com/bmc-toolbox/bmclib/cfgresources"
+	"github.com/jacobweinstock/registrar"
 )
 
 // Bmc represents all the required bmc items
@@ -45,6 +47,7 @@ type BmcCollection interface {
 	IsOn() (bool, error)         // PowerStateGetter
 	Serial() (string, error)
 	Status() (string, error)
+	Health() (Health, error)
 	TempC() (int, error)
 	Vendor() string
 	Slot() (int, error)
@@ -100,6 +103,7 @@ type CmcCollection interface {
 	Psus() ([]*Psu, error)
 	Serial() (string, error)
 	Status() (string, error)
+	Health() (Health, error)
 	IsPsuRedundant() (bool, error)
 	PsuRedundancyMode() (string, error)
 	StorageBlades() ([]*StorageBlade, error)
@@ -119,6 +123,109 @@ type CmcSetup interface {
 	SetFlexAddressState(int, bool) (bool, error)
 }
 
+// PowerController declares the power control shared by the BMCs and chassis,
+// so callers can drive the power of a device regardless of its vendor.
+type PowerController interface {
+	PowerState() (string, error)
+	PowerOn() (bool, error)
+	PowerOff() (bool, error)
+	PowerCycle() (bool, error)
+	PowerReset() (bool, error)
+}
+
+// UserManager declares the management of the local BMC user accounts,
+// so a fleet tool can rotate accounts regardless of the vendor.
+type UserManager interface {
+	CreateUser(User) error
+	ModifyUser(User) error
+	DeleteUser(string) error
+	ListUsers() ([]User, error)
+	ChangePassword(string, string) error
+}
+
+// EventLogReader declares the access to the event log of a BMC or chassis,
+// the entries use the normalized severities so log collection doesn't depend on the vendor.
+type EventLogReader interface {
+	GetEventLog() ([]EventLogEntry, error)
+	ClearEventLog() error
+}
+
+// EventSubscriber declares the subscription to the events of a BMC as they happen, so alerting
+// doesn't have to poll the event log. The channel is closed once the context is canceled.
+type EventSubscriber interface {
+	SubscribeEvents(ctx context.Context) (<-chan EventLogEntry, error)
+}
+
+// SensorReader declares the sensor readings of a BMC or chassis, so monitoring code
+// can poll them regardless of the vendor. Providers unable to read a kind of sensor
+// return an errors.FeatureUnsupportedError.
+type SensorReader interface {
+	Fans() ([]*Fan, error)
+	PSUs() ([]*Psu, error)
+	Temperatures() ([]*TemperatureSensor, error)
+	HealthSensors() ([]*HealthSensor, error)
+}
+
+// Pinger declares a cheap check that a BMC or chassis is reachable and accepts the credentials,
+// so orchestrators can skip the failing hosts before running a sequence of operations.
+type Pinger interface {
+	Ping(context.Context) error
+}
+
+// FirmwareInventorier declares the listing of the firmware installed on the components of a server
+// or chassis, so firmware compliance can be reported across a fleet regardless of the vendor.
+// Providers unable to list the firmware return an errors.FeatureUnsupportedError.
+type FirmwareInventorier interface {
+	GetFirmwareInventory() ([]Firmware, error)
+}
+
+// FirmwareUpdater declares a firmware update reporting its progress, the optional callback is called
+// as the update goes through the prepare, upload, verify, flash and reboot phases. Only the phases
+// a provider observes on the BMC are reported, some of them may be skipped.
+type FirmwareUpdater interface {
+	UpdateFirmwareWithProgress(source, file string, progress func(FirmwareProgress)) (bool, string, error)
+}
+
+// VirtualMediaController declares the mounting of images as virtual media, so provisioning
+// flows can boot a machine from an image regardless of the vendor. Providers unable to mount
+// a kind of media return an errors.FeatureUnsupportedError.
+type VirtualMediaController interface {
+	MountVirtualMedia(ctx context.Context, kind MediaKind, image string) error
+	UnmountVirtualMedia(ctx context.Context, kind MediaKind) error
+	VirtualMediaStatus(ctx context.Context) ([]VirtualMedia, error)
+}
+
+// PowerCapper declares the power consumption limit of a server or enclosure, so a power budgeting
+// service can enforce the caps regardless of the vendor. Providers unable to cap the power
+// return an errors.FeatureUnsupportedError.
+type PowerCapper interface {
+	GetPowerCap(ctx context.Context) (PowerCap, error)
+	SetPowerCap(ctx context.Context, powerCap PowerCap) error
+}
+
+// BIOSConfigurator declares the access to the BIOS settings of a server, so BIOS compliance can be
+// enforced across a fleet regardless of the vendor. SetBIOSSettings returns whether a reboot is
+// required to apply the settings, until then they are returned by PendingBIOSSettings.
+// Providers unable to configure the BIOS return an errors.FeatureUnsupportedError.
+type BIOSConfigurator interface {
+	GetBIOSSettings(ctx context.Context) (*BIOSSettings, error)
+	SetBIOSSettings(ctx context.Context, settings *BIOSSettings) (rebootRequired bool, err error)
+	PendingBIOSSettings(ctx context.Context) (*BIOSSettings, error)
+}
+
+// TimeSyncVerifier declares the check of the clock of a BMC against a reference clock, so a BMC
+// with NTP configured but unable to reach its servers is caught. The offset is positive when the
+// BMC clock is ahead. Providers unable to read the BMC clock return an errors.FeatureUnsupportedError.
+type TimeSyncVerifier interface {
+	VerifyTimeSync(ctx context.Context, tolerance time.Duration) (offset time.Duration, inSync bool, err error)
+}
+
+// CapabilityReporter declares the report of the operations supported by the detected hardware,
+// as the providers.Feature constants, so callers can check an operation before attempting it.
+type CapabilityReporter interface {
+	Capabilities() registrar.Features
+}
+
 // Configure interface declares methods implemented
 // to apply configuration to BMCs.
 type Configure interface {
@@ -136,3 +243,10 @@ type Configure interface {
 	GenerateCSR(*cfgresources.HTTPSCertAttributes) ([]byte, error)
 	UploadHTTPSCert([]byte, string, []byte, string) (bool, error)
 }
+
+// PartialSnapshotter declares a best effort snapshot, the fields that can't be read are left empty
+// instead of failing the whole snapshot, so the serial, model or power state are still recorded when
+// e.g. the license can't be read. The errors are keyed by the name of the field of the returned device.
+type PartialSnapshotter interface {
+	ServerSnapshotPartial() (interface{}, map[string]error)
+}
@@ -0,0 +1,19 @@
+package devices
+
+import "time"
+
+// License represents a BMC license, ExpiresAt is nil for perpetual licenses
+// or when the BMC doesn't expose the expiry date.
+type License struct {
+	Name      string     `json:"name"`
+	Type      string     `json:"type"`
+	Status    string     `json:"status,omitempty"`
+	Keys      []string   `json:"keys,omitempty"`
+	Features  []string   `json:"features,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Expired returns true when the license has an expiry date before t.
+func (l *License) Expired(t time.Time) bool {
+	return l != nil && l.ExpiresAt != nil && l.ExpiresAt.Before(t)
+}
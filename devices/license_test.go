@@ -0,0 +1,28 @@
+package devices
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLicenseExpired(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	past := now.Add(-24 * time.Hour)
+	future := now.Add(24 * time.Hour)
+
+	tests := map[string]struct {
+		license        *License
+		expectedAnswer bool
+	}{
+		"nil":       {nil, false},
+		"perpetual": {&License{Name: "iLO Advanced", Type: "Perpetual"}, false},
+		"expired":   {&License{Name: "iLO Advanced", Type: "Evaluation", ExpiresAt: &past}, true},
+		"valid":     {&License{Name: "iLO Advanced", Type: "Evaluation", ExpiresAt: &future}, false},
+	}
+
+	for name, tc := range tests {
+		if answer := tc.license.Expired(now); answer != tc.expectedAnswer {
+			t.Errorf("%s: Expected answer %v: found %v", name, tc.expectedAnswer, answer)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package devices
+
+// MemoryModule represents a DIMM slot, empty slots are reported with Populated set to false
+type MemoryModule struct {
+	Slot         string `json:"slot"` // Locator, e.g. P1-DIMMA1
+	Populated    bool   `json:"populated"`
+	SizeMB       int    `json:"size_mb,omitempty"`
+	SpeedMHz     int    `json:"speed_mhz,omitempty"`
+	Type         string `json:"type,omitempty"` // e.g. DDR4
+	Manufacturer string `json:"manufacturer,omitempty"`
+	PartNumber   string `json:"part_number,omitempty"`
+	Serial       string `json:"serial,omitempty"`
+}
@@ -0,0 +1,161 @@
+package devices
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Merge combines two snapshots of the same blade and returns the result, base and overlay are left untouched.
+//
+// Precedence rules:
+//   - fields set in base are kept, zero valued fields (empty strings, 0, false, nil) are filled from overlay.
+//   - slices of components are merged by a stable key (MAC address for Nics, serial for Disks and Psus,
+//     slot or position for the others), base elements come first, overlay elements with a new key are appended
+//     and elements present in both are merged with the same rules.
+//   - elements without a key (e.g. a disk without a serial) can't be matched and are only taken
+//     from overlay when base has none.
+func Merge(base, overlay *Blade) *Blade {
+	if base == nil {
+		return overlay.Clone()
+	}
+
+	merged := base.Clone()
+	if overlay != nil {
+		mergeValues(reflect.ValueOf(merged).Elem(), reflect.ValueOf(overlay.Clone()).Elem())
+	}
+
+	return merged
+}
+
+// MergeDiscrete combines two snapshots of the same discrete, following the Merge precedence rules.
+func MergeDiscrete(base, overlay *Discrete) *Discrete {
+	if base == nil {
+		return overlay.Clone()
+	}
+
+	merged := base.Clone()
+	if overlay != nil {
+		mergeValues(reflect.ValueOf(merged).Elem(), reflect.ValueOf(overlay.Clone()).Elem())
+	}
+
+	return merged
+}
+
+// MergeChassis combines two snapshots of the same chassis, following the Merge precedence rules,
+// blades are matched by serial and merged as well.
+func MergeChassis(base, overlay *Chassis) *Chassis {
+	if base == nil {
+		return overlay.Clone()
+	}
+
+	merged := base.Clone()
+	if overlay != nil {
+		mergeValues(reflect.ValueOf(merged).Elem(), reflect.ValueOf(overlay.Clone()).Elem())
+	}
+
+	return merged
+}
+
+// mergeKeys returns the key used to match the elements of the component slices
+var mergeKeys = map[reflect.Type]func(reflect.Value) string{
+	reflect.TypeOf(&Nic{}):               func(v reflect.Value) string { return strings.ToLower(v.Interface().(*Nic).MacAddress) },
+	reflect.TypeOf(&Disk{}):              func(v reflect.Value) string { return NormalizeSerial(v.Interface().(*Disk).Serial) },
+	reflect.TypeOf(&Psu{}):               func(v reflect.Value) string { return NormalizeSerial(v.Interface().(*Psu).Serial) },
+	reflect.TypeOf(&Blade{}):             func(v reflect.Value) string { return NormalizeSerial(v.Interface().(*Blade).Serial) },
+	reflect.TypeOf(&StorageBlade{}):      func(v reflect.Value) string { return NormalizeSerial(v.Interface().(*StorageBlade).Serial) },
+	reflect.TypeOf(&StorageController{}): func(v reflect.Value) string { return NormalizeSerial(v.Interface().(*StorageController).Serial) },
+	reflect.TypeOf(&GPU{}):               func(v reflect.Value) string { return v.Interface().(*GPU).Slot },
+	reflect.TypeOf(&CPU{}):               func(v reflect.Value) string { return v.Interface().(*CPU).Slot },
+	reflect.TypeOf(&MemoryModule{}):      func(v reflect.Value) string { return v.Interface().(*MemoryModule).Slot },
+	reflect.TypeOf(&TemperatureSensor{}): func(v reflect.Value) string { return v.Interface().(*TemperatureSensor).Name },
+	reflect.TypeOf(&Fan{}):               func(v reflect.Value) string { return positionKey(v.Interface().(*Fan).Position) },
+	reflect.TypeOf(&Interconnect{}):      func(v reflect.Value) string { return positionKey(v.Interface().(*Interconnect).Position) },
+	reflect.TypeOf(BootEntry{}):          func(v reflect.Value) string { return v.Interface().(BootEntry).Device },
+	reflect.TypeOf(Firmware{}):           func(v reflect.Value) string { return v.Interface().(Firmware).Component },
+}
+
+func positionKey(position int) string {
+	if position == 0 {
+		return ""
+	}
+
+	return fmt.Sprint(position)
+}
+
+// mergeValues fills the zero valued fields of the dst struct from src
+func mergeValues(dst, src reflect.Value) {
+	for i := 0; i < dst.NumField(); i++ {
+		if dst.Type().Field(i).PkgPath != "" {
+			continue
+		}
+
+		dstField, srcField := dst.Field(i), src.Field(i)
+		switch {
+		case dstField.Kind() == reflect.Slice:
+			dstField.Set(mergeSlices(dstField, srcField))
+		case dstField.IsZero():
+			dstField.Set(srcField)
+		case dstField.Kind() == reflect.Struct:
+			mergeValues(dstField, srcField)
+		case dstField.Kind() == reflect.Ptr && dstField.Elem().Kind() == reflect.Struct && !srcField.IsNil():
+			mergeValues(dstField.Elem(), srcField.Elem())
+		}
+	}
+}
+
+// mergeSlices merges src into dst by the element key, slices without a key are taken from src when dst is empty
+func mergeSlices(dst, src reflect.Value) reflect.Value {
+	if dst.Len() == 0 {
+		return src
+	}
+
+	key, ok := mergeKeys[dst.Type().Elem()]
+	if !ok {
+		return dst
+	}
+
+	positions := make(map[string]int)
+	for i := 0; i < dst.Len(); i++ {
+		if isNilPtr(dst.Index(i)) {
+			continue
+		}
+
+		if k := key(dst.Index(i)); k != "" {
+			positions[k] = i
+		}
+	}
+
+	merged := dst
+	for i := 0; i < src.Len(); i++ {
+		elem := src.Index(i)
+		if isNilPtr(elem) {
+			continue
+		}
+
+		k := key(elem)
+		if k == "" {
+			continue
+		}
+
+		pos, exists := positions[k]
+		if !exists {
+			positions[k] = merged.Len()
+			merged = reflect.Append(merged, elem)
+			continue
+		}
+
+		switch target := merged.Index(pos); target.Kind() {
+		case reflect.Ptr:
+			mergeValues(target.Elem(), elem.Elem())
+		case reflect.Struct:
+			mergeValues(target, elem)
+		}
+	}
+
+	return merged
+}
+
+func isNilPtr(v reflect.Value) bool {
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
@@ -0,0 +1,90 @@
+package devices
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeOverlapping(t *testing.T) {
+	base := &Blade{
+		Serial:      "cz3629fy3a",
+		Vendor:      HP,
+		BiosVersion: "I36",
+		Nics:        []*Nic{{Name: "bmc", MacAddress: "fc:15:b4:17:e2:2a"}},
+		Disks:       []*Disk{{Serial: "s403crxk0000e7227365", Status: "OK"}},
+	}
+
+	overlay := &Blade{
+		Serial:      "CZ3629FY3A",
+		BiosVersion: "I37",
+		Name:        "host01",
+		Memory:      256,
+		Nics: []*Nic{
+			{Name: "bmc", MacAddress: "FC:15:B4:17:E2:2A", Speed: "1G"},
+			{Name: "eth0", MacAddress: "fc:15:b4:17:e2:2b"},
+		},
+		Disks: []*Disk{{Serial: "S403CRXK0000E7227365", Model: "eg1200jemda", Status: "FAILED"}},
+	}
+
+	expectedAnswer := &Blade{
+		Serial:      "cz3629fy3a",
+		Vendor:      HP,
+		BiosVersion: "I36",
+		Name:        "host01",
+		Memory:      256,
+		Nics: []*Nic{
+			{Name: "bmc", MacAddress: "fc:15:b4:17:e2:2a", Speed: "1G"},
+			{Name: "eth0", MacAddress: "fc:15:b4:17:e2:2b"},
+		},
+		Disks: []*Disk{{Serial: "s403crxk0000e7227365", Model: "eg1200jemda", Status: "OK"}},
+	}
+
+	answer := Merge(base, overlay)
+	if !reflect.DeepEqual(answer, expectedAnswer) {
+		t.Errorf("Expected answer %+v: found %+v", expectedAnswer, answer)
+	}
+
+	if len(base.Nics) != 1 || base.Name != "" || base.Disks[0].Model != "" {
+		t.Errorf("Expected base to be left untouched: found %+v", base)
+	}
+}
+
+func TestMergeDisjoint(t *testing.T) {
+	base := &Discrete{Serial: "h16z4m2", Psus: []*Psu{{Serial: "ps1"}}, Disks: []*Disk{{Status: "OK"}}}
+	overlay := &Discrete{
+		Processor: "intel(r) xeon(r) gold 6140",
+		Psus:      []*Psu{{Serial: "ps2"}},
+		Disks:     []*Disk{{Status: "OK"}},
+		Nics:      []*Nic{{Name: "eth0"}},
+	}
+
+	expectedAnswer := &Discrete{
+		Serial:    "h16z4m2",
+		Processor: "intel(r) xeon(r) gold 6140",
+		Psus:      []*Psu{{Serial: "ps1"}, {Serial: "ps2"}},
+		Disks:     []*Disk{{Status: "OK"}},
+		Nics:      []*Nic{{Name: "eth0"}},
+	}
+
+	answer := MergeDiscrete(base, overlay)
+	if !reflect.DeepEqual(answer, expectedAnswer) {
+		t.Errorf("Expected answer %+v: found %+v", expectedAnswer, answer)
+	}
+}
+
+func TestMergeChassis(t *testing.T) {
+	base := &Chassis{Serial: "cz3629fy3a", Blades: []*Blade{{Serial: "blade1", BladePosition: 1}}}
+	overlay := &Chassis{
+		Model:  "BladeSystem c7000 Enclosure G2",
+		Blades: []*Blade{{Serial: "blade1", Name: "host01"}, {Serial: "blade2", BladePosition: 2}},
+	}
+
+	answer := MergeChassis(base, overlay)
+	if answer.Model != overlay.Model || len(answer.Blades) != 2 || answer.Blades[0].Name != "host01" || answer.Blades[0].BladePosition != 1 {
+		t.Errorf("Unexpected merged chassis %+v", answer)
+	}
+
+	if MergeChassis(nil, overlay).Model != overlay.Model {
+		t.Errorf("Expected the overlay when base is nil")
+	}
+}
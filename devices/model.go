@@ -0,0 +1,58 @@
+package devices
+
+import (
+	"regexp"
+	"strings"
+)
+
+// modelAliases maps known model variants, per vendor, to their canonical name.
+// Keys are lowercased with whitespace collapsed, canonical names match themselves.
+var modelAliases = map[string]map[string]string{
+	HP: {
+		"proliant dl360 g9":  "ProLiant DL360 Gen9",
+		"proliant dl380 g9":  "ProLiant DL380 Gen9",
+		"proliant dl380 g10": "ProLiant DL380 Gen10",
+		"proliant bl460c g8": "ProLiant BL460c Gen8",
+		"proliant bl460c g9": "ProLiant BL460c Gen9",
+		"bl460c gen9":        "ProLiant BL460c Gen9",
+		"dl380 gen9":         "ProLiant DL380 Gen9",
+		"dl380 gen10":        "ProLiant DL380 Gen10",
+	},
+	Supermicro: {
+		"x10drff-ctg": "X10DRFF-CTG",
+		"x10dri-t":    "X10DRi-T",
+		"x10dri-t4+":  "X10DRi-T4+",
+		"x11dph-t":    "X11DPH-T",
+		"x11scm-f":    "X11SCM-F",
+		"x11ssm-f":    "X11SSM-F",
+	},
+}
+
+var (
+	hpPrefix          = regexp.MustCompile(`(?i)^hpe?\s+`)
+	supermicroPrefix  = regexp.MustCompile(`(?i)^(supermicro\s+|mbd-)`)
+	supermicroPackage = regexp.MustCompile(`(?i)-[ob]$`)
+)
+
+// NormalizeModel returns the canonical model name for the given vendor and raw model string,
+// falling back to the raw string when the model is not known.
+func NormalizeModel(vendor, raw string) string {
+	model := strings.Join(strings.Fields(raw), " ")
+
+	switch vendor {
+	case HP:
+		model = hpPrefix.ReplaceAllString(model, "")
+	case Supermicro:
+		model = supermicroPrefix.ReplaceAllString(model, "")
+		model = supermicroPackage.ReplaceAllString(model, "")
+	}
+
+	key := strings.ToLower(model)
+	for alias, canonical := range modelAliases[vendor] {
+		if alias == key || strings.ToLower(canonical) == key {
+			return canonical
+		}
+	}
+
+	return raw
+}
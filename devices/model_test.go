@@ -0,0 +1,31 @@
+package devices
+
+import "testing"
+
+func TestNormalizeModel(t *testing.T) {
+	tests := []struct {
+		vendor         string
+		raw            string
+		expectedAnswer string
+	}{
+		{Supermicro, "X10DRFF-CTG", "X10DRFF-CTG"},
+		{Supermicro, "x10drff-ctg", "X10DRFF-CTG"},
+		{Supermicro, "MBD-X10DRi-T-O", "X10DRi-T"},
+		{Supermicro, "MBD-X11SCM-F-B", "X11SCM-F"},
+		{Supermicro, " Supermicro  X11DPH-T ", "X11DPH-T"},
+		{Supermicro, "X9DRFF-7", "X9DRFF-7"},
+		{HP, "ProLiant DL380 Gen9", "ProLiant DL380 Gen9"},
+		{HP, "HP ProLiant DL380 G9", "ProLiant DL380 Gen9"},
+		{HP, "HPE ProLiant DL380 Gen10", "ProLiant DL380 Gen10"},
+		{HP, "proliant  bl460c g8", "ProLiant BL460c Gen8"},
+		{HP, "ProLiant DL120 Gen7", "ProLiant DL120 Gen7"},
+		{Dell, "PowerEdge R640", "PowerEdge R640"},
+		{"", "", ""},
+	}
+
+	for _, tc := range tests {
+		if answer := NormalizeModel(tc.vendor, tc.raw); answer != tc.expectedAnswer {
+			t.Errorf("%s %q: Expected answer %q: found %q", tc.vendor, tc.raw, tc.expectedAnswer, answer)
+		}
+	}
+}
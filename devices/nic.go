@@ -6,4 +6,10 @@ type Nic struct {
 	Name       string
 	Up         bool
 	Speed      string
+	// The fields below are optional, providers that can't read them leave them at the zero value
+	SpeedMbps int  `json:",omitempty"`
+	MTU       int  `json:",omitempty"`
+	VlanID    int  `json:",omitempty"`
+	BMC       bool `json:",omitempty"` // Interface belongs to the BMC
+	Shared    bool `json:",omitempty"` // BMC traffic shares the interface with the host
 }
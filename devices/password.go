@@ -0,0 +1,94 @@
+package devices
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// PasswordPolicy is the set of requirements a BMC user password has to meet
+type PasswordPolicy struct {
+	MinLength int
+	// MaxLength is the longest password accepted, unbounded when zero
+	MaxLength      int
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSpecial bool
+}
+
+// IPMIPasswordMaxLength is the longest password an IPMI 2.0 user account takes, in bytes
+const IPMIPasswordMaxLength = 20
+
+// DefaultPasswordPolicy returns the policy the providers check the passwords against before creating a user or
+// changing a password, unless they're given another one. It follows the constraints common to BMCs: a minimum
+// length of 8 and a mix of upper case, lower case and digits.
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:    8,
+		RequireUpper: true,
+		RequireLower: true,
+		RequireDigit: true,
+	}
+}
+
+// IPMIPasswordPolicy returns the DefaultPasswordPolicy capped to the IPMIPasswordMaxLength,
+// for the providers managing the users through IPMI or the CGI endpoints wrapping it.
+func IPMIPasswordPolicy() PasswordPolicy {
+	policy := DefaultPasswordPolicy()
+	policy.MaxLength = IPMIPasswordMaxLength
+
+	return policy
+}
+
+// ValidatePassword returns an *errors.PasswordPolicyError listing the requirements of the policy the password
+// doesn't meet. The password has to be printable ASCII, most BMCs garble or reject the other characters.
+func ValidatePassword(pw string, policy PasswordPolicy) error {
+	var unmet []string
+
+	if len(pw) < policy.MinLength {
+		unmet = append(unmet, fmt.Sprintf("at least %d characters", policy.MinLength))
+	}
+	if policy.MaxLength > 0 && len(pw) > policy.MaxLength {
+		unmet = append(unmet, fmt.Sprintf("at most %d characters", policy.MaxLength))
+	}
+
+	var upper, lower, digit, special, unprintable bool
+	for _, r := range pw {
+		switch {
+		case r > unicode.MaxASCII || !unicode.IsPrint(r):
+			unprintable = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			special = true
+		}
+	}
+
+	if policy.RequireUpper && !upper {
+		unmet = append(unmet, "an upper case letter")
+	}
+	if policy.RequireLower && !lower {
+		unmet = append(unmet, "a lower case letter")
+	}
+	if policy.RequireDigit && !digit {
+		unmet = append(unmet, "a digit")
+	}
+	if policy.RequireSpecial && !special {
+		unmet = append(unmet, "a special character")
+	}
+	if unprintable {
+		unmet = append(unmet, "only printable ASCII characters")
+	}
+
+	if len(unmet) > 0 {
+		return &errors.PasswordPolicyError{Unmet: unmet}
+	}
+
+	return nil
+}
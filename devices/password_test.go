@@ -0,0 +1,54 @@
+package devices
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+)
+
+func TestValidatePassword(t *testing.T) {
+	strict := PasswordPolicy{MinLength: 12, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSpecial: true}
+
+	tests := []struct {
+		name     string
+		password string
+		policy   PasswordPolicy
+		unmet    []string
+	}{
+		{"default policy", "Sup3rSecret", DefaultPasswordPolicy(), nil},
+		{"default policy with special characters", "P@ssw0rd!", DefaultPasswordPolicy(), nil},
+		{"too short", "Ab1", DefaultPasswordPolicy(), []string{"at least 8 characters"}},
+		{"long password", "Abcdefghij0123456789x", DefaultPasswordPolicy(), nil},
+		{"too long for ipmi", "Abcdefghij0123456789x", IPMIPasswordPolicy(), []string{"at most 20 characters"}},
+		{"ipmi policy", "Abcdefghij0123456789", IPMIPasswordPolicy(), nil},
+		{"lower case only", "calvincalvin", DefaultPasswordPolicy(), []string{"an upper case letter", "a digit"}},
+		{"digits only", "12345678", DefaultPasswordPolicy(), []string{"an upper case letter", "a lower case letter"}},
+		{"empty", "", DefaultPasswordPolicy(), []string{"at least 8 characters", "an upper case letter", "a lower case letter", "a digit"}},
+		{"non ascii", "Pässw0rd", DefaultPasswordPolicy(), []string{"only printable ASCII characters"}},
+		{"strict policy", "Corr3ct-Horse-Battery", strict, nil},
+		{"strict policy without special characters", "Corr3ctHorseBattery", strict, []string{"a special character"}},
+		{"no requirements", "x", PasswordPolicy{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePassword(tt.password, tt.policy)
+			if tt.unmet == nil {
+				if err != nil {
+					t.Errorf("Expected no error: found %v", err)
+				}
+				return
+			}
+
+			var policyErr *bmclibErrs.PasswordPolicyError
+			if !errors.As(err, &policyErr) || !errors.Is(err, bmclibErrs.ErrWeakPassword) {
+				t.Fatalf("Expected error %v: found %v", bmclibErrs.ErrWeakPassword, err)
+			}
+			if !reflect.DeepEqual(policyErr.Unmet, tt.unmet) {
+				t.Errorf("Expected answer %v: found %v", tt.unmet, policyErr.Unmet)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package devices
+
+import (
+	"fmt"
+
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// PowerCap is the power consumption limit of a server or enclosure
+type PowerCap struct {
+	Enabled bool `json:"enabled"`
+	// Watts is the limit, it is ignored when the cap is disabled
+	Watts int `json:"watts,omitempty"`
+}
+
+// Validate returns an errors.ErrInvalidPowerCap when the cap is enabled without a positive limit
+func (p PowerCap) Validate() error {
+	if p.Enabled && p.Watts <= 0 {
+		return fmt.Errorf("limit of %d watts: %w", p.Watts, errors.ErrInvalidPowerCap)
+	}
+
+	return nil
+}
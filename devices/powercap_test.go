@@ -0,0 +1,31 @@
+package devices
+
+import (
+	"errors"
+	"testing"
+
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+)
+
+func TestPowerCapValidate(t *testing.T) {
+	tests := []struct {
+		powerCap PowerCap
+		valid    bool
+	}{
+		{PowerCap{Enabled: true, Watts: 450}, true},
+		{PowerCap{Enabled: false}, true},
+		{PowerCap{Enabled: false, Watts: -1}, true},
+		{PowerCap{Enabled: true}, false},
+		{PowerCap{Enabled: true, Watts: -450}, false},
+	}
+
+	for _, tt := range tests {
+		err := tt.powerCap.Validate()
+		if tt.valid && err != nil {
+			t.Errorf("Expected %+v to be valid: found %v", tt.powerCap, err)
+		}
+		if !tt.valid && !errors.Is(err, bmclibErrs.ErrInvalidPowerCap) {
+			t.Errorf("Expected error %v for %+v: found %v", bmclibErrs.ErrInvalidPowerCap, tt.powerCap, err)
+		}
+	}
+}
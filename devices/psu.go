@@ -1,11 +1,24 @@
 package devices
 
+import "fmt"
+
 // Psu represents a power supply device
 type Psu struct {
-	Serial     string
-	CapacityKw float64
-	PowerKw    float64
-	Status     string
-	PartNumber string
-	Position   int
+	Serial       string
+	CapacityKw   float64
+	PowerKw      float64
+	Status       string
+	PartNumber   string
+	Position     int     // Bay the PSU is installed in
+	Present      bool    `json:",omitempty"`
+	InputVoltage float64 `json:",omitempty"`
+	OutputWatts  int64   `json:",omitempty"`
+}
+
+// String returns a single line summary of the power supply, useful for logging
+func (p *Psu) String() string {
+	return fmt.Sprintf(
+		"Psu %d: part=%s serial=%s present=%t status=%s capacity=%.3fkW output=%dW input=%.1fV",
+		p.Position, p.PartNumber, p.Serial, p.Present, p.Status, p.CapacityKw, p.OutputWatts, p.InputVoltage,
+	)
 }
@@ -0,0 +1,187 @@
+package devices
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RedfishStatus is the Redfish Resource.Status shape
+type RedfishStatus struct {
+	Health string `json:"Health,omitempty"`
+	State  string `json:"State,omitempty"`
+}
+
+// RedfishProcessorSummary is the Redfish ComputerSystem.ProcessorSummary shape
+type RedfishProcessorSummary struct {
+	Count int    `json:"Count"`
+	Model string `json:"Model,omitempty"`
+}
+
+// RedfishMemorySummary is the Redfish ComputerSystem.MemorySummary shape
+type RedfishMemorySummary struct {
+	TotalSystemMemoryGiB float64 `json:"TotalSystemMemoryGiB"`
+}
+
+// RedfishComputerSystem holds the Redfish ComputerSystem properties that overlap with Blade and Discrete
+type RedfishComputerSystem struct {
+	Manufacturer     string                  `json:"Manufacturer,omitempty"`
+	Model            string                  `json:"Model,omitempty"`
+	SerialNumber     string                  `json:"SerialNumber,omitempty"`
+	HostName         string                  `json:"HostName,omitempty"`
+	BiosVersion      string                  `json:"BiosVersion,omitempty"`
+	PowerState       string                  `json:"PowerState,omitempty"`
+	ProcessorSummary RedfishProcessorSummary `json:"ProcessorSummary"`
+	MemorySummary    RedfishMemorySummary    `json:"MemorySummary"`
+	Status           RedfishStatus           `json:"Status"`
+}
+
+// RedfishChassis holds the Redfish Chassis properties that overlap with Chassis
+type RedfishChassis struct {
+	Manufacturer string        `json:"Manufacturer,omitempty"`
+	Model        string        `json:"Model,omitempty"`
+	SerialNumber string        `json:"SerialNumber,omitempty"`
+	Name         string        `json:"Name,omitempty"`
+	Status       RedfishStatus `json:"Status"`
+}
+
+// RedfishManagerAccount holds the Redfish ManagerAccount properties that overlap with User,
+// the password is write only and never returned by the BMC.
+type RedfishManagerAccount struct {
+	ID       string `json:"Id,omitempty"`
+	UserName string `json:"UserName"`
+	Password string `json:"Password,omitempty"`
+	RoleID   string `json:"RoleId,omitempty"`
+	Enabled  bool   `json:"Enabled"`
+}
+
+// ToUser maps the ManagerAccount to a User, the account slots without a user name are empty
+func (r *RedfishManagerAccount) ToUser() User {
+	id, _ := strconv.Atoi(r.ID)
+	return User{
+		ID:      id,
+		Name:    r.UserName,
+		Role:    NormalizeUserRole(r.RoleID),
+		Enabled: r.Enabled,
+	}
+}
+
+// RedfishRoleID maps a UserRole to the predefined Redfish role of the AccountService,
+// ok is false for the roles without a Redfish counterpart.
+func RedfishRoleID(role UserRole) (roleID string, ok bool) {
+	switch role {
+	case UserRoleAdmin:
+		return "Administrator", true
+	case UserRoleOperator:
+		return "Operator", true
+	case UserRoleReadOnly:
+		return "ReadOnly", true
+	default:
+		return "", false
+	}
+}
+
+// BladeToRedfish maps a Blade to a Redfish ComputerSystem
+func BladeToRedfish(b *Blade) *RedfishComputerSystem {
+	return &RedfishComputerSystem{
+		Manufacturer:     b.Vendor,
+		Model:            b.Model,
+		SerialNumber:     b.Serial,
+		HostName:         b.Name,
+		BiosVersion:      b.BiosVersion,
+		PowerState:       redfishPowerState(b.PowerState),
+		ProcessorSummary: RedfishProcessorSummary{Count: b.ProcessorCount, Model: b.Processor},
+		MemorySummary:    RedfishMemorySummary{TotalSystemMemoryGiB: float64(b.Memory)},
+		Status:           RedfishStatus{Health: redfishHealth(b.Status)},
+	}
+}
+
+// DiscreteToRedfish maps a Discrete to a Redfish ComputerSystem
+func DiscreteToRedfish(d *Discrete) *RedfishComputerSystem {
+	return &RedfishComputerSystem{
+		Manufacturer:     d.Vendor,
+		Model:            d.Model,
+		SerialNumber:     d.Serial,
+		HostName:         d.Name,
+		BiosVersion:      d.BiosVersion,
+		PowerState:       redfishPowerState(d.PowerState),
+		ProcessorSummary: RedfishProcessorSummary{Count: d.ProcessorCount, Model: d.Processor},
+		MemorySummary:    RedfishMemorySummary{TotalSystemMemoryGiB: float64(d.Memory)},
+		Status:           RedfishStatus{Health: redfishHealth(d.Status)},
+	}
+}
+
+// ChassisToRedfish maps a Chassis to a Redfish Chassis
+func ChassisToRedfish(c *Chassis) *RedfishChassis {
+	return &RedfishChassis{
+		Manufacturer: c.Vendor,
+		Model:        c.Model,
+		SerialNumber: c.Serial,
+		Name:         c.Name,
+		Status:       RedfishStatus{Health: redfishHealth(c.Status)},
+	}
+}
+
+// ToBlade maps the ComputerSystem to a Blade
+func (r *RedfishComputerSystem) ToBlade() *Blade {
+	return &Blade{
+		Vendor:         r.Manufacturer,
+		Model:          r.Model,
+		Serial:         r.SerialNumber,
+		Name:           r.HostName,
+		BiosVersion:    r.BiosVersion,
+		PowerState:     strings.ToLower(r.PowerState),
+		ProcessorCount: r.ProcessorSummary.Count,
+		Processor:      r.ProcessorSummary.Model,
+		Memory:         int(r.MemorySummary.TotalSystemMemoryGiB),
+		Status:         r.Status.Health,
+	}
+}
+
+// ToDiscrete maps the ComputerSystem to a Discrete
+func (r *RedfishComputerSystem) ToDiscrete() *Discrete {
+	return &Discrete{
+		Vendor:         r.Manufacturer,
+		Model:          r.Model,
+		Serial:         r.SerialNumber,
+		Name:           r.HostName,
+		BiosVersion:    r.BiosVersion,
+		PowerState:     strings.ToLower(r.PowerState),
+		ProcessorCount: r.ProcessorSummary.Count,
+		Processor:      r.ProcessorSummary.Model,
+		Memory:         int(r.MemorySummary.TotalSystemMemoryGiB),
+		Status:         r.Status.Health,
+	}
+}
+
+// ToChassis maps the Redfish Chassis to a Chassis
+func (r *RedfishChassis) ToChassis() *Chassis {
+	return &Chassis{
+		Vendor: r.Manufacturer,
+		Model:  r.Model,
+		Serial: r.SerialNumber,
+		Name:   r.Name,
+		Status: r.Status.Health,
+	}
+}
+
+// redfishPowerState maps the bmclib power state (on, off) to the Redfish PowerState enum
+func redfishPowerState(state string) string {
+	switch strings.ToLower(state) {
+	case "on":
+		return "On"
+	case "off":
+		return "Off"
+	default:
+		return ""
+	}
+}
+
+// redfishHealth maps a health string to the Redfish Health enum, which shares the Health vocabulary
+func redfishHealth(status string) string {
+	health := NormalizeHealth(status)
+	if health == HealthUnknown {
+		return ""
+	}
+
+	return string(health)
+}
@@ -0,0 +1,122 @@
+package devices
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiscreteToRedfish(t *testing.T) {
+	tests := []struct {
+		name     string
+		discrete *Discrete
+		expected *RedfishComputerSystem
+	}{
+		{
+			name: "powered on and healthy",
+			discrete: &Discrete{
+				Vendor: HP, Model: "ProLiant DL360 Gen10", Serial: "cz3629fy3a", Name: "host01", BiosVersion: "U32",
+				PowerState: "on", ProcessorCount: 2, Processor: "intel(r) xeon(r) gold 6140", Memory: 384, Status: "OK",
+			},
+			expected: &RedfishComputerSystem{
+				Manufacturer: HP, Model: "ProLiant DL360 Gen10", SerialNumber: "cz3629fy3a", HostName: "host01", BiosVersion: "U32",
+				PowerState: "On", ProcessorSummary: RedfishProcessorSummary{Count: 2, Model: "intel(r) xeon(r) gold 6140"},
+				MemorySummary: RedfishMemorySummary{TotalSystemMemoryGiB: 384}, Status: RedfishStatus{Health: "OK"},
+			},
+		},
+		{
+			name:     "powered off and degraded",
+			discrete: &Discrete{Serial: "cz3629fy3a", PowerState: "off", Status: "Degraded"},
+			expected: &RedfishComputerSystem{SerialNumber: "cz3629fy3a", PowerState: "Off", Status: RedfishStatus{Health: "Warning"}},
+		},
+		{
+			name:     "unknown state",
+			discrete: &Discrete{Serial: "cz3629fy3a", PowerState: "unknown", Status: "UNKNOWN"},
+			expected: &RedfishComputerSystem{SerialNumber: "cz3629fy3a"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			answer := DiscreteToRedfish(tc.discrete)
+			if !reflect.DeepEqual(answer, tc.expected) {
+				t.Errorf("Expected answer %+v: found %+v", tc.expected, answer)
+			}
+		})
+	}
+}
+
+func TestRedfishRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		blade *Blade
+	}{
+		{
+			name:  "full",
+			blade: &Blade{Vendor: Dell, Model: "PowerEdge M640", Serial: "h16z4m2", Name: "blade01", BiosVersion: "2.8.2", PowerState: "on", ProcessorCount: 2, Processor: "intel(r) xeon(r) gold 6140", Memory: 256, Status: "OK"},
+		},
+		{
+			name:  "powered off",
+			blade: &Blade{Vendor: Dell, Serial: "h16z4m2", PowerState: "off", Status: "Critical"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			answer := BladeToRedfish(tc.blade).ToBlade()
+			if !reflect.DeepEqual(answer, tc.blade) {
+				t.Errorf("Expected answer %+v: found %+v", tc.blade, answer)
+			}
+		})
+	}
+}
+
+func TestChassisRedfish(t *testing.T) {
+	tests := []struct {
+		name     string
+		chassis  *Chassis
+		expected *RedfishChassis
+	}{
+		{
+			name:     "healthy",
+			chassis:  &Chassis{Vendor: HP, Model: "BladeSystem c7000 Enclosure G2", Serial: "cz3629fy3a", Name: "chassis01", Status: "OK"},
+			expected: &RedfishChassis{Manufacturer: HP, Model: "BladeSystem c7000 Enclosure G2", SerialNumber: "cz3629fy3a", Name: "chassis01", Status: RedfishStatus{Health: "OK"}},
+		},
+		{
+			name:     "unknown health",
+			chassis:  &Chassis{Serial: "cz3629fy3a", Status: "UNKNOWN"},
+			expected: &RedfishChassis{SerialNumber: "cz3629fy3a"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			answer := ChassisToRedfish(tc.chassis)
+			if !reflect.DeepEqual(answer, tc.expected) {
+				t.Errorf("Expected answer %+v: found %+v", tc.expected, answer)
+			}
+
+			if back := answer.ToChassis(); back.Serial != tc.chassis.Serial || back.Model != tc.chassis.Model {
+				t.Errorf("Expected answer %+v: found %+v", tc.chassis, back)
+			}
+		})
+	}
+}
+
+func TestRedfishManagerAccount(t *testing.T) {
+	for _, role := range []UserRole{UserRoleAdmin, UserRoleOperator, UserRoleReadOnly} {
+		roleID, ok := RedfishRoleID(role)
+		if !ok {
+			t.Fatalf("Expected a Redfish role for %v", role)
+		}
+
+		account := &RedfishManagerAccount{ID: "3", UserName: "operator", RoleID: roleID, Enabled: true}
+		expectedAnswer := User{ID: 3, Name: "operator", Role: role, Enabled: true}
+		if answer := account.ToUser(); answer != expectedAnswer {
+			t.Errorf("Expected answer %v: found %v", expectedAnswer, answer)
+		}
+	}
+
+	if _, ok := RedfishRoleID(UserRoleOEM); ok {
+		t.Errorf("Expected no Redfish role for %v", UserRoleOEM)
+	}
+}
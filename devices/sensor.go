@@ -0,0 +1,17 @@
+package devices
+
+import "fmt"
+
+// HealthSensor represents a BMC sensor with its normalized health,
+// threshold sensors also carry their reading while discrete sensors only report a state.
+type HealthSensor struct {
+	Name    string  `json:"name"`
+	Type    string  `json:"type,omitempty"` // e.g. Temperature, Fan, Power Supply
+	Reading float64 `json:"reading,omitempty"`
+	Health  Health  `json:"health"`
+}
+
+// String returns a single line summary of the sensor, useful for logging
+func (h *HealthSensor) String() string {
+	return fmt.Sprintf("Sensor %s (%s): reading=%g health=%s", h.Name, h.Type, h.Reading, h.Health)
+}
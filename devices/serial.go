@@ -0,0 +1,9 @@
+package devices
+
+import "strings"
+
+// NormalizeSerial trims, collapses internal whitespace and lowercases a serial number
+// so serials read from different providers can be compared.
+func NormalizeSerial(serial string) string {
+	return strings.ToLower(strings.Join(strings.Fields(serial), " "))
+}
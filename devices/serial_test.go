@@ -0,0 +1,21 @@
+package devices
+
+import "testing"
+
+func TestNormalizeSerial(t *testing.T) {
+	tests := map[string]string{
+		"CZ3629FY3A":         "cz3629fy3a",
+		"  CZ3629FY3A  ":     "cz3629fy3a",
+		"cz3629fy3a\n":       "cz3629fy3a",
+		"\tA19627226A05569 ": "a19627226a05569",
+		"OM  123\t 456":      "om 123 456",
+		"":                   "",
+		"   ":                "",
+	}
+
+	for serial, expectedAnswer := range tests {
+		if answer := NormalizeSerial(serial); answer != expectedAnswer {
+			t.Errorf("%q: Expected answer %q: found %q", serial, expectedAnswer, answer)
+		}
+	}
+}
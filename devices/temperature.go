@@ -0,0 +1,22 @@
+package devices
+
+import "fmt"
+
+// TemperatureSensor represents a temperature sensor reading
+type TemperatureSensor struct {
+	Name     string  `json:"name,omitempty"`
+	Location string  `json:"location,omitempty"` // Zone or physical location of the sensor, e.g. CPU, Inlet, Exhaust
+	Reading  float64 `json:"reading"`            // Celsius, unless Unit says otherwise
+	Unit     string  `json:"unit,omitempty"`     // Defaults to TemperatureUnitCelsius
+	Status   string  `json:"status,omitempty"`
+}
+
+// String returns a single line summary of the sensor, useful for logging
+func (t *TemperatureSensor) String() string {
+	unit := t.Unit
+	if unit == "" {
+		unit = TemperatureUnitCelsius
+	}
+
+	return fmt.Sprintf("Sensor %s (%s): %.1f%s status=%s", t.Name, t.Location, t.Reading, unit, t.Status)
+}
@@ -0,0 +1,25 @@
+package devices
+
+import (
+	"time"
+)
+
+// MeasureTimeOffset reads the clock of a BMC and returns its offset from the reference clock, time.Now
+// when nil, and whether the offset is within the tolerance. The reference is taken halfway through
+// the read, so the round trip to the BMC doesn't count in the offset.
+func MeasureTimeOffset(reference func() time.Time, tolerance time.Duration, read func() (time.Time, error)) (offset time.Duration, inSync bool, err error) {
+	if reference == nil {
+		reference = time.Now
+	}
+
+	start := reference()
+	bmcTime, err := read()
+	if err != nil {
+		return 0, false, err
+	}
+	end := reference()
+
+	offset = bmcTime.Sub(start.Add(end.Sub(start) / 2))
+
+	return offset, offset <= tolerance && offset >= -tolerance, nil
+}
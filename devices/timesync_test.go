@@ -0,0 +1,66 @@
+package devices
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMeasureTimeOffset(t *testing.T) {
+	reference := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		bmcTime  time.Time
+		offset   time.Duration
+		expected bool
+	}{
+		{"in sync", reference.Add(2 * time.Second), 2 * time.Second, true},
+		{"behind within tolerance", reference.Add(-5 * time.Second), -5 * time.Second, true},
+		{"ahead", reference.Add(90 * time.Second), 90 * time.Second, false},
+		{"behind", reference.Add(-time.Hour), -time.Hour, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, inSync, err := MeasureTimeOffset(
+				func() time.Time { return reference },
+				5*time.Second,
+				func() (time.Time, error) { return tt.bmcTime, nil },
+			)
+			if err != nil {
+				t.Fatalf("Found errors calling MeasureTimeOffset %v", err)
+			}
+			if offset != tt.offset || inSync != tt.expected {
+				t.Errorf("Expected answer %v %v: found %v %v", tt.offset, tt.expected, offset, inSync)
+			}
+		})
+	}
+}
+
+func TestMeasureTimeOffsetRoundTrip(t *testing.T) {
+	// the reference moves 2s during the read, the BMC clock is read halfway through
+	reference := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	calls := 0
+	clock := func() time.Time {
+		calls++
+		return reference.Add(time.Duration(calls-1) * 2 * time.Second)
+	}
+
+	offset, inSync, err := MeasureTimeOffset(clock, 0, func() (time.Time, error) { return reference.Add(time.Second), nil })
+	if err != nil {
+		t.Fatalf("Found errors calling MeasureTimeOffset %v", err)
+	}
+	if offset != 0 || !inSync {
+		t.Errorf("Expected answer %v %v: found %v %v", 0, true, offset, inSync)
+	}
+}
+
+func TestMeasureTimeOffsetError(t *testing.T) {
+	errRead := errors.New("unreachable")
+
+	_, inSync, err := MeasureTimeOffset(nil, time.Second, func() (time.Time, error) { return time.Time{}, errRead })
+	if !errors.Is(err, errRead) || inSync {
+		t.Errorf("Expected answer %v: found %v", errRead, err)
+	}
+}
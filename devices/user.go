@@ -0,0 +1,46 @@
+package devices
+
+import "strings"
+
+// UserRole is the normalized privilege level of a BMC user
+type UserRole string
+
+const (
+	// UserRoleAdmin has full control of the BMC
+	UserRoleAdmin UserRole = "Admin"
+	// UserRoleOperator can operate the server (power, console) but not configure the BMC
+	UserRoleOperator UserRole = "Operator"
+	// UserRoleReadOnly can only read the BMC state
+	UserRoleReadOnly UserRole = "ReadOnly"
+	// UserRoleOEM is the IPMI OEM proprietary privilege level, its rights depend on the vendor
+	UserRoleOEM UserRole = "OEM"
+	// UserRoleNone has no access
+	UserRoleNone UserRole = "None"
+)
+
+// User represents a BMC user account, the password is never serialized
+type User struct {
+	ID       int      `json:"id"` // User slot on the BMC
+	Name     string   `json:"name"`
+	Role     UserRole `json:"role"`
+	Enabled  bool     `json:"enabled"`
+	Password string   `json:"-"`
+}
+
+// NormalizeUserRole maps the vendor specific privilege levels to a UserRole,
+// IPMI privilege levels are accepted both as names and numbers, the OEM level isn't assumed to grant
+// any of the other roles and is reported as UserRoleOEM.
+func NormalizeUserRole(role string) UserRole {
+	switch strings.ToLower(strings.TrimSpace(role)) {
+	case "admin", "administrator", "4", "04":
+		return UserRoleAdmin
+	case "operator", "3", "03":
+		return UserRoleOperator
+	case "oem", "oem proprietary", "5", "05":
+		return UserRoleOEM
+	case "readonly", "read only", "user", "2", "02":
+		return UserRoleReadOnly
+	default:
+		return UserRoleNone
+	}
+}
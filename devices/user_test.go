@@ -0,0 +1,40 @@
+package devices
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeUserRole(t *testing.T) {
+	tests := map[string]UserRole{
+		"Administrator": UserRoleAdmin,
+		"04":            UserRoleAdmin,
+		"Operator":      UserRoleOperator,
+		"3":             UserRoleOperator,
+		"ReadOnly":      UserRoleReadOnly,
+		"user":          UserRoleReadOnly,
+		"OEM":           UserRoleOEM,
+		"5":             UserRoleOEM,
+		"None":          UserRoleNone,
+		"15":            UserRoleNone,
+		"":              UserRoleNone,
+	}
+
+	for role, expectedAnswer := range tests {
+		if answer := NormalizeUserRole(role); answer != expectedAnswer {
+			t.Errorf("%q: Expected answer %v: found %v", role, expectedAnswer, answer)
+		}
+	}
+}
+
+func TestUserPasswordNotSerialized(t *testing.T) {
+	payload, err := json.Marshal(&User{ID: 2, Name: "ADMIN", Role: UserRoleAdmin, Enabled: true, Password: "secret"})
+	if err != nil {
+		t.Fatalf("Found errors marshaling the user %v", err)
+	}
+
+	if strings.Contains(string(payload), "secret") {
+		t.Errorf("Expected the password to be omitted: found %s", payload)
+	}
+}
@@ -0,0 +1,19 @@
+package devices
+
+// MediaKind is the kind of device a virtual media image is presented as
+type MediaKind string
+
+const (
+	// MediaCD presents the image as a CD/DVD drive
+	MediaCD MediaKind = "CD"
+	// MediaUSB presents the image as a USB stick
+	MediaUSB MediaKind = "USB"
+)
+
+// VirtualMedia is the state of a virtual media device of a BMC
+type VirtualMedia struct {
+	Kind MediaKind `json:"kind"`
+	// Image is the URI of the mounted image, empty when nothing is mounted
+	Image    string `json:"image,omitempty"`
+	Inserted bool   `json:"inserted"`
+}
@@ -20,13 +20,20 @@ const (
 	ProbeIdrac9        = "idrac9"
 	ProbeSupermicrox   = "supermicrox"
 	ProbeSupermicrox11 = "supermicrox11"
+	ProbeSupermicrox12 = "supermicrox12"
 	ProbeHpC7000       = "hpc7000"
 	ProbeM1000e        = "m1000e"
 	ProbeQuanta        = "quanta"
 	ProbeHpCl100       = "hpcl100"
+	ProbeRedfish       = "redfish"
 )
 
 // ScanAndConnect will scan the BMC trying to deduce the device type and return a working connection.
+//
+// The vendor specific probes are tried first, the subject of the BMC TLS certificate hinting which one
+// to start with when no Hint is given, and the generic Redfish probe last. The returned connection
+// implements devices.Bmc or devices.Cmc, the BMCs only matched by the Redfish probe are unsupported
+// and left to the bmclib Client. The scan stops with the context error once Options.Context is done.
 func ScanAndConnect(host string, username string, password string, options ...Option) (bmcConnection interface{}, err error) {
 	opts := &Options{HintCallback: func(_ string) error { return nil }}
 	for _, optFn := range options {
@@ -53,30 +60,39 @@ func ScanAndConnect(host string, username string, password string, options ...Op
 		return nil, err
 	}
 
-	probe := Probe{client: client, username: username, password: password, host: host, secureTLS: opts.secureTLS}
+	probe := Probe{client: client, username: username, password: password, host: host, secureTLS: opts.secureTLS, insecureTLS: opts.insecureTLS}
 
 	devices := map[string]func(context.Context, logr.Logger) (interface{}, error){
 		ProbeHpIlo:         probe.hpIlo,
 		ProbeIdrac8:        probe.idrac8,
 		ProbeIdrac9:        probe.idrac9,
+		ProbeSupermicrox12: probe.supermicrox12,
 		ProbeSupermicrox11: probe.supermicrox11,
 		ProbeSupermicrox:   probe.supermicrox,
 		ProbeHpC7000:       probe.hpC7000,
 		ProbeM1000e:        probe.m1000e,
 		ProbeQuanta:        probe.quanta,
 		ProbeHpCl100:       probe.hpCl100,
+		ProbeRedfish:       probe.redfish,
 	}
 
 	order := []string{
 		ProbeHpIlo,
 		ProbeIdrac8,
 		ProbeIdrac9,
+		ProbeSupermicrox12,
 		ProbeSupermicrox11,
 		ProbeSupermicrox,
 		ProbeHpC7000,
 		ProbeM1000e,
 		ProbeQuanta,
 		ProbeHpCl100,
+		ProbeRedfish,
+	}
+
+	if opts.Hint == "" {
+		opts.Hint = probe.certificateHint(opts.Context)
+		opts.Logger.V(1).Info("TLS certificate hint", "step", "ScanAndConnect", "host", host, "hint", opts.Hint)
 	}
 
 	if opts.Hint != "" {
@@ -84,6 +100,10 @@ func ScanAndConnect(host string, username string, password string, options ...Op
 	}
 
 	for _, probeID := range order {
+		if err := opts.Context.Err(); err != nil {
+			return nil, err
+		}
+
 		probeDevice := devices[probeID]
 
 		opts.Logger.V(1).Info("probing to identify device", "step", "ScanAndConnect", "host", host, "vendor", probeID)
@@ -126,6 +146,7 @@ type Options struct {
 	Context      context.Context
 
 	secureTLS            bool
+	insecureTLS          bool
 	certPool             *x509.CertPool
 	httpClientSetupFuncs []func(*http.Client)
 }
@@ -147,6 +168,15 @@ func WithSecureTLS(rootCAs *x509.CertPool) Option {
 	}
 }
 
+// WithInsecureTLS skips the verification of the BMC certificates, which are otherwise
+// verified against the system CAs. Use it for BMCs serving self-signed certificates.
+func WithInsecureTLS() Option {
+	return func(arg *Options) {
+		arg.httpClientSetupFuncs = append(arg.httpClientSetupFuncs, httpclient.WithInsecureTLS())
+		arg.insecureTLS = true
+	}
+}
+
 // WithProbeHint sets the Options.Hint option.
 func WithProbeHint(hint string) Option { return func(args *Options) { args.Hint = hint } }
 
@@ -1,13 +1,24 @@
 package discover
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bmc-toolbox/bmclib/providers/dell/idrac8"
 	"github.com/bmc-toolbox/bmclib/providers/dell/idrac9"
 	"github.com/bmc-toolbox/bmclib/providers/dell/m1000e"
@@ -15,6 +26,7 @@ import (
 	"github.com/bmc-toolbox/bmclib/providers/hp/ilo"
 	"github.com/bmc-toolbox/bmclib/providers/supermicro/supermicrox"
 	"github.com/bmc-toolbox/bmclib/providers/supermicro/supermicrox11"
+	"github.com/bmc-toolbox/bmclib/providers/supermicro/supermicrox12"
 	"github.com/bombsimon/logrusr/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
@@ -61,6 +73,13 @@ func setup(vendor string, answers map[string][]byte) (scanAndConnectCurry func(o
 				return
 			}
 
+			// the Redfish sessions are answered with their token in a header
+			if url == "/redfish/v1/SessionService/Sessions" {
+				w.Header().Set("X-Auth-Token", "token")
+				w.WriteHeader(http.StatusCreated)
+				return
+			}
+
 			if url == "/cgi/login.cgi" && r.Method == http.MethodPost && r.Form.Get("name") != "" {
 				_, _ = w.Write([]byte("../cgi/url_redirect.cgi?url_name=mainmenu"))
 			} else {
@@ -71,7 +90,7 @@ func setup(vendor string, answers map[string][]byte) (scanAndConnectCurry func(o
 
 	return func(opts ...Option) (bmc interface{}, err error) {
 			l := logrus.New()
-			opts = append(opts, WithLogger(logrusr.New(l)))
+			opts = append(opts, WithLogger(logrusr.New(l)), WithInsecureTLS())
 			return ScanAndConnect(ip, username, password, opts...)
 		},
 		server.Close
@@ -79,7 +98,8 @@ func setup(vendor string, answers map[string][]byte) (scanAndConnectCurry func(o
 
 // Golang doesn't have a way to assure a type implements an interface.
 // We test here that the code "compiles", i.e. that the types that we
-//   expect to implement an interface actually implement it.
+//
+//	expect to implement an interface actually implement it.
 func TestInterfaceImplemented(t *testing.T) {
 	var _ devices.Bmc = &ilo.Ilo{}
 	var _ devices.Cmc = &c7000.C7000{}
@@ -88,6 +108,7 @@ func TestInterfaceImplemented(t *testing.T) {
 	var _ devices.Cmc = &m1000e.M1000e{}
 	var _ devices.Bmc = &supermicrox.SupermicroX{}
 	var _ devices.Bmc = &supermicrox11.SupermicroX{}
+	var _ devices.Bmc = &supermicrox12.SupermicroX{}
 }
 
 func TestProbes(t *testing.T) {
@@ -106,6 +127,11 @@ func TestProbes(t *testing.T) {
 			wantHint: ProbeSupermicrox11,
 			wantType: (*supermicrox11.SupermicroX)(nil),
 		},
+		{
+			name:     "SupermicroX12",
+			wantHint: ProbeSupermicrox12,
+			wantType: (*supermicrox12.SupermicroX)(nil),
+		},
 		{
 			name:     "IDrac9",
 			wantHint: ProbeIdrac9,
@@ -163,6 +189,91 @@ func TestProbes(t *testing.T) {
 	}
 }
 
+func TestScanAndConnectContextDone(t *testing.T) {
+	scanAndConnect, cancel := setup("SupermicroX", _answers["SupermicroX"])
+	defer cancel()
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cancelCtx()
+
+	_, err := scanAndConnect(WithContext(ctx))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected answer %v: found %v", context.Canceled, err)
+	}
+}
+
+func TestProbeRedfishUnsupported(t *testing.T) {
+	scanAndConnect, cancel := setup("Redfish", _answers["Redfish"])
+	defer cancel()
+
+	hintCallBack := func(hint string) error {
+		t.Errorf("Expected no hint call back: found %q", hint)
+		return nil
+	}
+
+	bmc, err := scanAndConnect(WithProbeHint(ProbeRedfish), WithHintCallBack(hintCallBack))
+	if !errors.Is(err, bmclibErrs.ErrVendorUnknown) {
+		t.Errorf("Expected error %v: found %v", bmclibErrs.ErrVendorUnknown, err)
+	}
+	if bmc != nil {
+		t.Errorf("Expected answer %v: found %T", nil, bmc)
+	}
+}
+
+func TestCertificateHint(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "IPMI", Organization: []string{"Super Micro Computer"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	// the certificate is self-signed, as the BMCs ship them
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	server := httptest.NewUnstartedServer(http.NotFoundHandler())
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+	server.StartTLS()
+	defer server.Close()
+
+	probe := Probe{client: &http.Client{}, host: strings.TrimPrefix(server.URL, "https://"), secureTLS: true}
+
+	hint := probe.certificateHint(context.Background())
+	if hint != ProbeSupermicrox12 {
+		t.Errorf("Expected answer %q: found %q", ProbeSupermicrox12, hint)
+	}
+}
+
+func TestProbeForCertificate(t *testing.T) {
+	tt := []struct {
+		name    string
+		subject pkix.Name
+		want    string
+	}{
+		{"idrac", pkix.Name{CommonName: "idrac-H16Z4M2", Organization: []string{"Dell Inc."}}, ProbeIdrac9},
+		{"supermicro", pkix.Name{CommonName: "IPMI", Organization: []string{"Super Micro Computer"}}, ProbeSupermicrox12},
+		{"ilo", pkix.Name{CommonName: "ILOCZ3XXXXXX", Organization: []string{"Hewlett Packard Enterprise"}}, ProbeHpIlo},
+		{"onboard administrator", pkix.Name{CommonName: "OA-2C44FD8B3C5A", Organization: []string{"Hewlett-Packard"}, OrganizationalUnit: []string{"Onboard Administrator"}}, ProbeHpC7000},
+		{"unknown", pkix.Name{Organization: []string{"Acme Co"}}, ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got := probeForCertificate(&x509.Certificate{Subject: tc.subject})
+			if got != tc.want {
+				t.Errorf("Expected answer %q: found %q", tc.want, got)
+			}
+		})
+	}
+}
+
 func checkHint(t *testing.T, want string) func(string) error {
 	return func(got string) error {
 		t.Helper()
@@ -184,10 +295,12 @@ var (
 		ProbeIdrac9,
 		ProbeSupermicrox,
 		ProbeSupermicrox11,
+		ProbeSupermicrox12,
 		ProbeHpC7000,
 		ProbeM1000e,
 		ProbeQuanta,
 		ProbeHpCl100,
+		ProbeRedfish,
 	}
 
 	_answers = map[string]map[string][]byte{
@@ -215,8 +328,17 @@ var (
 				<PRODUCT LAN="0" MFC_NAME="Supermicro" PROD_NAME="" PART_NUM="SYS-5019C-MR-PH004" VERSION="NONE" SERIAL_NUM="S402854X0700021" ASSET_TAG=""/>
 			</FRU_INFO>
 		</IPMI>`)},
+		"SupermicroX12": {
+			"/redfish/v1/":                        []byte(`{"@odata.id": "/redfish/v1", "@odata.type": "#ServiceRoot.v1_5_2.ServiceRoot", "Id": "ServiceRoot", "Name": "Root Service", "RedfishVersion": "1.11.0", "Vendor": "Supermicro", "Systems": {"@odata.id": "/redfish/v1/Systems"}}`),
+			"/redfish/v1/SessionService/Sessions": []byte(""),
+			"/redfish/v1/Chassis":                 []byte(`{"@odata.id": "/redfish/v1/Chassis", "Members": [{"@odata.id": "/redfish/v1/Chassis/1"}]}`),
+			"/redfish/v1/Chassis/1":               []byte(`{"@odata.id": "/redfish/v1/Chassis/1", "Id": "1", "ChassisType": "RackMount", "Manufacturer": "Supermicro", "Model": "X12DPU-6", "SerialNumber": "C1160LK41MA0123"}`),
+		},
 
 		"Quanta": {"/page/login.html": []byte("Quanta")},
+		"Redfish": {
+			"/redfish/v1/": []byte(`{"@odata.id": "/redfish/v1", "@odata.type": "#ServiceRoot.v1_5_0.ServiceRoot", "Id": "RootService", "Name": "Root Service", "RedfishVersion": "1.8.0", "Systems": {"@odata.id": "/redfish/v1/Systems"}}`),
+		},
 		"C7000": {
 			"/xmldata": []byte(`<RIMP>
 			<MP>
@@ -3,11 +3,13 @@ package discover
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"crypto/x509"
 	"encoding/xml"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -23,6 +25,7 @@ import (
 	"github.com/bmc-toolbox/bmclib/providers/hp/ilo"
 	"github.com/bmc-toolbox/bmclib/providers/supermicro/supermicrox"
 	"github.com/bmc-toolbox/bmclib/providers/supermicro/supermicrox11"
+	"github.com/bmc-toolbox/bmclib/providers/supermicro/supermicrox12"
 	"github.com/go-logr/logr"
 )
 
@@ -33,12 +36,13 @@ var (
 )
 
 type Probe struct {
-	client    *http.Client
-	host      string
-	username  string
-	password  string
-	certPool  *x509.CertPool
-	secureTLS bool
+	client      *http.Client
+	host        string
+	username    string
+	password    string
+	certPool    *x509.CertPool
+	secureTLS   bool
+	insecureTLS bool
 }
 
 func (p *Probe) hpIlo(ctx context.Context, log logr.Logger) (bmcConnection interface{}, err error) {
@@ -85,6 +89,9 @@ func (p *Probe) hpIlo(ctx context.Context, log logr.Logger) (bmcConnection inter
 				if p.secureTLS {
 					opts = append(opts, ilo.WithSecureTLS(p.certPool))
 				}
+				if p.insecureTLS {
+					opts = append(opts, ilo.WithInsecureTLS())
+				}
 				log.V(1).Info("step", "ScanAndConnect", "host", p.host, "vendor", string(devices.HP), "msg", "it's a HP with iLo")
 				return ilo.NewWithOptions(ctx, p.host, p.username, p.password, log, opts...)
 			}
@@ -133,6 +140,9 @@ func (p *Probe) hpC7000(ctx context.Context, log logr.Logger) (bmcConnection int
 			if p.secureTLS {
 				opts = append(opts, c7000.WithSecureTLS(p.certPool))
 			}
+			if p.insecureTLS {
+				opts = append(opts, c7000.WithInsecureTLS())
+			}
 			log.V(1).Info("step", "ScanAndConnect", "host", p.host, "vendor", string(devices.HP), "msg", "it's a chassis")
 			return c7000.NewWithOptions(ctx, p.host, p.username, p.password, log, opts...)
 		}
@@ -197,6 +207,9 @@ func (p *Probe) idrac8(ctx context.Context, log logr.Logger) (bmcConnection inte
 		if p.secureTLS {
 			opts = append(opts, idrac8.WithSecureTLS(p.certPool))
 		}
+		if p.insecureTLS {
+			opts = append(opts, idrac8.WithInsecureTLS())
+		}
 		log.V(1).Info("step", "connection", "host", p.host, "vendor", string(devices.Dell), "msg", "it's a idrac8")
 		return idrac8.NewWithOptions(ctx, p.host, p.username, p.password, log, opts...)
 	}
@@ -230,6 +243,9 @@ func (p *Probe) idrac9(ctx context.Context, log logr.Logger) (bmcConnection inte
 		if p.secureTLS {
 			opts = append(opts, idrac9.WithSecureTLS(p.certPool))
 		}
+		if p.insecureTLS {
+			opts = append(opts, idrac9.WithInsecureTLS())
+		}
 		log.V(1).Info("step", "connection", "host", p.host, "vendor", string(devices.Dell), "msg", "it's a idrac9")
 		return idrac9.NewWithOptions(ctx, p.host, p.host, p.username, p.password, log, opts...)
 	}
@@ -263,6 +279,9 @@ func (p *Probe) m1000e(ctx context.Context, log logr.Logger) (bmcConnection inte
 		if p.secureTLS {
 			opts = append(opts, m1000e.WithSecureTLS(p.certPool))
 		}
+		if p.insecureTLS {
+			opts = append(opts, m1000e.WithInsecureTLS())
+		}
 		log.V(1).Info("step", "connection", "host", p.host, "vendor", string(devices.Dell), "msg", "it's a m1000e chassis")
 		return m1000e.NewWithOptions(ctx, p.host, p.username, p.password, log, opts...)
 	}
@@ -297,6 +316,9 @@ func (p *Probe) supermicrox(ctx context.Context, log logr.Logger) (bmcConnection
 		if p.secureTLS {
 			opts = append(opts, supermicrox.WithSecureTLS(p.certPool))
 		}
+		if p.insecureTLS {
+			opts = append(opts, supermicrox.WithInsecureTLS())
+		}
 		log.V(1).Info("it's a supermicro", "step", "connection", "host", p.host, "vendor", devices.Supermicro, "hardwareType", supermicrox.X10)
 
 		conn, err := supermicrox.NewWithOptions(ctx, p.host, p.username, p.password, log, opts...)
@@ -340,6 +362,9 @@ func (p *Probe) supermicrox11(ctx context.Context, log logr.Logger) (bmcConnecti
 		if p.secureTLS {
 			opts = append(opts, supermicrox11.WithSecureTLS(p.certPool))
 		}
+		if p.insecureTLS {
+			opts = append(opts, supermicrox11.WithInsecureTLS())
+		}
 		log.V(1).Info("it's a supermicrox11", "step", "connection", "host", p.host, "vendor", devices.Supermicro, "hardwareType", supermicrox11.X11)
 
 		conn, err := supermicrox11.NewWithOptions(ctx, p.host, p.username, p.password, log, opts...)
@@ -357,6 +382,59 @@ func (p *Probe) supermicrox11(ctx context.Context, log logr.Logger) (bmcConnecti
 	return bmcConnection, errors.ErrDeviceNotMatched
 }
 
+// supermicrox12 matches the X12 and X13 boards through their Redfish service, the older boards serving
+// one as well are left to the cgi based probes
+func (p *Probe) supermicrox12(ctx context.Context, log logr.Logger) (bmcConnection interface{}, err error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(time.Second*60))
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://%s/redfish/v1/", p.host), nil)
+	if err != nil {
+		return bmcConnection, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return bmcConnection, err
+	}
+
+	defer resp.Body.Close()
+	defer io.Copy(ioutil.Discard, resp.Body) // nolint
+
+	payload, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return bmcConnection, err
+	}
+
+	if resp.StatusCode == 200 && bytes.Contains(payload, []byte(`"RedfishVersion"`)) && bytes.Contains(payload, []byte("Supermicro")) {
+		opts := []supermicrox12.SupermicroXOption{}
+		if p.secureTLS {
+			opts = append(opts, supermicrox12.WithSecureTLS(p.certPool))
+		}
+		if p.insecureTLS {
+			opts = append(opts, supermicrox12.WithInsecureTLS())
+		}
+
+		conn, err := supermicrox12.NewWithOptions(ctx, p.host, p.username, p.password, log, opts...)
+		if err != nil {
+			return bmcConnection, err
+		}
+
+		gen, err := conn.Generation()
+		if err != nil {
+			return bmcConnection, err
+		}
+		if gen != "" {
+			log.V(1).Info("it's a supermicrox12", "step", "connection", "host", p.host, "vendor", devices.Supermicro, "hardwareType", gen)
+			return conn, nil
+		}
+
+		// the session opened to read the model isn't kept for the cgi based probes
+		_ = conn.Close(ctx)
+	}
+
+	return bmcConnection, errors.ErrDeviceNotMatched
+}
+
 func (p *Probe) quanta(ctx context.Context, log logr.Logger) (bmcConnection interface{}, err error) {
 	ctx, cancel := context.WithTimeout(ctx, time.Duration(time.Second*60))
 	defer cancel()
@@ -387,6 +465,91 @@ func (p *Probe) quanta(ctx context.Context, log logr.Logger) (bmcConnection inte
 	return bmcConnection, errors.ErrDeviceNotMatched
 }
 
+// redfish is the last resort probe, it identifies the BMCs serving a Redfish service root without
+// a vendor probe. No devices.Bmc implementation exists for a generic Redfish service, so the device
+// is reported as unsupported, the bmclib Client drives it through the redfish provider instead.
+func (p *Probe) redfish(ctx context.Context, log logr.Logger) (bmcConnection interface{}, err error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(time.Second*60))
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://%s/redfish/v1/", p.host), nil)
+	if err != nil {
+		return bmcConnection, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return bmcConnection, err
+	}
+
+	defer resp.Body.Close()
+	defer io.Copy(ioutil.Discard, resp.Body) // nolint
+
+	payload, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return bmcConnection, err
+	}
+
+	if resp.StatusCode == 200 && bytes.Contains(payload, []byte(`"RedfishVersion"`)) {
+		log.V(1).Info("step", "ScanAndConnect", "host", p.host, "msg", "it's a redfish service")
+		return bmcConnection, errors.NewErrUnsupportedHardware("generic redfish service not supported, use the bmclib client")
+	}
+
+	return bmcConnection, errors.ErrDeviceNotMatched
+}
+
+// certificateHint returns the probe matching the subject of the BMC TLS certificate, the default
+// certificates of the BMCs name their vendor. An empty hint is returned when the certificate
+// can't be read or doesn't name a known vendor.
+//
+// The default BMC certificates are self-signed, so the certificate is read from a handshake
+// skipping the verification, whatever the TLS options. No request is sent over it and the hint
+// only orders the probes, which verify the certificate as configured.
+func (p *Probe) certificateHint(ctx context.Context) string {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(time.Second*10))
+	defer cancel()
+
+	addr := p.host
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "443")
+	}
+
+	dialer := &tls.Dialer{Config: &tls.Config{InsecureSkipVerify: true}} // nolint: gosec
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return ""
+	}
+	defer conn.Close()
+
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return ""
+	}
+
+	return probeForCertificate(certs[0])
+}
+
+// probeForCertificate maps the subject of a BMC certificate to the probe of its vendor
+func probeForCertificate(cert *x509.Certificate) string {
+	subject := cert.Subject
+	fields := append([]string{subject.CommonName}, subject.Organization...)
+	fields = append(fields, subject.OrganizationalUnit...)
+	names := strings.ToLower(strings.Join(fields, " "))
+
+	switch {
+	case strings.Contains(names, "onboard administrator"):
+		return ProbeHpC7000
+	case strings.Contains(names, "hewlett"), strings.Contains(names, "integrated lights-out"):
+		return ProbeHpIlo
+	case strings.Contains(names, "idrac"), strings.Contains(names, "dell"):
+		return ProbeIdrac9
+	case strings.Contains(names, "super micro"), strings.Contains(names, "supermicro"):
+		// the x12 probe tells the generations apart, the older boards fall through to the cgi probes
+		return ProbeSupermicrox12
+	default:
+		return ""
+	}
+}
+
 func containsAnySubStr(data []byte, subStrs []string) bool {
 	for _, subStr := range subStrs {
 		if bytes.Contains(data, []byte(subStr)) {
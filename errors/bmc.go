@@ -0,0 +1,38 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BMCError carries the identity of the bmc an error came from, so errors collected
+// across many bmcs can be told apart, the inner error is kept for errors.Is and errors.As.
+type BMCError struct {
+	Vendor    string
+	IP        string
+	Operation string
+	Err       error
+}
+
+// NewBMCError wraps err with the bmc identity, it returns nil when err is nil
+// and err as is when it already carries a BMCError.
+func NewBMCError(vendor, ip, operation string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var bmcErr *BMCError
+	if errors.As(err, &bmcErr) {
+		return err
+	}
+
+	return &BMCError{Vendor: vendor, IP: ip, Operation: operation, Err: err}
+}
+
+func (e *BMCError) Error() string {
+	return fmt.Sprintf("%s %s %s: %v", e.Vendor, e.IP, e.Operation, e.Err)
+}
+
+func (e *BMCError) Unwrap() error {
+	return e.Err
+}
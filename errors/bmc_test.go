@@ -0,0 +1,33 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestBMCError(t *testing.T) {
+	if NewBMCError("supermicrox", "10.0.0.5", "PowerOn", nil) != nil {
+		t.Fatalf("Expected a nil error to stay nil")
+	}
+
+	err := NewBMCError("supermicrox", "10.0.0.5", "PowerOn", fmt.Errorf("login to 10.0.0.5 rejected: %w", ErrLoginFailed))
+
+	expectedAnswer := "supermicrox 10.0.0.5 PowerOn: login to 10.0.0.5 rejected: failed to login"
+	if err.Error() != expectedAnswer {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, err.Error())
+	}
+
+	if !errors.Is(err, ErrLoginFailed) {
+		t.Errorf("Expected %v to match ErrLoginFailed", err)
+	}
+
+	var bmcErr *BMCError
+	if !errors.As(err, &bmcErr) || bmcErr.IP != "10.0.0.5" || bmcErr.Operation != "PowerOn" {
+		t.Errorf("Expected to find the BMCError in %v", err)
+	}
+
+	if answer := NewBMCError("supermicrox", "10.0.0.5", "ServerSnapshot", err); answer != err {
+		t.Errorf("Expected answer %v: found %v", err, answer)
+	}
+}
@@ -9,6 +9,15 @@ var (
 	// ErrLoginFailed is returned when we fail to login to a bmc
 	ErrLoginFailed = errors.New("failed to login")
 
+	// ErrInvalidCredentials is returned when the bmc explicitly rejects the username or password
+	ErrInvalidCredentials = errors.New("invalid username or password")
+
+	// ErrSessionExpired is returned when the bmc reports the session used for the request is no longer valid
+	ErrSessionExpired = errors.New("session expired")
+
+	// ErrSessionClosed is returned when a call is made on a bmclib Session after it was closed
+	ErrSessionClosed = errors.New("session closed")
+
 	// ErrBiosNotFound is returned when we are not able to find the server bios version
 	ErrBiosNotFound = errors.New("bios version not found")
 
@@ -75,6 +84,9 @@ var (
 	// ErrUserAccountUpdate is returned when the user account failed to be updated
 	ErrUserAccountUpdate = errors.New("user account attributes could not be updated")
 
+	// ErrWeakPassword is returned when a password doesn't meet the password policy
+	ErrWeakPassword = errors.New("password doesn't meet the password policy")
+
 	// ErrRedfishChassisOdataID is returned when no compatible Chassis Odata IDs were identified
 	ErrRedfishChassisOdataID = errors.New("no compatible Chassis Odata IDs identified")
 
@@ -114,13 +126,51 @@ var (
 	// ErrPowerStatusSet is returned when a power status set query fails
 	ErrPowerStatusSet = errors.New("error setting power status")
 
+	// ErrPowerCapSet is returned when a power cap set query fails
+	ErrPowerCapSet = errors.New("error setting power cap")
+
+	// ErrInvalidPowerCap is returned for an enabled power cap without a positive limit
+	ErrInvalidPowerCap = errors.New("invalid power cap")
+
+	// ErrInvalidBIOSAttribute is returned when a BIOS setting doesn't match an attribute of the BIOS
+	ErrInvalidBIOSAttribute = errors.New("invalid BIOS attribute")
+
+	// ErrUIDStateSet is returned when the UID LED state could not be set
+	ErrUIDStateSet = errors.New("error setting UID LED state")
+
 	// ErrProviderImplementation is returned when theres an error in the BMC provider implementation
 	ErrProviderImplementation = errors.New("error in provider implementation")
 
+	// ErrBayEmpty is returned when a chassis bay is queried for a device that isn't present
+	ErrBayEmpty = errors.New("no device present in the chassis bay")
+
+	// ErrUnsupportedModel is returned when the provider can't map the detected model or generation to a known code path
+	ErrUnsupportedModel = errors.New("model not supported")
+
 	// ErrCompatibilityCheck is returned when the compatibility probe failed to complete successfully.
 	ErrCompatibilityCheck = errors.New("compatibility check failed")
+
+	// ErrIpmitoolNotFound is returned when the ipmitool binary the IPMI over LAN provider shells out to isn't in the PATH
+	ErrIpmitoolNotFound = errors.New("ipmitool not found in PATH")
 )
 
+// IsLoginFailed returns true when the bmc answered the login but rejected it,
+// as opposed to the bmc being unreachable, this includes ErrInvalidCredentials.
+func IsLoginFailed(err error) bool {
+	return errors.Is(err, ErrLoginFailed) || errors.Is(err, ErrInvalidCredentials)
+}
+
+// IsInvalidCredentials returns true when the bmc explicitly rejected the username or password.
+func IsInvalidCredentials(err error) bool {
+	return errors.Is(err, ErrInvalidCredentials)
+}
+
+// IsSessionExpired returns true when the bmc rejected the request because the session expired,
+// a new login is required before retrying.
+func IsSessionExpired(err error) bool {
+	return errors.Is(err, ErrSessionExpired)
+}
+
 type ErrUnsupportedHardware struct {
 	msg string
 }
@@ -132,3 +182,9 @@ func (e *ErrUnsupportedHardware) Error() string {
 func NewErrUnsupportedHardware(s string) error {
 	return &ErrUnsupportedHardware{s}
 }
+
+// IsUnsupportedModel returns true when the hardware, or the detected model of it, isn't supported by the provider.
+func IsUnsupportedModel(err error) bool {
+	var unsupportedHardware *ErrUnsupportedHardware
+	return errors.Is(err, ErrUnsupportedModel) || errors.As(err, &unsupportedHardware)
+}
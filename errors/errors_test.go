@@ -0,0 +1,84 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSentinelsMatchThroughWrapping(t *testing.T) {
+	sentinels := []error{
+		ErrLoginFailed,
+		ErrInvalidSerial,
+		ErrPageNotFound,
+		ErrUnableToReadData,
+		ErrNon200Response,
+		ErrNotImplemented,
+		ErrFeatureUnavailable,
+		ErrUserAccountNotFound,
+		ErrFirmwareInstall,
+		ErrPowerStatusSet,
+		ErrBayEmpty,
+	}
+
+	for _, sentinel := range sentinels {
+		once := fmt.Errorf("ChassisSerial: %w", sentinel)
+		twice := fmt.Errorf("10.0.0.1: %w", once)
+
+		if !errors.Is(once, sentinel) || !errors.Is(twice, sentinel) {
+			t.Errorf("Expected %q to match %q through wrapping", twice, sentinel)
+		}
+
+		for _, other := range sentinels {
+			if other != sentinel && errors.Is(twice, other) {
+				t.Errorf("Expected %q not to match %q", twice, other)
+			}
+		}
+	}
+}
+
+func TestIsLoginFailed(t *testing.T) {
+	if !IsLoginFailed(fmt.Errorf("login to 10.0.0.1 rejected: %w", ErrLoginFailed)) {
+		t.Errorf("Expected a wrapped ErrLoginFailed to be reported as a failed login")
+	}
+
+	if IsLoginFailed(fmt.Errorf("dial tcp 10.0.0.1:443: connection refused")) || IsLoginFailed(nil) {
+		t.Errorf("Expected other errors not to be reported as a failed login")
+	}
+}
+
+func TestIsSessionExpired(t *testing.T) {
+	if !IsSessionExpired(fmt.Errorf("GET json/license: %w", ErrSessionExpired)) {
+		t.Errorf("Expected a wrapped ErrSessionExpired to be reported as an expired session")
+	}
+
+	if IsSessionExpired(ErrLoginFailed) || IsSessionExpired(nil) {
+		t.Errorf("Expected other errors not to be reported as an expired session")
+	}
+}
+
+func TestIsUnsupportedModel(t *testing.T) {
+	tests := map[error]bool{
+		fmt.Errorf("snmp settings for ilo3: %w", ErrUnsupportedModel): true,
+		NewErrUnsupportedHardware("quanta hardware not supported"):    true,
+		ErrNotImplemented: false,
+	}
+
+	for err, expectedAnswer := range tests {
+		if answer := IsUnsupportedModel(err); answer != expectedAnswer {
+			t.Errorf("%v: Expected answer %v: found %v", err, expectedAnswer, answer)
+		}
+	}
+}
+
+func TestIsInvalidCredentials(t *testing.T) {
+	err := fmt.Errorf("login to 10.0.0.1 rejected: %w", ErrInvalidCredentials)
+	if !IsInvalidCredentials(err) || !IsLoginFailed(err) {
+		t.Errorf("Expected %v to be reported as invalid credentials and a failed login", err)
+	}
+
+	err = fmt.Errorf("login to 10.0.0.1 rejected: %w", ErrLoginFailed)
+	if IsInvalidCredentials(err) {
+		t.Errorf("Expected %v not to be reported as invalid credentials", err)
+	}
+}
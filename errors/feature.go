@@ -0,0 +1,25 @@
+package errors
+
+import "fmt"
+
+// FeatureUnsupportedError is returned by methods that can't run on the detected hardware,
+// it matches ErrFeatureUnavailable with errors.Is.
+type FeatureUnsupportedError struct {
+	Feature string
+	Vendor  string
+	Model   string
+}
+
+// NewFeatureUnsupportedError returns a FeatureUnsupportedError for the feature on the given vendor and model.
+func NewFeatureUnsupportedError(feature, vendor, model string) *FeatureUnsupportedError {
+	return &FeatureUnsupportedError{Feature: feature, Vendor: vendor, Model: model}
+}
+
+func (e *FeatureUnsupportedError) Error() string {
+	return fmt.Sprintf("%s isn't supported on %s %s", e.Feature, e.Vendor, e.Model)
+}
+
+// Is keeps the error matching ErrFeatureUnavailable for callers checking the sentinel.
+func (e *FeatureUnsupportedError) Is(target error) bool {
+	return target == ErrFeatureUnavailable
+}
@@ -0,0 +1,29 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFeatureUnsupportedError(t *testing.T) {
+	err := fmt.Errorf("10.0.0.1: %w", NewFeatureUnsupportedError("screenshot", "HP", "ilo4"))
+
+	var featureErr *FeatureUnsupportedError
+	if !errors.As(err, &featureErr) {
+		t.Fatalf("Expected a FeatureUnsupportedError in %v", err)
+	}
+
+	if featureErr.Feature != "screenshot" || featureErr.Model != "ilo4" {
+		t.Errorf("Expected answer %v: found %+v", "screenshot ilo4", featureErr)
+	}
+
+	expectedAnswer := "10.0.0.1: screenshot isn't supported on HP ilo4"
+	if err.Error() != expectedAnswer {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, err.Error())
+	}
+
+	if !errors.Is(err, ErrFeatureUnavailable) || errors.Is(err, ErrNotImplemented) {
+		t.Errorf("Expected %v to match ErrFeatureUnavailable only", err)
+	}
+}
@@ -0,0 +1,65 @@
+package errors
+
+import "fmt"
+
+// FirmwareUpdatePhase identifies the stage of a firmware update
+type FirmwareUpdatePhase string
+
+const (
+	// FirmwareUpdatePhasePrepare is the device being readied for the update, like the image read or
+	// downloaded, the host powered off or the bmc switched to its update mode
+	FirmwareUpdatePhasePrepare FirmwareUpdatePhase = "prepare"
+	// FirmwareUpdatePhaseUpload is the transfer of the image to the bmc
+	FirmwareUpdatePhaseUpload FirmwareUpdatePhase = "upload"
+	// FirmwareUpdatePhaseVerify is the bmc validating the image before flashing
+	FirmwareUpdatePhaseVerify FirmwareUpdatePhase = "verify"
+	// FirmwareUpdatePhaseFlash is the image being written
+	FirmwareUpdatePhaseFlash FirmwareUpdatePhase = "flash"
+	// FirmwareUpdatePhaseReboot is the device restarting into the new firmware
+	FirmwareUpdatePhaseReboot FirmwareUpdatePhase = "reboot"
+)
+
+// FirmwareUpdateError is returned when a firmware update fails, Phase tells how far the update got.
+type FirmwareUpdateError struct {
+	Phase FirmwareUpdatePhase
+	Err   error
+}
+
+// NewFirmwareUpdateError returns a FirmwareUpdateError for a failure in the given phase.
+func NewFirmwareUpdateError(phase FirmwareUpdatePhase, err error) *FirmwareUpdateError {
+	return &FirmwareUpdateError{Phase: phase, Err: err}
+}
+
+func (e *FirmwareUpdateError) Error() string {
+	return fmt.Sprintf("firmware update failed during %s: %v", e.Phase, e.Err)
+}
+
+func (e *FirmwareUpdateError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches ErrFirmwareUpload for the failures before the image reached the device
+// and ErrFirmwareInstall for the later phases.
+func (e *FirmwareUpdateError) Is(target error) bool {
+	uploaded := e.Phase != FirmwareUpdatePhasePrepare && e.Phase != FirmwareUpdatePhaseUpload
+
+	switch target {
+	case ErrFirmwareUpload:
+		return !uploaded
+	case ErrFirmwareInstall:
+		return uploaded
+	}
+
+	return false
+}
+
+// RetrySafe returns true when the update failed before the image was written,
+// a failure while flashing or rebooting needs the device to be checked first.
+func (e *FirmwareUpdateError) RetrySafe() bool {
+	switch e.Phase {
+	case FirmwareUpdatePhasePrepare, FirmwareUpdatePhaseUpload, FirmwareUpdatePhaseVerify:
+		return true
+	}
+
+	return false
+}
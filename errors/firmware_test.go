@@ -0,0 +1,48 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFirmwareUpdateError(t *testing.T) {
+	tests := []struct {
+		phase     FirmwareUpdatePhase
+		retrySafe bool
+		sentinel  error
+	}{
+		{FirmwareUpdatePhasePrepare, true, ErrFirmwareUpload},
+		{FirmwareUpdatePhaseUpload, true, ErrFirmwareUpload},
+		{FirmwareUpdatePhaseVerify, true, ErrFirmwareInstall},
+		{FirmwareUpdatePhaseFlash, false, ErrFirmwareInstall},
+		{FirmwareUpdatePhaseReboot, false, ErrFirmwareInstall},
+	}
+
+	cause := fmt.Errorf("connection closed")
+	for _, tc := range tests {
+		err := fmt.Errorf("UpdateFirmware: %w", NewFirmwareUpdateError(tc.phase, cause))
+
+		var updateErr *FirmwareUpdateError
+		if !errors.As(err, &updateErr) {
+			t.Fatalf("Expected a FirmwareUpdateError in %v", err)
+		}
+
+		if updateErr.Phase != tc.phase {
+			t.Errorf("Expected answer %v: found %v", tc.phase, updateErr.Phase)
+		}
+
+		if updateErr.RetrySafe() != tc.retrySafe {
+			t.Errorf("%s: Expected answer %v: found %v", tc.phase, tc.retrySafe, updateErr.RetrySafe())
+		}
+
+		if !errors.Is(err, tc.sentinel) || !errors.Is(err, cause) {
+			t.Errorf("%s: Expected %v to match %v and the cause", tc.phase, err, tc.sentinel)
+		}
+	}
+
+	expectedAnswer := "firmware update failed during flash: connection closed"
+	if answer := NewFirmwareUpdateError(FirmwareUpdatePhaseFlash, cause).Error(); answer != expectedAnswer {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, answer)
+	}
+}
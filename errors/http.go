@@ -0,0 +1,108 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// httpErrorBodyLimit is the number of characters of the response body kept in an HTTPError
+const httpErrorBodyLimit = 256
+
+// HTTPError is returned when the bmc answers a request with an unexpected status code,
+// use errors.As to retrieve it and branch on the StatusCode.
+type HTTPError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	Body       string        // excerpt of the response body, whitespace collapsed
+	RetryAfter time.Duration // from the Retry-After header, zero when the bmc didn't send one
+
+	tooManySessions bool
+}
+
+// NewHTTPError returns an HTTPError keeping a short excerpt of the response body.
+func NewHTTPError(method, url string, statusCode int, body []byte) *HTTPError {
+	excerpt := strings.Join(strings.Fields(string(body)), " ")
+	if len(excerpt) > httpErrorBodyLimit {
+		excerpt = excerpt[:httpErrorBodyLimit] + "..."
+	}
+
+	return &HTTPError{StatusCode: statusCode, Method: method, URL: url, Body: excerpt, tooManySessions: tooManySessions(string(body))}
+}
+
+// NewHTTPErrorFromResponse returns an HTTPError for the response, reading the Retry-After header when present.
+func NewHTTPErrorFromResponse(resp *http.Response, body []byte) *HTTPError {
+	var method, url string
+	if resp.Request != nil {
+		method, url = resp.Request.Method, resp.Request.URL.String()
+	}
+
+	e := NewHTTPError(method, url, resp.StatusCode, body)
+	e.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+
+	return e
+}
+
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprintf("%s %s returned status code %d", e.Method, e.URL, e.StatusCode)
+	if e.Body != "" {
+		msg += ": " + e.Body
+	}
+
+	return msg
+}
+
+// Is keeps the status code sentinels matching with errors.Is when an HTTPError is returned instead.
+func (e *HTTPError) Is(target error) bool {
+	switch target {
+	case ErrPageNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case Err500:
+		return e.StatusCode == http.StatusInternalServerError
+	case ErrNon200Response:
+		return e.StatusCode != http.StatusOK
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests || e.tooManySessions
+	}
+
+	return false
+}
+
+// IsNotFound returns true when the bmc didn't find the requested page or endpoint,
+// whether err carries ErrPageNotFound or an HTTPError with a 404.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrPageNotFound)
+}
+
+// IsUnauthorized returns true when the bmc refused the request as unauthorized,
+// whether err carries Err401Redfish or an HTTPError with a 401.
+func IsUnauthorized(err error) bool {
+	if errors.Is(err, Err401Redfish) {
+		return true
+	}
+
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusUnauthorized
+}
+
+// ErrResponseTooLarge is matched by errors returned when a bmc response body exceeds the size limit of the client
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// ResponseTooLargeError is returned when a bmc response body exceeds the size limit of the client,
+// reading it whole could exhaust the memory.
+type ResponseTooLargeError struct {
+	URL   string
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response from %s exceeds the limit of %d bytes", e.URL, e.Limit)
+}
+
+// Is matches ErrResponseTooLarge
+func (e *ResponseTooLargeError) Is(target error) bool {
+	return target == ErrResponseTooLarge
+}
@@ -0,0 +1,69 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestHTTPError(t *testing.T) {
+	err := fmt.Errorf("reading fru: %w", NewHTTPError("GET", "https://10.0.0.1/cgi/ipmi.cgi", 404, []byte("<html>\n  not found\n</html>")))
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("Found errors: expected an HTTPError in %v", err)
+	}
+
+	if httpErr.StatusCode != 404 {
+		t.Errorf("Expected answer %v: found %v", 404, httpErr.StatusCode)
+	}
+
+	expectedAnswer := "reading fru: GET https://10.0.0.1/cgi/ipmi.cgi returned status code 404: <html> not found </html>"
+	if err.Error() != expectedAnswer {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, err.Error())
+	}
+
+	if !errors.Is(err, ErrPageNotFound) || !errors.Is(err, ErrNon200Response) || errors.Is(err, Err500) {
+		t.Errorf("Expected a 404 HTTPError to match ErrPageNotFound and ErrNon200Response only")
+	}
+}
+
+func TestHTTPErrorBodyExcerpt(t *testing.T) {
+	err := NewHTTPError("POST", "https://10.0.0.1/hpoa", 500, []byte(strings.Repeat("a", 1000)))
+	if len(err.Body) != httpErrorBodyLimit+len("...") {
+		t.Errorf("Expected answer %v: found %v", httpErrorBodyLimit+len("..."), len(err.Body))
+	}
+
+	if !errors.Is(err, Err500) {
+		t.Errorf("Expected a 500 HTTPError to match Err500")
+	}
+}
+
+func TestIsNotFoundIsUnauthorized(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		notFound     bool
+		unauthorized bool
+	}{
+		{"ErrPageNotFound", ErrPageNotFound, true, false},
+		{"wrapped ErrPageNotFound", fmt.Errorf("Serial: %w", ErrPageNotFound), true, false},
+		{"404", fmt.Errorf("Serial: %w", NewHTTPError("GET", "https://10.0.0.1/redfish/v1/Chassis/1", 404, nil)), true, false},
+		{"Err401Redfish", fmt.Errorf("PowerOn: %w", Err401Redfish), false, true},
+		{"401", NewHTTPError("GET", "https://10.0.0.1/redfish/v1/Systems/1", 401, nil), false, true},
+		{"403", NewHTTPError("GET", "https://10.0.0.1/redfish/v1/Systems/1", 403, nil), false, false},
+		{"500", NewHTTPError("POST", "https://10.0.0.1/hpoa", 500, nil), false, false},
+		{"nil", nil, false, false},
+	}
+
+	for _, tc := range tests {
+		if answer := IsNotFound(tc.err); answer != tc.notFound {
+			t.Errorf("%s: IsNotFound Expected answer %v: found %v", tc.name, tc.notFound, answer)
+		}
+
+		if answer := IsUnauthorized(tc.err); answer != tc.unauthorized {
+			t.Errorf("%s: IsUnauthorized Expected answer %v: found %v", tc.name, tc.unauthorized, answer)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MultiError collects several errors, e.g. the per field failures of a snapshot collected in partial mode,
+// while the caller still returns the data it could read.
+type MultiError struct {
+	Errors []error
+}
+
+// Append adds err to the collected errors, nil errors are ignored.
+func (m *MultiError) Append(err error) {
+	if err != nil {
+		m.Errors = append(m.Errors, err)
+	}
+}
+
+// ErrorOrNil returns nil when no errors were collected, so the MultiError can be returned as is.
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || len(m.Errors) == 0 {
+		return nil
+	}
+
+	return m
+}
+
+func (m *MultiError) Error() string {
+	if len(m.Errors) == 1 {
+		return m.Errors[0].Error()
+	}
+
+	msgs := make([]string, len(m.Errors))
+	for idx, err := range m.Errors {
+		msgs[idx] = err.Error()
+	}
+
+	return fmt.Sprintf("%d errors occurred: %s", len(m.Errors), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the collected errors, errors.Is and errors.As traverse them from Go 1.20.
+func (m *MultiError) Unwrap() []error {
+	return m.Errors
+}
+
+// Is reports whether any of the collected errors matches target, for toolchains older than Go 1.20.
+func (m *MultiError) Is(target error) bool {
+	for _, err := range m.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first collected error that matches target, for toolchains older than Go 1.20.
+func (m *MultiError) As(target interface{}) bool {
+	for _, err := range m.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,54 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMultiError(t *testing.T) {
+	multiErr := &MultiError{}
+	if multiErr.ErrorOrNil() != nil {
+		t.Fatalf("Expected no error when nothing was collected")
+	}
+
+	multiErr.Append(fmt.Errorf("Serial: %w", ErrInvalidSerial))
+	multiErr.Append(nil)
+	multiErr.Append(fmt.Errorf("Nics: %w", NewHTTPError("GET", "https://10.0.0.1/redfish/v1/Systems/1", 500, nil)))
+	multiErr.Append(fmt.Errorf("Model: %w", ErrTimeout))
+
+	err := fmt.Errorf("ServerSnapshot: %w", multiErr.ErrorOrNil())
+
+	if len(multiErr.Errors) != 3 {
+		t.Errorf("Expected answer %v: found %v", 3, len(multiErr.Errors))
+	}
+
+	expectedAnswer := "ServerSnapshot: 3 errors occurred: Serial: unable to find the serial number; Nics: GET https://10.0.0.1/redfish/v1/Systems/1 returned status code 500; Model: request timed out"
+	if err.Error() != expectedAnswer {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, err.Error())
+	}
+
+	for _, target := range []error{ErrInvalidSerial, Err500, ErrTimeout} {
+		if !errors.Is(err, target) {
+			t.Errorf("Expected %v to match %v", err, target)
+		}
+	}
+
+	if errors.Is(err, ErrLoginFailed) {
+		t.Errorf("Expected %v not to match %v", err, ErrLoginFailed)
+	}
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != 500 {
+		t.Errorf("Expected to find the HTTPError in %v", err)
+	}
+}
+
+func TestMultiErrorSingle(t *testing.T) {
+	multiErr := &MultiError{}
+	multiErr.Append(ErrLoginFailed)
+
+	if multiErr.Error() != ErrLoginFailed.Error() {
+		t.Errorf("Expected answer %v: found %v", ErrLoginFailed.Error(), multiErr.Error())
+	}
+}
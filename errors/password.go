@@ -0,0 +1,18 @@
+package errors
+
+import "strings"
+
+// PasswordPolicyError is returned for a password rejected before it is sent to the bmc,
+// it lists the requirements of the policy the password doesn't meet and matches ErrWeakPassword.
+type PasswordPolicyError struct {
+	Unmet []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return ErrWeakPassword.Error() + ": " + strings.Join(e.Unmet, ", ")
+}
+
+// Is keeps the error matching ErrWeakPassword for callers checking the sentinel.
+func (e *PasswordPolicyError) Is(target error) bool {
+	return target == ErrWeakPassword
+}
@@ -0,0 +1,107 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrRateLimited is matched by errors returned when the bmc refuses a request because of too many
+// requests or sessions, it's retryable but needs a longer backoff.
+var ErrRateLimited = errors.New("rate limited by the bmc")
+
+// rateLimitBackoffFactor multiplies the retry backoff when the bmc rate limits without a Retry-After
+const rateLimitBackoffFactor = 4
+
+// MaxRetryDelay bounds the wait before a retry, whatever the Retry-After sent by the bmc
+const MaxRetryDelay = 2 * time.Minute
+
+// tooManySessionsMessages are the responses of bmcs that have run out of sessions
+var tooManySessionsMessages = []string{
+	"too many sessions",
+	"maximum number of sessions",
+	"maximum number of user sessions",
+}
+
+// IsRateLimited returns true when the bmc refused the request because of too many requests or sessions.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// RetryAfter returns the Retry-After sent by the bmc with a rate limited response, or zero.
+func RetryAfter(err error) time.Duration {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.RetryAfter
+	}
+
+	return 0
+}
+
+// RetryDelay returns how long to wait before retrying after err, given the regular backoff:
+// the Retry-After sent by the bmc when present, a longer backoff for rate limited requests
+// and the regular backoff otherwise. The delay never exceeds MaxRetryDelay.
+func RetryDelay(err error, backoff time.Duration) (delay time.Duration) {
+	switch retryAfter := RetryAfter(err); {
+	case retryAfter > 0:
+		delay = retryAfter
+	case IsRateLimited(err):
+		delay = backoff * rateLimitBackoffFactor
+	default:
+		delay = backoff
+	}
+
+	if delay > MaxRetryDelay {
+		return MaxRetryDelay
+	}
+
+	return delay
+}
+
+// RetryDelayWithin returns the RetryDelay before retrying after err, ok is false when ctx
+// is done or its deadline expires before the delay, the retry is then pointless.
+func RetryDelayWithin(ctx context.Context, err error, backoff time.Duration) (delay time.Duration, ok bool) {
+	if ctx.Err() != nil {
+		return 0, false
+	}
+
+	delay = RetryDelay(err, backoff)
+	if deadline, set := ctx.Deadline(); set && time.Until(deadline) < delay {
+		return delay, false
+	}
+
+	return delay, true
+}
+
+// tooManySessions returns true when body is a bmc reporting it ran out of sessions
+func tooManySessions(body string) bool {
+	body = strings.ToLower(body)
+	for _, msg := range tooManySessionsMessages {
+		if strings.Contains(body, msg) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an http date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+
+	return 0
+}
@@ -0,0 +1,98 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRateLimited(t *testing.T) {
+	req := &http.Request{Method: "POST", URL: &url.URL{Scheme: "https", Host: "10.0.0.1", Path: "/cgi/login.cgi"}}
+
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"30"}}, Request: req}
+	err := fmt.Errorf("login: %w", NewHTTPErrorFromResponse(resp, nil))
+
+	if !IsRateLimited(err) || !IsRetryable(err) {
+		t.Errorf("Expected %v to be rate limited and retryable", err)
+	}
+
+	if answer := RetryAfter(err); answer != 30*time.Second {
+		t.Errorf("Expected answer %v: found %v", 30*time.Second, answer)
+	}
+
+	if answer := RetryDelay(err, time.Second); answer != 30*time.Second {
+		t.Errorf("Expected answer %v: found %v", 30*time.Second, answer)
+	}
+
+	resp = &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Request: req}
+	err = NewHTTPErrorFromResponse(resp, []byte("<html>Login failed: too many sessions are open</html>"))
+
+	if !IsRateLimited(err) || RetryAfter(err) != 0 {
+		t.Errorf("Expected %v to be rate limited without a Retry-After", err)
+	}
+
+	if answer := RetryDelay(err, time.Second); answer != rateLimitBackoffFactor*time.Second {
+		t.Errorf("Expected answer %v: found %v", rateLimitBackoffFactor*time.Second, answer)
+	}
+
+	if answer := RetryDelay(Err500, time.Second); answer != time.Second {
+		t.Errorf("Expected answer %v: found %v", time.Second, answer)
+	}
+
+	if IsRateLimited(NewHTTPError("GET", "https://10.0.0.1/", 503, nil)) {
+		t.Errorf("Expected a 503 not to be rate limited")
+	}
+}
+
+func TestRetryDelayBounded(t *testing.T) {
+	req := &http.Request{Method: "GET", URL: &url.URL{Scheme: "https", Host: "10.0.0.1", Path: "/redfish/v1"}}
+	resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{"Retry-After": []string{"86400"}}, Request: req}
+	err := NewHTTPErrorFromResponse(resp, nil)
+
+	if answer := RetryDelay(err, time.Second); answer != MaxRetryDelay {
+		t.Errorf("Expected answer %v: found %v", MaxRetryDelay, answer)
+	}
+
+	if answer := RetryDelay(ErrRateLimited, time.Hour); answer != MaxRetryDelay {
+		t.Errorf("Expected answer %v: found %v", MaxRetryDelay, answer)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if _, ok := RetryDelayWithin(ctx, err, time.Second); ok {
+		t.Errorf("Expected a delay past the deadline of the context not to be waited")
+	}
+
+	if delay, ok := RetryDelayWithin(ctx, Err500, time.Second); !ok || delay != time.Second {
+		t.Errorf("Expected answer %v: found %v, %v", time.Second, delay, ok)
+	}
+
+	cancel()
+	if _, ok := RetryDelayWithin(ctx, Err500, time.Second); ok {
+		t.Errorf("Expected no retry once the context is done")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]time.Duration{
+		"120":                           2 * time.Minute,
+		" 5 ":                           5 * time.Second,
+		"Tue, 01 Jun 2021 12:01:00 GMT": time.Minute,
+		"Tue, 01 Jun 2021 11:00:00 GMT": 0,
+		"":                              0,
+		"-1":                            0,
+		"soon":                          0,
+	}
+
+	for value, expectedAnswer := range tests {
+		if answer := parseRetryAfter(value, now); answer != expectedAnswer {
+			t.Errorf("%q: Expected answer %v: found %v", value, expectedAnswer, answer)
+		}
+	}
+}
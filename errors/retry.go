@@ -0,0 +1,51 @@
+package errors
+
+import (
+	"errors"
+	"io"
+	"syscall"
+)
+
+// nonRetryable are the errors a retry won't fix, they take precedence over the checks in IsRetryable.
+var nonRetryable = []error{
+	ErrLoginFailed,
+	ErrInvalidCredentials,
+	ErrInvalidUserRole,
+	ErrUserParamsRequired,
+	ErrUserAccountExists,
+	ErrNoUserSlotsAvailable,
+	ErrUnsupportedModel,
+	ErrFeatureUnavailable,
+	ErrNotImplemented,
+	ErrResponseTooLarge,
+}
+
+// IsRetryable returns true when err is likely transient and the request is worth retrying:
+// connection resets, timeouts, 5xx responses and rate limiting, use RetryDelay for the wait before retrying.
+// 4xx responses, authentication failures and validation errors are not retryable.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	for _, e := range nonRetryable {
+		if errors.Is(err, e) {
+			return false
+		}
+	}
+
+	if IsRateLimited(err) {
+		return true
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 500
+	}
+
+	return errors.Is(err, Err500) ||
+		IsTimeout(err) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
@@ -0,0 +1,48 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestIsRetryable(t *testing.T) {
+	connReset := &url.Error{Op: "Post", URL: "https://10.0.0.1/hpoa", Err: &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}}
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedAnswer bool
+	}{
+		{"nil", nil, false},
+		{"connection reset", connReset, true},
+		{"unexpected eof", fmt.Errorf("reading body: %w", io.ErrUnexpectedEOF), true},
+		{"context deadline", context.DeadlineExceeded, true},
+		{"timeout", fmt.Errorf("query: %w", ErrTimeout), true},
+		{"500", NewHTTPError("POST", "https://10.0.0.1/cgi/ipmi.cgi", 500, nil), true},
+		{"503", fmt.Errorf("get: %w", NewHTTPError("GET", "https://10.0.0.1/", 503, nil)), true},
+		{"Err500", Err500, true},
+		{"400", NewHTTPError("POST", "https://10.0.0.1/cgi/op.cgi", 400, nil), false},
+		{"401", NewHTTPError("GET", "https://10.0.0.1/", 401, nil), false},
+		{"404", NewHTTPError("GET", "https://10.0.0.1/", 404, nil), false},
+		{"login failed", fmt.Errorf("login returned status code 500: %w", ErrLoginFailed), false},
+		{"invalid credentials", ErrInvalidCredentials, false},
+		{"invalid user role", ErrInvalidUserRole, false},
+		{"user params", ErrUserParamsRequired, false},
+		{"unsupported model", ErrUnsupportedModel, false},
+		{"not implemented", ErrNotImplemented, false},
+		{"canceled", context.Canceled, false},
+		{"other", fmt.Errorf("unexpected payload"), false},
+	}
+
+	for _, tc := range tests {
+		if answer := IsRetryable(tc.err); answer != tc.expectedAnswer {
+			t.Errorf("%s: Expected answer %v: found %v", tc.name, tc.expectedAnswer, answer)
+		}
+	}
+}
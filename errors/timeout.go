@@ -0,0 +1,53 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+// ErrTimeout is matched by errors returned when a request to the bmc timed out
+var ErrTimeout = errors.New("request timed out")
+
+// IsTimeout returns true when err is, or wraps, a context deadline, a net.Error timeout
+// (which includes the http.Client Timeout) or ErrTimeout.
+func IsTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, ErrTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// WrapTimeout makes timeout errors match ErrTimeout, keeping the original error in the chain,
+// any other error is returned as is.
+func WrapTimeout(err error) error {
+	if !IsTimeout(err) || errors.Is(err, ErrTimeout) {
+		return err
+	}
+
+	return &sentinelError{err: err, sentinel: ErrTimeout}
+}
+
+// sentinelError makes err match sentinel with errors.Is while keeping err in the chain
+type sentinelError struct {
+	err      error
+	sentinel error
+}
+
+func (e *sentinelError) Error() string {
+	return e.err.Error()
+}
+
+func (e *sentinelError) Unwrap() error {
+	return e.err
+}
+
+func (e *sentinelError) Is(target error) bool {
+	return target == e.sentinel
+}
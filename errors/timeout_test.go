@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type netTimeout struct{}
+
+func (netTimeout) Error() string   { return "i/o timeout" }
+func (netTimeout) Timeout() bool   { return true }
+func (netTimeout) Temporary() bool { return true }
+
+func TestIsTimeout(t *testing.T) {
+	tests := map[string]struct {
+		err            error
+		expectedAnswer bool
+	}{
+		"context deadline": {fmt.Errorf("postXML: %w", context.DeadlineExceeded), true},
+		"net timeout":      {fmt.Errorf("read: %w", netTimeout{}), true},
+		"sentinel":         {ErrTimeout, true},
+		"canceled":         {context.Canceled, false},
+		"other":            {ErrLoginFailed, false},
+		"nil":              {nil, false},
+	}
+
+	for name, tc := range tests {
+		if answer := IsTimeout(tc.err); answer != tc.expectedAnswer {
+			t.Errorf("%s: Expected answer %v: found %v", name, tc.expectedAnswer, answer)
+		}
+	}
+}
+
+func TestIsTimeoutHTTPClient(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	client := &http.Client{Timeout: 50 * time.Millisecond}
+	_, err := client.Get(server.URL)
+	if !IsTimeout(err) {
+		t.Fatalf("Expected the client timeout to be recognized: found %v", err)
+	}
+
+	wrapped := WrapTimeout(err)
+	if !errors.Is(wrapped, ErrTimeout) {
+		t.Errorf("Expected %v to match ErrTimeout", wrapped)
+	}
+
+	var netErr interface{ Timeout() bool }
+	if !errors.As(wrapped, &netErr) {
+		t.Errorf("Expected the original error to be kept in the chain")
+	}
+
+	if WrapTimeout(ErrLoginFailed) != ErrLoginFailed {
+		t.Errorf("Expected other errors to be returned as is")
+	}
+}
@@ -0,0 +1,45 @@
+package errors
+
+import (
+	"crypto/x509"
+	"errors"
+)
+
+// ErrTLSVerification is matched by errors returned when the bmc certificate couldn't be verified
+var ErrTLSVerification = errors.New("bmc certificate not trusted")
+
+// IsTLSVerificationError returns true when err is, or wraps, a certificate verification failure:
+// an unknown authority, a hostname mismatch or an expired or otherwise invalid certificate.
+func IsTLSVerificationError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, ErrTLSVerification) {
+		return true
+	}
+
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+
+	return errors.As(err, &unknownAuthority) ||
+		errors.As(err, &hostname) ||
+		errors.As(err, &invalid)
+}
+
+// WrapTLSVerification makes certificate verification errors match ErrTLSVerification,
+// keeping the original error in the chain, any other error is returned as is.
+func WrapTLSVerification(err error) error {
+	if !IsTLSVerificationError(err) || errors.Is(err, ErrTLSVerification) {
+		return err
+	}
+
+	return &sentinelError{err: err, sentinel: ErrTLSVerification}
+}
+
+// WrapRequestError applies WrapTimeout and WrapTLSVerification,
+// providers use it on the errors returned by the http client.
+func WrapRequestError(err error) error {
+	return WrapTLSVerification(WrapTimeout(err))
+}
@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIsTLSVerificationError(t *testing.T) {
+	tests := map[string]struct {
+		err            error
+		expectedAnswer bool
+	}{
+		"hostname mismatch": {fmt.Errorf("Post: %w", x509.HostnameError{Host: "10.0.0.1", Certificate: &x509.Certificate{}}), true},
+		"expired":           {x509.CertificateInvalidError{Reason: x509.Expired}, true},
+		"unknown authority": {x509.UnknownAuthorityError{}, true},
+		"sentinel":          {ErrTLSVerification, true},
+		"other":             {ErrTimeout, false},
+		"nil":               {nil, false},
+	}
+
+	for name, tc := range tests {
+		if answer := IsTLSVerificationError(tc.err); answer != tc.expectedAnswer {
+			t.Errorf("%s: Expected answer %v: found %v", name, tc.expectedAnswer, answer)
+		}
+	}
+}
+
+func TestIsTLSVerificationErrorSelfSigned(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	// the default client doesn't trust the self-signed test server certificate
+	_, err := http.Get(server.URL)
+	if !IsTLSVerificationError(err) {
+		t.Fatalf("Expected the self-signed certificate to fail verification: found %v", err)
+	}
+
+	wrapped := WrapRequestError(err)
+	if !errors.Is(wrapped, ErrTLSVerification) || errors.Is(wrapped, ErrTimeout) {
+		t.Errorf("Expected %v to match ErrTLSVerification only", wrapped)
+	}
+
+	if wrapped.Error() != err.Error() {
+		t.Errorf("Expected answer %v: found %v", err.Error(), wrapped.Error())
+	}
+}
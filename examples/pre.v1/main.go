@@ -33,6 +33,7 @@ func main() {
 		*pass,
 		discover.WithContext(ctx),
 		discover.WithLogger(logger),
+		discover.WithInsecureTLS(),
 	)
 
 	if err != nil {
@@ -49,6 +49,9 @@ func main() {
 		}
 		// a nil pool uses the system certs
 		clientOpts = append(clientOpts, bmclib.WithSecureTLS(pool))
+	} else {
+		// BMCs usually serve self-signed certificates
+		clientOpts = append(clientOpts, bmclib.WithInsecureTLS())
 	}
 
 	cl := bmclib.NewClient(*host, strconv.Itoa(*port), *user, *pass, clientOpts...)
@@ -0,0 +1,38 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+// BIOSConfigurator checks the configurator reads back the BIOS setting it is set, from the pending
+// settings when it reports a reboot is required to apply it, from the current settings otherwise.
+// The configurator must be backed by a mock BMC keeping the settings it is set.
+func BIOSConfigurator(t *testing.T, configurator devices.BIOSConfigurator, name, value string) {
+	t.Helper()
+
+	ctx := context.Background()
+	settings := devices.NewBIOSSettings()
+	settings.Set(name, value)
+
+	rebootRequired, err := configurator.SetBIOSSettings(ctx, settings)
+	if err != nil {
+		t.Fatalf("Found errors calling SetBIOSSettings %v", err)
+	}
+
+	read := configurator.GetBIOSSettings
+	if rebootRequired {
+		read = configurator.PendingBIOSSettings
+	}
+
+	answer, err := read(ctx)
+	if err != nil {
+		t.Fatalf("Found errors reading the BIOS settings %v", err)
+	}
+
+	if found, ok := answer.Get(name); !ok || found != value {
+		t.Errorf("Expected answer %v=%v (reboot required: %v): found %v", name, value, rebootRequired, found)
+	}
+}
@@ -0,0 +1,59 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+// biosStub keeps the BIOS settings in memory, staging them until a reboot when applyOnReboot is set
+type biosStub struct {
+	applyOnReboot bool
+	current       *devices.BIOSSettings
+	pending       *devices.BIOSSettings
+}
+
+func newBIOSStub(applyOnReboot bool) *biosStub {
+	current := devices.NewBIOSSettings()
+	current.Set("BootMode", "Bios")
+	current.Set("LogicalProc", "Enabled")
+
+	return &biosStub{applyOnReboot: applyOnReboot, current: current, pending: devices.NewBIOSSettings()}
+}
+
+func (b *biosStub) GetBIOSSettings(ctx context.Context) (*devices.BIOSSettings, error) {
+	return b.current, nil
+}
+
+func (b *biosStub) SetBIOSSettings(ctx context.Context, settings *devices.BIOSSettings) (bool, error) {
+	target := b.current
+	if b.applyOnReboot {
+		target = b.pending
+	}
+
+	for _, name := range settings.Names() {
+		value, _ := settings.Get(name)
+		target.Set(name, value)
+	}
+
+	return b.applyOnReboot, nil
+}
+
+func (b *biosStub) PendingBIOSSettings(ctx context.Context) (*devices.BIOSSettings, error) {
+	return b.pending, nil
+}
+
+func TestBIOSConfigurator(t *testing.T) {
+	for name, applyOnReboot := range map[string]bool{"immediate": false, "on reboot": true} {
+		t.Run(name, func(t *testing.T) {
+			stub := newBIOSStub(applyOnReboot)
+			BIOSConfigurator(t, stub, "BootMode", "Uefi")
+
+			current, _ := stub.current.Get("BootMode")
+			if applyOnReboot && current != "Bios" {
+				t.Errorf("Expected the current BootMode to be kept until the reboot: found %v", current)
+			}
+		})
+	}
+}
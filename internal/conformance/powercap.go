@@ -0,0 +1,41 @@
+// Package conformance holds the checks shared by the provider tests
+// to verify an implementation of a devices interface behaves as documented.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+)
+
+// PowerCapper checks the capper reads back an enabled cap of the given watts and a disabled cap
+// once set, and rejects an enabled cap without a limit. The capper must be backed by a mock
+// BMC keeping the cap it is set.
+func PowerCapper(t *testing.T, capper devices.PowerCapper, watts int) {
+	t.Helper()
+
+	ctx := context.Background()
+	for _, powerCap := range []devices.PowerCap{{Enabled: true, Watts: watts}, {Enabled: false}} {
+		err := capper.SetPowerCap(ctx, powerCap)
+		if err != nil {
+			t.Fatalf("Found errors calling SetPowerCap(%+v) %v", powerCap, err)
+		}
+
+		answer, err := capper.GetPowerCap(ctx)
+		if err != nil {
+			t.Fatalf("Found errors calling GetPowerCap %v", err)
+		}
+
+		if answer != powerCap {
+			t.Errorf("Expected answer %+v: found %+v", powerCap, answer)
+		}
+	}
+
+	err := capper.SetPowerCap(ctx, devices.PowerCap{Enabled: true})
+	if !errors.Is(err, bmclibErrs.ErrInvalidPowerCap) {
+		t.Errorf("Expected error %v: found %v", bmclibErrs.ErrInvalidPowerCap, err)
+	}
+}
@@ -0,0 +1,39 @@
+package httpclient
+
+import (
+	"net/http"
+)
+
+// SessionAuth adds the session token obtained at login to the requests made to a BMC,
+// firmware versions differ in where they expect it.
+type SessionAuth interface {
+	Authorize(req *http.Request, token string)
+}
+
+// CookieAuth sends the session token in the cookie named Name, e.g. the SID cookie
+// of the Supermicro web interface.
+type CookieAuth struct {
+	Name string
+}
+
+// Authorize implements SessionAuth
+func (a CookieAuth) Authorize(req *http.Request, token string) {
+	if token == "" {
+		return
+	}
+	req.AddCookie(&http.Cookie{Name: a.Name, Value: token})
+}
+
+// HeaderAuth sends the session token in the header named Name, e.g. X-SID-TOKEN or a CSRF
+// token header, for firmware that moved away from session cookies.
+type HeaderAuth struct {
+	Name string
+}
+
+// Authorize implements SessionAuth
+func (a HeaderAuth) Authorize(req *http.Request, token string) {
+	if token == "" {
+		return
+	}
+	req.Header.Set(a.Name, token)
+}
@@ -0,0 +1,44 @@
+package httpclient
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSessionAuth(t *testing.T) {
+	tt := []struct {
+		name       string
+		auth       SessionAuth
+		token      string
+		wantCookie string
+		wantHeader string
+	}{
+		{name: "cookie", auth: CookieAuth{Name: "SID"}, token: "abc", wantCookie: "abc"},
+		{name: "header", auth: HeaderAuth{Name: "X-SID-TOKEN"}, token: "abc", wantHeader: "abc"},
+		{name: "cookie without token", auth: CookieAuth{Name: "SID"}},
+		{name: "header without token", auth: HeaderAuth{Name: "X-SID-TOKEN"}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "https://127.0.0.1/cgi/ipmi.cgi", nil)
+			if err != nil {
+				t.Fatalf("Found errors building the request: %s", err)
+			}
+
+			tc.auth.Authorize(req, tc.token)
+
+			var cookie string
+			if c, err := req.Cookie("SID"); err == nil {
+				cookie = c.Value
+			}
+			if cookie != tc.wantCookie {
+				t.Errorf("Expected answer %v: found %v", tc.wantCookie, cookie)
+			}
+
+			if header := req.Header.Get("X-SID-TOKEN"); header != tc.wantHeader {
+				t.Errorf("Expected answer %v: found %v", tc.wantHeader, header)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sync"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// HostJar is an http.CookieJar keeping a separate jar for each host and port, cookies
+// being scoped by domain only a standard jar mixes the SID cookies of BMCs reached
+// through the same address on different ports.
+type HostJar struct {
+	mu   sync.Mutex
+	jars map[string]http.CookieJar
+}
+
+// NewHostJar returns an empty HostJar
+func NewHostJar() *HostJar {
+	return &HostJar{jars: map[string]http.CookieJar{}}
+}
+
+// SetCookies implements http.CookieJar
+func (j *HostJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.jar(u).SetCookies(u, cookies)
+}
+
+// Cookies implements http.CookieJar
+func (j *HostJar) Cookies(u *url.URL) []*http.Cookie {
+	return j.jar(u).Cookies(u)
+}
+
+// jar returns the jar of the host and port of u, creating it on first use
+func (j *HostJar) jar(u *url.URL) http.CookieJar {
+	host := u.Host
+	if u.Port() == "" {
+		switch u.Scheme {
+		case "http":
+			host += ":80"
+		default:
+			host += ":443"
+		}
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	jar, ok := j.jars[host]
+	if !ok {
+		// cookiejar.New never fails with these options
+		jar, _ = cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+		j.jars[host] = jar
+	}
+
+	return jar
+}
+
+// IsolateCookies returns a copy of an HTTP client with its own empty HostJar, so a client
+// shared between several providers never leaks session cookies from one instance to another.
+// The copy shares the transport and its connection pool with the original client.
+func IsolateCookies(c *http.Client) *http.Client {
+	if c == nil {
+		return nil
+	}
+
+	isolated := *c
+	isolated.Jar = NewHostJar()
+
+	return &isolated
+}
+
+// WithoutCookies returns a jar that stores every cookie but never sends the ones named names,
+// for BMCs expecting the session token somewhere else than in the cookie carrying it at login.
+func WithoutCookies(jar http.CookieJar, names ...string) http.CookieJar {
+	return &filteredJar{CookieJar: jar, names: names}
+}
+
+// filteredJar is an http.CookieJar leaving some cookies out of the requests
+type filteredJar struct {
+	http.CookieJar
+	names []string
+}
+
+// Cookies implements http.CookieJar
+func (j *filteredJar) Cookies(u *url.URL) (cookies []*http.Cookie) {
+	for _, cookie := range j.CookieJar.Cookies(u) {
+		if !j.filtered(cookie.Name) {
+			cookies = append(cookies, cookie)
+		}
+	}
+
+	return cookies
+}
+
+// filtered returns whether the cookie named name is left out of the requests
+func (j *filteredJar) filtered(name string) bool {
+	for _, n := range j.names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
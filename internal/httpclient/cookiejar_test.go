@@ -0,0 +1,115 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// sessionServer sets the given SID on login and echoes back the SID it receives otherwise
+func sessionServer(sid string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/cgi/login.cgi" {
+			http.SetCookie(w, &http.Cookie{Name: "SID", Value: sid, Path: "/"})
+			return
+		}
+		if cookie, err := r.Cookie("SID"); err == nil {
+			w.Header().Set("X-Received-SID", cookie.Value)
+		}
+	}))
+}
+
+func TestHostJarIsolation(t *testing.T) {
+	// both BMCs are reached through 127.0.0.1, only their ports differ
+	bmcA := sessionServer("session-a")
+	defer bmcA.Close()
+	bmcB := sessionServer("session-b")
+	defer bmcB.Close()
+
+	client, err := Build()
+	if err != nil {
+		t.Fatalf("Found errors building the client: %s", err)
+	}
+
+	for _, bmc := range []*httptest.Server{bmcA, bmcB} {
+		resp, err := client.Get(bmc.URL + "/cgi/login.cgi")
+		if err != nil {
+			t.Fatalf("Found errors logging in: %s", err)
+		}
+		resp.Body.Close()
+	}
+
+	cases := []struct {
+		bmc  *httptest.Server
+		want string
+	}{
+		{bmcA, "session-a"},
+		{bmcB, "session-b"},
+	}
+	for _, tc := range cases {
+		resp, err := client.Get(tc.bmc.URL + "/cgi/ipmi.cgi")
+		if err != nil {
+			t.Fatalf("Found errors calling the BMC: %s", err)
+		}
+		resp.Body.Close()
+
+		if got := resp.Header.Get("X-Received-SID"); got != tc.want {
+			t.Errorf("Expected answer %v: found %v", tc.want, got)
+		}
+	}
+}
+
+func TestIsolateCookies(t *testing.T) {
+	bmc := sessionServer("session-a")
+	defer bmc.Close()
+
+	shared, err := Build()
+	if err != nil {
+		t.Fatalf("Found errors building the client: %s", err)
+	}
+	resp, err := shared.Get(bmc.URL + "/cgi/login.cgi")
+	if err != nil {
+		t.Fatalf("Found errors logging in: %s", err)
+	}
+	resp.Body.Close()
+
+	isolated := IsolateCookies(shared)
+	resp, err = isolated.Get(bmc.URL + "/cgi/ipmi.cgi")
+	if err != nil {
+		t.Fatalf("Found errors calling the BMC: %s", err)
+	}
+	resp.Body.Close()
+
+	if got := resp.Header.Get("X-Received-SID"); got != "" {
+		t.Errorf("Expected answer %v: found %v", "no session cookie", got)
+	}
+	if isolated.Transport != shared.Transport {
+		t.Errorf("Expected the isolated client to share the transport of the original client")
+	}
+}
+
+func TestWithoutCookies(t *testing.T) {
+	bmc := sessionServer("session-a")
+	defer bmc.Close()
+
+	client, err := Build()
+	if err != nil {
+		t.Fatalf("Found errors building the client: %s", err)
+	}
+	resp, err := client.Get(bmc.URL + "/cgi/login.cgi")
+	if err != nil {
+		t.Fatalf("Found errors logging in: %s", err)
+	}
+	resp.Body.Close()
+
+	client.Jar = WithoutCookies(client.Jar, "SID")
+	resp, err = client.Get(bmc.URL + "/cgi/ipmi.cgi")
+	if err != nil {
+		t.Fatalf("Found errors calling the BMC: %s", err)
+	}
+	resp.Body.Close()
+
+	if got := resp.Header.Get("X-Received-SID"); got != "" {
+		t.Errorf("Expected answer %v: found %v", "no session cookie", got)
+	}
+}
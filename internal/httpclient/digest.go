@@ -0,0 +1,258 @@
+package httpclient
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// DigestAuthTransport is an http.RoundTripper answering the HTTP Digest authentication
+// challenges (RFC 7616) of the BMCs, with the MD5 and SHA-256 algorithms and the auth qop.
+// Once challenged, the following requests are authorized upfront with the same nonce.
+type DigestAuthTransport struct {
+	// Next is the wrapped RoundTripper, http.DefaultTransport when nil
+	Next     http.RoundTripper
+	Username string
+	Password string
+
+	mu        sync.Mutex
+	challenge *digestChallenge
+	nc        int
+}
+
+// digestChallenge holds the parameters of a WWW-Authenticate: Digest header
+type digestChallenge struct {
+	realm     string
+	nonce     string
+	opaque    string
+	algorithm string
+	qop       string
+}
+
+// RoundTrip implements http.RoundTripper
+func (dt *DigestAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := dt.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	first := req
+	if authorization := dt.authorization(req); authorization != "" {
+		first = cloneWithAuthorization(req, authorization)
+	}
+
+	resp, err := next.RoundTrip(first)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challenge, ok := parseDigestChallenge(resp.Header.Values("WWW-Authenticate"))
+	if !ok {
+		return resp, nil
+	}
+
+	// the body was consumed by the first attempt, it has to be rewound
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return resp, nil
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		req = req.Clone(req.Context())
+		req.Body = body
+	}
+
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	dt.mu.Lock()
+	dt.challenge, dt.nc = challenge, 0
+	dt.mu.Unlock()
+
+	return next.RoundTrip(cloneWithAuthorization(req, dt.authorization(req)))
+}
+
+// next implements roundTripperWrapper
+func (dt *DigestAuthTransport) next() *http.RoundTripper {
+	return &dt.Next
+}
+
+// authorization returns the Authorization header answering the last challenge, empty before the first one
+func (dt *DigestAuthTransport) authorization(req *http.Request) string {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	c := dt.challenge
+	if c == nil {
+		return ""
+	}
+
+	newHash := md5.New
+	if strings.HasPrefix(strings.ToUpper(c.algorithm), "SHA-256") {
+		newHash = sha256.New
+	}
+	h := func(s string) string {
+		return hexDigest(newHash(), s)
+	}
+
+	cnonce := newCnonce()
+	ha1 := h(dt.Username + ":" + c.realm + ":" + dt.Password)
+	if strings.HasSuffix(strings.ToLower(c.algorithm), "-sess") {
+		ha1 = h(ha1 + ":" + c.nonce + ":" + cnonce)
+	}
+	uri := req.URL.RequestURI()
+	ha2 := h(req.Method + ":" + uri)
+
+	fields := []string{
+		fmt.Sprintf("username=%q", dt.Username),
+		fmt.Sprintf("realm=%q", c.realm),
+		fmt.Sprintf("nonce=%q", c.nonce),
+		fmt.Sprintf("uri=%q", uri),
+	}
+
+	var response string
+	if c.qop == "" {
+		response = h(ha1 + ":" + c.nonce + ":" + ha2)
+	} else {
+		dt.nc++
+		nc := fmt.Sprintf("%08x", dt.nc)
+		response = h(strings.Join([]string{ha1, c.nonce, nc, cnonce, c.qop, ha2}, ":"))
+		fields = append(fields, "qop="+c.qop, "nc="+nc, fmt.Sprintf("cnonce=%q", cnonce))
+	}
+	fields = append(fields, fmt.Sprintf("response=%q", response))
+
+	if c.algorithm != "" {
+		fields = append(fields, "algorithm="+c.algorithm)
+	}
+	if c.opaque != "" {
+		fields = append(fields, fmt.Sprintf("opaque=%q", c.opaque))
+	}
+
+	return "Digest " + strings.Join(fields, ", ")
+}
+
+// parseDigestChallenge returns the first Digest challenge of the WWW-Authenticate headers
+// using a supported algorithm and qop.
+func parseDigestChallenge(headers []string) (*digestChallenge, bool) {
+	for _, header := range headers {
+		if !strings.HasPrefix(strings.ToLower(header), "digest ") {
+			continue
+		}
+
+		params := parseAuthParams(header[len("digest "):])
+		c := &digestChallenge{
+			realm:     params["realm"],
+			nonce:     params["nonce"],
+			opaque:    params["opaque"],
+			algorithm: params["algorithm"],
+		}
+
+		switch strings.ToUpper(c.algorithm) {
+		case "", "MD5", "MD5-SESS", "SHA-256", "SHA-256-SESS":
+		default:
+			continue
+		}
+
+		if qop, ok := params["qop"]; ok {
+			for _, option := range strings.Split(qop, ",") {
+				if strings.TrimSpace(option) == "auth" {
+					c.qop = "auth"
+				}
+			}
+			// auth-int only is not supported
+			if c.qop == "" {
+				continue
+			}
+		}
+
+		if c.nonce != "" {
+			return c, true
+		}
+	}
+
+	return nil, false
+}
+
+// parseAuthParams parses the comma separated key=value or key="value" parameters of an authentication header
+func parseAuthParams(s string) map[string]string {
+	params := map[string]string{}
+	for len(s) > 0 {
+		s = strings.TrimLeft(s, " ,")
+		eq := strings.IndexByte(s, '=')
+		if eq < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(s[:eq]))
+		s = strings.TrimLeft(s[eq+1:], " ")
+
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			end := 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(s) {
+				// unterminated quoted value
+				value, s = strings.ReplaceAll(s[1:], `\"`, `"`), ""
+			} else {
+				value, s = strings.ReplaceAll(s[1:end], `\"`, `"`), s[end+1:]
+			}
+		} else {
+			end := strings.IndexByte(s, ',')
+			if end < 0 {
+				end = len(s)
+			}
+			value = strings.TrimSpace(s[:end])
+			s = s[end:]
+		}
+		params[key] = value
+	}
+
+	return params
+}
+
+func hexDigest(h hash.Hash, s string) string {
+	_, _ = h.Write([]byte(s))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func newCnonce() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// cloneWithAuthorization returns a copy of req carrying the Authorization header,
+// a RoundTripper must not modify the caller's request.
+func cloneWithAuthorization(req *http.Request, authorization string) *http.Request {
+	clone := req.Clone(req.Context())
+	clone.Header.Set("Authorization", authorization)
+	return clone
+}
+
+// WithDigestAuth wraps the transport of an HTTP client in a DigestAuthTransport
+// authenticating with the given credentials.
+func WithDigestAuth(username, password string) func(*http.Client) {
+	return func(c *http.Client) {
+		if c == nil {
+			return
+		}
+		if c.Transport == nil {
+			c.Transport = DefaultTransport()
+		}
+		c.Transport = &DigestAuthTransport{Next: c.Transport, Username: username, Password: password}
+	}
+}
@@ -0,0 +1,128 @@
+package httpclient
+
+import (
+	"crypto/md5"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// digestServer is a stub BMC requiring digest authentication with MD5 and the auth qop
+type digestServer struct {
+	username, password string
+	challenges         int
+	bodies             []string
+}
+
+func (d *digestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const realm, nonce = "bmc@example.com", "dcd98b7102dd2f0e8b11d0f600bfb0c093"
+
+	authorization := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, "Digest ") {
+		d.challenges++
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Digest realm="%s", qop="auth,auth-int", nonce="%s", opaque="5ccc069c403ebaf9f0171e9517f40e41"`, realm, nonce))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	params := parseAuthParams(authorization[len("Digest "):])
+	md5Hex := func(s string) string { return fmt.Sprintf("%x", md5.Sum([]byte(s))) }
+	ha1 := md5Hex(d.username + ":" + realm + ":" + d.password)
+	ha2 := md5Hex(r.Method + ":" + params["uri"])
+	expected := md5Hex(strings.Join([]string{ha1, nonce, params["nc"], params["cnonce"], params["qop"], ha2}, ":"))
+
+	if params["response"] != expected || params["uri"] != r.URL.RequestURI() || params["opaque"] != "5ccc069c403ebaf9f0171e9517f40e41" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	body, _ := ioutil.ReadAll(r.Body)
+	d.bodies = append(d.bodies, string(body))
+}
+
+func TestWithDigestAuth(t *testing.T) {
+	cases := []struct {
+		name           string
+		password       string
+		wantStatus     int
+		wantChallenges int
+	}{
+		{"valid credentials", "hunter2", http.StatusOK, 1},
+		{"wrong password", "wrong", http.StatusUnauthorized, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			bmc := &digestServer{username: "ADMIN", password: "hunter2"}
+			server := httptest.NewServer(bmc)
+			defer server.Close()
+
+			client, err := Build(WithDigestAuth("ADMIN", tc.password))
+			if err != nil {
+				t.Fatalf("Found errors building the client: %s", err)
+			}
+
+			resp, err := client.Get(server.URL + "/redfish/v1/Systems?expand=1")
+			if err != nil {
+				t.Fatalf("Found errors calling the server: %s", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tc.wantStatus {
+				t.Errorf("Expected answer %v: found %v", tc.wantStatus, resp.StatusCode)
+			}
+
+			// the next request is authorized upfront, with the body sent once
+			resp, err = client.Post(server.URL+"/cgi/op.cgi", "text/plain", strings.NewReader("op=POWER_INFO.XML"))
+			if err != nil {
+				t.Fatalf("Found errors calling the server: %s", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tc.wantStatus {
+				t.Errorf("Expected answer %v: found %v", tc.wantStatus, resp.StatusCode)
+			}
+
+			if bmc.challenges != tc.wantChallenges {
+				t.Errorf("Expected answer %v: found %v", tc.wantChallenges, bmc.challenges)
+			}
+			if tc.wantStatus == http.StatusOK && (len(bmc.bodies) != 2 || bmc.bodies[1] != "op=POWER_INFO.XML") {
+				t.Errorf("Expected answer %v: found %v", "op=POWER_INFO.XML", bmc.bodies)
+			}
+		})
+	}
+}
+
+func TestParseDigestChallenge(t *testing.T) {
+	cases := []struct {
+		name      string
+		header    string
+		wantOK    bool
+		wantQop   string
+		wantNonce string
+	}{
+		{"md5 with qop", `Digest realm="r", qop="auth", nonce="abc"`, true, "auth", "abc"},
+		{"legacy without qop", `Digest realm="r", nonce="abc"`, true, "", "abc"},
+		{"sha-256", `Digest realm="r", qop="auth", algorithm=SHA-256, nonce="abc"`, true, "auth", "abc"},
+		{"auth-int only", `Digest realm="r", qop="auth-int", nonce="abc"`, false, "", ""},
+		{"unsupported algorithm", `Digest realm="r", algorithm=SHA-512-256, nonce="abc"`, false, "", ""},
+		{"basic challenge", `Basic realm="r"`, false, "", ""},
+		{"escaped quotes", `Digest realm="the \"bmc\"", nonce="abc"`, true, "", "abc"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ok := parseDigestChallenge([]string{tc.header})
+			if ok != tc.wantOK {
+				t.Fatalf("Expected answer %v: found %v", tc.wantOK, ok)
+			}
+			if !ok {
+				return
+			}
+			if c.qop != tc.wantQop || c.nonce != tc.wantNonce {
+				t.Errorf("Expected answer %v/%v: found %v/%v", tc.wantQop, tc.wantNonce, c.qop, c.nonce)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"strings"
+)
+
+// redacted replaces the secrets in debug dumps
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders are masked in debug dumps, they carry credentials or session tokens
+// under names the sensitiveHeaderWords don't catch.
+var sensitiveHeaders = []string{"User", "St2"}
+
+// sensitiveHeaderWords mask any header whose name contains one of them, so the credential and
+// token headers of the vendors (X-Auth-Token, XSRF-TOKEN, X-CSRFTOKEN, password...) are masked
+// without listing each of them.
+var sensitiveHeaderWords = []string{"auth", "token", "password", "passwd", "csrf", "cookie", "session"}
+
+// DumpRequestOut works like httputil.DumpRequestOut, masking the credentials and session
+// cookies of the request so the dump can safely be logged.
+func DumpRequestOut(req *http.Request, body bool) ([]byte, error) {
+	header := req.Header
+	req.Header = redactHeader(header)
+	defer func() { req.Header = header }()
+
+	return httputil.DumpRequestOut(req, body)
+}
+
+// DumpResponse works like httputil.DumpResponse, masking the session cookies set by
+// the response so the dump can safely be logged.
+func DumpResponse(resp *http.Response, body bool) ([]byte, error) {
+	header := resp.Header
+	resp.Header = redactHeader(header)
+	defer func() { resp.Header = header }()
+
+	return httputil.DumpResponse(resp, body)
+}
+
+// redactHeader returns a copy of header with the values of the sensitive headers masked.
+// The authorization scheme and the cookie names are kept as they help debugging.
+func redactHeader(header http.Header) http.Header {
+	redactedHeader := header.Clone()
+	for name, values := range redactedHeader {
+		if !sensitiveHeader(name) {
+			continue
+		}
+		for i, value := range values {
+			values[i] = redactValue(http.CanonicalHeaderKey(name), value)
+		}
+	}
+
+	return redactedHeader
+}
+
+// sensitiveHeader returns true when the header named name may carry a credential
+func sensitiveHeader(name string) bool {
+	for _, sensitive := range sensitiveHeaders {
+		if strings.EqualFold(name, sensitive) {
+			return true
+		}
+	}
+
+	lower := strings.ToLower(name)
+	for _, word := range sensitiveHeaderWords {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func redactValue(name, value string) string {
+	switch name {
+	case "Authorization", "Proxy-Authorization":
+		if scheme := strings.Fields(value); len(scheme) > 1 {
+			return scheme[0] + " " + redacted
+		}
+	case "Cookie":
+		cookies := strings.Split(value, ";")
+		for i, cookie := range cookies {
+			cookies[i] = redactCookie(cookie)
+		}
+		return strings.Join(cookies, ";")
+	case "Set-Cookie":
+		// only the value is secret, the attributes following it are kept
+		parts := strings.SplitN(value, ";", 2)
+		parts[0] = redactCookie(parts[0])
+		return strings.Join(parts, ";")
+	}
+
+	return redacted
+}
+
+// redactCookie masks the value of a name=value cookie pair
+func redactCookie(cookie string) string {
+	nameValue := strings.SplitN(cookie, "=", 2)
+	if len(nameValue) != 2 {
+		return redacted
+	}
+
+	return nameValue[0] + "=" + redacted
+}
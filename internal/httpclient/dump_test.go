@@ -0,0 +1,88 @@
+package httpclient
+
+import (
+	"bufio"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDumpRequestOut(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://bmc.example.com/cgi/op.cgi", strings.NewReader("op=POWER_INFO.XML"))
+	req.SetBasicAuth("ADMIN", "hunter2")
+	req.Header.Set("Cookie", "SID=s3cr3t; langSetFlag=0")
+	req.Header.Set("X-Auth-Token", "t0k3n")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	dump, err := DumpRequestOut(req, true)
+	if err != nil {
+		t.Fatalf("Found errors dumping the request: %s", err)
+	}
+
+	for _, secret := range []string{"hunter2", req.Header.Get("Authorization")[len("Basic "):], "s3cr3t", "t0k3n"} {
+		if strings.Contains(string(dump), secret) {
+			t.Errorf("Expected secret %q to be masked: found %s", secret, dump)
+		}
+	}
+
+	for _, kept := range []string{"Authorization: Basic [REDACTED]", "Cookie: SID=[REDACTED]; langSetFlag=[REDACTED]", "application/x-www-form-urlencoded", "op=POWER_INFO.XML"} {
+		if !strings.Contains(string(dump), kept) {
+			t.Errorf("Expected answer %q: found %s", kept, dump)
+		}
+	}
+
+	// the request itself is left untouched
+	if user, pass, ok := req.BasicAuth(); !ok || user != "ADMIN" || pass != "hunter2" {
+		t.Errorf("Expected answer %v: found %v:%v", "ADMIN:hunter2", user, pass)
+	}
+}
+
+func TestDumpRequestOutVendorHeaders(t *testing.T) {
+	// the iDRAC9 login sends the credentials as headers, the other vendors use their own token headers
+	req, _ := http.NewRequest(http.MethodPost, "https://bmc.example.com/sysmgmt/2015/bmc/session", nil)
+	req.Header.Add("user", `"root"`)
+	req.Header.Add("password", `"calvin"`)
+	req.Header.Set("XSRF-TOKEN", "xsrf-s3cr3t")
+	req.Header.Set("ST2", "st2-s3cr3t")
+	req.Header.Set("X-CSRFTOKEN", "csrf-s3cr3t")
+	req.Header.Set("Accept", "application/json")
+
+	dump, err := DumpRequestOut(req, true)
+	if err != nil {
+		t.Fatalf("Found errors dumping the request: %s", err)
+	}
+
+	for _, secret := range []string{"root", "calvin", "xsrf-s3cr3t", "st2-s3cr3t", "csrf-s3cr3t"} {
+		if strings.Contains(string(dump), secret) {
+			t.Errorf("Expected secret %q to be masked: found %s", secret, dump)
+		}
+	}
+
+	if !strings.Contains(string(dump), "Accept: application/json") {
+		t.Errorf("Expected answer %q: found %s", "Accept: application/json", dump)
+	}
+}
+
+func TestDumpResponse(t *testing.T) {
+	raw := "HTTP/1.1 200 OK\r\nSet-Cookie: SID=s3cr3t; path=/; secure\r\nContent-Length: 2\r\n\r\nok"
+	resp, err := http.ReadResponse(bufio.NewReader(strings.NewReader(raw)), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dump, err := DumpResponse(resp, true)
+	if err != nil {
+		t.Fatalf("Found errors dumping the response: %s", err)
+	}
+
+	if strings.Contains(string(dump), "s3cr3t") {
+		t.Errorf("Expected the session cookie to be masked: found %s", dump)
+	}
+	expected := "Set-Cookie: SID=[REDACTED]; path=/; secure"
+	if !strings.Contains(string(dump), expected) {
+		t.Errorf("Expected answer %q: found %s", expected, dump)
+	}
+	if resp.Header.Get("Set-Cookie") != "SID=s3cr3t; path=/; secure" {
+		t.Errorf("Expected the response header to be left untouched: found %v", resp.Header.Get("Set-Cookie"))
+	}
+}
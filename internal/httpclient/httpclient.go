@@ -3,13 +3,29 @@ package httpclient
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
-	"net/http/cookiejar"
+	"net/url"
+	"regexp"
 	"strings"
 	"time"
+)
+
+// DefaultTimeout is the overall request timeout applied to clients built by Build,
+// it keeps a wedged BMC from hanging the caller forever.
+const DefaultTimeout = 120 * time.Second
 
-	"golang.org/x/net/publicsuffix"
+const (
+	// DefaultMaxIdleConns is the maximum number of idle connections kept across all BMCs
+	DefaultMaxIdleConns = 100
+	// DefaultMaxIdleConnsPerHost is the maximum number of idle connections kept per BMC,
+	// most BMCs only serve a handful of concurrent connections.
+	DefaultMaxIdleConnsPerHost = 2
+	// DefaultIdleConnTimeout is how long an idle connection is kept before being closed,
+	// it stays below the session timeout of most BMC web servers.
+	DefaultIdleConnTimeout = 30 * time.Second
 )
 
 // SecureTLS disables InsecureSkipVerify and adds a cert pool to an HTTP client's
@@ -18,23 +34,95 @@ func SecureTLS(c *http.Client, rootCAs *x509.CertPool) {
 	if c == nil {
 		return
 	}
-	tp := DefaultTransport()
-	if c.Transport != nil {
-		if assertedTransport, ok := c.Transport.(*http.Transport); ok {
-			tp = assertedTransport
-		}
-		// otherwise, we overwrite the transport
-	}
+	tp := transport(c)
 	tp.TLSClientConfig.InsecureSkipVerify = false
 	tp.TLSClientConfig.RootCAs = rootCAs
 	c.Transport = tp
 }
 
-// DefaultTransport sets an HTTP Transport
+// roundTripperWrapper is implemented by the RoundTrippers of this package wrapping another one
+type roundTripperWrapper interface {
+	next() *http.RoundTripper
+}
+
+// roundTrippers returns the chain of RoundTrippers of an HTTP client, from the outermost
+// wrapper of this package to the transport doing the actual requests.
+func roundTrippers(c *http.Client) []http.RoundTripper {
+	chain := []http.RoundTripper{}
+	for rt := c.Transport; rt != nil; {
+		chain = append(chain, rt)
+		wrapper, ok := rt.(roundTripperWrapper)
+		if !ok {
+			break
+		}
+		rt = *wrapper.next()
+	}
+
+	return chain
+}
+
+// transport returns the *http.Transport of an HTTP client, looking through the wrapping
+// RoundTrippers of this package. A client using any other kind of RoundTripper gets
+// a fresh DefaultTransport.
+func transport(c *http.Client) *http.Transport {
+	slot := &c.Transport
+	for {
+		if wrapper, ok := (*slot).(roundTripperWrapper); ok {
+			slot = wrapper.next()
+			continue
+		}
+		break
+	}
+
+	if assertedTransport, ok := (*slot).(*http.Transport); ok {
+		if assertedTransport.TLSClientConfig == nil {
+			assertedTransport.TLSClientConfig = &tls.Config{}
+		}
+		return assertedTransport
+	}
+
+	// otherwise, we overwrite the transport
+	tp := DefaultTransport()
+	*slot = tp
+	return tp
+}
+
+// failingTransport fails every request with err, it reports the errors of setup options
+// at the first request since the options themselves can't return one.
+// The wrapped transport is kept so the options applied later still configure it.
+type failingTransport struct {
+	Next http.RoundTripper
+	err  error
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, t.err
+}
+
+// next implements roundTripperWrapper
+func (t *failingTransport) next() *http.RoundTripper {
+	return &t.Next
+}
+
+// fail makes every request of an HTTP client fail with err
+func fail(c *http.Client, err error) {
+	c.Transport = &failingTransport{Next: c.Transport, err: err}
+}
+
+// DefaultTransport sets an HTTP Transport, proxies are taken from the environment.
+// Server certificates are verified against the system CAs, WithInsecureTLS must be
+// used explicitly to skip the verification.
 func DefaultTransport() *http.Transport {
 	return &http.Transport{
-		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
-		DisableKeepAlives: true,
+		Proxy:               http.ProxyFromEnvironment,
+		TLSClientConfig:     &tls.Config{},
+		MaxIdleConns:        DefaultMaxIdleConns,
+		MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		IdleConnTimeout:     DefaultIdleConnTimeout,
 		Dial: (&net.Dialer{
 			Timeout:   120 * time.Second,
 			KeepAlive: 120 * time.Second,
@@ -44,6 +132,17 @@ func DefaultTransport() *http.Transport {
 	}
 }
 
+// WithInsecureTLS skips the verification of the server certificates, this is a deliberate
+// choice for BMCs serving self-signed certificates since clients verify them by default.
+func WithInsecureTLS() func(*http.Client) {
+	return func(c *http.Client) {
+		if c == nil {
+			return
+		}
+		transport(c).TLSClientConfig.InsecureSkipVerify = true
+	}
+}
+
 // SecureTLSOption disables InsecureSkipVerify and adds a cert pool to an HTTP client's
 // TLS config
 func SecureTLSOption(rootCAs *x509.CertPool) func(*http.Client) {
@@ -52,17 +151,157 @@ func SecureTLSOption(rootCAs *x509.CertPool) func(*http.Client) {
 	}
 }
 
-// Build builds a client session with our default parameters
-func Build(opts ...func(*http.Client)) (client *http.Client, err error) {
-	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+// CertPoolFromFile builds a cert pool from the PEM encoded certificates of a file
+func CertPoolFromFile(path string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(path)
 	if err != nil {
-		return client, err
+		return nil, fmt.Errorf("unable to read CA certificates: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no valid PEM certificate found in %s", path)
+	}
+
+	return pool, nil
+}
+
+// WithCACertFile enforces trusted TLS connections using the CA certificates of a PEM file.
+// A missing file or a file without any valid certificate makes every request fail with the loading error.
+func WithCACertFile(path string) func(*http.Client) {
+	return func(c *http.Client) {
+		if c == nil {
+			return
+		}
+		pool, err := CertPoolFromFile(path)
+		if err != nil {
+			fail(c, err)
+			return
+		}
+		SecureTLS(c, pool)
+	}
+}
+
+// WithClientCert authenticates an HTTP client with the given PEM encoded certificate and key,
+// for BMCs requiring mutual TLS.
+// A certificate not matching its key makes every request fail with the parsing error.
+func WithClientCert(certPEM, keyPEM []byte) func(*http.Client) {
+	return func(c *http.Client) {
+		if c == nil {
+			return
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			fail(c, fmt.Errorf("invalid client certificate: %w", err))
+			return
+		}
+		tp := transport(c)
+		tp.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+}
+
+// WithTimeout sets the overall timeout of an HTTP client, a zero or negative
+// duration resets it to DefaultTimeout.
+// The timeout composes with per-request contexts: whichever expires first aborts the request.
+func WithTimeout(d time.Duration) func(*http.Client) {
+	return func(c *http.Client) {
+		if c == nil {
+			return
+		}
+		if d <= 0 {
+			d = DefaultTimeout
+		}
+		c.Timeout = d
+	}
+}
+
+// WithProxy routes the requests of an HTTP client through the given HTTP or HTTPS proxy,
+// overriding the proxy settings of the environment.
+// An invalid proxy URL makes every request fail with the parsing error.
+func WithProxy(proxyURL string) func(*http.Client) {
+	return func(c *http.Client) {
+		if c == nil {
+			return
+		}
+		tp := transport(c)
+		u, err := url.Parse(proxyURL)
+		if err == nil && (u.Scheme != "http" && u.Scheme != "https" || u.Host == "") {
+			err = fmt.Errorf("unsupported proxy url %q", proxyURL)
+		}
+		if err != nil {
+			tp.Proxy = func(*http.Request) (*url.URL, error) { return nil, err }
+			return
+		}
+		tp.Proxy = http.ProxyURL(u)
+	}
+}
+
+// WithConnectionPool sets the idle connection pool limits of an HTTP client's transport.
+// Zero values keep the defaults.
+func WithConnectionPool(maxIdleConns, maxIdleConnsPerHost int, idleConnTimeout time.Duration) func(*http.Client) {
+	return func(c *http.Client) {
+		if c == nil {
+			return
+		}
+		tp := transport(c)
+		if maxIdleConns > 0 {
+			tp.MaxIdleConns = maxIdleConns
+		}
+		if maxIdleConnsPerHost > 0 {
+			tp.MaxIdleConnsPerHost = maxIdleConnsPerHost
+		}
+		if idleConnTimeout > 0 {
+			tp.IdleConnTimeout = idleConnTimeout
+		}
+	}
+}
+
+// WithoutKeepAlives disables connection reuse, for BMCs that misbehave on persistent connections
+func WithoutKeepAlives() func(*http.Client) {
+	return func(c *http.Client) {
+		if c == nil {
+			return
+		}
+		transport(c).DisableKeepAlives = true
 	}
+}
 
+// WithHTTP2 enables or disables HTTP/2 on an HTTP client's transport.
+// Transports built by DefaultTransport only speak HTTP/1.1 unless HTTP/2 is enabled here.
+func WithHTTP2(enabled bool) func(*http.Client) {
+	return func(c *http.Client) {
+		if c == nil {
+			return
+		}
+		tp := transport(c)
+		tp.ForceAttemptHTTP2 = enabled
+		if enabled {
+			tp.TLSNextProto = nil
+			tp.TLSClientConfig.NextProtos = nil
+			return
+		}
+		// a non-nil empty map keeps the transport from upgrading to HTTP/2
+		tp.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		tp.TLSClientConfig.NextProtos = []string{"http/1.1"}
+	}
+}
+
+// WithForceHTTP1 makes an HTTP client speak HTTP/1.1 only, for BMC web servers that
+// break when HTTP/2 is negotiated.
+func WithForceHTTP1() func(*http.Client) {
+	return WithHTTP2(false)
+}
+
+// Build builds a client session with our default parameters,
+// each client gets its own cookie jar scoped by BMC host and port.
+func Build(opts ...func(*http.Client)) (client *http.Client, err error) {
 	client = &http.Client{
-		Timeout:   time.Second * 120,
-		Transport: DefaultTransport(),
-		Jar:       jar,
+		Timeout: DefaultTimeout,
+		Transport: &UserAgentTransport{
+			Next:      &ResponseLimitTransport{Next: DefaultTransport(), MaxBytes: DefaultMaxResponseBytes},
+			UserAgent: DefaultUserAgent,
+		},
+		Jar: NewHostJar(),
 	}
 
 	for _, opt := range opts {
@@ -74,7 +313,31 @@ func Build(opts ...func(*http.Client)) (client *http.Client, err error) {
 	return client, err
 }
 
-// StandardizeProcessorName makes the processor name standard across vendors
+var (
+	// processorVendor matches the vendor or family names StandardizeProcessorName knows about
+	processorVendor = regexp.MustCompile(`(?i)\b(intel|xeon|pentium|celeron|atom|amd|epyc|opteron|ryzen|ampere|altra|armv\d+|arm|neoverse|cavium|thunderx\d*|graviton\d*)\b`)
+	// processorFrequency matches a trailing frequency not introduced by an @,
+	// as in "ARMv8 Neoverse-N1 2.5 GHz"
+	processorFrequency = regexp.MustCompile(`(?i)\s+\d+(\.\d+)?\s*[gm]hz$`)
+	// processorCoreCount matches the core count AMD and ARM vendors append to the name,
+	// as in "AMD EPYC 7742 64-Core Processor"
+	processorCoreCount = regexp.MustCompile(`(?i)\s+\d+-core(\s+processor)?$`)
+	// trademarkSymbols are dropped from the names, "(R)" and "(TM)" are kept as they always were
+	trademarkSymbols = strings.NewReplacer("\u00ae", " ", "\u2122", " ")
+)
+
+// StandardizeProcessorName makes the processor name standard across vendors,
+// Intel Xeon, AMD EPYC and ARM names lose their frequency and core count.
+// Names from unknown vendors are returned unchanged.
 func StandardizeProcessorName(name string) string {
-	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(strings.Split(name, "@")[0]), " 0"))
+	if !processorVendor.MatchString(name) {
+		return name
+	}
+
+	standard := trademarkSymbols.Replace(strings.Split(name, "@")[0])
+	standard = strings.Join(strings.Fields(standard), " ")
+	standard = processorFrequency.ReplaceAllString(standard, "")
+	standard = processorCoreCount.ReplaceAllString(standard, "")
+
+	return strings.ToLower(strings.TrimSuffix(standard, " 0"))
 }
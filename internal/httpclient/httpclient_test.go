@@ -1,12 +1,27 @@
 package httpclient
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func CertPoolFromCert(cert *x509.Certificate) *x509.CertPool {
@@ -17,26 +32,37 @@ func CertPoolFromCert(cert *x509.Certificate) *x509.CertPool {
 
 func TestBuildWithOptions(t *testing.T) {
 	cases := []struct {
-		name         string
-		secureClient bool
-		withCertPool func(cert *x509.Certificate) *x509.CertPool
-		wantErr      bool
+		name           string
+		secureClient   bool
+		insecureClient bool
+		withCertPool   func(cert *x509.Certificate) *x509.CertPool
+		wantErr        bool
 	}{
 		{
-			"Default not secure, no error",
+			"Default secure, want an error",
+			false,
 			false,
 			func(_ *x509.Certificate) *x509.CertPool { return nil },
+			true,
+		},
+		{
+			"Explicitly insecure, no error",
+			false,
+			true,
+			func(_ *x509.Certificate) *x509.CertPool { return nil },
 			false,
 		},
 		{
-			"Default secure, want an error",
+			"Secure with system CAs, want an error",
 			true,
+			false,
 			func(_ *x509.Certificate) *x509.CertPool { return nil },
 			true,
 		},
 		{
-			"Default secure, no error",
+			"Secure with cert pool, no error",
 			true,
+			false,
 			CertPoolFromCert,
 			false,
 		},
@@ -51,6 +77,9 @@ func TestBuildWithOptions(t *testing.T) {
 			if tc.secureClient {
 				opts = append(opts, SecureTLSOption(tc.withCertPool(server.Certificate())))
 			}
+			if tc.insecureClient {
+				opts = append(opts, WithInsecureTLS())
+			}
 			client, err := Build(opts...)
 			if err != nil {
 				t.Fatal(client)
@@ -77,3 +106,385 @@ func TestBuildWithOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestWithTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	cases := []struct {
+		name          string
+		clientTimeout time.Duration
+		ctxTimeout    time.Duration
+	}{
+		{
+			"client timeout expires first",
+			50 * time.Millisecond,
+			time.Minute,
+		},
+		{
+			"context deadline expires first",
+			time.Minute,
+			50 * time.Millisecond,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := Build(WithTimeout(tc.clientTimeout), WithInsecureTLS())
+			if err != nil {
+				t.Fatalf("Found errors building the client: %s", err)
+			}
+			if client.Timeout != tc.clientTimeout {
+				t.Errorf("Expected answer %v: found %v", tc.clientTimeout, client.Timeout)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), tc.ctxTimeout)
+			defer cancel()
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+			start := time.Now()
+			_, err = client.Do(req)
+			if err == nil {
+				t.Fatal("Missing expected timeout error")
+			}
+			urlErr, ok := err.(*url.Error)
+			if !ok || !urlErr.Timeout() {
+				t.Fatalf("Expected a timeout error: got %T: '%s'", err, err)
+			}
+			if elapsed := time.Since(start); elapsed > 10*time.Second {
+				t.Errorf("Expected the request to be aborted early: took %v", elapsed)
+			}
+		})
+	}
+}
+
+func TestWithTimeoutDefault(t *testing.T) {
+	client, err := Build(WithTimeout(0))
+	if err != nil {
+		t.Fatalf("Found errors building the client: %s", err)
+	}
+	if client.Timeout != DefaultTimeout {
+		t.Errorf("Expected answer %v: found %v", DefaultTimeout, client.Timeout)
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// a proxied plain HTTP request carries the absolute URL of the target
+		proxied = r.URL.String()
+		fmt.Fprintln(w, `{"hello": "proxy"}`)
+	}))
+	defer proxy.Close()
+
+	client, err := Build(WithProxy(proxy.URL))
+	if err != nil {
+		t.Fatalf("Found errors building the client: %s", err)
+	}
+
+	resp, err := client.Get("http://bmc.example.com/redfish/v1/")
+	if err != nil {
+		t.Fatalf("Found errors calling through the proxy: %s", err)
+	}
+	resp.Body.Close()
+
+	expected := "http://bmc.example.com/redfish/v1/"
+	if proxied != expected {
+		t.Errorf("Expected answer %v: found %v", expected, proxied)
+	}
+}
+
+func TestWithProxyInvalidURL(t *testing.T) {
+	client, err := Build(WithProxy("socks5://proxy.example.com:1080"))
+	if err != nil {
+		t.Fatalf("Found errors building the client: %s", err)
+	}
+
+	_, err = client.Get("http://bmc.example.com/")
+	if err == nil {
+		t.Fatal("Missing expected error")
+	}
+}
+
+// countingServer is a TLS server counting the connections opened to it
+func countingServer() (*httptest.Server, *int32) {
+	var conns int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"hello": "client"}`)
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	server.StartTLS()
+	return server, &conns
+}
+
+func TestConnectionReuse(t *testing.T) {
+	cases := []struct {
+		name      string
+		opts      []func(*http.Client)
+		wantConns int32
+	}{
+		{"keep alives reuse the connection", nil, 1},
+		{"without keep alives", []func(*http.Client){WithoutKeepAlives()}, 5},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server, conns := countingServer()
+			defer server.Close()
+
+			client, err := Build(append(tc.opts, WithInsecureTLS())...)
+			if err != nil {
+				t.Fatalf("Found errors building the client: %s", err)
+			}
+
+			for i := 0; i < 5; i++ {
+				resp, err := client.Get(server.URL)
+				if err != nil {
+					t.Fatalf("Found errors calling the server: %s", err)
+				}
+				_, _ = io.Copy(ioutil.Discard, resp.Body)
+				resp.Body.Close()
+			}
+
+			if *conns != tc.wantConns {
+				t.Errorf("Expected answer %v: found %v", tc.wantConns, *conns)
+			}
+		})
+	}
+}
+
+func BenchmarkSequentialRequests(b *testing.B) {
+	server, _ := countingServer()
+	defer server.Close()
+
+	client, err := Build(WithInsecureTLS())
+	if err != nil {
+		b.Fatalf("Found errors building the client: %s", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			b.Fatalf("Found errors calling the server: %s", err)
+		}
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}
+}
+
+func TestWithCACertFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"hello": "client"}`)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := ioutil.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	garbageFile := filepath.Join(dir, "garbage.pem")
+	if err := ioutil.WriteFile(garbageFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{"valid CA file", caFile, ""},
+		{"missing file", filepath.Join(dir, "missing.pem"), "unable to read CA certificates"},
+		{"no valid certificate", garbageFile, "no valid PEM certificate found"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := Build(WithCACertFile(tc.path))
+			if err != nil {
+				t.Fatalf("Found errors building the client: %s", err)
+			}
+
+			resp, err := client.Get(server.URL)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Found errors calling the server: %s", err)
+				}
+				resp.Body.Close()
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected answer %v: found %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+// selfSignedPEM returns a PEM encoded self-signed client certificate and its key
+func selfSignedPEM(t *testing.T) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "bmclib"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestWithClientCert(t *testing.T) {
+	certPEM, keyPEM := selfSignedPEM(t)
+	_, otherKeyPEM := selfSignedPEM(t)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(certPEM)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"hello": "client"}`)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	cases := []struct {
+		name    string
+		opts    []func(*http.Client)
+		wantErr string
+	}{
+		{"matching certificate", []func(*http.Client){WithInsecureTLS(), WithClientCert(certPEM, keyPEM)}, ""},
+		{"no certificate", []func(*http.Client){WithInsecureTLS()}, "remote error: tls"},
+		{"certificate not matching the key", []func(*http.Client){WithInsecureTLS(), WithClientCert(certPEM, otherKeyPEM)}, "invalid client certificate"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := Build(tc.opts...)
+			if err != nil {
+				t.Fatalf("Found errors building the client: %s", err)
+			}
+
+			resp, err := client.Get(server.URL)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Found errors calling the server: %s", err)
+				}
+				resp.Body.Close()
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected answer %v: found %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestStandardizeProcessorName(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"intel xeon", "Intel(R) Xeon(R) CPU E5-2630 v3 @ 2.40GHz", "intel(r) xeon(r) cpu e5-2630 v3"},
+		{"intel xeon stepping 0", "Intel(R) Xeon(R) CPU E5-2630 0 @ 2.20GHz", "intel(r) xeon(r) cpu e5-2630"},
+		{"intel xeon scalable", "Intel(R) Xeon(R) Silver 4110 CPU @ 2.10GHz", "intel(r) xeon(r) silver 4110 cpu"},
+		{"amd epyc", "AMD EPYC 7742 64-Core Processor", "amd epyc 7742"},
+		{"amd epyc single socket", "AMD EPYC 7502P 32-Core Processor   ", "amd epyc 7502p"},
+		{"amd epyc with frequency", "AMD EPYC 7313 16-Core Processor @ 3.0GHz", "amd epyc 7313"},
+		{"ampere altra", "Ampere(R) Altra(R) Processor Q80-30 CPU @ 3.0GHz", "ampere(r) altra(r) processor q80-30 cpu"},
+		{"ampere altra max", "Ampere(R) Altra(R) Max Processor M128-30 128-Core", "ampere(r) altra(r) max processor m128-30"},
+		{"arm frequency without @", "ARMv8 Neoverse-N1 2.5 GHz", "armv8 neoverse-n1"},
+		{"cavium thunderx2", "Cavium ThunderX2(R) CPU CN9980 v2.2 @ 2.20GHz", "cavium thunderx2(r) cpu cn9980 v2.2"},
+		{"empty", "", ""},
+		{"only spaces", "   ", "   "},
+		{"trademark symbols", "Intel\u00ae Xeon\u00ae Gold 6248 CPU @ 2.50GHz", "intel xeon gold 6248 cpu"},
+		{"trademark sign", "AMD EPYC\u2122 7742 64-Core Processor", "amd epyc 7742"},
+		{"repeated whitespace", "Intel(R)  Xeon(R)   CPU E5-2690 v4 @ 2.60GHz", "intel(r) xeon(r) cpu e5-2690 v4"},
+		{"tabs and newlines", "\tAMD EPYC 7302\n16-Core Processor\n", "amd epyc 7302"},
+		{"truncated xeon", "Intel(R) Xeon(TM)", "intel(r) xeon(tm)"},
+		{"unknown vendor", "Not Specified", "Not Specified"},
+		{"unknown vendor with an @", "To Be Filled By O.E.M. @ N/A", "To Be Filled By O.E.M. @ N/A"},
+		{"only a frequency", "@ 2.20GHz", "@ 2.20GHz"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := StandardizeProcessorName(tc.input)
+			if result != tc.expected {
+				t.Errorf("Expected answer %v: found %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestWithHTTP2(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"hello": "client"}`)
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	cases := []struct {
+		name       string
+		opts       []func(*http.Client)
+		forceHTTP2 bool
+		wantProto  int
+	}{
+		{"default", nil, false, 1},
+		{"HTTP/2 enabled", []func(*http.Client){WithHTTP2(true)}, true, 2},
+		{"HTTP/1.1 forced", []func(*http.Client){WithHTTP2(true), WithForceHTTP1()}, false, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := Build(append(tc.opts, WithInsecureTLS())...)
+			if err != nil {
+				t.Fatalf("Found errors building the client: %s", err)
+			}
+
+			tp := transport(client)
+			if tp.ForceAttemptHTTP2 != tc.forceHTTP2 {
+				t.Errorf("Expected answer %v: found %v", tc.forceHTTP2, tp.ForceAttemptHTTP2)
+			}
+
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatalf("Found errors calling the server: %s", err)
+			}
+			resp.Body.Close()
+
+			if resp.ProtoMajor != tc.wantProto {
+				t.Errorf("Expected answer %v: found %v", tc.wantProto, resp.Proto)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// DefaultMaxResponseBytes bounds the response bodies read by the clients built by Build,
+// it fits screenshots and large inventories while keeping a misbehaving BMC from exhausting the memory.
+const DefaultMaxResponseBytes int64 = 64 << 20
+
+// ResponseLimitTransport is an http.RoundTripper bounding the size of the response bodies,
+// reading past MaxBytes fails with an *errors.ResponseTooLargeError.
+type ResponseLimitTransport struct {
+	// Next is the wrapped RoundTripper, http.DefaultTransport when nil
+	Next http.RoundTripper
+	// MaxBytes is the largest body allowed, zero or less disables the limit
+	MaxBytes int64
+}
+
+// RoundTrip implements http.RoundTripper
+func (lt *ResponseLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := lt.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil || lt.MaxBytes <= 0 {
+		return resp, err
+	}
+
+	tooLarge := &errors.ResponseTooLargeError{URL: req.URL.String(), Limit: lt.MaxBytes}
+	if resp.ContentLength > lt.MaxBytes {
+		resp.Body.Close()
+		return nil, tooLarge
+	}
+
+	resp.Body = &limitedBody{
+		reader: io.LimitReader(resp.Body, lt.MaxBytes+1),
+		closer: resp.Body,
+		limit:  lt.MaxBytes,
+		err:    tooLarge,
+	}
+
+	return resp, nil
+}
+
+// next implements roundTripperWrapper
+func (lt *ResponseLimitTransport) next() *http.RoundTripper {
+	return &lt.Next
+}
+
+// limitedBody fails the reads going past limit, the underlying reader is limited to
+// one byte more than allowed so an oversized body is told apart from one of exactly limit bytes.
+type limitedBody struct {
+	reader io.Reader
+	closer io.Closer
+	limit  int64
+	read   int64
+	err    error
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.reader.Read(p)
+	b.read += int64(n)
+	if b.read > b.limit {
+		return n - int(b.read-b.limit), b.err
+	}
+
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.closer.Close()
+}
+
+// WithMaxResponseBytes bounds the size of the response bodies read by an HTTP client,
+// replacing DefaultMaxResponseBytes. Zero or less disables the limit.
+func WithMaxResponseBytes(n int64) func(*http.Client) {
+	return func(c *http.Client) {
+		if c == nil {
+			return
+		}
+
+		for _, rt := range roundTrippers(c) {
+			if lt, ok := rt.(*ResponseLimitTransport); ok {
+				lt.MaxBytes = n
+				return
+			}
+		}
+
+		if c.Transport == nil {
+			c.Transport = DefaultTransport()
+		}
+		c.Transport = &ResponseLimitTransport{Next: c.Transport, MaxBytes: n}
+	}
+}
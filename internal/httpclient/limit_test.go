@@ -0,0 +1,92 @@
+package httpclient
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+)
+
+func TestWithMaxResponseBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := strings.Repeat("a", 64)
+		if r.URL.Path == "/chunked" {
+			// flushing before writing the body keeps the Content-Length out of the response
+			w.(http.Flusher).Flush()
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		name    string
+		limit   int64
+		path    string
+		wantErr bool
+	}{
+		{"body within the limit", 64, "/", false},
+		{"oversized body with a content length", 32, "/", true},
+		{"oversized chunked body", 32, "/chunked", true},
+		{"chunked body within the limit", 64, "/chunked", false},
+		{"limit disabled", 0, "/chunked", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := Build(WithMaxResponseBytes(tc.limit))
+			if err != nil {
+				t.Fatalf("Found errors building the client: %s", err)
+			}
+
+			var body []byte
+			resp, err := client.Get(server.URL + tc.path)
+			if err == nil {
+				body, err = ioutil.ReadAll(resp.Body)
+				resp.Body.Close()
+			}
+
+			if !tc.wantErr {
+				if err != nil {
+					t.Fatalf("Found errors reading the response: %s", err)
+				}
+				if len(body) != 64 {
+					t.Errorf("Expected answer %v: found %v", 64, len(body))
+				}
+				return
+			}
+
+			var tooLarge *bmclibErrs.ResponseTooLargeError
+			if !errors.As(err, &tooLarge) || tooLarge.Limit != tc.limit {
+				t.Fatalf("Expected answer %v: found %v", bmclibErrs.ErrResponseTooLarge, err)
+			}
+			if !errors.Is(err, bmclibErrs.ErrResponseTooLarge) {
+				t.Errorf("Expected answer %v: found %v", bmclibErrs.ErrResponseTooLarge, err)
+			}
+			if int64(len(body)) > tc.limit {
+				t.Errorf("Expected at most %v bytes: found %v", tc.limit, len(body))
+			}
+		})
+	}
+}
+
+func TestBuildBoundsResponses(t *testing.T) {
+	client, err := Build()
+	if err != nil {
+		t.Fatalf("Found errors building the client: %s", err)
+	}
+
+	for _, rt := range roundTrippers(client) {
+		if lt, ok := rt.(*ResponseLimitTransport); ok {
+			if lt.MaxBytes != DefaultMaxResponseBytes {
+				t.Errorf("Expected answer %v: found %v", DefaultMaxResponseBytes, lt.MaxBytes)
+			}
+			return
+		}
+	}
+
+	t.Error("Expected the client to bound the response bodies")
+}
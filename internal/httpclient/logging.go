@@ -0,0 +1,63 @@
+package httpclient
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// LoggingTransport is an http.RoundTripper logging a one line summary of each round-trip at V(1),
+// giving the timing of the requests made to a slow BMC without the full dumps logged at V(2).
+type LoggingTransport struct {
+	// Next is the wrapped RoundTripper, http.DefaultTransport when nil
+	Next http.RoundTripper
+	Log  logr.Logger
+}
+
+// RoundTrip implements http.RoundTripper
+func (lt *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := lt.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	start := time.Now()
+	resp, err := next.RoundTrip(req)
+
+	method := req.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	var status int
+	if resp != nil {
+		status = resp.StatusCode
+	}
+
+	keysAndValues := []interface{}{"method", method, "host", req.URL.Host, "path", req.URL.Path, "status", status, "elapsed", time.Since(start)}
+	if err != nil {
+		keysAndValues = append(keysAndValues, "error", err.Error())
+	}
+	lt.Log.V(1).Info("bmc request", keysAndValues...)
+
+	return resp, err
+}
+
+// next implements roundTripperWrapper
+func (lt *LoggingTransport) next() *http.RoundTripper {
+	return &lt.Next
+}
+
+// WithRequestLogging wraps the transport of an HTTP client in a LoggingTransport logging
+// the method, host, path, status and duration of each request to log.
+func WithRequestLogging(log logr.Logger) func(*http.Client) {
+	return func(c *http.Client) {
+		if c == nil {
+			return
+		}
+		if c.Transport == nil {
+			c.Transport = DefaultTransport()
+		}
+		c.Transport = &LoggingTransport{Next: c.Transport, Log: log}
+	}
+}
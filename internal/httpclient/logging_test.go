@@ -0,0 +1,103 @@
+package httpclient
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// captureSink is a logr.LogSink keeping the key/value pairs of the Info entries
+type captureSink struct {
+	entries []map[string]interface{}
+	levels  []int
+}
+
+func (s *captureSink) Init(logr.RuntimeInfo)  {}
+func (s *captureSink) Enabled(level int) bool { return true }
+func (s *captureSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	entry := map[string]interface{}{"msg": msg}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		entry[keysAndValues[i].(string)] = keysAndValues[i+1]
+	}
+	s.entries = append(s.entries, entry)
+	s.levels = append(s.levels, level)
+}
+func (s *captureSink) Error(err error, msg string, keysAndValues ...interface{}) {}
+func (s *captureSink) WithValues(keysAndValues ...interface{}) logr.LogSink      { return s }
+func (s *captureSink) WithName(name string) logr.LogSink                         { return s }
+
+func TestWithRequestLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "hello")
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+
+	sink := &captureSink{}
+	client, err := Build(WithRequestLogging(logr.New(sink)))
+	if err != nil {
+		t.Fatalf("Found errors building the client: %s", err)
+	}
+
+	for _, path := range []string{"/redfish/v1", "/missing"} {
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("Found errors calling the server: %s", err)
+		}
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	// nothing listens on port 1
+	_, _ = client.Get("http://127.0.0.1:1/cgi/login.cgi")
+
+	if len(sink.entries) != 3 {
+		t.Fatalf("Expected answer %v: found %v", 3, len(sink.entries))
+	}
+
+	expected := []struct {
+		host    string
+		path    string
+		status  int
+		wantErr bool
+	}{
+		{serverURL.Host, "/redfish/v1", http.StatusOK, false},
+		{serverURL.Host, "/missing", http.StatusNotFound, false},
+		{"127.0.0.1:1", "/cgi/login.cgi", 0, true},
+	}
+	for i, e := range expected {
+		entry := sink.entries[i]
+		if sink.levels[i] != 1 {
+			t.Errorf("Expected answer %v: found %v", 1, sink.levels[i])
+		}
+		if entry["method"] != http.MethodGet {
+			t.Errorf("Expected answer %v: found %v", http.MethodGet, entry["method"])
+		}
+		if entry["host"] != e.host {
+			t.Errorf("Expected answer %v: found %v", e.host, entry["host"])
+		}
+		if entry["path"] != e.path {
+			t.Errorf("Expected answer %v: found %v", e.path, entry["path"])
+		}
+		if entry["status"] != e.status {
+			t.Errorf("Expected answer %v: found %v", e.status, entry["status"])
+		}
+		if _, ok := entry["error"]; ok != e.wantErr {
+			t.Errorf("Expected answer %v: found %v", e.wantErr, entry["error"])
+		}
+		if elapsed, ok := entry["elapsed"].(time.Duration); !ok || elapsed <= 0 {
+			t.Errorf("Expected a positive duration: found %v", entry["elapsed"])
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package httpclient
+
+import (
+	"net/http"
+	"time"
+)
+
+// RequestInfo describes a round-trip made to a BMC, it is handed to the observers
+// registered with WithObserver.
+type RequestInfo struct {
+	Method     string
+	Host       string
+	StatusCode int // zero when the request failed before getting a response
+	// ContentLength is the length of the response body, -1 when unknown
+	ContentLength int64
+	Duration      time.Duration
+	Err           error
+}
+
+// ObserverTransport is an http.RoundTripper calling Observer after each round-trip,
+// so metrics or logs can be collected without changing the providers.
+type ObserverTransport struct {
+	// Next is the wrapped RoundTripper, http.DefaultTransport when nil
+	Next     http.RoundTripper
+	Observer func(RequestInfo)
+}
+
+// RoundTrip implements http.RoundTripper
+func (ot *ObserverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := ot.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	start := time.Now()
+	resp, err := next.RoundTrip(req)
+	if ot.Observer == nil {
+		return resp, err
+	}
+
+	info := RequestInfo{
+		Method:        req.Method,
+		Host:          req.URL.Host,
+		ContentLength: -1,
+		Duration:      time.Since(start),
+		Err:           err,
+	}
+	if info.Method == "" {
+		info.Method = http.MethodGet
+	}
+	if resp != nil {
+		info.StatusCode = resp.StatusCode
+		info.ContentLength = resp.ContentLength
+	}
+	ot.Observer(info)
+
+	return resp, err
+}
+
+// next implements roundTripperWrapper
+func (ot *ObserverTransport) next() *http.RoundTripper {
+	return &ot.Next
+}
+
+// WithObserver wraps the transport of an HTTP client in an ObserverTransport calling
+// observer after each round-trip.
+func WithObserver(observer func(RequestInfo)) func(*http.Client) {
+	return func(c *http.Client) {
+		if c == nil || observer == nil {
+			return
+		}
+		if c.Transport == nil {
+			c.Transport = DefaultTransport()
+		}
+		c.Transport = &ObserverTransport{Next: c.Transport, Observer: observer}
+	}
+}
@@ -0,0 +1,77 @@
+package httpclient
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestWithObserver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "hello")
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+
+	var infos []RequestInfo
+	client, err := Build(WithObserver(func(info RequestInfo) { infos = append(infos, info) }))
+	if err != nil {
+		t.Fatalf("Found errors building the client: %s", err)
+	}
+
+	for _, path := range []string{"/", "/missing"} {
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("Found errors calling the server: %s", err)
+		}
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	// nothing listens on port 1
+	_, _ = client.Get("http://127.0.0.1:1/")
+
+	if len(infos) != 3 {
+		t.Fatalf("Expected answer %v: found %v", 3, len(infos))
+	}
+
+	expected := []struct {
+		host          string
+		statusCode    int
+		contentLength int64
+		wantErr       bool
+	}{
+		{serverURL.Host, http.StatusOK, 5, false},
+		{serverURL.Host, http.StatusNotFound, 0, false},
+		{"127.0.0.1:1", 0, -1, true},
+	}
+	for i, e := range expected {
+		info := infos[i]
+		if info.Method != http.MethodGet {
+			t.Errorf("Expected answer %v: found %v", http.MethodGet, info.Method)
+		}
+		if info.Host != e.host {
+			t.Errorf("Expected answer %v: found %v", e.host, info.Host)
+		}
+		if info.StatusCode != e.statusCode {
+			t.Errorf("Expected answer %v: found %v", e.statusCode, info.StatusCode)
+		}
+		if info.ContentLength != e.contentLength {
+			t.Errorf("Expected answer %v: found %v", e.contentLength, info.ContentLength)
+		}
+		if (info.Err != nil) != e.wantErr {
+			t.Errorf("Expected answer %v: found %v", e.wantErr, info.Err)
+		}
+		if info.Duration <= 0 {
+			t.Errorf("Expected a positive duration: found %v", info.Duration)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package httpclient
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimitTransport is an http.RoundTripper pacing requests with a token bucket,
+// keeping fleet scans below the session and throughput limits of the BMCs.
+// Requests block until a token is available or their context is done.
+type RateLimitTransport struct {
+	// Next is the wrapped RoundTripper, http.DefaultTransport when nil
+	Next http.RoundTripper
+	// RequestsPerSecond is the rate at which the bucket refills, zero or less disables the limit
+	RequestsPerSecond float64
+	// Burst is the size of the bucket, at least one request
+	Burst int
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// RoundTrip implements http.RoundTripper
+func (rt *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := rt.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	if err := rt.wait(req); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	return next.RoundTrip(req)
+}
+
+// wait takes a token from the bucket, blocking until one is available
+func (rt *RateLimitTransport) wait(req *http.Request) error {
+	if rt.RequestsPerSecond <= 0 {
+		return nil
+	}
+
+	delay := rt.reserve(time.Now())
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		// hand the reserved token back for the next requests
+		rt.mu.Lock()
+		rt.tokens++
+		rt.mu.Unlock()
+		return req.Context().Err()
+	}
+}
+
+// reserve takes a token from the bucket and returns how long to wait before using it,
+// the bucket goes negative while tokens are reserved ahead of time.
+func (rt *RateLimitTransport) reserve(now time.Time) time.Duration {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	burst := float64(rt.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+
+	if rt.last.IsZero() {
+		rt.tokens = burst
+	} else {
+		rt.tokens += now.Sub(rt.last).Seconds() * rt.RequestsPerSecond
+		if rt.tokens > burst {
+			rt.tokens = burst
+		}
+	}
+	rt.last = now
+
+	rt.tokens--
+	if rt.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-rt.tokens / rt.RequestsPerSecond * float64(time.Second))
+}
+
+// next implements roundTripperWrapper
+func (rt *RateLimitTransport) next() *http.RoundTripper {
+	return &rt.Next
+}
+
+// WithRateLimit wraps the transport of an HTTP client in a RateLimitTransport allowing
+// requestsPerSecond requests per second with bursts of up to burst requests.
+func WithRateLimit(requestsPerSecond float64, burst int) func(*http.Client) {
+	return func(c *http.Client) {
+		if c == nil {
+			return
+		}
+		if c.Transport == nil {
+			c.Transport = DefaultTransport()
+		}
+		c.Transport = &RateLimitTransport{Next: c.Transport, RequestsPerSecond: requestsPerSecond, Burst: burst}
+	}
+}
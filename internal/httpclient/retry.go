@@ -0,0 +1,132 @@
+package httpclient
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+const (
+	// DefaultRetryAttempts is the number of attempts made by a RetryTransport without explicit Attempts
+	DefaultRetryAttempts = 3
+	// DefaultRetryBackoff is the delay before the first retry of a RetryTransport without explicit Backoff
+	DefaultRetryBackoff = time.Second
+)
+
+// RetryTransport is an http.RoundTripper retrying requests that failed with a transient error,
+// as told by errors.IsRetryable, or that were answered with a 429 or 5xx status.
+// Only idempotent requests are retried unless RetryNonIdempotent is set, and requests
+// with a body are only retried when the body can be rewound through GetBody.
+type RetryTransport struct {
+	// Next is the wrapped RoundTripper, http.DefaultTransport when nil
+	Next http.RoundTripper
+	// Attempts is the total number of attempts, including the first one
+	Attempts int
+	// Backoff is the delay before the first retry, it doubles on every further retry.
+	// A Retry-After sent by the BMC takes precedence, up to errors.MaxRetryDelay, and
+	// the request fails without waiting when the wait would outlast its context.
+	Backoff time.Duration
+	// RetryNonIdempotent allows retrying POST, PATCH and other non-idempotent requests
+	RetryNonIdempotent bool
+}
+
+// RoundTrip implements http.RoundTripper
+func (rt *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := rt.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	attempts := rt.Attempts
+	if attempts <= 0 {
+		attempts = DefaultRetryAttempts
+	}
+	if !rt.retryable(req) {
+		attempts = 1
+	}
+
+	backoff := rt.Backoff
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := next.RoundTrip(req)
+		if err == nil && resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < http.StatusInternalServerError {
+			return resp, nil
+		}
+
+		failure := err
+		if failure == nil {
+			failure = errors.NewHTTPErrorFromResponse(resp, nil)
+		}
+		if attempt >= attempts || !errors.IsRetryable(failure) {
+			return resp, err
+		}
+
+		// a Retry-After past the deadline of the request would only delay its failure
+		delay, ok := errors.RetryDelayWithin(req.Context(), failure, backoff)
+		if !ok {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// next implements roundTripperWrapper
+func (rt *RetryTransport) next() *http.RoundTripper {
+	return &rt.Next
+}
+
+// retryable returns whether the request can safely be sent more than once
+func (rt *RetryTransport) retryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodTrace:
+		return true
+	}
+
+	return rt.RetryNonIdempotent
+}
+
+// WithRetry wraps the transport of an HTTP client in a RetryTransport making the given
+// number of attempts for idempotent requests.
+func WithRetry(attempts int, backoff time.Duration) func(*http.Client) {
+	return func(c *http.Client) {
+		if c == nil {
+			return
+		}
+		if c.Transport == nil {
+			c.Transport = DefaultTransport()
+		}
+		c.Transport = &RetryTransport{Next: c.Transport, Attempts: attempts, Backoff: backoff}
+	}
+}
@@ -0,0 +1,122 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer fails the first failures requests with a 503
+func flakyServer(failures int32) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	return server, &calls
+}
+
+func TestRetryTransport(t *testing.T) {
+	cases := []struct {
+		name               string
+		method             string
+		failures           int32
+		retryNonIdempotent bool
+		wantStatus         int
+		wantCalls          int32
+	}{
+		{"GET recovers", http.MethodGet, 2, false, http.StatusOK, 3},
+		{"GET gives up", http.MethodGet, 5, false, http.StatusServiceUnavailable, 3},
+		{"POST is not retried", http.MethodPost, 1, false, http.StatusServiceUnavailable, 1},
+		{"POST retried on opt in", http.MethodPost, 1, true, http.StatusOK, 2},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server, calls := flakyServer(tc.failures)
+			defer server.Close()
+
+			client := &http.Client{Transport: &RetryTransport{
+				Next:               http.DefaultTransport,
+				Attempts:           3,
+				Backoff:            time.Millisecond,
+				RetryNonIdempotent: tc.retryNonIdempotent,
+			}}
+
+			req, _ := http.NewRequest(tc.method, server.URL, strings.NewReader("payload"))
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Found errors calling the server: %s", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Errorf("Expected answer %v: found %v", tc.wantStatus, resp.StatusCode)
+			}
+			if *calls != tc.wantCalls {
+				t.Errorf("Expected answer %v: found %v", tc.wantCalls, *calls)
+			}
+		})
+	}
+}
+
+func TestRetryTransportRetryAfterPastDeadline(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &RetryTransport{Next: http.DefaultTransport, Attempts: 3, Backoff: time.Millisecond}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Found errors calling the server: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected answer %v: found %v", http.StatusServiceUnavailable, resp.StatusCode)
+	}
+	if calls != 1 {
+		t.Errorf("Expected answer %v: found %v", 1, calls)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the Retry-After past the deadline not to be waited: found %v", elapsed)
+	}
+}
+
+func TestWithRetryKeepsTransportOptions(t *testing.T) {
+	client, err := Build(WithRetry(2, time.Millisecond), WithProxy("http://proxy.example.com:3128"))
+	if err != nil {
+		t.Fatalf("Found errors building the client: %s", err)
+	}
+
+	if _, ok := client.Transport.(*RetryTransport); !ok {
+		t.Fatalf("Expected answer *RetryTransport: found %T", client.Transport)
+	}
+	tp := transport(client)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://bmc.example.com/", nil)
+	proxyURL, err := tp.Proxy(req)
+	if err != nil {
+		t.Fatalf("Found errors resolving the proxy: %s", err)
+	}
+	if proxyURL.Host != "proxy.example.com:3128" {
+		t.Errorf("Expected answer %v: found %v", "proxy.example.com:3128", proxyURL.Host)
+	}
+}
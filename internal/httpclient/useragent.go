@@ -0,0 +1,90 @@
+package httpclient
+
+import (
+	"net/http"
+	"runtime/debug"
+	"strings"
+)
+
+// modulePath is the path of the bmclib module, used to look its version up in the build info
+const modulePath = "github.com/bmc-toolbox/bmclib"
+
+// DefaultUserAgent is sent by the clients built by Build, it lets BMC audit logs tell
+// bmclib requests apart from other tooling.
+var DefaultUserAgent = "bmclib/" + moduleVersion()
+
+// moduleVersion returns the version of bmclib the binary was built with
+func moduleVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "devel"
+	}
+
+	version := info.Main.Version
+	if info.Main.Path != modulePath {
+		version = ""
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath {
+				version = dep.Version
+				break
+			}
+		}
+	}
+
+	version = strings.Trim(version, "()")
+	if version == "" {
+		return "devel"
+	}
+
+	return version
+}
+
+// UserAgentTransport is an http.RoundTripper setting the User-Agent header of the requests
+// that don't already carry one.
+type UserAgentTransport struct {
+	// Next is the wrapped RoundTripper, http.DefaultTransport when nil
+	Next      http.RoundTripper
+	UserAgent string
+}
+
+// RoundTrip implements http.RoundTripper
+func (ut *UserAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := ut.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	if ut.UserAgent != "" && req.Header.Get("User-Agent") == "" {
+		// a RoundTripper must not modify the caller's request
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", ut.UserAgent)
+	}
+
+	return next.RoundTrip(req)
+}
+
+// next implements roundTripperWrapper
+func (ut *UserAgentTransport) next() *http.RoundTripper {
+	return &ut.Next
+}
+
+// WithUserAgent sets the User-Agent header sent by an HTTP client, replacing DefaultUserAgent.
+func WithUserAgent(ua string) func(*http.Client) {
+	return func(c *http.Client) {
+		if c == nil {
+			return
+		}
+
+		for _, rt := range roundTrippers(c) {
+			if ut, ok := rt.(*UserAgentTransport); ok {
+				ut.UserAgent = ua
+				return
+			}
+		}
+
+		if c.Transport == nil {
+			c.Transport = DefaultTransport()
+		}
+		c.Transport = &UserAgentTransport{Next: c.Transport, UserAgent: ua}
+	}
+}
@@ -0,0 +1,55 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithUserAgent(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		name      string
+		opts      []func(*http.Client)
+		reqHeader string
+		want      string
+	}{
+		{"default user agent", nil, "", DefaultUserAgent},
+		{"custom user agent", []func(*http.Client){WithUserAgent("fleet-scanner/1.0")}, "", "fleet-scanner/1.0"},
+		{"custom user agent on a wrapped transport", []func(*http.Client){WithRetry(2, 0), WithUserAgent("fleet-scanner/1.0")}, "", "fleet-scanner/1.0"},
+		{"request header is kept", []func(*http.Client){WithUserAgent("fleet-scanner/1.0")}, "curl/7.79.1", "curl/7.79.1"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := Build(tc.opts...)
+			if err != nil {
+				t.Fatalf("Found errors building the client: %s", err)
+			}
+
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			if tc.reqHeader != "" {
+				req.Header.Set("User-Agent", tc.reqHeader)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Found errors calling the server: %s", err)
+			}
+			resp.Body.Close()
+
+			if got != tc.want {
+				t.Errorf("Expected answer %v: found %v", tc.want, got)
+			}
+		})
+	}
+
+	if !strings.HasPrefix(DefaultUserAgent, "bmclib/") {
+		t.Errorf("Expected answer %v: found %v", "bmclib/<version>", DefaultUserAgent)
+	}
+}
@@ -0,0 +1,82 @@
+package ipmi
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ChassisStatus is the chassis status reported by the BMC, as listed by ipmitool chassis status
+type ChassisStatus struct {
+	PowerOn            bool
+	PowerOverload      bool
+	PowerInterlock     bool
+	MainPowerFault     bool
+	PowerControlFault  bool
+	PowerRestorePolicy string
+	LastPowerEvent     string
+	ChassisIntrusion   bool
+	FrontPanelLockout  bool
+	DriveFault         bool
+	CoolingFault       bool
+}
+
+// ChassisStatus returns the chassis status of the machine
+func (i *Ipmi) ChassisStatus(ctx context.Context) (status *ChassisStatus, err error) {
+	output, err := i.run(ctx, []string{"chassis", "status"})
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", err, output)
+	}
+
+	return parseChassisStatus(output)
+}
+
+// parseChassisStatus parses the "Key : value" lines of ipmitool chassis status
+func parseChassisStatus(output string) (*ChassisStatus, error) {
+	status := &ChassisStatus{}
+	found := false
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		kv := strings.SplitN(scanner.Text(), ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		// the flags are reported as true/false or active/inactive
+		flag := value == "true" || value == "active"
+
+		switch key {
+		case "System Power":
+			status.PowerOn = value == "on"
+			found = true
+		case "Power Overload":
+			status.PowerOverload = flag
+		case "Power Interlock":
+			status.PowerInterlock = flag
+		case "Main Power Fault":
+			status.MainPowerFault = flag
+		case "Power Control Fault":
+			status.PowerControlFault = flag
+		case "Power Restore Policy":
+			status.PowerRestorePolicy = value
+		case "Last Power Event":
+			status.LastPowerEvent = value
+		case "Chassis Intrusion":
+			status.ChassisIntrusion = flag
+		case "Front-Panel Lockout":
+			status.FrontPanelLockout = flag
+		case "Drive Fault":
+			status.DriveFault = flag
+		case "Cooling/Fan Fault":
+			status.CoolingFault = flag
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("unexpected chassis status output: %v", output)
+	}
+
+	return status, nil
+}
@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"strings"
 
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
 	"github.com/pkg/errors"
 )
 
@@ -29,7 +30,7 @@ func New(username string, password string, host string) (ipmi *Ipmi, err error)
 
 	ipmi.ipmitool, err = exec.LookPath("ipmitool")
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(bmclibErrs.ErrIpmitoolNotFound, err.Error())
 	}
 
 	return ipmi, err
@@ -273,6 +274,19 @@ func (i *Ipmi) PowerState(ctx context.Context) (state string, err error) {
 	return i.run(ctx, []string{"chassis", "power", "status"})
 }
 
+// ClearSystemEventLog clears the system event log (SEL) of the BMC
+func (i *Ipmi) ClearSystemEventLog(ctx context.Context) (status bool, err error) {
+	output, err := i.run(ctx, []string{"sel", "clear"})
+	if err != nil {
+		return false, fmt.Errorf("%v: %v", err, output)
+	}
+
+	if !strings.HasPrefix(output, "Clearing SEL") {
+		return false, fmt.Errorf("%v: %v", err, output)
+	}
+	return true, err
+}
+
 // ReadUsers list all BMC users
 func (i *Ipmi) ReadUsers(ctx context.Context) (users []map[string]string, err error) {
 	output, err := i.run(ctx, []string{"user", "list"})
@@ -0,0 +1,94 @@
+package ipmi
+
+import (
+	"bufio"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/pkg/errors"
+)
+
+// selTimeLayout is the date and time layout of the ipmitool sel elist columns
+const selTimeLayout = "01/02/2006 15:04:05"
+
+// selSeverities maps the keywords of the event descriptions to a severity, the first match wins
+// so "non-critical" and "uncorrectable" have to be tested before "critical" and "correctable",
+// the events matching none are informational.
+var selSeverities = []struct {
+	keyword  string
+	severity devices.Severity
+}{
+	{"non-critical", devices.SeverityWarning},
+	{"uncorrectable", devices.SeverityCritical},
+	{"correctable ecc", devices.SeverityWarning},
+	{"redundancy lost", devices.SeverityWarning},
+	{"degraded", devices.SeverityWarning},
+	{"limit exceeded", devices.SeverityWarning},
+	{"critical", devices.SeverityCritical},
+	{"non-recoverable", devices.SeverityCritical},
+	{"failure", devices.SeverityCritical},
+	{"fault", devices.SeverityCritical},
+	{"lost", devices.SeverityCritical},
+}
+
+// ReadSystemEventLog returns the entries of the system event log (SEL) of the BMC
+func (i *Ipmi) ReadSystemEventLog(ctx context.Context) (entries []devices.EventLogEntry, err error) {
+	output, err := i.run(ctx, []string{"sel", "elist"})
+	if err != nil {
+		return entries, errors.Wrap(err, "error reading the system event log")
+	}
+
+	return parseSelElist(output), nil
+}
+
+// parseSelElist parses the pipe separated lines of ipmitool sel elist:
+// id | date | time | sensor | description | direction [| details]
+func parseSelElist(output string) (entries []devices.EventLogEntry) {
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		columns := strings.Split(scanner.Text(), "|")
+		if len(columns) < 6 {
+			continue
+		}
+		for x := range columns {
+			columns[x] = strings.TrimSpace(columns[x])
+		}
+
+		entry := devices.EventLogEntry{
+			ID:        columns[0],
+			Sensor:    columns[3],
+			EventType: columns[5],
+			Message:   columns[4],
+			Severity:  selSeverity(columns[4]),
+		}
+		if len(columns) > 6 && columns[6] != "" {
+			entry.Message += ": " + strings.Join(columns[6:], " ")
+		}
+		if entry.EventType == "Deasserted" {
+			entry.Severity = devices.SeverityOK
+		}
+
+		// the events logged before the BMC clock was set are reported as Pre-Init
+		if timestamp, err := time.Parse(selTimeLayout, columns[1]+" "+columns[2]); err == nil {
+			entry.Timestamp = timestamp.UTC()
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// selSeverity derives the severity of an event from its description
+func selSeverity(description string) devices.Severity {
+	description = strings.ToLower(description)
+	for _, s := range selSeverities {
+		if strings.Contains(description, s.keyword) {
+			return s.severity
+		}
+	}
+
+	return devices.SeverityOK
+}
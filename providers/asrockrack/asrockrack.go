@@ -5,6 +5,7 @@ import (
 	"context"
 	"crypto/x509"
 	"net/http"
+	"time"
 
 	"github.com/bmc-toolbox/bmclib/devices"
 	"github.com/bmc-toolbox/bmclib/internal/httpclient"
@@ -44,6 +45,8 @@ type ASRockRack struct {
 	skipLogout           bool // A Close() / httpsLogout() request is ignored if the BMC was just flashed - since the sessions are terminated either way
 	log                  logr.Logger
 	httpClientSetupFuncs []func(*http.Client)
+	// passwordPolicy is checked by the user management before a password is sent to the BMC
+	passwordPolicy devices.PasswordPolicy
 }
 
 // ASRockOption is a type that can configure an *ASRockRack
@@ -57,6 +60,109 @@ func WithSecureTLS(rootCAs *x509.CertPool) ASRockOption {
 	}
 }
 
+// WithInsecureTLS skips the verification of the BMC certificate, which is otherwise
+// verified against the system CAs. Use it for BMCs serving self-signed certificates.
+func WithInsecureTLS() ASRockOption {
+	return func(r *ASRockRack) {
+		r.httpClientSetupFuncs = append(r.httpClientSetupFuncs, httpclient.WithInsecureTLS())
+	}
+}
+
+// WithSecureTLSFromFile enforces trusted TLS connections using the CA certificates of a PEM file.
+// Requests fail with a loading error when the file is missing or holds no valid certificate.
+func WithSecureTLSFromFile(caCertFile string) ASRockOption {
+	return func(r *ASRockRack) {
+		r.httpClientSetupFuncs = append(r.httpClientSetupFuncs, httpclient.WithCACertFile(caCertFile))
+	}
+}
+
+// WithClientCert authenticates to the BMC with the given PEM encoded client certificate and key.
+func WithClientCert(certPEM, keyPEM []byte) ASRockOption {
+	return func(r *ASRockRack) {
+		r.httpClientSetupFuncs = append(r.httpClientSetupFuncs, httpclient.WithClientCert(certPEM, keyPEM))
+	}
+}
+
+// WithObserver calls observer after each HTTP round-trip made to the BMC,
+// to collect metrics or logs.
+func WithObserver(observer func(providers.RequestInfo)) ASRockOption {
+	return func(r *ASRockRack) {
+		r.httpClientSetupFuncs = append(r.httpClientSetupFuncs, httpclient.WithObserver(observer))
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the HTTP requests made to the BMC,
+// it defaults to bmclib/<version>.
+func WithUserAgent(ua string) ASRockOption {
+	return func(r *ASRockRack) {
+		r.httpClientSetupFuncs = append(r.httpClientSetupFuncs, httpclient.WithUserAgent(ua))
+	}
+}
+
+// WithForceHTTP1 restricts the HTTP requests made to the BMC to HTTP/1.1,
+// for BMC web servers returning garbled responses over HTTP/2.
+func WithForceHTTP1() ASRockOption {
+	return func(r *ASRockRack) {
+		r.httpClientSetupFuncs = append(r.httpClientSetupFuncs, httpclient.WithForceHTTP1())
+	}
+}
+
+// WithMaxResponseBytes bounds the size of the responses read from the BMC,
+// it defaults to httpclient.DefaultMaxResponseBytes.
+func WithMaxResponseBytes(n int64) ASRockOption {
+	return func(r *ASRockRack) {
+		r.httpClientSetupFuncs = append(r.httpClientSetupFuncs, httpclient.WithMaxResponseBytes(n))
+	}
+}
+
+// WithDigestAuth answers the HTTP Digest authentication challenges of the BMC
+// with the credentials of the ASRockRack, for firmware requiring digest auth.
+func WithDigestAuth() ASRockOption {
+	return func(r *ASRockRack) {
+		r.httpClientSetupFuncs = append(r.httpClientSetupFuncs, httpclient.WithDigestAuth(r.username, r.password))
+	}
+}
+
+// WithRequestLogging logs the method, host, path, status and duration of each HTTP request
+// made to the BMC at V(1) with the logger of the ASRockRack, lighter than the dumps logged at V(2).
+func WithRequestLogging() ASRockOption {
+	return func(r *ASRockRack) {
+		r.httpClientSetupFuncs = append(r.httpClientSetupFuncs, httpclient.WithRequestLogging(r.log))
+	}
+}
+
+// WithTimeout sets the overall timeout of the HTTP requests made to the BMC,
+// it defaults to httpclient.DefaultTimeout.
+func WithTimeout(d time.Duration) ASRockOption {
+	return func(r *ASRockRack) {
+		r.httpClientSetupFuncs = append(r.httpClientSetupFuncs, httpclient.WithTimeout(d))
+	}
+}
+
+// WithProxy routes the HTTP requests made to the BMC through the given proxy,
+// by default the proxy settings of the environment are honored.
+func WithProxy(proxyURL string) ASRockOption {
+	return func(r *ASRockRack) {
+		r.httpClientSetupFuncs = append(r.httpClientSetupFuncs, httpclient.WithProxy(proxyURL))
+	}
+}
+
+// WithRetry retries the idempotent HTTP requests made to the BMC on transient failures,
+// making up to attempts attempts with an exponential backoff.
+func WithRetry(attempts int, backoff time.Duration) ASRockOption {
+	return func(r *ASRockRack) {
+		r.httpClientSetupFuncs = append(r.httpClientSetupFuncs, httpclient.WithRetry(attempts, backoff))
+	}
+}
+
+// WithRateLimit paces the HTTP requests made to the BMC to requestsPerSecond,
+// allowing bursts of up to burst requests.
+func WithRateLimit(requestsPerSecond float64, burst int) ASRockOption {
+	return func(r *ASRockRack) {
+		r.httpClientSetupFuncs = append(r.httpClientSetupFuncs, httpclient.WithRateLimit(requestsPerSecond, burst))
+	}
+}
+
 // WithHTTPClient sets an HTTP client on the ASRockRack
 func WithHTTPClient(c *http.Client) ASRockOption {
 	return func(ar *ASRockRack) {
@@ -64,6 +170,14 @@ func WithHTTPClient(c *http.Client) ASRockOption {
 	}
 }
 
+// WithPasswordPolicy sets the policy the passwords are checked against before they're sent to the BMC,
+// devices.IPMIPasswordPolicy() by default.
+func WithPasswordPolicy(policy devices.PasswordPolicy) ASRockOption {
+	return func(r *ASRockRack) {
+		r.passwordPolicy = policy
+	}
+}
+
 // New returns a new ASRockRack instance ready to be used
 func New(ip string, username string, password string, log logr.Logger) (*ASRockRack, error) {
 	return NewWithOptions(ip, username, password, log)
@@ -77,6 +191,8 @@ func NewWithOptions(ip string, username string, password string, log logr.Logger
 		password:     password,
 		log:          log,
 		loginSession: &loginSession{},
+		// the accounts are IPMI users, their passwords are capped to 20 bytes
+		passwordPolicy: devices.IPMIPasswordPolicy(),
 	}
 	for _, opt := range opts {
 		opt(r)
@@ -87,6 +203,11 @@ func NewWithOptions(ip string, username string, password string, log logr.Logger
 			return nil, err
 		}
 	} else {
+		// a client may be shared between instances, each one keeps its own cookies
+		r.httpClient = httpclient.IsolateCookies(r.httpClient)
+		if r.httpClient.Timeout == 0 {
+			r.httpClient.Timeout = httpclient.DefaultTimeout
+		}
 		for _, setupFunc := range r.httpClientSetupFuncs {
 			setupFunc(r.httpClient)
 		}
@@ -9,11 +9,11 @@ import (
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
-	"net/http/httputil"
 	"os"
 
 	"github.com/bmc-toolbox/bmclib/devices"
 	"github.com/bmc-toolbox/bmclib/errors"
+	"github.com/bmc-toolbox/bmclib/internal/httpclient"
 )
 
 // API session setup response payload
@@ -537,7 +537,7 @@ func (a *ASRockRack) httpsLogin(ctx context.Context) error {
 
 	resp, statusCode, err := a.queryHTTPS(ctx, urlEndpoint, "POST", bytes.NewReader(payload), headers, 0)
 	if err != nil {
-		return fmt.Errorf("Error logging in: " + err.Error())
+		return fmt.Errorf("Error logging in: %w", err)
 	}
 
 	if statusCode == 401 {
@@ -547,7 +547,7 @@ func (a *ASRockRack) httpsLogin(ctx context.Context) error {
 	// Unmarshal login session
 	err = json.Unmarshal(resp, a.loginSession)
 	if err != nil {
-		return fmt.Errorf("error unmarshalling response payload: " + err.Error())
+		return fmt.Errorf("error unmarshalling response payload: %w", err)
 	}
 
 	return nil
@@ -557,11 +557,11 @@ func (a *ASRockRack) httpsLogin(ctx context.Context) error {
 func (a *ASRockRack) httpsLogout(ctx context.Context) error {
 	_, statusCode, err := a.queryHTTPS(ctx, "api/session", "DELETE", nil, nil, 0)
 	if err != nil {
-		return fmt.Errorf("Error logging out: " + err.Error())
+		return fmt.Errorf("Error logging out: %w", err)
 	}
 
 	if err != nil {
-		return fmt.Errorf("Error logging out: " + err.Error())
+		return fmt.Errorf("Error logging out: %w", err)
 	}
 
 	if statusCode != http.StatusOK {
@@ -599,7 +599,9 @@ func (a *ASRockRack) queryHTTPS(ctx context.Context, endpoint, method string, pa
 
 	// debug dump request
 	if os.Getenv("BMCLIB_LOG_LEVEL") == "trace" {
-		reqDump, _ := httputil.DumpRequestOut(req, true)
+		// the login payload carries the credentials, only its headers are dumped
+		login := method == "POST" && endpoint == "api/session"
+		reqDump, _ := httpclient.DumpRequestOut(req, !login)
 		a.log.V(3).Info("trace", "url", URL, "requestDump", string(reqDump))
 	}
 
@@ -610,7 +612,7 @@ func (a *ASRockRack) queryHTTPS(ctx context.Context, endpoint, method string, pa
 
 	// debug dump response
 	if os.Getenv("BMCLIB_LOG_LEVEL") == "trace" {
-		respDump, _ := httputil.DumpResponse(resp, true)
+		respDump, _ := httpclient.DumpResponse(resp, true)
 		a.log.V(3).Info("trace", "responseDump", string(respDump))
 	}
 
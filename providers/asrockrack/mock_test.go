@@ -61,7 +61,7 @@ func TestMain(m *testing.M) {
 	l.Level = logrus.DebugLevel
 	// setup bmc client
 	tLog := logrusr.New(l)
-	aClient, err = New(bmcURL.Host, "foo", "bar", tLog)
+	aClient, err = NewWithOptions(bmcURL.Host, "foo", "bar", tLog, WithInsecureTLS())
 	if err != nil {
 		log.Fatal(err.Error())
 	}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/bmc-toolbox/bmclib/devices"
 	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bmc-toolbox/bmclib/internal"
 )
@@ -79,6 +80,11 @@ func (a *ASRockRack) UserCreate(ctx context.Context, user, pass, role string) (o
 		return false, bmclibErrs.ErrUserParamsRequired
 	}
 
+	err = devices.ValidatePassword(pass, a.passwordPolicy)
+	if err != nil {
+		return false, err
+	}
+
 	// fetch current list of accounts
 	accounts, err := a.listUsers(ctx)
 	if err != nil {
@@ -122,6 +128,11 @@ func (a *ASRockRack) UserUpdate(ctx context.Context, user, pass, role string) (o
 		return false, bmclibErrs.ErrUserParamsRequired
 	}
 
+	err = devices.ValidatePassword(pass, a.passwordPolicy)
+	if err != nil {
+		return false, err
+	}
+
 	accounts, err := a.listUsers(ctx)
 	if err != nil {
 		return false, errors.Wrap(bmclibErrs.ErrRetrievingUserAccounts, err.Error())
@@ -43,6 +43,22 @@ var (
 			bmclibErrs.ErrUserParamsRequired,
 			"param not defined",
 		},
+		{
+			"foo",
+			"calvin",
+			"Administrator",
+			false,
+			bmclibErrs.ErrWeakPassword,
+			"password too weak",
+		},
+		{
+			"foo",
+			"Abcdefghij0123456789xyz",
+			"Administrator",
+			false,
+			bmclibErrs.ErrWeakPassword,
+			"password longer than the ipmi limit",
+		},
 	}
 )
 
@@ -92,7 +108,7 @@ func Test_UserCreate(t *testing.T) {
 	tests = append(tests,
 		[]testCase{{
 			"root",
-			"calvin",
+			"Calvin123",
 			"Administrator",
 			true,
 			nil,
@@ -100,7 +116,7 @@ func Test_UserCreate(t *testing.T) {
 		},
 			{
 				"admin",
-				"foo",
+				"Foobar123",
 				"Administrator",
 				false,
 				bmclibErrs.ErrUserAccountExists,
@@ -127,7 +143,7 @@ func Test_UserUpdate(t *testing.T) {
 		[]testCase{
 			{
 				"admin",
-				"calvin",
+				"Calvin123",
 				"Administrator",
 				true,
 				nil,
@@ -135,7 +151,7 @@ func Test_UserUpdate(t *testing.T) {
 			},
 			{
 				"badmin",
-				"calvin",
+				"Calvin123",
 				"Administrator",
 				false,
 				bmclibErrs.ErrUserAccountNotFound,
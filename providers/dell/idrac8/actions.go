@@ -5,8 +5,14 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+
+	"github.com/bmc-toolbox/bmclib/devices"
 )
 
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the PowerController interface.
+var _ devices.PowerController = (*IDrac8)(nil)
+
 // PowerCycle reboots the machine via bmc
 func (i *IDrac8) PowerCycle() (bool, error) {
 	output, err := i.sshClient.Run("racadm serveraction hardreset")
@@ -21,6 +27,20 @@ func (i *IDrac8) PowerCycle() (bool, error) {
 	return false, fmt.Errorf(output)
 }
 
+// PowerReset resets the machine via bmc, without powering it off
+func (i *IDrac8) PowerReset() (bool, error) {
+	output, err := i.sshClient.Run("racadm serveraction hardreset")
+	if err != nil {
+		return false, fmt.Errorf("output: %q: %w", output, err)
+	}
+
+	if strings.Contains(output, "successful") {
+		return true, nil
+	}
+
+	return false, fmt.Errorf(output)
+}
+
 // PowerCycleBmc reboots the bmc we are connected to
 func (i *IDrac8) PowerCycleBmc() (bool, error) {
 	output, err := i.sshClient.Run("racadm racreset hard")
@@ -2,8 +2,11 @@ package idrac8
 
 import (
 	"context"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/bmc-toolbox/bmclib/devices"
 	"github.com/bmc-toolbox/bmclib/sshmock"
 	"github.com/bombsimon/logrusr/v2"
 	"github.com/go-logr/logr"
@@ -24,6 +27,26 @@ var (
 		"racadm serveraction powerup":     []byte(`Server power operation successful`),
 		"racadm serveraction powerdown":   []byte(`Server power operation successful`),
 		"racadm serveraction powerstatus": []byte(`Server power status: ON`),
+		"racadm getsel": []byte(`Record:      1
+Date/Time:   05/11/2021 11:26:06
+Source:      system
+Severity:    Ok
+Description: Log cleared.
+-------------------------------------------------------------------------------
+Record:      2
+Date/Time:   05/11/2021 11:27:48
+Source:      system
+Severity:    Critical
+Description: The power input for power supply 2 is lost.
+-------------------------------------------------------------------------------
+Record:      3
+Date/Time:   05/12/2021 08:01:17
+Source:      system
+Severity:    Non-Critical
+Description: The system inlet temperature is greater than the upper warning threshold.
+-------------------------------------------------------------------------------
+`),
+		"racadm clrsel": []byte(`The SEL was cleared successfully.`),
 		"racadm config -g cfgServerInfo -o cfgServerBootOnce 1": []byte(`Object value modified successfully
 
 
@@ -58,7 +81,7 @@ func setupBMC() (func(), *IDrac8, error) {
 	}
 
 	testLogger := logrus.New()
-	bmc, err := New(context.TODO(), address, sshUsername, sshPassword, logrusr.New(testLogger))
+	bmc, err := NewWithOptions(context.TODO(), address, sshUsername, sshPassword, logrusr.New(testLogger), WithInsecureTLS())
 	if err != nil {
 		tearDown()
 		return nil, nil, err
@@ -86,6 +109,12 @@ func Test_IDrac8(t *testing.T) {
 			want:      true,
 			wantErr:   false,
 		},
+		{
+			name:      "PowerReset",
+			bmcMethod: bmc.PowerReset,
+			want:      true,
+			wantErr:   false,
+		},
 		{
 			name:      "PowerCycleBmc",
 			bmcMethod: bmc.PowerCycleBmc,
@@ -132,3 +161,31 @@ func Test_IDrac8(t *testing.T) {
 		})
 	}
 }
+
+func TestGetEventLog(t *testing.T) {
+	tearDown, bmc, err := setupBMC()
+	if err != nil {
+		t.Fatalf("failed to setup BMC: %v", err)
+	}
+	defer tearDown()
+
+	expected := []devices.EventLogEntry{
+		{ID: "1", Timestamp: time.Date(2021, 5, 11, 11, 26, 6, 0, time.UTC), Sensor: "system", Severity: devices.SeverityOK, Message: "Log cleared."},
+		{ID: "2", Timestamp: time.Date(2021, 5, 11, 11, 27, 48, 0, time.UTC), Sensor: "system", Severity: devices.SeverityCritical, Message: "The power input for power supply 2 is lost."},
+		{ID: "3", Timestamp: time.Date(2021, 5, 12, 8, 1, 17, 0, time.UTC), Sensor: "system", Severity: devices.SeverityWarning, Message: "The system inlet temperature is greater than the upper warning threshold."},
+	}
+
+	entries, err := bmc.GetEventLog()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.GetEventLog %v", err)
+	}
+
+	if !reflect.DeepEqual(expected, entries) {
+		t.Errorf("Expected answer %+v: found %+v", expected, entries)
+	}
+
+	err = bmc.ClearEventLog()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.ClearEventLog %v", err)
+	}
+}
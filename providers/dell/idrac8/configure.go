@@ -128,6 +128,8 @@ func (i *IDrac8) User(cfgUsers []*cfgresources.User) (err error) {
 
 	usedIDs := make(map[int]bool)
 	for _, cfgUser := range cfgUsers {
+		i.log.V(2).Info("applying user resource", "step", "applyUserParams", "IP", i.ip, "User", cfgUser.Redacted())
+
 		// If the user is not enabled in the config, just skip.
 		// The next section is going to wipe it out.
 		if !cfgUser.Enable {
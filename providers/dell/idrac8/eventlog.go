@@ -0,0 +1,37 @@
+package idrac8
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/providers/dell"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the EventLogReader interface.
+var _ devices.EventLogReader = (*IDrac8)(nil)
+
+// GetEventLog returns the system event log (SEL) of the server
+func (i *IDrac8) GetEventLog() (entries []devices.EventLogEntry, err error) {
+	output, err := i.sshClient.Run("racadm getsel")
+	if err != nil {
+		return entries, fmt.Errorf("output: %q: %w", output, err)
+	}
+
+	return dell.ParseRacadmSel(output), nil
+}
+
+// ClearEventLog clears the system event log (SEL) of the server
+func (i *IDrac8) ClearEventLog() error {
+	output, err := i.sshClient.Run("racadm clrsel")
+	if err != nil {
+		return fmt.Errorf("output: %q: %w", output, err)
+	}
+
+	if strings.Contains(output, "successfully") {
+		return nil
+	}
+
+	return fmt.Errorf(output)
+}
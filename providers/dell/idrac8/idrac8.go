@@ -9,15 +9,16 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bmc-toolbox/bmclib/devices"
 	"github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bmc-toolbox/bmclib/internal/httpclient"
 	"github.com/bmc-toolbox/bmclib/internal/sshclient"
+	"github.com/bmc-toolbox/bmclib/providers"
 	"github.com/bmc-toolbox/bmclib/providers/dell"
 	"github.com/go-logr/logr"
 )
@@ -53,6 +54,109 @@ func WithSecureTLS(rootCAs *x509.CertPool) IDrac8Option {
 	}
 }
 
+// WithInsecureTLS skips the verification of the BMC certificate, which is otherwise
+// verified against the system CAs. Use it for BMCs serving self-signed certificates.
+func WithInsecureTLS() IDrac8Option {
+	return func(i *IDrac8) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithInsecureTLS())
+	}
+}
+
+// WithSecureTLSFromFile enforces trusted TLS connections using the CA certificates of a PEM file.
+// Requests fail with a loading error when the file is missing or holds no valid certificate.
+func WithSecureTLSFromFile(caCertFile string) IDrac8Option {
+	return func(i *IDrac8) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithCACertFile(caCertFile))
+	}
+}
+
+// WithClientCert authenticates to the BMC with the given PEM encoded client certificate and key.
+func WithClientCert(certPEM, keyPEM []byte) IDrac8Option {
+	return func(i *IDrac8) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithClientCert(certPEM, keyPEM))
+	}
+}
+
+// WithObserver calls observer after each HTTP round-trip made to the BMC,
+// to collect metrics or logs.
+func WithObserver(observer func(providers.RequestInfo)) IDrac8Option {
+	return func(i *IDrac8) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithObserver(observer))
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the HTTP requests made to the BMC,
+// it defaults to bmclib/<version>.
+func WithUserAgent(ua string) IDrac8Option {
+	return func(i *IDrac8) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithUserAgent(ua))
+	}
+}
+
+// WithForceHTTP1 restricts the HTTP requests made to the BMC to HTTP/1.1,
+// for BMC web servers returning garbled responses over HTTP/2.
+func WithForceHTTP1() IDrac8Option {
+	return func(i *IDrac8) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithForceHTTP1())
+	}
+}
+
+// WithMaxResponseBytes bounds the size of the responses read from the BMC,
+// it defaults to httpclient.DefaultMaxResponseBytes.
+func WithMaxResponseBytes(n int64) IDrac8Option {
+	return func(i *IDrac8) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithMaxResponseBytes(n))
+	}
+}
+
+// WithDigestAuth answers the HTTP Digest authentication challenges of the BMC
+// with the credentials of the IDrac8, for firmware requiring digest auth.
+func WithDigestAuth() IDrac8Option {
+	return func(i *IDrac8) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithDigestAuth(i.username, i.password))
+	}
+}
+
+// WithRequestLogging logs the method, host, path, status and duration of each HTTP request
+// made to the BMC at V(1) with the logger of the IDrac8, lighter than the dumps logged at V(2).
+func WithRequestLogging() IDrac8Option {
+	return func(i *IDrac8) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithRequestLogging(i.log))
+	}
+}
+
+// WithTimeout sets the overall timeout of the HTTP requests made to the BMC,
+// it defaults to httpclient.DefaultTimeout.
+func WithTimeout(d time.Duration) IDrac8Option {
+	return func(i *IDrac8) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithTimeout(d))
+	}
+}
+
+// WithProxy routes the HTTP requests made to the BMC through the given proxy,
+// by default the proxy settings of the environment are honored.
+func WithProxy(proxyURL string) IDrac8Option {
+	return func(i *IDrac8) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithProxy(proxyURL))
+	}
+}
+
+// WithRetry retries the idempotent HTTP requests made to the BMC on transient failures,
+// making up to attempts attempts with an exponential backoff.
+func WithRetry(attempts int, backoff time.Duration) IDrac8Option {
+	return func(i *IDrac8) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithRetry(attempts, backoff))
+	}
+}
+
+// WithRateLimit paces the HTTP requests made to the BMC to requestsPerSecond,
+// allowing bursts of up to burst requests.
+func WithRateLimit(requestsPerSecond float64, burst int) IDrac8Option {
+	return func(i *IDrac8) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithRateLimit(requestsPerSecond, burst))
+	}
+}
+
 // New returns a new IDrac8 ready to be used
 func New(ctx context.Context, host string, username string, password string, log logr.Logger) (*IDrac8, error) {
 	return NewWithOptions(ctx, host, username, password, log)
@@ -103,7 +207,7 @@ func (i *IDrac8) put(endpoint string, payload []byte) (statusCode int, response
 		}
 	}
 
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
 	i.log.V(2).Info("requestTrace", "requestDump", string(reqDump), "url", fmt.Sprintf("%s/%s", bmcURL, endpoint))
 
 	resp, err := i.httpClient.Do(req)
@@ -113,7 +217,7 @@ func (i *IDrac8) put(endpoint string, payload []byte) (statusCode int, response
 
 	defer resp.Body.Close()
 
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	i.log.V(2).Info("responseTrace", "responseDump", string(respDump))
 
 	response, err = ioutil.ReadAll(resp.Body)
@@ -122,7 +226,7 @@ func (i *IDrac8) put(endpoint string, payload []byte) (statusCode int, response
 	}
 
 	if resp.StatusCode == 500 {
-		return resp.StatusCode, response, errors.Err500
+		return resp.StatusCode, response, errors.NewHTTPErrorFromResponse(resp, response)
 	}
 
 	return resp.StatusCode, response, err
@@ -162,7 +266,7 @@ func (i *IDrac8) post(endpoint string, data []byte, formDataContentType string)
 		req.AddCookie(c)
 	}
 
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
 	i.log.V(2).Info("requestTrace", "requestDump", string(reqDump), "url", fmt.Sprintf("https://%s/%s", i.ip, endpoint))
 
 	resp, err := i.httpClient.Do(req)
@@ -170,7 +274,7 @@ func (i *IDrac8) post(endpoint string, data []byte, formDataContentType string)
 		return 0, []byte{}, err
 	}
 	defer resp.Body.Close()
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	i.log.V(2).Info("responseTrace", "responseDump", string(respDump))
 
 	body, err = ioutil.ReadAll(resp.Body)
@@ -206,7 +310,7 @@ func (i *IDrac8) get(endpoint string, extraHeaders *map[string]string) (statusCo
 			req.AddCookie(cookie)
 		}
 	}
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
 	i.log.V(2).Info("requestTrace", "requestDump", string(reqDump), "url", fmt.Sprintf("%s/%s", bmcURL, endpoint))
 
 	resp, err := i.httpClient.Do(req)
@@ -214,7 +318,7 @@ func (i *IDrac8) get(endpoint string, extraHeaders *map[string]string) (statusCo
 		return 0, nil, err
 	}
 	defer resp.Body.Close()
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	i.log.V(2).Info("responseTrace", "responseDump", string(respDump))
 
 	payload, err = ioutil.ReadAll(resp.Body)
@@ -223,7 +327,7 @@ func (i *IDrac8) get(endpoint string, extraHeaders *map[string]string) (statusCo
 	}
 
 	if resp.StatusCode == 404 {
-		return 404, payload, errors.ErrPageNotFound
+		return 404, payload, errors.NewHTTPErrorFromResponse(resp, payload)
 	}
 
 	return resp.StatusCode, payload, err
@@ -295,7 +399,7 @@ func (i *IDrac8) Serial() (serial string, err error) {
 		if component.Classname == "DCIM_SystemView" {
 			for _, property := range component.Properties {
 				if property.Name == "NodeID" && property.Type == "string" {
-					return strings.ToLower(property.Value), nil
+					return devices.NormalizeSerial(property.Value), nil
 				}
 			}
 		}
@@ -315,7 +419,7 @@ func (i *IDrac8) ChassisSerial() (serial string, err error) {
 		if component.Classname == "DCIM_SystemView" {
 			for _, property := range component.Properties {
 				if property.Name == "ChassisServiceTag" && property.Type == "string" {
-					return strings.ToLower(property.Value), err
+					return devices.NormalizeSerial(property.Value), err
 				}
 			}
 		}
@@ -359,6 +463,16 @@ func (i *IDrac8) Status() (status string, err error) {
 	return "OK", err
 }
 
+// Health returns the normalized health from the bmc
+func (i *IDrac8) Health() (health devices.Health, err error) {
+	status, err := i.Status()
+	if err != nil {
+		return devices.HealthUnknown, err
+	}
+
+	return devices.NormalizeHealth(status), err
+}
+
 // PowerKw returns the current power usage in Kw
 func (i *IDrac8) PowerKw() (power float64, err error) {
 	err = i.httpLogin()
@@ -602,7 +716,7 @@ func (i *IDrac8) Disks() (disks []*devices.Disk, err error) {
 				if property.Name == "Model" {
 					disk.Model = strings.ToLower(property.Value)
 				} else if property.Name == "SerialNumber" {
-					disk.Serial = strings.ToLower(property.Value)
+					disk.Serial = devices.NormalizeSerial(property.Value)
 				} else if property.Name == "MediaType" {
 					if property.DisplayValue == "Solid State Drive" {
 						disk.Type = "SSD"
@@ -620,7 +734,7 @@ func (i *IDrac8) Disks() (disks []*devices.Disk, err error) {
 					if err != nil {
 						return disks, err
 					}
-					disk.Size = fmt.Sprintf("%d GB", size/1024/1024/1024)
+					disk.Size = devices.FormatDiskSize(int64(size))
 				} else if property.Name == "Revision" {
 					disk.FwVersion = strings.ToLower(property.Value)
 				}
@@ -785,7 +899,7 @@ func (i *IDrac8) ServerSnapshot() (server interface{}, err error) { // nolint: g
 	}
 
 	if isBlade, _ := i.IsBlade(); isBlade {
-		blade := &devices.Blade{}
+		blade := &devices.Blade{CollectedAt: time.Now().UTC()}
 		blade.Vendor = i.Vendor()
 		blade.BmcAddress = i.ip
 		blade.BmcType = i.HardwareType()
@@ -856,7 +970,7 @@ func (i *IDrac8) ServerSnapshot() (server interface{}, err error) { // nolint: g
 		}
 		server = blade
 	} else {
-		discrete := &devices.Discrete{}
+		discrete := &devices.Discrete{CollectedAt: time.Now().UTC()}
 		discrete.Vendor = i.Vendor()
 		discrete.BmcAddress = i.ip
 		discrete.BmcType = i.HardwareType()
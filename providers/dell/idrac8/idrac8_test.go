@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -5247,7 +5248,7 @@ func setup() (bmc *IDrac8, err error) {
 	}
 
 	testLogger := logrus.New()
-	bmc, err = New(context.TODO(), ip, username, password, logrusr.New(testLogger))
+	bmc, err = NewWithOptions(context.TODO(), ip, username, password, logrusr.New(testLogger), WithInsecureTLS())
 	if err != nil {
 		return bmc, err
 	}
@@ -5663,7 +5664,7 @@ func TestDiskDisks(t *testing.T) {
 		{
 			Serial:    "phdv707000d51p6egn",
 			Type:      "SSD",
-			Size:      "1490 GB",
+			Size:      "1600 GB",
 			Model:     "ssdsc2bb016t7r",
 			Location:  "Disk 0 in Backplane 1 of Integrated Storage Controller 1",
 			Status:    "OK",
@@ -5672,7 +5673,7 @@ func TestDiskDisks(t *testing.T) {
 		{
 			Serial:    "phdv707000fx1p6egn",
 			Type:      "SSD",
-			Size:      "1490 GB",
+			Size:      "1600 GB",
 			Model:     "ssdsc2bb016t7r",
 			Location:  "Disk 1 in Backplane 1 of Integrated Storage Controller 1",
 			Status:    "OK",
@@ -5757,3 +5758,35 @@ func TestUpdateCredentials(t *testing.T) {
 
 	tearDown()
 }
+
+func TestListUsers(t *testing.T) {
+	expectedAnswer := []devices.User{
+		{ID: 2, Name: "root", Role: devices.UserRoleAdmin, Enabled: true},
+		{ID: 3, Name: "monitoring", Role: devices.UserRoleReadOnly, Enabled: true},
+		{ID: 4, Name: "disabled", Role: devices.UserRoleOperator},
+	}
+
+	data := answers["/data"]
+	defer func() { answers["/data"] = data }()
+	answers["/data"] = []byte(`<?xml version="1.0" encoding="UTF-8"?><root>
+		<user><name></name><id>1</id><privileges>0</privileges><enabled>0</enabled><lanPriv>15</lanPriv><serialPriv>15</serialPriv><solEnabled>0</solEnabled></user>
+		<user><name>root</name><id>2</id><privileges>511</privileges><enabled>1</enabled><lanPriv>4</lanPriv><serialPriv>4</serialPriv><solEnabled>1</solEnabled></user>
+		<user><name>monitoring</name><id>3</id><privileges>1</privileges><enabled>1</enabled><lanPriv>2</lanPriv><serialPriv>2</serialPriv><solEnabled>0</solEnabled></user>
+		<user><name>disabled</name><id>4</id><privileges>499</privileges><enabled>0</enabled><lanPriv>3</lanPriv><serialPriv>3</serialPriv><solEnabled>0</solEnabled></user>
+		<status>ok</status></root>`)
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	answer, err := bmc.ListUsers()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.ListUsers %v", err)
+	}
+
+	if !reflect.DeepEqual(answer, expectedAnswer) {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, answer)
+	}
+}
@@ -0,0 +1,83 @@
+package idrac8
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the UserManager interface.
+var _ devices.UserManager = (*IDrac8)(nil)
+
+// userRoles maps the iDRAC privilege bitmasks to the user roles
+var userRoles = map[string]devices.UserRole{
+	"511": devices.UserRoleAdmin,
+	"499": devices.UserRoleOperator,
+	"1":   devices.UserRoleReadOnly,
+	"0":   devices.UserRoleNone,
+}
+
+// userRole returns the role of an iDRAC privilege bitmask, the custom bitmasks grant
+// iDRAC specific rights and are reported as UserRoleOEM.
+func userRole(privilege string) devices.UserRole {
+	if role, ok := userRoles[privilege]; ok {
+		return role
+	}
+
+	return devices.UserRoleOEM
+}
+
+// CreateUser isn't supported on iDRAC8 yet
+func (i *IDrac8) CreateUser(user devices.User) error {
+	return errors.NewFeatureUnsupportedError("user management", i.Vendor(), i.HardwareType())
+}
+
+// ModifyUser isn't supported on iDRAC8 yet
+func (i *IDrac8) ModifyUser(user devices.User) error {
+	return errors.NewFeatureUnsupportedError("user management", i.Vendor(), i.HardwareType())
+}
+
+// DeleteUser isn't supported on iDRAC8 yet
+func (i *IDrac8) DeleteUser(name string) error {
+	return errors.NewFeatureUnsupportedError("user management", i.Vendor(), i.HardwareType())
+}
+
+// ListUsers returns the user accounts configured on the iDRAC, the accounts are only read
+// through the web interface while their changes need racadm, ListUsers implements the UserManager interface.
+func (i *IDrac8) ListUsers() (users []devices.User, err error) {
+	err = i.httpLogin()
+	if err != nil {
+		return users, err
+	}
+
+	usersInfo, err := i.queryUsers()
+	if err != nil {
+		return users, fmt.Errorf("%w: %s", errors.ErrRetrievingUserAccounts, err)
+	}
+
+	for id, user := range usersInfo {
+		if user.UserName == "" {
+			continue
+		}
+
+		users = append(users, devices.User{
+			ID:      id,
+			Name:    user.UserName,
+			Role:    userRole(user.Privilege),
+			Enabled: user.Enable == "Enabled",
+		})
+	}
+
+	// the users are returned in a map keyed by slot
+	sort.Slice(users, func(a, b int) bool { return users[a].ID < users[b].ID })
+
+	return users, nil
+}
+
+// ChangePassword isn't supported on iDRAC8 yet
+func (i *IDrac8) ChangePassword(name string, password string) error {
+	return errors.NewFeatureUnsupportedError("user management", i.Vendor(), i.HardwareType())
+}
@@ -0,0 +1,137 @@
+package idrac9
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the BIOSConfigurator interface.
+var _ devices.BIOSConfigurator = (*IDrac9)(nil)
+
+const (
+	biosURI         = "redfish/v1/Systems/System.Embedded.1/Bios"
+	biosSettingsURI = biosURI + "/Settings"
+)
+
+// biosAttributes returns the Attributes of the given redfish BIOS resource
+func (i *IDrac9) biosAttributes(endpoint string) (attributes map[string]interface{}, err error) {
+	statusCode, response, err := i.queryRedfish("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode != 200 {
+		return nil, fmt.Errorf("GET request to %s failed with status code %d", endpoint, statusCode)
+	}
+
+	var payload struct {
+		Attributes map[string]interface{} `json:"Attributes"`
+	}
+
+	err = json.Unmarshal(response, &payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return payload.Attributes, nil
+}
+
+// GetBIOSSettings returns the current BIOS attributes
+func (i *IDrac9) GetBIOSSettings(ctx context.Context) (*devices.BIOSSettings, error) {
+	err := i.httpLogin()
+	if err != nil {
+		return nil, err
+	}
+
+	attributes, err := i.biosAttributes(biosURI)
+	if err != nil {
+		return nil, err
+	}
+
+	return devices.BIOSSettingsFromAttributes(attributes), nil
+}
+
+// SetBIOSSettings stages the BIOS attributes differing from the current ones and queues the job
+// applying them, the iDRAC applies BIOS changes on the next reboot only.
+func (i *IDrac9) SetBIOSSettings(ctx context.Context, settings *devices.BIOSSettings) (rebootRequired bool, err error) {
+	err = i.httpLogin()
+	if err != nil {
+		return false, err
+	}
+
+	current, err := i.biosAttributes(biosURI)
+	if err != nil {
+		return false, err
+	}
+
+	attributes, err := settings.Attributes(current)
+	if err != nil {
+		return false, err
+	}
+
+	for name, value := range attributes {
+		if current[name] == value {
+			delete(attributes, name)
+		}
+	}
+
+	if len(attributes) == 0 {
+		return false, nil
+	}
+
+	// Purge any existing pending BIOS setting jobs, otherwise the attributes can't be set
+	err = i.purgeJobsForBiosSettings()
+	if err != nil {
+		return false, err
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{"Attributes": attributes})
+	if err != nil {
+		return false, err
+	}
+
+	statusCode, _, err := i.queryRedfish("PATCH", biosSettingsURI, payload)
+	if err != nil {
+		return false, fmt.Errorf("PATCH request to set BIOS config failed with error %w", err)
+	} else if statusCode != 200 {
+		return false, fmt.Errorf("PATCH request to set BIOS config failed with status code %d", statusCode)
+	}
+
+	err = i.queueJobs(biosSettingsURI)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// PendingBIOSSettings returns the BIOS attributes staged for the next reboot
+func (i *IDrac9) PendingBIOSSettings(ctx context.Context) (*devices.BIOSSettings, error) {
+	err := i.httpLogin()
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := i.biosAttributes(biosURI)
+	if err != nil {
+		return nil, err
+	}
+
+	staged, err := i.biosAttributes(biosSettingsURI)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := devices.NewBIOSSettings()
+	for _, diff := range devices.BIOSSettingsFromAttributes(staged).Diff(devices.BIOSSettingsFromAttributes(current)) {
+		if diff.Present {
+			pending.Set(diff.Name, diff.Value)
+		}
+	}
+
+	return pending, nil
+}
@@ -0,0 +1,79 @@
+package idrac9
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/internal/conformance"
+)
+
+// setupBios registers the redfish BIOS and job endpoints on the test server, keeping the
+// staged attributes and the queued jobs.
+func setupBios(t *testing.T) (bmc *IDrac9, staged map[string]interface{}, jobs *[]string) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	current := map[string]interface{}{"BootMode": "Uefi", "LogicalProc": "Enabled", "NumLock": "On", "SysProfile": "PerfOptimized"}
+	staged = map[string]interface{}{}
+	jobs = &[]string{}
+
+	mux.HandleFunc("/redfish/v1/Systems/System.Embedded.1/Bios", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"Attributes": current})
+	})
+	mux.HandleFunc("/redfish/v1/Systems/System.Embedded.1/Bios/Settings", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			var payload struct {
+				Attributes map[string]interface{}
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			for name, value := range payload.Attributes {
+				staged[name] = value
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"Attributes": staged})
+	})
+	mux.HandleFunc("/redfish/v1/Managers/iDRAC.Embedded.1/Jobs", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			var target TargetSettingsURI
+			_ = json.NewDecoder(r.Body).Decode(&target)
+			*jobs = append(*jobs, target.TargetSettingsURI)
+		}
+		_, _ = w.Write([]byte(`{"Members":[],"Members@odata.count":0}`))
+	})
+
+	return bmc, staged, jobs
+}
+
+func TestBIOSConfigurator(t *testing.T) {
+	bmc, _, jobs := setupBios(t)
+	defer tearDown()
+
+	conformance.BIOSConfigurator(t, bmc, "SysProfile", "PerfPerWattOptimizedOs")
+
+	expectedJobs := []string{"/redfish/v1/Systems/System.Embedded.1/Bios/Settings"}
+	if len(*jobs) != 1 || (*jobs)[0] != expectedJobs[0] {
+		t.Errorf("Expected answer %v: found %v", expectedJobs, *jobs)
+	}
+}
+
+func TestSetBIOSSettingsUnchanged(t *testing.T) {
+	bmc, staged, jobs := setupBios(t)
+	defer tearDown()
+
+	settings := devices.NewBIOSSettings()
+	settings.Set("BootMode", "Uefi")
+
+	rebootRequired, err := bmc.SetBIOSSettings(context.TODO(), settings)
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.SetBIOSSettings %v", err)
+	}
+
+	if rebootRequired || len(staged) != 0 || len(*jobs) != 0 {
+		t.Errorf("Expected no change to be staged: found reboot required %v, staged %v, jobs %v", rebootRequired, staged, *jobs)
+	}
+}
@@ -202,6 +202,8 @@ func (i *IDrac9) User(cfgUsers []*cfgresources.User) (err error) {
 	userID := 2
 
 	for _, cfgUser := range cfgUsers {
+		i.log.V(2).Info("applying user resource", "step", "applyUserParams", "IP", i.ip, "User", cfgUser.Redacted())
+
 		// If the user is not enabled in the config, just skip.
 		if !cfgUser.Enable {
 			continue
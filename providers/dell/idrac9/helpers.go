@@ -20,7 +20,7 @@ func (i *IDrac9) putUser(userID int, user UserInfo) (err error) {
 	endpoint := fmt.Sprintf("sysmgmt/2012/server/configgroup/iDRAC.Users.%d", userID)
 	statusCode, _, err := i.put(endpoint, payload)
 	if err != nil {
-		return fmt.Errorf("PUT request to set User config failed with error %s!", err.Error())
+		return fmt.Errorf("PUT request to set User config failed with error %w!", err)
 	} else if statusCode != 200 {
 		return fmt.Errorf("PUT request to set User config failed with status code %d!", statusCode)
 	}
@@ -42,7 +42,7 @@ func (i *IDrac9) putLdap(ldap Ldap) (err error) {
 	endpoint := "sysmgmt/2012/server/configgroup/iDRAC.LDAP"
 	statusCode, _, err := i.put(endpoint, payload)
 	if err != nil {
-		return fmt.Errorf("PUT request to set LDAP config failed with error %s!", err.Error())
+		return fmt.Errorf("PUT request to set LDAP config failed with error %w!", err)
 	} else if statusCode != 200 {
 		return fmt.Errorf("PUT request to set LDAP config failed with status code %d!", statusCode)
 	}
@@ -63,7 +63,7 @@ func (i *IDrac9) putLdapRoleGroup(roleID string, ldapRoleGroup LdapRoleGroup) (e
 	endpoint := fmt.Sprintf("sysmgmt/2012/server/configgroup/iDRAC.LDAPRoleGroup.%s", roleID)
 	statusCode, _, err := i.put(endpoint, payload)
 	if err != nil {
-		return fmt.Errorf("PUT request to set LDAPRoleGroup config failed with error %s!", err.Error())
+		return fmt.Errorf("PUT request to set LDAPRoleGroup config failed with error %w!", err)
 	} else if statusCode != 200 {
 		return fmt.Errorf("PUT request to set LDAPRoleGroup config failed with status code %d!", statusCode)
 	}
@@ -85,7 +85,7 @@ func (i *IDrac9) putTimezone(timezone Timezone) (err error) {
 	endpoint := "sysmgmt/2012/server/configgroup/iDRAC.Time"
 	statusCode, _, err := i.put(endpoint, payload)
 	if err != nil {
-		return fmt.Errorf("PUT request to set Timezone config failed with error %s!", err.Error())
+		return fmt.Errorf("PUT request to set Timezone config failed with error %w!", err)
 	} else if statusCode != 200 {
 		return fmt.Errorf("PUT request to set Timezone config failed with status code %d!", statusCode)
 	}
@@ -107,7 +107,7 @@ func (i *IDrac9) putNtpConfig(ntpConfig NtpConfig) (err error) {
 	endpoint := "sysmgmt/2012/server/configgroup/iDRAC.NTPConfigGroup"
 	statusCode, _, err := i.put(endpoint, payload)
 	if err != nil {
-		return fmt.Errorf("PUT request to set NTP config failed with error %s!", err.Error())
+		return fmt.Errorf("PUT request to set NTP config failed with error %w!", err)
 	} else if statusCode != 200 {
 		return fmt.Errorf("PUT request to set NTP config failed with status code %d!", statusCode)
 	}
@@ -129,7 +129,7 @@ func (i *IDrac9) putSyslog(syslog Syslog) (err error) {
 	endpoint := "sysmgmt/2012/server/configgroup/iDRAC.Syslog"
 	statusCode, _, err := i.put(endpoint, payload)
 	if err != nil {
-		return fmt.Errorf("PUT request to set Syslog config failed with error %s!", err.Error())
+		return fmt.Errorf("PUT request to set Syslog config failed with error %w!", err)
 	} else if statusCode != 200 {
 		return fmt.Errorf("PUT request to set Syslog config failed with status code %d!", statusCode)
 	}
@@ -151,7 +151,7 @@ func (i *IDrac9) putIPv4(ipv4 Ipv4) (err error) {
 	endpoint := "sysmgmt/2012/server/configgroup/iDRAC.IPv4"
 	statusCode, _, err := i.put(endpoint, payload)
 	if err != nil {
-		return fmt.Errorf("PUT request to set IPv4 config failed with error %s!", err.Error())
+		return fmt.Errorf("PUT request to set IPv4 config failed with error %w!", err)
 	} else if statusCode != 200 {
 		return fmt.Errorf("PUT request to set IPv4 config failed with status code %d!", statusCode)
 	}
@@ -173,7 +173,7 @@ func (i *IDrac9) putSerialOverLan(serialOverLan SerialOverLan) (err error) {
 	endpoint := "sysmgmt/2012/server/configgroup/iDRAC.IPMISOL"
 	statusCode, _, err := i.put(endpoint, payload)
 	if err != nil {
-		return fmt.Errorf("PUT request to set SerialOverLAN config failed with error %s!", err.Error())
+		return fmt.Errorf("PUT request to set SerialOverLAN config failed with error %w!", err)
 	} else if statusCode != 200 {
 		return fmt.Errorf("PUT request to set SerialOverLAN config failed with status code %d!", statusCode)
 	}
@@ -195,7 +195,7 @@ func (i *IDrac9) putSerialRedirection(serialRedirection SerialRedirection) (err
 	endpoint := "sysmgmt/2012/server/configgroup/iDRAC.SerialRedirection"
 	statusCode, _, err := i.put(endpoint, payload)
 	if err != nil {
-		return fmt.Errorf("PUT request to set SerialRedirection config failed with error %s!", err.Error())
+		return fmt.Errorf("PUT request to set SerialRedirection config failed with error %w!", err)
 	} else if statusCode != 200 {
 		return fmt.Errorf("PUT request to set SerialRedirection config failed with status code %d!", statusCode)
 	}
@@ -217,7 +217,7 @@ func (i *IDrac9) putIpmiOverLan(ipmiOverLan IpmiOverLan) (err error) {
 	endpoint := "sysmgmt/2012/server/configgroup/iDRAC.IPMILAN"
 	statusCode, _, err := i.put(endpoint, payload)
 	if err != nil {
-		return fmt.Errorf("PUT request to set IPMIOverLAN config failed with error %s!", err.Error())
+		return fmt.Errorf("PUT request to set IPMIOverLAN config failed with error %w!", err)
 	} else if statusCode != 200 {
 		return fmt.Errorf("PUT request to set IPMIOverLAN config failed with status code %d!", statusCode)
 	}
@@ -237,7 +237,7 @@ func (i *IDrac9) putCSR(csrInfo CSRInfo) (err error) {
 	endpoint := "sysmgmt/2012/server/configgroup/iDRAC.Security"
 	statusCode, _, err := i.put(endpoint, payload)
 	if err != nil {
-		return fmt.Errorf("PUT request to set CSR config failed with error %s!", err.Error())
+		return fmt.Errorf("PUT request to set CSR config failed with error %w!", err)
 	} else if statusCode != 200 {
 		return fmt.Errorf("PUT request to set CSR attributes failed with status code %d!", statusCode)
 	}
@@ -251,7 +251,7 @@ func (i *IDrac9) putAlertConfig() (err error) {
 	endpoint := "sysmgmt/2012/server/eventpolicy"
 	statusCode, _, err := i.put(endpoint, alertConfigPayload)
 	if err != nil {
-		return fmt.Errorf("PUT request to set AlertConfig config failed with error %s!", err.Error())
+		return fmt.Errorf("PUT request to set AlertConfig config failed with error %w!", err)
 	} else if statusCode != 200 {
 		return fmt.Errorf("PUT request to set AlertConfig config failed with status code %d!", statusCode)
 	}
@@ -271,7 +271,7 @@ func (i *IDrac9) putAlertEnable(alertEnable AlertEnable) (err error) {
 	endpoint := "sysmgmt/2012/server/configgroup/iDRAC.IPMILAN"
 	statusCode, _, err := i.put(endpoint, payload)
 	if err != nil {
-		return fmt.Errorf("PUT request to set AlertEnable config failed with error %s!", err.Error())
+		return fmt.Errorf("PUT request to set AlertEnable config failed with error %w!", err)
 	} else if statusCode != 200 {
 		return fmt.Errorf("PUT request to set AlertEnable config failed with status code %d!", statusCode)
 	}
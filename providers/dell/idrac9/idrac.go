@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/bmc-toolbox/bmclib/devices"
 	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bmc-toolbox/bmclib/providers"
 	"github.com/go-logr/logr"
@@ -25,6 +26,8 @@ type Conn struct {
 	Log       logr.Logger
 	conn      *http.Client
 	xsrfToken string
+	// passwordPolicy is checked by the user management before a password is sent to the BMC
+	passwordPolicy devices.PasswordPolicy
 }
 
 const (
@@ -49,8 +52,16 @@ func WithHTTPClientConnOption(cli *http.Client) ConnOption {
 	}
 }
 
+// WithPasswordPolicyConnOption sets the policy the passwords are checked against before they're
+// sent to the BMC, devices.DefaultPasswordPolicy() by default.
+func WithPasswordPolicyConnOption(policy devices.PasswordPolicy) ConnOption {
+	return func(c *Conn) {
+		c.passwordPolicy = policy
+	}
+}
+
 func NewConn(host, port, user, pass string, log logr.Logger, opts ...ConnOption) *Conn {
-	conn := &Conn{Host: host, Port: port, User: user, Pass: pass, Log: log}
+	conn := &Conn{Host: host, Port: port, User: user, Pass: pass, Log: log, passwordPolicy: devices.DefaultPasswordPolicy()}
 	for _, opt := range opts {
 		opt(conn)
 	}
@@ -58,7 +69,7 @@ func NewConn(host, port, user, pass string, log logr.Logger, opts ...ConnOption)
 }
 
 func (c *Conn) newIdrac9() *IDrac9 {
-	return &IDrac9{ip: c.Host, username: c.User, password: c.Pass, log: c.Log, httpClient: c.conn}
+	return &IDrac9{ip: c.Host, username: c.User, password: c.Pass, log: c.Log, httpClient: c.conn, passwordPolicy: c.passwordPolicy}
 }
 
 func (c *Conn) Name() string {
@@ -150,6 +161,11 @@ func (c *Conn) Compatible(ctx context.Context) bool {
 }
 
 func (c *Conn) UserCreate(ctx context.Context, user, pass, role string) (ok bool, err error) {
+	err = devices.ValidatePassword(pass, c.passwordPolicy)
+	if err != nil {
+		return false, err
+	}
+
 	idrac := c.newIdrac9()
 	idrac.xsrfToken = c.xsrfToken
 	idrac.httpClient = c.conn
@@ -197,6 +213,11 @@ func (c *Conn) UserCreate(ctx context.Context, user, pass, role string) (ok bool
 }
 
 func (c *Conn) UserUpdate(ctx context.Context, user, pass, role string) (ok bool, err error) {
+	err = devices.ValidatePassword(pass, c.passwordPolicy)
+	if err != nil {
+		return false, err
+	}
+
 	idrac := c.newIdrac9()
 	idrac.xsrfToken = c.xsrfToken
 	idrac.httpClient = c.conn
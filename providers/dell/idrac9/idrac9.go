@@ -8,10 +8,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/go-multierror"
@@ -20,6 +20,7 @@ import (
 	"github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bmc-toolbox/bmclib/internal/httpclient"
 	"github.com/bmc-toolbox/bmclib/internal/sshclient"
+	"github.com/bmc-toolbox/bmclib/providers"
 	"github.com/bmc-toolbox/bmclib/providers/dell"
 )
 
@@ -40,6 +41,8 @@ type IDrac9 struct {
 	ctx                  context.Context
 	log                  logr.Logger
 	httpClientSetupFuncs []func(*http.Client)
+	// passwordPolicy is checked by the user management before a password is sent to the BMC
+	passwordPolicy devices.PasswordPolicy
 }
 
 // IDrac9Option is a type that can configure an *IDrac9
@@ -53,6 +56,117 @@ func WithSecureTLS(rootCAs *x509.CertPool) IDrac9Option {
 	}
 }
 
+// WithInsecureTLS skips the verification of the BMC certificate, which is otherwise
+// verified against the system CAs. Use it for BMCs serving self-signed certificates.
+func WithInsecureTLS() IDrac9Option {
+	return func(i *IDrac9) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithInsecureTLS())
+	}
+}
+
+// WithSecureTLSFromFile enforces trusted TLS connections using the CA certificates of a PEM file.
+// Requests fail with a loading error when the file is missing or holds no valid certificate.
+func WithSecureTLSFromFile(caCertFile string) IDrac9Option {
+	return func(i *IDrac9) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithCACertFile(caCertFile))
+	}
+}
+
+// WithClientCert authenticates to the BMC with the given PEM encoded client certificate and key.
+func WithClientCert(certPEM, keyPEM []byte) IDrac9Option {
+	return func(i *IDrac9) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithClientCert(certPEM, keyPEM))
+	}
+}
+
+// WithObserver calls observer after each HTTP round-trip made to the BMC,
+// to collect metrics or logs.
+func WithObserver(observer func(providers.RequestInfo)) IDrac9Option {
+	return func(i *IDrac9) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithObserver(observer))
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the HTTP requests made to the BMC,
+// it defaults to bmclib/<version>.
+func WithUserAgent(ua string) IDrac9Option {
+	return func(i *IDrac9) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithUserAgent(ua))
+	}
+}
+
+// WithForceHTTP1 restricts the HTTP requests made to the BMC to HTTP/1.1,
+// for BMC web servers returning garbled responses over HTTP/2.
+func WithForceHTTP1() IDrac9Option {
+	return func(i *IDrac9) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithForceHTTP1())
+	}
+}
+
+// WithMaxResponseBytes bounds the size of the responses read from the BMC,
+// it defaults to httpclient.DefaultMaxResponseBytes.
+func WithMaxResponseBytes(n int64) IDrac9Option {
+	return func(i *IDrac9) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithMaxResponseBytes(n))
+	}
+}
+
+// WithDigestAuth answers the HTTP Digest authentication challenges of the BMC
+// with the credentials of the IDrac9, for firmware requiring digest auth.
+func WithDigestAuth() IDrac9Option {
+	return func(i *IDrac9) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithDigestAuth(i.username, i.password))
+	}
+}
+
+// WithRequestLogging logs the method, host, path, status and duration of each HTTP request
+// made to the BMC at V(1) with the logger of the IDrac9, lighter than the dumps logged at V(2).
+func WithRequestLogging() IDrac9Option {
+	return func(i *IDrac9) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithRequestLogging(i.log))
+	}
+}
+
+// WithTimeout sets the overall timeout of the HTTP requests made to the BMC,
+// it defaults to httpclient.DefaultTimeout.
+func WithTimeout(d time.Duration) IDrac9Option {
+	return func(i *IDrac9) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithTimeout(d))
+	}
+}
+
+// WithProxy routes the HTTP requests made to the BMC through the given proxy,
+// by default the proxy settings of the environment are honored.
+func WithProxy(proxyURL string) IDrac9Option {
+	return func(i *IDrac9) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithProxy(proxyURL))
+	}
+}
+
+// WithRetry retries the idempotent HTTP requests made to the BMC on transient failures,
+// making up to attempts attempts with an exponential backoff.
+func WithRetry(attempts int, backoff time.Duration) IDrac9Option {
+	return func(i *IDrac9) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithRetry(attempts, backoff))
+	}
+}
+
+// WithRateLimit paces the HTTP requests made to the BMC to requestsPerSecond,
+// allowing bursts of up to burst requests.
+func WithRateLimit(requestsPerSecond float64, burst int) IDrac9Option {
+	return func(i *IDrac9) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithRateLimit(requestsPerSecond, burst))
+	}
+}
+
+// WithPasswordPolicy sets the policy the passwords are checked against before they're sent to the BMC,
+// devices.DefaultPasswordPolicy() by default.
+func WithPasswordPolicy(policy devices.PasswordPolicy) IDrac9Option {
+	return func(i *IDrac9) {
+		i.passwordPolicy = policy
+	}
+}
+
 // WithHTTPClient sets an HTTP client on an *IDrac9
 func WithHTTPClient(c *http.Client) IDrac9Option {
 	return func(i *IDrac9) {
@@ -72,12 +186,17 @@ func NewWithOptions(ctx context.Context, host, httpHost string, username string,
 		return nil, err
 	}
 
-	idrac := &IDrac9{ip: httpHost, username: username, password: password, sshClient: sshClient, ctx: ctx, log: log}
+	idrac := &IDrac9{ip: httpHost, username: username, password: password, sshClient: sshClient, ctx: ctx, log: log, passwordPolicy: devices.DefaultPasswordPolicy()}
 
 	for _, opt := range opts {
 		opt(idrac)
 	}
 	if idrac.httpClient != nil {
+		// a client may be shared between instances, each one keeps its own cookies
+		idrac.httpClient = httpclient.IsolateCookies(idrac.httpClient)
+		if idrac.httpClient.Timeout == 0 {
+			idrac.httpClient.Timeout = httpclient.DefaultTimeout
+		}
 		for _, setupFunc := range idrac.httpClientSetupFuncs {
 			setupFunc(idrac.httpClient)
 		}
@@ -112,7 +231,7 @@ func (i *IDrac9) get(endpoint string, extraHeaders *map[string]string) (statusCo
 		}
 	}
 
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
 	i.log.V(2).Info("requestTrace", "requestDump", string(reqDump), "url", fmt.Sprintf("%s/%s", bmcURL, endpoint))
 
 	resp, err := i.httpClient.Do(req)
@@ -121,7 +240,7 @@ func (i *IDrac9) get(endpoint string, extraHeaders *map[string]string) (statusCo
 	}
 	defer resp.Body.Close()
 
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	i.log.V(2).Info("responseTrace", "responseDump", string(respDump))
 
 	payload, err = ioutil.ReadAll(resp.Body)
@@ -130,7 +249,7 @@ func (i *IDrac9) get(endpoint string, extraHeaders *map[string]string) (statusCo
 	}
 
 	if resp.StatusCode == 404 {
-		return 404, payload, errors.ErrPageNotFound
+		return 404, payload, errors.NewHTTPErrorFromResponse(resp, payload)
 	}
 
 	return resp.StatusCode, payload, err
@@ -147,7 +266,7 @@ func (i *IDrac9) put(endpoint string, body []byte) (statusCode int, payload []by
 
 	req.Header.Add("XSRF-TOKEN", i.xsrfToken)
 
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
 	i.log.V(2).Info("requestTrace", "requestDump", string(reqDump), "url", fmt.Sprintf("%s/%s", bmcURL, endpoint))
 
 	resp, err := i.httpClient.Do(req)
@@ -156,7 +275,7 @@ func (i *IDrac9) put(endpoint string, body []byte) (statusCode int, payload []by
 	}
 	defer resp.Body.Close()
 
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	i.log.V(2).Info("responseTrace", "responseDump", string(respDump))
 
 	payload, err = ioutil.ReadAll(resp.Body)
@@ -165,7 +284,7 @@ func (i *IDrac9) put(endpoint string, body []byte) (statusCode int, payload []by
 	}
 
 	if resp.StatusCode == 500 {
-		return resp.StatusCode, payload, errors.Err500
+		return resp.StatusCode, payload, errors.NewHTTPErrorFromResponse(resp, payload)
 	}
 
 	return resp.StatusCode, payload, err
@@ -182,7 +301,7 @@ func (i *IDrac9) delete(endpoint string) (statusCode int, payload []byte, err er
 
 	req.Header.Add("XSRF-TOKEN", i.xsrfToken)
 
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
 	i.log.V(2).Info("requestTrace", "requestDump", string(reqDump), "url", fmt.Sprintf("%s/%s", bmcURL, endpoint))
 
 	resp, err := i.httpClient.Do(req)
@@ -192,7 +311,7 @@ func (i *IDrac9) delete(endpoint string) (statusCode int, payload []byte, err er
 
 	defer resp.Body.Close()
 
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	i.log.V(2).Info("responseTrace", "responseDump", string(respDump))
 
 	payload, err = ioutil.ReadAll(resp.Body)
@@ -227,7 +346,7 @@ func (i *IDrac9) post(endpoint string, data []byte, formDataContentType string)
 		req.Header.Set("Content-Type", formDataContentType)
 	}
 
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
 	i.log.V(2).Info("requestTrace", "requestDump", string(reqDump), "url", fmt.Sprintf("https://%s/%s", i.ip, endpoint))
 
 	resp, err := i.httpClient.Do(req)
@@ -235,7 +354,7 @@ func (i *IDrac9) post(endpoint string, data []byte, formDataContentType string)
 		return 0, []byte{}, err
 	}
 	defer resp.Body.Close()
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	i.log.V(2).Info("responseTrace", "responseDump", string(respDump))
 
 	body, err = ioutil.ReadAll(resp.Body)
@@ -312,7 +431,7 @@ func (i *IDrac9) Serial() (serial string, err error) {
 		if component.Classname == "DCIM_SystemView" {
 			for _, property := range component.Properties {
 				if property.Name == "NodeID" && property.Type == "string" {
-					return strings.ToLower(property.Value), err
+					return devices.NormalizeSerial(property.Value), err
 				}
 			}
 		}
@@ -332,7 +451,7 @@ func (i *IDrac9) ChassisSerial() (serial string, err error) {
 		if component.Classname == "DCIM_SystemView" {
 			for _, property := range component.Properties {
 				if property.Name == "ChassisServiceTag" && property.Type == "string" {
-					return strings.ToLower(property.Value), err
+					return devices.NormalizeSerial(property.Value), err
 				}
 			}
 		}
@@ -376,6 +495,16 @@ func (i *IDrac9) Status() (status string, err error) {
 	return "OK", err
 }
 
+// Health returns the normalized health from the bmc
+func (i *IDrac9) Health() (health devices.Health, err error) {
+	status, err := i.Status()
+	if err != nil {
+		return devices.HealthUnknown, err
+	}
+
+	return devices.NormalizeHealth(status), err
+}
+
 // PowerKw returns the current power usage in Kw
 func (i *IDrac9) PowerKw() (power float64, err error) {
 	err = i.httpLogin()
@@ -445,6 +574,55 @@ func (i *IDrac9) BiosVersion() (version string, err error) {
 	return version, err
 }
 
+// firmwareProperties maps the inventory classes carrying firmware to the property holding the version
+var firmwareProperties = map[string]string{
+	"DCIM_SystemView":       "BIOSVersionString",
+	"DCIM_iDRACCardView":    "FirmwareVersion",
+	"DCIM_ControllerView":   "ControllerFirmwareVersion",
+	"DCIM_NICView":          "FamilyVersion",
+	"DCIM_PhysicalDiskView": "Revision",
+}
+
+// FirmwareInventory returns the firmware installed on each component, named by their FQDD
+func (i *IDrac9) FirmwareInventory() (inventory []devices.Firmware, err error) {
+	err = i.loadHwData()
+	if err != nil {
+		return inventory, err
+	}
+
+	for _, component := range i.iDracInventory.Component {
+		versionProperty, ok := firmwareProperties[component.Classname]
+		if !ok {
+			continue
+		}
+
+		// iDRAC.Embedded.1-1#IDRACinfo -> iDRAC.Embedded.1-1
+		name := strings.Split(component.Key, "#")[0]
+		if component.Classname == "DCIM_SystemView" {
+			name = "BIOS"
+		}
+
+		for _, property := range component.Properties {
+			if property.Name == versionProperty && property.Value != "" {
+				// every component in the iDRAC inventory can be updated with a Dell update package
+				inventory = append(inventory, devices.Firmware{Component: name, Installed: property.Value, Updatable: true})
+				break
+			}
+		}
+	}
+
+	return inventory, err
+}
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the FirmwareInventorier interface.
+var _ devices.FirmwareInventorier = (*IDrac9)(nil)
+
+// GetFirmwareInventory returns the firmware installed on each component, see FirmwareInventory
+func (i *IDrac9) GetFirmwareInventory() ([]devices.Firmware, error) {
+	return i.FirmwareInventory()
+}
+
 // Name returns the name of this server from the bmc point of view
 func (i *IDrac9) Name() (name string, err error) {
 	err = i.loadHwData()
@@ -722,6 +900,53 @@ func (i *IDrac9) IsBlade() (isBlade bool, err error) {
 	return isBlade, err
 }
 
+// GPUs returns a list of the video controllers installed in the PCIe slots of the device,
+// the embedded video controller is not reported. The VBIOS version and memory size are read
+// from the redfish processors, they're left empty on the firmware not listing the GPUs there.
+func (i *IDrac9) GPUs() (gpus []*devices.GPU, err error) {
+	err = i.loadHwData()
+	if err != nil {
+		return gpus, err
+	}
+
+	for _, component := range i.iDracInventory.Component {
+		if component.Classname != "DCIM_VideoView" || strings.HasPrefix(component.Key, "Video.Embedded") {
+			continue
+		}
+
+		gpu := &devices.GPU{Slot: component.Key}
+		for _, property := range component.Properties {
+			switch property.Name {
+			case "Description":
+				gpu.Model = property.Value
+			case "Manufacturer":
+				gpu.Vendor = property.Value
+			case "DeviceDescription":
+				gpu.Description = property.Value
+			}
+		}
+
+		processor, err := i.getProcessor(component.Key)
+		if err != nil {
+			return gpus, err
+		}
+
+		if processor != nil {
+			if processor.FirmwareVersion != "" {
+				gpu.Firmware = &devices.Firmware{Installed: processor.FirmwareVersion}
+			}
+
+			for _, memory := range processor.ProcessorMemory {
+				gpu.MemoryMB += memory.CapacityMiB
+			}
+		}
+
+		gpus = append(gpus, gpu)
+	}
+
+	return gpus, err
+}
+
 // Psus returns a list of psus installed on the device
 func (i *IDrac9) Psus() (psus []*devices.Psu, err error) {
 	err = i.httpLogin()
@@ -789,7 +1014,7 @@ func (i *IDrac9) ServerSnapshot() (server interface{}, err error) { // nolint: g
 	}
 
 	if isBlade, _ := i.IsBlade(); isBlade {
-		blade := &devices.Blade{}
+		blade := &devices.Blade{CollectedAt: time.Now().UTC()}
 		blade.Vendor = i.Vendor()
 		blade.BmcAddress = i.ip
 		blade.BmcType = i.HardwareType()
@@ -860,7 +1085,7 @@ func (i *IDrac9) ServerSnapshot() (server interface{}, err error) { // nolint: g
 		}
 		server = blade
 	} else {
-		discrete := &devices.Discrete{}
+		discrete := &devices.Discrete{CollectedAt: time.Now().UTC()}
 		discrete.Vendor = i.Vendor()
 		discrete.BmcAddress = i.ip
 		discrete.BmcType = i.HardwareType()
@@ -885,6 +1110,14 @@ func (i *IDrac9) ServerSnapshot() (server interface{}, err error) { // nolint: g
 		if err != nil {
 			return nil, err
 		}
+		discrete.GPUs, err = i.GPUs()
+		if err != nil {
+			return nil, err
+		}
+		discrete.FirmwareInventory, err = i.FirmwareInventory()
+		if err != nil {
+			return nil, err
+		}
 		discrete.BiosVersion, err = i.BiosVersion()
 		if err != nil {
 			return nil, err
@@ -949,7 +1182,7 @@ func (i *IDrac9) Disks() (disks []*devices.Disk, err error) {
 				if property.Name == "Model" {
 					disk.Model = strings.ToLower(property.Value)
 				} else if property.Name == "SerialNumber" {
-					disk.Serial = strings.ToLower(property.Value)
+					disk.Serial = devices.NormalizeSerial(property.Value)
 				} else if property.Name == "MediaType" {
 					if property.DisplayValue == "Solid State Drive" {
 						disk.Type = "SSD"
@@ -967,9 +1200,19 @@ func (i *IDrac9) Disks() (disks []*devices.Disk, err error) {
 					if err != nil {
 						return disks, err
 					}
-					disk.Size = fmt.Sprintf("%d GB", size/1024/1024/1024)
+					disk.Size = devices.FormatDiskSize(int64(size))
 				} else if property.Name == "Revision" {
 					disk.FwVersion = strings.ToLower(property.Value)
+				} else if property.Name == "BusProtocol" {
+					disk.Interface = diskInterface(property.DisplayValue)
+				} else if property.Name == "PredictiveFailureState" {
+					disk.SmartStatus = property.DisplayValue
+				} else if property.Name == "RemainingRatedWriteEndurance" {
+					remaining, err := strconv.Atoi(property.Value)
+					// 255 is reported when the drive doesn't expose its endurance
+					if err == nil && remaining <= 100 {
+						disk.WearLevel = 100 - remaining
+					}
 				}
 			}
 
@@ -981,6 +1224,18 @@ func (i *IDrac9) Disks() (disks []*devices.Disk, err error) {
 	return disks, err
 }
 
+// diskInterface maps the iDRAC bus protocol to the disk interface, PCIe drives are NVMe
+func diskInterface(busProtocol string) string {
+	switch busProtocol {
+	case "PCIe":
+		return "NVMe"
+	case "Unknown":
+		return ""
+	default:
+		return busProtocol
+	}
+}
+
 // UpdateCredentials updates login credentials
 func (i *IDrac9) UpdateCredentials(username string, password string) {
 	i.username = username
@@ -1,13 +1,18 @@
 package idrac9
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bombsimon/logrusr/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
@@ -2376,6 +2381,24 @@ var (
 					 <DisplayValue>Video.Embedded.1-1</DisplayValue>
 				   </PROPERTY>
 				</Component>
+				<Component Classname="DCIM_VideoView" Key="Video.Slot.3-1">
+				   <PROPERTY NAME="Description" TYPE="string">
+					 <VALUE>GV100GL [Tesla V100 PCIe 32GB]</VALUE>
+					 <DisplayValue>GV100GL [Tesla V100 PCIe 32GB]</DisplayValue>
+				   </PROPERTY>
+				   <PROPERTY NAME="Manufacturer" TYPE="string">
+					 <VALUE>NVIDIA Corporation</VALUE>
+					 <DisplayValue>NVIDIA Corporation</DisplayValue>
+				   </PROPERTY>
+				   <PROPERTY NAME="DeviceDescription" TYPE="string">
+					 <VALUE>Video Controller in Slot 3</VALUE>
+					 <DisplayValue>Video Controller in Slot 3</DisplayValue>
+				   </PROPERTY>
+				   <PROPERTY NAME="FQDD" TYPE="string">
+					 <VALUE>Video.Slot.3-1</VALUE>
+					 <DisplayValue>Video.Slot.3-1</DisplayValue>
+				   </PROPERTY>
+				</Component>
 				<Component Classname="DCIM_PhysicalDiskView" Key="Disk.Bay.0:Enclosure.Internal.0-1:RAID.Integrated.1-1">
 				   <PROPERTY NAME="LastUpdateTime" TYPE="string">
 					 <VALUE>20180207111330.000000+000</VALUE>
@@ -3263,7 +3286,10 @@ var (
 		"/sysmgmt/2015/bmc/session/logout":                       []byte(``),
 		"/sysmgmt/2015/bmc/session":                              []byte(`{"authResult":0}`),
 		"/sysmgmt/2013/server/sensor/powersupplyunit":            []byte(`{"Powersupplyunit":{"0x15||PSU.Slot.1":{"fw_version":"0.11.1a","health":2,"input_wattage":2260,"line_status":"n/a","max_output_wattage":"n/a","name":"PS1 Status","output_wattage":2000,"part_number":"0J5WMGA02","status":1,"type":0}}}`),
+		"/sysmgmt/2012/server/configgroup/iDRAC.Users":           []byte(`{"iDRAC.Users":{"1":{"Enable":"Disabled","IpmiLanPrivilege":"No Access","Privilege":"0","SolEnable":"Disabled","UserName":""},"2":{"Enable":"Enabled","IpmiLanPrivilege":"Administrator","Privilege":"511","SolEnable":"Enabled","UserName":"root"},"3":{"Enable":"Enabled","IpmiLanPrivilege":"User","Privilege":"1","SolEnable":"Disabled","UserName":"monitoring"},"4":{"Enable":"Disabled","IpmiLanPrivilege":"No Access","Privilege":"0","SolEnable":"Disabled","UserName":""},"5":{"Enable":"Disabled","IpmiLanPrivilege":"No Access","Privilege":"0","SolEnable":"Disabled","UserName":""}}}`),
 		"/sysmgmt/2012/server/configgroup/System.ServerTopology": []byte(`{"System.ServerTopology":{"AisleName":"","BladeSlotNumInChassis":"4","DataCenterName":"","RackName":"","RackSlot":"1","RoomName":"","SizeOfManagedSystemInU":"2"}}`),
+
+		"/redfish/v1/Systems/System.Embedded.1/Processors/Video.Slot.3-1": []byte(`{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/Processors/Video.Slot.3-1","FirmwareVersion":"88.00.48.00.01","Id":"Video.Slot.3-1","Manufacturer":"NVIDIA Corporation","Model":"GV100GL [Tesla V100 PCIe 32GB]","ProcessorMemory":[{"CapacityMiB":32768,"IntegratedMemory":true,"MemoryType":"HBM2"}],"ProcessorType":"GPU"}`),
 	}
 )
 
@@ -3288,7 +3314,7 @@ func setup() (bmc *IDrac9, err error) {
 	}
 
 	testLogger := logrus.New()
-	bmc, err = New(context.TODO(), ip, ip, username, password, logrusr.New(testLogger))
+	bmc, err = NewWithOptions(context.TODO(), ip, ip, username, password, logrusr.New(testLogger), WithInsecureTLS())
 	if err != nil {
 		return bmc, err
 	}
@@ -3624,22 +3650,28 @@ func TestIDracLicense(t *testing.T) {
 func TestDiskDisks(t *testing.T) {
 	expectedAnswer := []*devices.Disk{
 		{
-			Serial:    "s37mnx0j700554",
-			Type:      "SSD",
-			Size:      "3576 GB",
-			Model:     "mz7lm3t8hmlp0d3",
-			Location:  "Disk 0 in Backplane 1 of Integrated RAID Controller 1",
-			Status:    "OK",
-			FwVersion: "gc57",
+			Serial:      "s37mnx0j700554",
+			Type:        "SSD",
+			Size:        "3840 GB",
+			Model:       "mz7lm3t8hmlp0d3",
+			Location:    "Disk 0 in Backplane 1 of Integrated RAID Controller 1",
+			Status:      "OK",
+			FwVersion:   "gc57",
+			SmartStatus: "Smart Alert Absent",
+			WearLevel:   1,
+			Interface:   "SATA",
 		},
 		{
-			Serial:    "s37mnx0j700557",
-			Type:      "SSD",
-			Size:      "3576 GB",
-			Model:     "mz7lm3t8hmlp0d3",
-			Location:  "Disk 1 in Backplane 1 of Integrated RAID Controller 1",
-			Status:    "OK",
-			FwVersion: "gc57",
+			Serial:      "s37mnx0j700557",
+			Type:        "SSD",
+			Size:        "3840 GB",
+			Model:       "mz7lm3t8hmlp0d3",
+			Location:    "Disk 1 in Backplane 1 of Integrated RAID Controller 1",
+			Status:      "OK",
+			FwVersion:   "gc57",
+			SmartStatus: "Smart Alert Absent",
+			WearLevel:   1,
+			Interface:   "SATA",
 		},
 	}
 
@@ -3664,7 +3696,10 @@ func TestDiskDisks(t *testing.T) {
 			disk.Status != expectedAnswer[pos].Status ||
 			disk.Model != expectedAnswer[pos].Model ||
 			disk.FwVersion != expectedAnswer[pos].FwVersion ||
-			disk.Location != expectedAnswer[pos].Location {
+			disk.Location != expectedAnswer[pos].Location ||
+			disk.SmartStatus != expectedAnswer[pos].SmartStatus ||
+			disk.WearLevel != expectedAnswer[pos].WearLevel ||
+			disk.Interface != expectedAnswer[pos].Interface {
 			t.Errorf("Expected answer %v: found %v", expectedAnswer[pos], disk)
 		}
 	}
@@ -3672,6 +3707,72 @@ func TestDiskDisks(t *testing.T) {
 	tearDown()
 }
 
+func TestIDracGPUs(t *testing.T) {
+	expectedAnswer := []*devices.GPU{
+		{
+			Description: "Video Controller in Slot 3",
+			Vendor:      "NVIDIA Corporation",
+			Model:       "GV100GL [Tesla V100 PCIe 32GB]",
+			Slot:        "Video.Slot.3-1",
+			MemoryMB:    32768,
+			Firmware:    &devices.Firmware{Installed: "88.00.48.00.01"},
+		},
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	gpus, err := bmc.GPUs()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.GPUs %v", err)
+	}
+
+	if !reflect.DeepEqual(gpus, expectedAnswer) {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, gpus)
+	}
+
+	tearDown()
+}
+
+func TestIDracFirmwareInventory(t *testing.T) {
+	expectedAnswer := []devices.Firmware{
+		{Component: "RAID.Integrated.1-1", Installed: "25.5.3.0004", Updatable: true},
+		{Component: "BIOS", Installed: "1.2.71", Updatable: true},
+		{Component: "NIC.Integrated.1-1-1", Installed: "18.0.17", Updatable: true},
+		{Component: "NIC.Integrated.1-2-1", Installed: "18.0.17", Updatable: true},
+		{Component: "Disk.Bay.0:Enclosure.Internal.0-1:RAID.Integrated.1-1", Installed: "GC57", Updatable: true},
+		{Component: "Disk.Bay.1:Enclosure.Internal.0-1:RAID.Integrated.1-1", Installed: "GC57", Updatable: true},
+		{Component: "iDRAC.Embedded.1-1", Installed: "3.15.15.15", Updatable: true},
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	inventory, err := bmc.FirmwareInventory()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.FirmwareInventory %v", err)
+	}
+
+	if !reflect.DeepEqual(inventory, expectedAnswer) {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, inventory)
+	}
+
+	inventory, err = bmc.GetFirmwareInventory()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.GetFirmwareInventory %v", err)
+	}
+
+	if !reflect.DeepEqual(inventory, expectedAnswer) {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, inventory)
+	}
+
+	tearDown()
+}
+
 func TestIDracPsu(t *testing.T) {
 	expectedAnswer := []*devices.Psu{
 		{
@@ -3766,6 +3867,52 @@ func TestIDracPoweState(t *testing.T) {
 	tearDown()
 }
 
+func TestIDracServerSnapshot(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	answer, err := bmc.ServerSnapshot()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.ServerSnapshot %v", err)
+	}
+
+	blade, ok := answer.(*devices.Blade)
+	if !ok {
+		t.Fatalf("Expected answer %T: found %T", &devices.Blade{}, answer)
+	}
+
+	expected := &devices.Blade{
+		Vendor:        devices.Dell,
+		BmcType:       "idrac9",
+		Serial:        "h16z4m2",
+		ChassisSerial: "h1645m2",
+		Model:         "PowerEdge M640",
+		BmcVersion:    "3.15.15.15",
+		PowerState:    "on",
+		BladePosition: 2,
+	}
+	got := &devices.Blade{
+		Vendor:        blade.Vendor,
+		BmcType:       blade.BmcType,
+		Serial:        blade.Serial,
+		ChassisSerial: blade.ChassisSerial,
+		Model:         blade.Model,
+		BmcVersion:    blade.BmcVersion,
+		PowerState:    blade.PowerState,
+		BladePosition: blade.BladePosition,
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("Expected answer %+v: found %+v", expected, got)
+	}
+
+	if len(blade.Nics) == 0 || len(blade.Disks) == 0 || blade.Memory == 0 || blade.CollectedAt.IsZero() {
+		t.Errorf("Expected the snapshot to carry the nics, disks and memory: found %+v", blade)
+	}
+}
+
 func TestIDracInterface(t *testing.T) {
 	bmc, err := setup()
 	if err != nil {
@@ -3794,3 +3941,148 @@ func TestUpdateCredentials(t *testing.T) {
 
 	tearDown()
 }
+
+func TestLoginDumpMasksCredentials(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	var logs bytes.Buffer
+	testLogger := logrus.New()
+	testLogger.SetOutput(&logs)
+	testLogger.SetLevel(logrus.TraceLevel)
+	bmc.log = logrusr.New(testLogger)
+	bmc.UpdateCredentials("operator", "S3cr3tPassw0rd")
+
+	err = bmc.httpLogin()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.httpLogin %v", err)
+	}
+
+	if !strings.Contains(logs.String(), "requestDump") {
+		t.Fatalf("Expected the login request to be dumped: found %s", logs.String())
+	}
+
+	for _, secret := range []string{"S3cr3tPassw0rd", "operator"} {
+		if strings.Contains(logs.String(), secret) {
+			t.Errorf("Expected %q to be masked: found %s", secret, logs.String())
+		}
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	expectedAnswer := []devices.User{
+		{ID: 2, Name: "root", Role: devices.UserRoleAdmin, Enabled: true},
+		{ID: 3, Name: "monitoring", Role: devices.UserRoleReadOnly, Enabled: true},
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	answer, err := bmc.ListUsers()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.ListUsers %v", err)
+	}
+
+	if !reflect.DeepEqual(answer, expectedAnswer) {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, answer)
+	}
+}
+
+func TestUserManager(t *testing.T) {
+	tests := []struct {
+		name        string
+		call        func(bmc *IDrac9) error
+		endpoint    string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name: "create",
+			call: func(bmc *IDrac9) error {
+				return bmc.CreateUser(devices.User{Name: "operator", Password: "S3cretPass", Role: devices.UserRoleOperator})
+			},
+			endpoint: "PUT /sysmgmt/2012/server/configgroup/iDRAC.Users.4",
+			expected: `{"iDRAC.Users":{"UserName":"operator","Password":"S3cretPass","Enable":"Enabled","Privilege":"499","IpmiLanPrivilege":"Operator","SolEnable":"Enabled"}}`,
+		},
+		{
+			name: "create existing",
+			call: func(bmc *IDrac9) error {
+				return bmc.CreateUser(devices.User{Name: "monitoring", Password: "S3cretPass", Role: devices.UserRoleReadOnly})
+			},
+			expectedErr: bmclibErrs.ErrUserAccountExists,
+		},
+		{
+			name: "create too weak",
+			call: func(bmc *IDrac9) error {
+				return bmc.CreateUser(devices.User{Name: "operator", Password: "weak", Role: devices.UserRoleOperator})
+			},
+			expectedErr: bmclibErrs.ErrWeakPassword,
+		},
+		{
+			name: "modify",
+			call: func(bmc *IDrac9) error {
+				return bmc.ModifyUser(devices.User{Name: "monitoring", Role: devices.UserRoleAdmin})
+			},
+			endpoint: "PUT /sysmgmt/2012/server/configgroup/iDRAC.Users.3",
+			expected: `{"iDRAC.Users":{"Privilege":"511","IpmiLanPrivilege":"Administrator"}}`,
+		},
+		{
+			name:     "delete",
+			call:     func(bmc *IDrac9) error { return bmc.DeleteUser("monitoring") },
+			endpoint: "DELETE /sysmgmt/2017/server/user?userid=3",
+		},
+		{
+			name:        "delete missing",
+			call:        func(bmc *IDrac9) error { return bmc.DeleteUser("missing") },
+			expectedErr: bmclibErrs.ErrUserAccountNotFound,
+		},
+		{
+			name:     "change password",
+			call:     func(bmc *IDrac9) error { return bmc.ChangePassword("monitoring", "N3wSecretPass") },
+			endpoint: "PUT /sysmgmt/2012/server/configgroup/iDRAC.Users.3",
+			expected: `{"iDRAC.Users":{"Password":"N3wSecretPass"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bmc, err := setup()
+			if err != nil {
+				t.Fatalf("Found errors during the test setup %v", err)
+			}
+			defer tearDown()
+
+			sent := map[string]string{}
+			record := func(w http.ResponseWriter, r *http.Request) {
+				payload, _ := ioutil.ReadAll(r.Body)
+				sent[r.Method+" "+r.URL.RequestURI()] = string(payload)
+			}
+			mux.HandleFunc("/sysmgmt/2012/server/configgroup/iDRAC.Users.3", record)
+			mux.HandleFunc("/sysmgmt/2012/server/configgroup/iDRAC.Users.4", record)
+			mux.HandleFunc("/sysmgmt/2017/server/user", record)
+
+			err = tt.call(bmc)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Expected error %v: found %v", tt.expectedErr, err)
+			}
+
+			if tt.endpoint == "" {
+				if len(sent) != 0 {
+					t.Errorf("Expected nothing sent to the bmc: found %v", sent)
+				}
+				return
+			}
+
+			payload, ok := sent[tt.endpoint]
+			if !ok || payload != tt.expected {
+				t.Errorf("Expected answer %v: found %v", tt.expected, sent)
+			}
+		})
+	}
+}
@@ -14,6 +14,19 @@ type Odata struct {
 	JobState     string              `json:"JobState,omitempty"`
 }
 
+// Processor declares the parameters read from the redfish processor payload,
+// the GPUs are listed as processors named after their FQDD, e.g. Video.Slot.3-1.
+type Processor struct {
+	FirmwareVersion string            `json:"FirmwareVersion,omitempty"` // VBIOS version of the GPUs
+	ProcessorMemory []ProcessorMemory `json:"ProcessorMemory,omitempty"`
+}
+
+// ProcessorMemory declares the parameters read from the memory entries of a redfish processor.
+type ProcessorMemory struct {
+	CapacityMiB int64  `json:"CapacityMiB,omitempty"`
+	MemoryType  string `json:"MemoryType,omitempty"`
+}
+
 // BiosSettings is an alias type of cfgresources.Idrac9BiosSettings.
 // All supported BIOS settings can be queried from through redfish/v1/Systems/System.Embedded.1/Bios.
 // NOTE: All fields in this struct are expected to be of type string, for details see diffBiosSettings().
@@ -6,12 +6,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"reflect"
 	"strings"
 
 	bmclibErrors "github.com/bmc-toolbox/bmclib/errors"
+	"github.com/bmc-toolbox/bmclib/internal/httpclient"
 	"github.com/pkg/errors"
 )
 
@@ -66,6 +66,33 @@ func (i *IDrac9) getBiosSettings() (biosSettings *BiosSettings, err error) {
 	return oData.Attributes, err
 }
 
+// getProcessor returns the redfish processor with the given FQDD, it's nil when the
+// firmware doesn't list it, older releases don't report the GPUs as processors.
+func (i *IDrac9) getProcessor(fqdd string) (processor *Processor, err error) {
+	endpoint := fmt.Sprintf("redfish/v1/Systems/System.Embedded.1/Processors/%s", fqdd)
+
+	statusCode, response, err := i.queryRedfish("GET", endpoint, nil)
+	if err != nil {
+		return processor, err
+	}
+
+	if statusCode == 404 {
+		return processor, nil
+	}
+
+	if statusCode != 200 {
+		return processor, bmclibErrors.NewHTTPError("GET", fmt.Sprintf("https://%s/%s", i.ip, endpoint), statusCode, response)
+	}
+
+	processor = &Processor{}
+	err = json.Unmarshal(response, processor)
+	if err != nil {
+		return nil, err
+	}
+
+	return processor, err
+}
+
 /*
 //returns the bios settings pending reboot
 func (i *IDrac9) biosSettingsPendingReboot() (pendingBiosSettings *BiosSettings, err error) {
@@ -106,7 +133,7 @@ func (i *IDrac9) setBiosSettings(biosSettings *BiosSettings) (err error) {
 
 	statusCode, _, err := i.queryRedfish("PATCH", biosSettingsURI, payload)
 	if err != nil {
-		return fmt.Errorf("PATCH request to set BIOS config failed with error %s!", err.Error())
+		return fmt.Errorf("PATCH request to set BIOS config failed with error %w!", err)
 	} else if statusCode != 200 {
 		return fmt.Errorf("PATCH request to set BIOS config failed with status code %d!", statusCode)
 	}
@@ -135,7 +162,7 @@ func (i *IDrac9) queueJobs(jobURI string) (err error) {
 
 	statusCode, _, err := i.queryRedfish("POST", endpoint, payload)
 	if err != nil {
-		return fmt.Errorf("POST request to queue job %s failed with error %s!", jobURI, err.Error())
+		return fmt.Errorf("POST request to queue job %s failed with error %w!", jobURI, err)
 	} else if statusCode != 200 {
 		return fmt.Errorf("POST request to queue job %s failed with status code %d!", jobURI, statusCode)
 	}
@@ -153,7 +180,7 @@ func (i *IDrac9) purgeJob(jobID string) (err error) {
 
 	statusCode, _, err := i.queryRedfish("DELETE", endpoint, nil)
 	if err != nil {
-		return fmt.Errorf("DELETE request to purge job %s failed with error %s!", jobID, err.Error())
+		return fmt.Errorf("DELETE request to purge job %s failed with error %w!", jobID, err)
 	} else if statusCode != 200 {
 		return fmt.Errorf("DELETE request to purge job %s failed with status code %d!", jobID, statusCode)
 	}
@@ -286,7 +313,7 @@ func (i *IDrac9) queryRedfish(method string, endpoint string, payload []byte) (s
 		}
 	}
 
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
 	i.log.V(2).Info("requestTrace", "requestDump", string(reqDump), "url", fmt.Sprintf("%s/%s", bmcURL, endpoint))
 
 	resp, err := i.httpClient.Do(req)
@@ -295,7 +322,7 @@ func (i *IDrac9) queryRedfish(method string, endpoint string, payload []byte) (s
 	}
 	defer resp.Body.Close()
 
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	i.log.V(2).Info("responseTrace", "responseDump", string(respDump))
 
 	if resp.StatusCode == 401 {
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 
 	"github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bmc-toolbox/bmclib/internal/httpclient"
@@ -34,7 +33,7 @@ func (i *IDrac9) httpLogin() (err error) {
 	req.Header.Add("user", fmt.Sprintf("\"%s\"", i.username))
 	req.Header.Add("password", fmt.Sprintf("\"%s\"", i.password))
 
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
 	i.log.V(2).Info("requestTrace", "requestDump", string(reqDump), "url", url)
 
 	resp, err := httpClient.Do(req)
@@ -57,7 +56,7 @@ func (i *IDrac9) httpLogin() (err error) {
 	}
 	defer resp.Body.Close()
 
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	i.log.V(2).Info("responseTrace", "responseDump", string(respDump))
 
 	iDracAuth := &dell.IDracAuth{}
@@ -80,7 +79,7 @@ func (i *IDrac9) httpLogin() (err error) {
 func (i *IDrac9) loadHwData() (err error) {
 	err = i.httpLogin()
 	if err != nil {
-		return fmt.Errorf("IDrac9.loadHwData(): HTTP login problem: " + err.Error())
+		return fmt.Errorf("IDrac9.loadHwData(): HTTP login problem: %w", err)
 	}
 
 	url := "sysmgmt/2012/server/inventory/hardware"
@@ -98,7 +97,7 @@ func (i *IDrac9) loadHwData() (err error) {
 	iDracInventory := &dell.IDracInventory{}
 	err = xml.Unmarshal(response, iDracInventory)
 	if err != nil {
-		return fmt.Errorf("IDrac9.loadHwData(): XML unmarshal problem: " + err.Error())
+		return fmt.Errorf("IDrac9.loadHwData(): XML unmarshal problem: %w", err)
 	}
 
 	if iDracInventory.Component == nil {
@@ -0,0 +1,222 @@
+package idrac9
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the UserManager interface.
+var _ devices.UserManager = (*IDrac9)(nil)
+
+// userPrivileges maps the user roles to the iDRAC privilege bitmask and IPMI LAN privilege
+var userPrivileges = map[devices.UserRole]UserInfo{
+	devices.UserRoleAdmin:    {Privilege: "511", IpmiLanPrivilege: "Administrator"},
+	devices.UserRoleOperator: {Privilege: "499", IpmiLanPrivilege: "Operator"},
+	devices.UserRoleReadOnly: {Privilege: "1", IpmiLanPrivilege: "User"},
+}
+
+// userRole returns the role of an iDRAC user from its privilege bitmask,
+// custom bitmasks fall back to the IPMI LAN privilege.
+func userRole(user UserInfo) devices.UserRole {
+	for role, privileges := range userPrivileges {
+		if user.Privilege == privileges.Privilege {
+			return role
+		}
+	}
+
+	if user.Privilege == "0" {
+		return devices.UserRoleNone
+	}
+
+	return devices.NormalizeUserRole(user.IpmiLanPrivilege)
+}
+
+// users logs in and returns the user account slots of the iDRAC
+func (i *IDrac9) users() (users UsersInfo, err error) {
+	err = i.httpLogin()
+	if err != nil {
+		return users, err
+	}
+
+	users, err = i.queryUsers()
+	if err != nil {
+		return users, fmt.Errorf("%w: %s", errors.ErrRetrievingUserAccounts, err)
+	}
+
+	return users, nil
+}
+
+// findUser returns the slot of the user account with the given name
+func (i *IDrac9) findUser(name string) (id int, err error) {
+	users, err := i.users()
+	if err != nil {
+		return id, err
+	}
+
+	for id, user := range users {
+		if user.UserName == name {
+			return id, nil
+		}
+	}
+
+	return id, fmt.Errorf("%w: %s", errors.ErrUserAccountNotFound, name)
+}
+
+// ListUsers returns the user accounts configured on the iDRAC,
+// ListUsers implements the UserManager interface.
+func (i *IDrac9) ListUsers() (users []devices.User, err error) {
+	idracUsers, err := i.users()
+	if err != nil {
+		return users, err
+	}
+
+	for id, user := range idracUsers {
+		if user.UserName == "" {
+			continue
+		}
+
+		users = append(users, devices.User{
+			ID:      id,
+			Name:    user.UserName,
+			Role:    userRole(user),
+			Enabled: user.Enable == "Enabled",
+		})
+	}
+
+	// the users are returned in a map keyed by slot
+	sort.Slice(users, func(a, b int) bool { return users[a].ID < users[b].ID })
+
+	return users, nil
+}
+
+// CreateUser creates a user account in the first free slot of the iDRAC, slot 1 is reserved,
+// CreateUser implements the UserManager interface.
+func (i *IDrac9) CreateUser(user devices.User) (err error) {
+	if user.Name == "" || user.Password == "" {
+		return errors.ErrUserParamsRequired
+	}
+
+	err = devices.ValidatePassword(user.Password, i.passwordPolicy)
+	if err != nil {
+		return err
+	}
+
+	privileges, ok := userPrivileges[user.Role]
+	if !ok {
+		return fmt.Errorf("%w: %s", errors.ErrInvalidUserRole, user.Role)
+	}
+
+	users, err := i.users()
+	if err != nil {
+		return err
+	}
+
+	var userID int
+	for id, usr := range users {
+		if usr.UserName == user.Name {
+			return fmt.Errorf("%w: %s", errors.ErrUserAccountExists, user.Name)
+		}
+		if id > 1 && usr.UserName == "" && (userID == 0 || id < userID) {
+			userID = id
+		}
+	}
+
+	if userID == 0 {
+		return errors.ErrNoUserSlotsAvailable
+	}
+
+	privileges.UserName = user.Name
+	privileges.Password = user.Password
+	privileges.Enable = "Enabled"
+	privileges.SolEnable = "Enabled"
+
+	err = i.putUser(userID, privileges)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errors.ErrUserAccountUpdate, err)
+	}
+
+	return nil
+}
+
+// ModifyUser sets the role of an existing user account, its password is changed as well
+// when user.Password is set and kept otherwise, ModifyUser implements the UserManager interface.
+func (i *IDrac9) ModifyUser(user devices.User) (err error) {
+	if user.Name == "" {
+		return errors.ErrUserParamsRequired
+	}
+
+	if user.Password != "" {
+		err = devices.ValidatePassword(user.Password, i.passwordPolicy)
+		if err != nil {
+			return err
+		}
+	}
+
+	privileges, ok := userPrivileges[user.Role]
+	if !ok {
+		return fmt.Errorf("%w: %s", errors.ErrInvalidUserRole, user.Role)
+	}
+
+	userID, err := i.findUser(user.Name)
+	if err != nil {
+		return err
+	}
+
+	privileges.Password = user.Password
+
+	err = i.putUser(userID, privileges)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errors.ErrUserAccountUpdate, err)
+	}
+
+	return nil
+}
+
+// DeleteUser removes a user account from the iDRAC,
+// DeleteUser implements the UserManager interface.
+func (i *IDrac9) DeleteUser(name string) (err error) {
+	userID, err := i.findUser(name)
+	if err != nil {
+		return err
+	}
+
+	statusCode, response, err := i.delete(fmt.Sprintf("sysmgmt/2017/server/user?userid=%d", userID))
+	if err != nil {
+		return fmt.Errorf("%w: %s", errors.ErrUserAccountUpdate, err)
+	}
+
+	if statusCode < 200 || statusCode > 299 {
+		return fmt.Errorf("%w: %s", errors.ErrUserAccountUpdate, string(response))
+	}
+
+	return nil
+}
+
+// ChangePassword sets the password of a user account, keeping its role,
+// ChangePassword implements the UserManager interface.
+func (i *IDrac9) ChangePassword(name string, password string) (err error) {
+	if name == "" || password == "" {
+		return errors.ErrUserParamsRequired
+	}
+
+	err = devices.ValidatePassword(password, i.passwordPolicy)
+	if err != nil {
+		return err
+	}
+
+	userID, err := i.findUser(name)
+	if err != nil {
+		return err
+	}
+
+	err = i.putUser(userID, UserInfo{Password: password})
+	if err != nil {
+		return fmt.Errorf("%w: %s", errors.ErrUserAccountUpdate, err)
+	}
+
+	return nil
+}
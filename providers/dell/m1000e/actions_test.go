@@ -125,7 +125,7 @@ func setupBMC() (func(), *M1000e, error) {
 	}
 
 	testLogger := logrus.New()
-	bmc, err := New(context.TODO(), address, sshUsername, sshPassword, logrusr.New(testLogger))
+	bmc, err := NewWithOptions(context.TODO(), address, sshUsername, sshPassword, logrusr.New(testLogger), WithInsecureTLS())
 	if err != nil {
 		tearDown()
 		return nil, nil, err
@@ -5,12 +5,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"strings"
 
 	"github.com/bmc-toolbox/bmclib/cfgresources"
 	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/internal/httpclient"
 	"github.com/google/go-querystring/query"
 )
 
@@ -84,6 +84,8 @@ func (m *M1000e) User(cfgUsers []*cfgresources.User) (err error) {
 	}
 
 	for id, cfgUser := range cfgUsers {
+		m.log.V(2).Info("applying user resource", "step", "apply-user-cfg", "ip", m.ip, "user", cfgUser.Redacted())
+
 		userID := id + 1
 		userParams := m.newUserCfg(cfgUser, userID)
 		userParams.SessionToken = m.SessionToken
@@ -334,7 +336,7 @@ func (m *M1000e) CurrentHTTPSCert() (c []*x509.Certificate, b bool, e error) {
 //	req, err := http.NewRequest("POST", url, body)
 //	req.Header.Set("Content-Type", writer.FormDataContentType())
 //	if log.GetLevel() == log.TraceLevel {
-//		dump, err := httputil.DumpRequestOut(req, true)
+//		dump, err := httpclient.DumpRequestOut(req, true)
 //		if err == nil {
 //			log.Println(fmt.Sprintf("[Request] https://%s/cgi-bin/webcgi/%s", m.ip, endpoint))
 //			log.Println(">>>>>>>>>>>>>>>")
@@ -349,7 +351,7 @@ func (m *M1000e) CurrentHTTPSCert() (c []*x509.Certificate, b bool, e error) {
 //	}
 //	defer resp.Body.Close()
 //	if log.GetLevel() == log.TraceLevel {
-//		dump, err := httputil.DumpResponse(resp, true)
+//		dump, err := httpclient.DumpResponse(resp, true)
 //		if err == nil {
 //			log.Println("[Response]")
 //			log.Println("<<<<<<<<<<<<<<")
@@ -384,7 +386,7 @@ func (m *M1000e) post(endpoint string, form *url.Values) (err error) {
 	}
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
 	m.log.V(2).Info("requestTrace", "requestDump", string(reqDump), "url", fmt.Sprintf("https://%s/cgi-bin/webcgi/%s", m.ip, endpoint))
 
 	resp, err := m.httpClient.Do(req)
@@ -392,7 +394,7 @@ func (m *M1000e) post(endpoint string, form *url.Values) (err error) {
 		return err
 	}
 	defer resp.Body.Close()
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	m.log.V(2).Info("responseTrace", "responseDump", string(respDump))
 
 	_, err = ioutil.ReadAll(resp.Body)
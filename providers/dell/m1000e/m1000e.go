@@ -11,11 +11,13 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bmc-toolbox/bmclib/devices"
 	"github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bmc-toolbox/bmclib/internal/httpclient"
 	"github.com/bmc-toolbox/bmclib/internal/sshclient"
+	"github.com/bmc-toolbox/bmclib/providers"
 	"github.com/bmc-toolbox/bmclib/providers/dell"
 	"github.com/go-logr/logr"
 )
@@ -57,6 +59,109 @@ func WithSecureTLS(rootCAs *x509.CertPool) M1000eOption {
 	}
 }
 
+// WithInsecureTLS skips the verification of the BMC certificate, which is otherwise
+// verified against the system CAs. Use it for BMCs serving self-signed certificates.
+func WithInsecureTLS() M1000eOption {
+	return func(m *M1000e) {
+		m.httpClientSetupFuncs = append(m.httpClientSetupFuncs, httpclient.WithInsecureTLS())
+	}
+}
+
+// WithSecureTLSFromFile enforces trusted TLS connections using the CA certificates of a PEM file.
+// Requests fail with a loading error when the file is missing or holds no valid certificate.
+func WithSecureTLSFromFile(caCertFile string) M1000eOption {
+	return func(m *M1000e) {
+		m.httpClientSetupFuncs = append(m.httpClientSetupFuncs, httpclient.WithCACertFile(caCertFile))
+	}
+}
+
+// WithClientCert authenticates to the BMC with the given PEM encoded client certificate and key.
+func WithClientCert(certPEM, keyPEM []byte) M1000eOption {
+	return func(m *M1000e) {
+		m.httpClientSetupFuncs = append(m.httpClientSetupFuncs, httpclient.WithClientCert(certPEM, keyPEM))
+	}
+}
+
+// WithObserver calls observer after each HTTP round-trip made to the BMC,
+// to collect metrics or logs.
+func WithObserver(observer func(providers.RequestInfo)) M1000eOption {
+	return func(m *M1000e) {
+		m.httpClientSetupFuncs = append(m.httpClientSetupFuncs, httpclient.WithObserver(observer))
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the HTTP requests made to the BMC,
+// it defaults to bmclib/<version>.
+func WithUserAgent(ua string) M1000eOption {
+	return func(m *M1000e) {
+		m.httpClientSetupFuncs = append(m.httpClientSetupFuncs, httpclient.WithUserAgent(ua))
+	}
+}
+
+// WithForceHTTP1 restricts the HTTP requests made to the BMC to HTTP/1.1,
+// for BMC web servers returning garbled responses over HTTP/2.
+func WithForceHTTP1() M1000eOption {
+	return func(m *M1000e) {
+		m.httpClientSetupFuncs = append(m.httpClientSetupFuncs, httpclient.WithForceHTTP1())
+	}
+}
+
+// WithMaxResponseBytes bounds the size of the responses read from the BMC,
+// it defaults to httpclient.DefaultMaxResponseBytes.
+func WithMaxResponseBytes(n int64) M1000eOption {
+	return func(m *M1000e) {
+		m.httpClientSetupFuncs = append(m.httpClientSetupFuncs, httpclient.WithMaxResponseBytes(n))
+	}
+}
+
+// WithDigestAuth answers the HTTP Digest authentication challenges of the BMC
+// with the credentials of the M1000e, for firmware requiring digest auth.
+func WithDigestAuth() M1000eOption {
+	return func(m *M1000e) {
+		m.httpClientSetupFuncs = append(m.httpClientSetupFuncs, httpclient.WithDigestAuth(m.username, m.password))
+	}
+}
+
+// WithRequestLogging logs the method, host, path, status and duration of each HTTP request
+// made to the BMC at V(1) with the logger of the M1000e, lighter than the dumps logged at V(2).
+func WithRequestLogging() M1000eOption {
+	return func(m *M1000e) {
+		m.httpClientSetupFuncs = append(m.httpClientSetupFuncs, httpclient.WithRequestLogging(m.log))
+	}
+}
+
+// WithTimeout sets the overall timeout of the HTTP requests made to the BMC,
+// it defaults to httpclient.DefaultTimeout.
+func WithTimeout(d time.Duration) M1000eOption {
+	return func(m *M1000e) {
+		m.httpClientSetupFuncs = append(m.httpClientSetupFuncs, httpclient.WithTimeout(d))
+	}
+}
+
+// WithProxy routes the HTTP requests made to the BMC through the given proxy,
+// by default the proxy settings of the environment are honored.
+func WithProxy(proxyURL string) M1000eOption {
+	return func(m *M1000e) {
+		m.httpClientSetupFuncs = append(m.httpClientSetupFuncs, httpclient.WithProxy(proxyURL))
+	}
+}
+
+// WithRetry retries the idempotent HTTP requests made to the BMC on transient failures,
+// making up to attempts attempts with an exponential backoff.
+func WithRetry(attempts int, backoff time.Duration) M1000eOption {
+	return func(m *M1000e) {
+		m.httpClientSetupFuncs = append(m.httpClientSetupFuncs, httpclient.WithRetry(attempts, backoff))
+	}
+}
+
+// WithRateLimit paces the HTTP requests made to the BMC to requestsPerSecond,
+// allowing bursts of up to burst requests.
+func WithRateLimit(requestsPerSecond float64, burst int) M1000eOption {
+	return func(m *M1000e) {
+		m.httpClientSetupFuncs = append(m.httpClientSetupFuncs, httpclient.WithRateLimit(requestsPerSecond, burst))
+	}
+}
+
 // Returns a connection to an M1000e.
 func New(ctx context.Context, host string, username string, password string, log logr.Logger) (*M1000e, error) {
 	return NewWithOptions(ctx, host, username, password, log)
@@ -101,7 +206,7 @@ func (m *M1000e) get(endpoint string) (payload []byte, err error) {
 	}
 
 	if resp.StatusCode == 404 {
-		return payload, errors.ErrPageNotFound
+		return payload, errors.NewHTTPErrorFromResponse(resp, payload)
 	}
 
 	// Dell has a really shitty consistency of the data type returned, here we fix what's possible
@@ -146,7 +251,7 @@ func (m *M1000e) Serial() (serial string, err error) {
 		return serial, err
 	}
 
-	return strings.ToLower(m.cmcJSON.Chassis.ChassisGroupMemberHealthBlob.ChassisStatus.ROChassisServiceTag), nil
+	return devices.NormalizeSerial(m.cmcJSON.Chassis.ChassisGroupMemberHealthBlob.ChassisStatus.ROChassisServiceTag), nil
 }
 
 // PowerKw returns the current power usage in Kw
@@ -213,7 +318,9 @@ func (m *M1000e) Fans() (fans []*devices.Fan, err error) {
 
 			f := &devices.Fan{
 				Serial:     fmt.Sprintf("%d_%s", p, serial),
+				Name:       fmt.Sprintf("Fan %s", pos),
 				Position:   p,
+				Present:    true,
 				Status:     status,
 				CurrentRPM: fan.FanRPM,
 			}
@@ -248,6 +355,24 @@ func (m *M1000e) Status() (status string, err error) {
 	return status, err
 }
 
+// Health returns the normalized health from the bmc,
+// anything but OK is either a critical alert or a CMC error.
+func (m *M1000e) Health() (health devices.Health, err error) {
+	status, err := m.Status()
+	if err != nil {
+		return devices.HealthUnknown, err
+	}
+
+	switch status {
+	case "OK":
+		return devices.HealthOK, err
+	case "":
+		return devices.HealthUnknown, err
+	default:
+		return devices.HealthCritical, err
+	}
+}
+
 // Version returns the current firmware version of the bmc
 func (m *M1000e) Version() (version string, err error) {
 	err = m.httpLogin()
@@ -368,7 +493,7 @@ func (m *M1000e) StorageBlades() (storageBlades []*devices.StorageBlade, err err
 		if dellBlade.BladePresent == 1 && dellBlade.IsStorageBlade == 1 {
 			storageBlade := devices.StorageBlade{}
 			storageBlade.BladePosition = dellBlade.BladeMasterSlot
-			storageBlade.Serial = strings.ToLower(dellBlade.BladeSvcTag)
+			storageBlade.Serial = devices.NormalizeSerial(dellBlade.BladeSvcTag)
 			storageBlade.Model = dellBlade.BladeModel
 			storageBlade.PowerKw = float64(dellBlade.ActualPwrConsump) / 1000
 			temp, err := strconv.Atoi(dellBlade.BladeTemperature)
@@ -405,7 +530,7 @@ func (m *M1000e) Blades() (blades []*devices.Blade, err error) {
 		if dellBlade.BladePresent == 1 && dellBlade.IsStorageBlade == 0 {
 			blade := devices.Blade{}
 			blade.BladePosition = dellBlade.BladeMasterSlot
-			blade.Serial = strings.ToLower(dellBlade.BladeSvcTag)
+			blade.Serial = devices.NormalizeSerial(dellBlade.BladeSvcTag)
 			blade.Model = dellBlade.BladeModel
 			if dellBlade.BladePowerState == 1 {
 				blade.PowerState = "on"
@@ -503,7 +628,7 @@ func (m *M1000e) Vendor() (vendor string) {
 
 // ChassisSnapshot do best effort to populate the server data and returns a blade or discrete
 func (m *M1000e) ChassisSnapshot() (chassis *devices.Chassis, err error) {
-	chassis = &devices.Chassis{}
+	chassis = &devices.Chassis{CollectedAt: time.Now().UTC()}
 	chassis.Vendor = m.Vendor()
 	chassis.BmcAddress = m.ip
 	chassis.Name, err = m.Name()
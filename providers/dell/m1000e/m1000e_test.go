@@ -1710,7 +1710,7 @@ func setup() (r *M1000e, err error) {
 	}
 
 	testLogger := logrus.New()
-	r, err = New(context.TODO(), ip, username, password, logrusr.New(testLogger))
+	r, err = NewWithOptions(context.TODO(), ip, username, password, logrusr.New(testLogger), WithInsecureTLS())
 	if err != nil {
 		return r, err
 	}
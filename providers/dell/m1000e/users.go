@@ -0,0 +1,35 @@
+package m1000e
+
+import (
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the UserManager interface.
+var _ devices.UserManager = (*M1000e)(nil)
+
+// CreateUser isn't supported on M1000e yet
+func (m *M1000e) CreateUser(user devices.User) error {
+	return errors.NewFeatureUnsupportedError("user management", m.Vendor(), m.HardwareType())
+}
+
+// ModifyUser isn't supported on M1000e yet
+func (m *M1000e) ModifyUser(user devices.User) error {
+	return errors.NewFeatureUnsupportedError("user management", m.Vendor(), m.HardwareType())
+}
+
+// DeleteUser isn't supported on M1000e yet
+func (m *M1000e) DeleteUser(name string) error {
+	return errors.NewFeatureUnsupportedError("user management", m.Vendor(), m.HardwareType())
+}
+
+// ListUsers isn't supported on M1000e yet
+func (m *M1000e) ListUsers() ([]devices.User, error) {
+	return nil, errors.NewFeatureUnsupportedError("user management", m.Vendor(), m.HardwareType())
+}
+
+// ChangePassword isn't supported on M1000e yet
+func (m *M1000e) ChangePassword(name string, password string) error {
+	return errors.NewFeatureUnsupportedError("user management", m.Vendor(), m.HardwareType())
+}
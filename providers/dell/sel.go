@@ -0,0 +1,61 @@
+package dell
+
+import (
+	"bufio"
+	"strings"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+// racadmSelTimeLayout is the layout of the Date/Time field of racadm getsel
+const racadmSelTimeLayout = "01/02/2006 15:04:05"
+
+// ParseRacadmSel parses the output of racadm getsel, made of "Key: value" records
+// separated by dashed lines, into event log entries.
+func ParseRacadmSel(output string) (entries []devices.EventLogEntry) {
+	var entry *devices.EventLogEntry
+
+	flush := func() {
+		if entry != nil && entry.ID != "" {
+			entries = append(entries, *entry)
+		}
+		entry = nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "---") {
+			flush()
+			continue
+		}
+
+		kv := strings.SplitN(line, ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		if entry == nil {
+			entry = &devices.EventLogEntry{Severity: devices.SeverityUnknown}
+		}
+
+		value := strings.TrimSpace(kv[1])
+		switch strings.TrimSpace(kv[0]) {
+		case "Record":
+			entry.ID = value
+		case "Date/Time":
+			if timestamp, err := time.Parse(racadmSelTimeLayout, value); err == nil {
+				entry.Timestamp = timestamp.UTC()
+			}
+		case "Source":
+			entry.Sensor = value
+		case "Severity":
+			entry.Severity = devices.NormalizeSeverity(value)
+		case "Description":
+			entry.Message = value
+		}
+	}
+	flush()
+
+	return entries
+}
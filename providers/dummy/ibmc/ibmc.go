@@ -150,6 +150,11 @@ func (i *Ibmc) Status() (string, error) {
 	return "", nil
 }
 
+// Health implements the Bmc interface
+func (i *Ibmc) Health() (devices.Health, error) {
+	return devices.HealthUnknown, nil
+}
+
 // TempC implements the Bmc interface
 func (i *Ibmc) TempC() (int, error) {
 	return 0, nil
@@ -1,16 +1,24 @@
 package c7000
 
 import (
+	"encoding/xml"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/bmc-toolbox/bmclib/devices"
 	"github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bmc-toolbox/bmclib/internal"
 )
 
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the PowerController interface.
+var _ devices.PowerController = (*C7000)(nil)
+
 // PowerCycle reboots the chassis
-func (c *C7000) PowerCycle() (bool, error) {
+func (c *C7000) PowerCycle() (status bool, err error) {
+	defer c.wrapError("PowerCycle", &err)
+
 	output, err := c.sshClient.Run("RESTART OA ACTIVE")
 	if err != nil {
 		return false, fmt.Errorf("output: %q: %w", output, err)
@@ -24,17 +32,46 @@ func (c *C7000) PowerCycle() (bool, error) {
 }
 
 // PowerOn power on the chassis
-func (c *C7000) PowerOn() (bool, error) {
-	return false, errors.ErrFeatureUnavailable
+func (c *C7000) PowerOn() (status bool, err error) {
+	defer c.wrapError("PowerOn", &err)
+
+	return false, errors.NewFeatureUnsupportedError("power on", c.Vendor(), c.HardwareType())
 }
 
 // PowerOff power off the chassis
-func (c *C7000) PowerOff() (bool, error) {
-	return false, errors.ErrFeatureUnavailable
+func (c *C7000) PowerOff() (status bool, err error) {
+	defer c.wrapError("PowerOff", &err)
+
+	return false, errors.NewFeatureUnsupportedError("power off", c.Vendor(), c.HardwareType())
+}
+
+// PowerReset resets the chassis, the OA can only be restarted which PowerCycle does
+func (c *C7000) PowerReset() (status bool, err error) {
+	defer c.wrapError("PowerReset", &err)
+
+	return false, errors.NewFeatureUnsupportedError("power reset", c.Vendor(), c.HardwareType())
+}
+
+// PowerState returns the current power state of the chassis, "on" while the OA answers
+func (c *C7000) PowerState() (state string, err error) {
+	defer c.wrapError("PowerState", &err)
+
+	on, err := c.IsOn()
+	if err != nil {
+		return "", err
+	}
+
+	if on {
+		return "on", nil
+	}
+
+	return "off", nil
 }
 
 // IsOn tells if a machine is currently powered on
-func (c *C7000) IsOn() (bool, error) {
+func (c *C7000) IsOn() (status bool, err error) {
+	defer c.wrapError("IsOn", &err)
+
 	if c.sshClient != nil { // TODO: run "help"?
 		return true, nil
 	}
@@ -42,7 +79,9 @@ func (c *C7000) IsOn() (bool, error) {
 }
 
 // FindBladePosition receives a serial and find the position of the blade using it
-func (c *C7000) FindBladePosition(serial string) (int, error) {
+func (c *C7000) FindBladePosition(serial string) (position int, err error) {
+	defer c.wrapError("FindBladePosition", &err)
+
 	output, err := c.sshClient.Run("SHOW SERVER NAMES")
 	if err != nil {
 		return -1, err
@@ -62,7 +101,9 @@ func (c *C7000) FindBladePosition(serial string) (int, error) {
 }
 
 // PowerCycleBlade reboots the machine via bmc
-func (c *C7000) PowerCycleBlade(position int) (bool, error) {
+func (c *C7000) PowerCycleBlade(position int) (status bool, err error) {
+	defer c.wrapError("PowerCycleBlade", &err)
+
 	output, err := c.sshClient.Run(fmt.Sprintf("REBOOT SERVER %d FORCE", position))
 	if err != nil {
 		return false, fmt.Errorf("output: %q: %w", output, err)
@@ -80,7 +121,9 @@ func (c *C7000) PowerCycleBlade(position int) (bool, error) {
 }
 
 // ReseatBlade reboots the machine via bmc
-func (c *C7000) ReseatBlade(position int) (bool, error) {
+func (c *C7000) ReseatBlade(position int) (status bool, err error) {
+	defer c.wrapError("ReseatBlade", &err)
+
 	output, err := c.sshClient.Run(fmt.Sprintf("RESET SERVER %d", position))
 	if err != nil {
 		return false, fmt.Errorf("output: %q: %w", output, err)
@@ -94,7 +137,9 @@ func (c *C7000) ReseatBlade(position int) (bool, error) {
 }
 
 // PowerOnBlade power on the machine via bmc
-func (c *C7000) PowerOnBlade(position int) (bool, error) {
+func (c *C7000) PowerOnBlade(position int) (status bool, err error) {
+	defer c.wrapError("PowerOnBlade", &err)
+
 	output, err := c.sshClient.Run(fmt.Sprintf("POWERON SERVER %d", position))
 	if err != nil {
 		return false, fmt.Errorf("output: %q: %w", output, err)
@@ -108,7 +153,9 @@ func (c *C7000) PowerOnBlade(position int) (bool, error) {
 }
 
 // PowerOffBlade power off the machine via bmc
-func (c *C7000) PowerOffBlade(position int) (bool, error) {
+func (c *C7000) PowerOffBlade(position int) (status bool, err error) {
+	defer c.wrapError("PowerOffBlade", &err)
+
 	output, err := c.sshClient.Run(fmt.Sprintf("POWEROFF SERVER %d FORCE", position))
 	if err != nil {
 		return false, fmt.Errorf("output: %q: %w", output, err)
@@ -121,7 +168,9 @@ func (c *C7000) PowerOffBlade(position int) (bool, error) {
 }
 
 // IsOnBlade tells if a machine is currently powered on
-func (c *C7000) IsOnBlade(position int) (bool, error) {
+func (c *C7000) IsOnBlade(position int) (status bool, err error) {
+	defer c.wrapError("IsOnBlade", &err)
+
 	output, err := c.sshClient.Run(fmt.Sprintf("SHOW SERVER STATUS %d", position))
 	if err != nil {
 		return false, fmt.Errorf("output: %q: %w", output, err)
@@ -134,8 +183,110 @@ func (c *C7000) IsOnBlade(position int) (bool, error) {
 	return false, fmt.Errorf(output)
 }
 
+// IsBladeOn tells if the blade in the given bay is currently powered on,
+// the state is read from the OA blade status instead of the ssh console used by IsOnBlade.
+func (c *C7000) IsBladeOn(bay int) (isOn bool, err error) {
+	defer c.wrapError("IsBladeOn", &err)
+
+	statusCode, body, err := c.postXML(getBladeStatus{BayNumber: bay})
+	if err != nil {
+		return false, err
+	}
+
+	if statusCode != 200 {
+		return false, fmt.Errorf("getBladeStatus: %w", c.xmlError(statusCode, body))
+	}
+
+	var bladeStatus EnvelopeBladeStatus
+	err = xml.Unmarshal(body, &bladeStatus)
+	if err != nil {
+		return false, err
+	}
+
+	status := bladeStatus.Body.GetBladeStatusResponse.BladeStatus
+	if status.Presence != "PRESENT" {
+		return false, fmt.Errorf("bay %d: %w", bay, errors.ErrBayEmpty)
+	}
+
+	switch status.Powered {
+	case "POWER_ON", "POWER_REBOOT":
+		return true, nil
+	case "POWER_OFF", "POWER_STAGED":
+		return false, nil
+	default:
+		return false, fmt.Errorf("bay %d: unknown power state %q: %w", bay, status.Powered, errors.ErrPowerStatusRead)
+	}
+}
+
+// GetEnclosureUID tells if the enclosure UID LED is on, a blinking LED is reported as on.
+func (c *C7000) GetEnclosureUID() (status bool, err error) {
+	defer c.wrapError("GetEnclosureUID", &err)
+
+	statusCode, body, err := c.postXML(getEnclosureStatus{})
+	if err != nil {
+		return false, err
+	}
+
+	if statusCode != 200 {
+		return false, fmt.Errorf("getEnclosureStatus: %w", c.xmlError(statusCode, body))
+	}
+
+	var enclosureStatus EnvelopeEnclosureStatus
+	err = xml.Unmarshal(body, &enclosureStatus)
+	if err != nil {
+		return false, err
+	}
+
+	switch uid := enclosureStatus.Body.GetEnclosureStatusResponse.EnclosureStatus.UID; uid {
+	case "UID_ON", "UID_BLINK":
+		return true, nil
+	case "UID_OFF":
+		return false, nil
+	default:
+		return false, fmt.Errorf("unknown enclosure UID state %q: %w", uid, errors.ErrUnableToReadData)
+	}
+}
+
+// SetEnclosureUID switches the enclosure UID LED on or off,
+// the state is read back from the OA to confirm the change was applied.
+func (c *C7000) SetEnclosureUID(on bool) (err error) {
+	defer c.wrapError("SetEnclosureUID", &err)
+
+	uid := "UID_CMD_OFF"
+	if on {
+		uid = "UID_CMD_ON"
+	}
+
+	statusCode, body, err := c.postXML(setEnclosureUID{UID: uid})
+	if err != nil {
+		return err
+	}
+
+	if statusCode != 200 {
+		var fault EnvelopeFault
+		if xml.Unmarshal(body, &fault) == nil && fault.Body.Fault.Reason.Text != "" {
+			return fmt.Errorf("setEnclosureUid rejected: %s: %w", fault.Body.Fault.Reason.Text, errors.ErrUIDStateSet)
+		}
+
+		return fmt.Errorf("setEnclosureUid returned status code %d: %w", statusCode, errors.ErrUIDStateSet)
+	}
+
+	state, err := c.GetEnclosureUID()
+	if err != nil {
+		return err
+	}
+
+	if state != on {
+		return fmt.Errorf("enclosure UID LED state was not changed: %w", errors.ErrUIDStateSet)
+	}
+
+	return nil
+}
+
 // PowerCycleBmcBlade reboots the bmc we are connected to
-func (c *C7000) PowerCycleBmcBlade(position int) (bool, error) {
+func (c *C7000) PowerCycleBmcBlade(position int) (status bool, err error) {
+	defer c.wrapError("PowerCycleBmcBlade", &err)
+
 	output, err := c.sshClient.Run(fmt.Sprintf("RESET ILO %d", position))
 	if err != nil {
 		return false, fmt.Errorf("output: %q: %w", output, err)
@@ -149,8 +300,10 @@ func (c *C7000) PowerCycleBmcBlade(position int) (bool, error) {
 }
 
 // PxeOnceBlade makes the machine to boot via pxe once
-func (c *C7000) PxeOnceBlade(position int) (bool, error) {
-	status, err := c.PowerCycleBlade(position)
+func (c *C7000) PxeOnceBlade(position int) (status bool, err error) {
+	defer c.wrapError("PxeOnceBlade", &err)
+
+	status, err = c.PowerCycleBlade(position)
 	if err != nil {
 		return status, err
 	}
@@ -168,7 +321,9 @@ func (c *C7000) PxeOnceBlade(position int) (bool, error) {
 }
 
 // Configures dynamic power behavior.
-func (c *C7000) SetDynamicPower(enable bool) (bool, error) {
+func (c *C7000) SetDynamicPower(enable bool) (status bool, err error) {
+	defer c.wrapError("SetDynamicPower", &err)
+
 	var state string
 	if enable {
 		state = "ON"
@@ -189,28 +344,63 @@ func (c *C7000) SetDynamicPower(enable bool) (bool, error) {
 	return false, fmt.Errorf(output)
 }
 
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the FirmwareUpdater interface.
+var _ devices.FirmwareUpdater = (*C7000)(nil)
+
 // UpdateFirmware updates the chassis firmware
-func (c *C7000) UpdateFirmware(source, file string) (bool, string, error) {
+func (c *C7000) UpdateFirmware(source, file string) (status bool, output string, err error) {
+	defer c.wrapError("UpdateFirmware", &err)
+
+	return c.UpdateFirmwareWithProgress(source, file, nil)
+}
+
+// UpdateFirmwareWithProgress updates the chassis firmware, reporting to the optional progress callback.
+// The update image command blocks until the OA flashed the image and can't be polled, so the C7000
+// can't report an intermediate progress: the start of the download is reported when the command is
+// sent and the completed flash once the output confirms it, the phases in between are not reported.
+func (c *C7000) UpdateFirmwareWithProgress(source, file string, progress func(devices.FirmwareProgress)) (status bool, output string, err error) {
+	defer c.wrapError("UpdateFirmwareWithProgress", &err)
+
 	cmd := fmt.Sprintf("update image %s/%s", source, file)
-	output, err := c.sshClient.Run(cmd)
+	devices.ReportFirmwareProgress(progress, errors.FirmwareUpdatePhaseUpload, 0, fmt.Sprintf("downloading %s/%s", source, file))
+
+	output, err = c.sshClient.Run(cmd)
 	if err != nil {
-		return false, "", fmt.Errorf("output: %q: %w", output, err)
+		// the OA drops the session when it restarts after flashing, losing it before means the image wasn't written
+		phase := errors.FirmwareUpdatePhaseUpload
+		if strings.Contains(output, "Flashing Active Onboard Administrator") {
+			phase = errors.FirmwareUpdatePhaseFlash
+		}
+
+		return false, "", errors.NewFirmwareUpdateError(phase, fmt.Errorf("output: %q: %w", output, err))
 	}
 
 	if strings.Contains(output, "Flashing Active Onboard Administrator") {
+		devices.ReportFirmwareProgress(progress, errors.FirmwareUpdatePhaseFlash, 100, "active Onboard Administrator flashed")
 		return true, output, nil
 	}
 
-	return false, output, fmt.Errorf(output)
+	// the OA rejected the image, either failing to download it or to validate it
+	phase := errors.FirmwareUpdatePhaseVerify
+	if strings.Contains(strings.ToLower(output), "download") {
+		phase = errors.FirmwareUpdatePhaseUpload
+	}
+
+	return false, output, errors.NewFirmwareUpdateError(phase, fmt.Errorf("%s", strings.TrimSpace(output)))
 }
 
 func (c *C7000) CheckFirmwareVersion() (version string, err error) {
+	defer c.wrapError("CheckFirmwareVersion", &err)
+
 	return "", fmt.Errorf("not yet implemented")
 }
 
 // ModBladeBmcUser modfies BMC Admin user account password through the chassis,
 // this method will attempt to modify a user account on all BMCs in a chassis.
-func (c *C7000) ModBladeBmcUser(username string, password string) error {
+func (c *C7000) ModBladeBmcUser(username string, password string) (err error) {
+	defer c.wrapError("ModBladeBmcUser", &err)
+
 	ribcl := `HPONCFG all  << end_marker
 <RIBCL VERSION="2.0">
 <LOGIN USER_LOGIN="__USERNAME__" PASSWORD="__PASSWORD__">
@@ -243,7 +433,9 @@ end_marker`
 
 // AddBladeBmcAdmin configures BMC Admin user accounts through the chassis.
 // this method will attempt to add the user to all BMCs in a chassis.
-func (c *C7000) AddBladeBmcAdmin(username string, password string) error {
+func (c *C7000) AddBladeBmcAdmin(username string, password string) (err error) {
+	defer c.wrapError("AddBladeBmcAdmin", &err)
+
 	ribcl := `HPONCFG all  << end_marker
 <RIBCL VERSION="2.0">
 <LOGIN USER_LOGIN="__USERNAME__" PASSWORD="__PASSWORD__">
@@ -282,7 +474,9 @@ end_marker`
 }
 
 // RemoveBladeBmcUser removes the user account from all BMCs through the chassis.
-func (c *C7000) RemoveBladeBmcUser(username string) error {
+func (c *C7000) RemoveBladeBmcUser(username string) (err error) {
+	defer c.wrapError("RemoveBladeBmcUser", &err)
+
 	ribcl := `HPONCFG all  << end_marker
 <RIBCL VERSION="2.0">
 <LOGIN USER_LOGIN="__USERNAME__" PASSWORD="">
@@ -312,11 +506,15 @@ end_marker`
 
 // SetFlexAddressState Enable/Disable FlexAddress disables flex Addresses for blades
 // FlexAddress is a virtual addressing scheme
-func (c *C7000) SetFlexAddressState(_ int, _ bool) (bool, error) {
+func (c *C7000) SetFlexAddressState(_ int, _ bool) (status bool, err error) {
+	defer c.wrapError("SetFlexAddressState", &err)
+
 	return false, errors.ErrNotImplemented
 }
 
 // Enable/Disable the IpmiOverLan parameter per blade in the chassis.
-func (c *C7000) SetIpmiOverLan(_ int, _ bool) (bool, error) {
+func (c *C7000) SetIpmiOverLan(_ int, _ bool) (status bool, err error) {
+	defer c.wrapError("SetIpmiOverLan", &err)
+
 	return false, errors.ErrNotImplemented
 }
@@ -1,6 +1,17 @@
 package c7000
 
 import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+	"github.com/bmc-toolbox/bmclib/internal/conformance"
 	"github.com/bmc-toolbox/bmclib/internal/sshclient"
 	"github.com/bmc-toolbox/bmclib/sshmock"
 	"github.com/go-logr/logr"
@@ -19,6 +30,12 @@ const (
 var (
 	sshAnswers = map[string][]byte{
 		"RESTART OA ACTIVE": []byte(`Restarting Onboard Administrator in bay`),
+		"SHOW SYSLOG OA": []byte(`Syslog for Onboard Administrator in bay 1:
+			Jan  2 10:15:22 OA: Enclosure Status changed from OK to Degraded.
+			Jan  2 10:16:02 OA: Power supply 3 failed.
+			Jan  2 10:20:45 OA: User "Administrator" logged into the Onboard Administrator.
+		`),
+		"CLEAR SYSLOG OA": []byte(`Onboard Administrator syslog cleared.`),
 		"SHOW SERVER NAMES": []byte(`
 			Bay Server Name                                       Serial Number   Status   Power   UID Partner
 			--- ------------------------------------------------- --------------- -------- ------- --- -------
@@ -63,6 +80,10 @@ var (
         	`),
 		"RESET ILO 1":                []byte(`Bay 1: Successfully reset iLO through Hardware reset`),
 		"SET SERVER BOOT ONCE PXE 1": []byte(`Blade #1 boot order changed to PXE`),
+		"update image http://fw.example.com/hpoa480.bin": []byte(`Downloading firmware image...
+			Flashing Active Onboard Administrator`),
+		"update image http://fw.example.com/missing.bin": []byte(`Unable to download the firmware image.`),
+		"update image http://fw.example.com/corrupt.bin": []byte(`The firmware image is invalid.`),
 		"SET POWER SAVINGS OFF": []byte(`Power Settings were updated to:
 
 			Power Mode: Redundant
@@ -153,6 +174,12 @@ func Test_chassisBMC(t *testing.T) {
 			want:      false,
 			wantErr:   true,
 		},
+		{
+			name:      "PowerReset",
+			bmcMethod: bmc.PowerReset,
+			want:      false,
+			wantErr:   true,
+		},
 		{
 			name:      "IsOn",
 			bmcMethod: bmc.IsOn,
@@ -176,6 +203,25 @@ func Test_chassisBMC(t *testing.T) {
 	}
 }
 
+func Test_PowerState(t *testing.T) {
+	tearDown, bmc, err := setupBMC()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	want := "on"
+
+	got, err := bmc.PowerState()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.PowerState %v", err)
+	}
+
+	if got != want {
+		t.Errorf("Expected answer %v: found %v", want, got)
+	}
+}
+
 func Test_FindBladePosition(t *testing.T) {
 	tearDown, bmc, err := setupBMC()
 	if err != nil {
@@ -296,6 +342,87 @@ func Test_IsOnBlade(t *testing.T) {
 	}
 }
 
+func Test_IsBladeOn(t *testing.T) {
+	chassis, err := setupSOAP()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	answer, err := chassis.IsBladeOn(1)
+	if err != nil {
+		t.Fatalf("Found errors calling chassis.IsBladeOn %v", err)
+	}
+
+	if !answer {
+		t.Errorf("Expected blade in bay 1 to be powered on")
+	}
+
+	_, err = chassis.IsBladeOn(2)
+	if !errors.Is(err, bmclibErrs.ErrBayEmpty) {
+		t.Errorf("Expected error %v for an empty bay: found %v", bmclibErrs.ErrBayEmpty, err)
+	}
+}
+
+func Test_EnclosureUID(t *testing.T) {
+	uid := "UID_OFF"
+	chassis, err := setupHPOA(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := ioutil.ReadAll(r.Body)
+		switch {
+		case strings.Contains(string(payload), "<hpoa:setEnclosureUid>"):
+			if strings.Contains(string(payload), "UID_CMD_ON") {
+				uid = "UID_ON"
+			} else {
+				uid = "UID_OFF"
+			}
+			_, _ = w.Write([]byte(`<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://www.w3.org/2003/05/soap-envelope" xmlns:hpoa="hpoa.xsd"><SOAP-ENV:Body><hpoa:setEnclosureUidResponse/></SOAP-ENV:Body></SOAP-ENV:Envelope>`))
+		case strings.Contains(string(payload), "<hpoa:getEnclosureStatus>"):
+			_, _ = w.Write([]byte(`<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://www.w3.org/2003/05/soap-envelope" xmlns:hpoa="hpoa.xsd"><SOAP-ENV:Body><hpoa:getEnclosureStatusResponse><hpoa:enclosureStatus><hpoa:operationalStatus>OP_STATUS_OK</hpoa:operationalStatus><hpoa:uid>` + uid + `</hpoa:uid></hpoa:enclosureStatus></hpoa:getEnclosureStatusResponse></SOAP-ENV:Body></SOAP-ENV:Envelope>`))
+		default:
+			_, _ = w.Write(answers["/hpoa"])
+		}
+	})
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	err = chassis.SetEnclosureUID(true)
+	if err != nil {
+		t.Fatalf("Found errors calling chassis.SetEnclosureUID %v", err)
+	}
+
+	answer, err := chassis.GetEnclosureUID()
+	if err != nil {
+		t.Fatalf("Found errors calling chassis.GetEnclosureUID %v", err)
+	}
+
+	if !answer {
+		t.Errorf("Expected the enclosure UID LED to be on")
+	}
+}
+
+func Test_EnclosureUIDRejected(t *testing.T) {
+	chassis, err := setupHPOA(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := ioutil.ReadAll(r.Body)
+		if strings.Contains(string(payload), "<hpoa:setEnclosureUid>") {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write(soapFault("SOAP-ENV:Sender", "The user does not have the required privileges."))
+			return
+		}
+		_, _ = w.Write(answers["/hpoa"])
+	})
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	err = chassis.SetEnclosureUID(true)
+	if !errors.Is(err, bmclibErrs.ErrUIDStateSet) {
+		t.Errorf("Expected error %v: found %v", bmclibErrs.ErrUIDStateSet, err)
+	}
+}
+
 func Test_PowerCycleBmcBlade(t *testing.T) {
 	tearDown, bmc, err := setupBMC()
 	if err != nil {
@@ -395,3 +522,132 @@ func Test_SetFlexAddressState(t *testing.T) {
 		t.Errorf("got = %v, want %v", got, want)
 	}
 }
+
+func Test_UpdateFirmware(t *testing.T) {
+	tearDown, bmc, err := setupBMC()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	tests := []struct {
+		file      string
+		want      bool
+		wantPhase bmclibErrs.FirmwareUpdatePhase
+	}{
+		{"hpoa480.bin", true, ""},
+		{"missing.bin", false, bmclibErrs.FirmwareUpdatePhaseUpload},
+		{"corrupt.bin", false, bmclibErrs.FirmwareUpdatePhaseVerify},
+	}
+
+	for _, tc := range tests {
+		got, _, err := bmc.UpdateFirmware("http://fw.example.com", tc.file)
+		if got != tc.want {
+			t.Errorf("%s: got = %v, want %v", tc.file, got, tc.want)
+		}
+
+		if tc.wantPhase == "" {
+			if err != nil {
+				t.Errorf("%s: error = %v, wantErr false", tc.file, err)
+			}
+			continue
+		}
+
+		var updateErr *bmclibErrs.FirmwareUpdateError
+		if !errors.As(err, &updateErr) {
+			t.Fatalf("%s: expected a FirmwareUpdateError, got %v", tc.file, err)
+		}
+
+		if updateErr.Phase != tc.wantPhase {
+			t.Errorf("%s: got phase = %v, want %v", tc.file, updateErr.Phase, tc.wantPhase)
+		}
+	}
+}
+
+func Test_UpdateFirmwareWithProgress(t *testing.T) {
+	tearDown, bmc, err := setupBMC()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	var phases []bmclibErrs.FirmwareUpdatePhase
+	progress := func(p devices.FirmwareProgress) {
+		phases = append(phases, p.Phase)
+	}
+
+	got, _, err := bmc.UpdateFirmwareWithProgress("http://fw.example.com", "hpoa480.bin", progress)
+	if err != nil || !got {
+		t.Fatalf("Found errors calling bmc.UpdateFirmwareWithProgress %v", err)
+	}
+
+	// only the start of the update and the flash confirmed by the output are observed
+	want := []bmclibErrs.FirmwareUpdatePhase{
+		bmclibErrs.FirmwareUpdatePhaseUpload,
+		bmclibErrs.FirmwareUpdatePhaseFlash,
+	}
+	if !reflect.DeepEqual(phases, want) {
+		t.Errorf("got phases = %v, want %v", phases, want)
+	}
+
+	// a nil callback is a no-op
+	got, _, err = bmc.UpdateFirmwareWithProgress("http://fw.example.com", "hpoa480.bin", nil)
+	if err != nil || !got {
+		t.Fatalf("Found errors calling bmc.UpdateFirmwareWithProgress %v", err)
+	}
+}
+
+func Test_PowerCap(t *testing.T) {
+	ceiling := "0"
+	var posted string
+	chassis, err := setupHPOA(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := ioutil.ReadAll(r.Body)
+		switch {
+		case strings.Contains(string(payload), "<hpoa:setPowerConfigInfo>"):
+			posted = string(payload)
+			ceiling = regexp.MustCompile(`<hpoa:powerCeiling>(\d+)</hpoa:powerCeiling>`).FindStringSubmatch(posted)[1]
+			_, _ = w.Write([]byte(`<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://www.w3.org/2003/05/soap-envelope" xmlns:hpoa="hpoa.xsd"><SOAP-ENV:Body><hpoa:setPowerConfigInfoResponse/></SOAP-ENV:Body></SOAP-ENV:Envelope>`))
+		case strings.Contains(string(payload), "<hpoa:getPowerConfigInfo>"):
+			_, _ = w.Write([]byte(`<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://www.w3.org/2003/05/soap-envelope" xmlns:hpoa="hpoa.xsd"><SOAP-ENV:Body><hpoa:getPowerConfigInfoResponse><hpoa:powerConfigInfo><hpoa:powerCeiling>` + ceiling + `</hpoa:powerCeiling><hpoa:redundancyMode>AC_REDUNDANT</hpoa:redundancyMode><hpoa:dynamicPowerSaverEnabled>true</hpoa:dynamicPowerSaverEnabled></hpoa:powerConfigInfo></hpoa:getPowerConfigInfoResponse></SOAP-ENV:Body></SOAP-ENV:Envelope>`))
+		default:
+			_, _ = w.Write(answers["/hpoa"])
+		}
+	})
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	conformance.PowerCapper(t, chassis, 9000)
+
+	// the other settings of the power configuration are kept
+	for _, want := range []string{"<hpoa:redundancyMode>AC_REDUNDANT</hpoa:redundancyMode>", "<hpoa:dynamicPowerSaverEnabled>true</hpoa:dynamicPowerSaverEnabled>"} {
+		if !strings.Contains(posted, want) {
+			t.Errorf("Expected %s in the payload: found %s", want, posted)
+		}
+	}
+}
+
+func Test_PowerCapRejected(t *testing.T) {
+	chassis, err := setupHPOA(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := ioutil.ReadAll(r.Body)
+		switch {
+		case strings.Contains(string(payload), "<hpoa:setPowerConfigInfo>"):
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write(soapFault("SOAP-ENV:Sender", "The power ceiling is below the minimum power required."))
+		case strings.Contains(string(payload), "<hpoa:getPowerConfigInfo>"):
+			_, _ = w.Write([]byte(`<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://www.w3.org/2003/05/soap-envelope" xmlns:hpoa="hpoa.xsd"><SOAP-ENV:Body><hpoa:getPowerConfigInfoResponse><hpoa:powerConfigInfo><hpoa:powerCeiling>0</hpoa:powerCeiling><hpoa:redundancyMode>AC_REDUNDANT</hpoa:redundancyMode><hpoa:dynamicPowerSaverEnabled>false</hpoa:dynamicPowerSaverEnabled></hpoa:powerConfigInfo></hpoa:getPowerConfigInfoResponse></SOAP-ENV:Body></SOAP-ENV:Envelope>`))
+		default:
+			_, _ = w.Write(answers["/hpoa"])
+		}
+	})
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	err = chassis.SetPowerCap(context.TODO(), devices.PowerCap{Enabled: true, Watts: 100})
+	if !errors.Is(err, bmclibErrs.ErrPowerCapSet) {
+		t.Errorf("Expected error %v: found %v", bmclibErrs.ErrPowerCapSet, err)
+	}
+}
@@ -0,0 +1,33 @@
+package c7000
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the BIOSConfigurator interface.
+var _ devices.BIOSConfigurator = (*C7000)(nil)
+
+// GetBIOSSettings isn't supported on the chassis, the blades are configured through their iLO
+func (c *C7000) GetBIOSSettings(ctx context.Context) (settings *devices.BIOSSettings, err error) {
+	defer c.wrapError("GetBIOSSettings", &err)
+
+	return nil, errors.NewFeatureUnsupportedError("BIOS settings", c.Vendor(), c.HardwareType())
+}
+
+// SetBIOSSettings isn't supported on the chassis, the blades are configured through their iLO
+func (c *C7000) SetBIOSSettings(ctx context.Context, settings *devices.BIOSSettings) (rebootRequired bool, err error) {
+	defer c.wrapError("SetBIOSSettings", &err)
+
+	return false, errors.NewFeatureUnsupportedError("BIOS settings", c.Vendor(), c.HardwareType())
+}
+
+// PendingBIOSSettings isn't supported on the chassis, the blades are configured through their iLO
+func (c *C7000) PendingBIOSSettings(ctx context.Context) (settings *devices.BIOSSettings, err error) {
+	defer c.wrapError("PendingBIOSSettings", &err)
+
+	return nil, errors.NewFeatureUnsupportedError("BIOS settings", c.Vendor(), c.HardwareType())
+}
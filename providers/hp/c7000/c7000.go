@@ -8,11 +8,13 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/bmc-toolbox/bmclib/devices"
 	"github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bmc-toolbox/bmclib/internal/httpclient"
 	"github.com/bmc-toolbox/bmclib/internal/sshclient"
+	"github.com/bmc-toolbox/bmclib/providers"
 	"github.com/bmc-toolbox/bmclib/providers/hp"
 	"github.com/go-logr/logr"
 )
@@ -27,8 +29,9 @@ type C7000 struct {
 	ip                   string
 	username             string
 	password             string
-	XMLToken             string // Required to send SOAP XML payloads.
-	httpClient           *http.Client
+	XMLToken             string       // Required to send SOAP XML payloads.
+	httpClient           *http.Client // set once logged in
+	baseClient           *http.Client // shared by every request made to the BMC
 	sshClient            *sshclient.SSHClient
 	Rimp                 *hp.Rimp
 	ctx                  context.Context
@@ -47,13 +50,122 @@ func WithSecureTLS(rootCAs *x509.CertPool) C7000Option {
 	}
 }
 
+// WithInsecureTLS skips the verification of the BMC certificate, which is otherwise
+// verified against the system CAs. Use it for BMCs serving self-signed certificates.
+func WithInsecureTLS() C7000Option {
+	return func(i *C7000) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithInsecureTLS())
+	}
+}
+
+// WithSecureTLSFromFile enforces trusted TLS connections using the CA certificates of a PEM file.
+// Requests fail with a loading error when the file is missing or holds no valid certificate.
+func WithSecureTLSFromFile(caCertFile string) C7000Option {
+	return func(i *C7000) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithCACertFile(caCertFile))
+	}
+}
+
+// WithClientCert authenticates to the BMC with the given PEM encoded client certificate and key.
+func WithClientCert(certPEM, keyPEM []byte) C7000Option {
+	return func(i *C7000) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithClientCert(certPEM, keyPEM))
+	}
+}
+
+// WithObserver calls observer after each HTTP round-trip made to the BMC,
+// to collect metrics or logs.
+func WithObserver(observer func(providers.RequestInfo)) C7000Option {
+	return func(i *C7000) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithObserver(observer))
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the HTTP requests made to the BMC,
+// it defaults to bmclib/<version>.
+func WithUserAgent(ua string) C7000Option {
+	return func(i *C7000) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithUserAgent(ua))
+	}
+}
+
+// WithForceHTTP1 restricts the HTTP requests made to the BMC to HTTP/1.1,
+// for BMC web servers returning garbled responses over HTTP/2.
+func WithForceHTTP1() C7000Option {
+	return func(i *C7000) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithForceHTTP1())
+	}
+}
+
+// WithMaxResponseBytes bounds the size of the responses read from the BMC,
+// it defaults to httpclient.DefaultMaxResponseBytes.
+func WithMaxResponseBytes(n int64) C7000Option {
+	return func(i *C7000) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithMaxResponseBytes(n))
+	}
+}
+
+// WithDigestAuth answers the HTTP Digest authentication challenges of the BMC
+// with the credentials of the C7000, for firmware requiring digest auth.
+func WithDigestAuth() C7000Option {
+	return func(i *C7000) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithDigestAuth(i.username, i.password))
+	}
+}
+
+// WithRequestLogging logs the method, host, path, status and duration of each HTTP request
+// made to the BMC at V(1) with the logger of the C7000, lighter than the dumps logged at V(2).
+func WithRequestLogging() C7000Option {
+	return func(i *C7000) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithRequestLogging(i.log))
+	}
+}
+
+// WithTimeout sets the overall timeout of the HTTP requests made to the BMC,
+// it defaults to httpclient.DefaultTimeout.
+func WithTimeout(d time.Duration) C7000Option {
+	return func(i *C7000) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithTimeout(d))
+	}
+}
+
+// WithProxy routes the HTTP requests made to the BMC through the given proxy,
+// by default the proxy settings of the environment are honored.
+func WithProxy(proxyURL string) C7000Option {
+	return func(i *C7000) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithProxy(proxyURL))
+	}
+}
+
+// WithRetry retries the idempotent HTTP requests made to the BMC on transient failures,
+// making up to attempts attempts with an exponential backoff.
+func WithRetry(attempts int, backoff time.Duration) C7000Option {
+	return func(i *C7000) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithRetry(attempts, backoff))
+	}
+}
+
+// WithRateLimit paces the HTTP requests made to the BMC to requestsPerSecond,
+// allowing bursts of up to burst requests.
+func WithRateLimit(requestsPerSecond float64, burst int) C7000Option {
+	return func(i *C7000) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithRateLimit(requestsPerSecond, burst))
+	}
+}
+
 // New returns a connection to C7000
 func New(ctx context.Context, host string, username string, password string, log logr.Logger) (*C7000, error) {
 	return NewWithOptions(ctx, host, username, password, log)
 }
 
-// NewWithOptions returns a new C7000 with options ready to be used
+// NewWithOptions returns a new C7000 with options ready to be used.
+// The given context is kept as the base context for the requests made by this C7000,
+// cancelling it aborts any in-flight call to the chassis.
 func NewWithOptions(ctx context.Context, host string, username string, password string, log logr.Logger, opts ...C7000Option) (*C7000, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	c := &C7000{ip: host, username: username, password: password, ctx: ctx, log: log}
 
 	// apply options early in case we need to set httpClientSetupFunc during setup
@@ -61,13 +173,18 @@ func NewWithOptions(ctx context.Context, host string, username string, password
 		opt(c)
 	}
 
-	client, err := httpclient.Build(c.httpClientSetupFuncs...)
+	client, err := c.client()
 	if err != nil {
 		return nil, err
 	}
 
 	url := fmt.Sprintf("https://%s/xmldata?item=all", host)
-	resp, err := client.Get(url)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -102,8 +219,26 @@ func NewWithOptions(ctx context.Context, host string, username string, password
 	return c, nil
 }
 
+// context returns the base context given to the constructor,
+// falling back to context.Background for a C7000 that wasn't built through it.
+func (c *C7000) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+
+	return c.ctx
+}
+
+// wrapError attaches the chassis identity to the error returned by a public method,
+// it's meant to be deferred with the named error result.
+func (c *C7000) wrapError(operation string, err *error) {
+	*err = errors.NewBMCError(BMCType, c.ip, operation, *err)
+}
+
 // CheckCredentials verify whether the credentials are valid or not
 func (c *C7000) CheckCredentials() (err error) {
+	defer c.wrapError("CheckCredentials", &err)
+
 	err = c.httpLogin()
 	if err != nil {
 		return err
@@ -113,6 +248,8 @@ func (c *C7000) CheckCredentials() (err error) {
 
 // Name returns the hostname of the machine
 func (c *C7000) Name() (name string, err error) {
+	defer c.wrapError("Name", &err)
+
 	return c.Rimp.Infra2.Encl, err
 }
 
@@ -123,38 +260,50 @@ func (c *C7000) HardwareType() (model string) {
 
 // Model returns the full device model string
 func (c *C7000) Model() (model string, err error) {
+	defer c.wrapError("Model", &err)
+
 	return c.Rimp.MP.Pn, nil
 }
 
 // Serial returns the device serial
 func (c *C7000) Serial() (serial string, err error) {
-	return strings.ToLower(strings.TrimSpace(c.Rimp.Infra2.EnclSn)), nil
+	defer c.wrapError("Serial", &err)
+
+	return devices.NormalizeSerial(c.Rimp.Infra2.EnclSn), nil
 }
 
 // PowerKw returns the current power usage in Kw
 func (c *C7000) PowerKw() (power float64, err error) {
+	defer c.wrapError("PowerKw", &err)
+
 	return c.Rimp.Infra2.ChassisPower.PowerConsumed / 1000.00, err
 }
 
 // TempC returns the current temperature of the machine
 func (c *C7000) TempC() (temp int, err error) {
+	defer c.wrapError("TempC", &err)
+
 	return c.Rimp.Infra2.Temp.C, err
 }
 
 // Psus returns a list of psus installed on the device
 func (c *C7000) Psus() (psus []*devices.Psu, err error) {
+	defer c.wrapError("Psus", &err)
+
 	for _, psu := range c.Rimp.Infra2.ChassisPower.Powersupply {
 		if psus == nil {
 			psus = make([]*devices.Psu, 0)
 		}
 
 		p := &devices.Psu{
-			Serial:     strings.ToLower(strings.TrimSpace(psu.Sn)),
-			Status:     psu.Status,
-			PowerKw:    psu.ActualOutput / 1000.00,
-			CapacityKw: psu.Capacity / 1000.00,
-			PartNumber: psu.Pn,
-			Position:   psu.Bay.Connection,
+			Serial:      devices.NormalizeSerial(psu.Sn),
+			Status:      psu.Status,
+			PowerKw:     psu.ActualOutput / 1000.00,
+			CapacityKw:  psu.Capacity / 1000.00,
+			PartNumber:  psu.Pn,
+			Position:    psu.Bay.Connection,
+			Present:     true,
+			OutputWatts: int64(psu.ActualOutput),
 		}
 		psus = append(psus, p)
 	}
@@ -164,6 +313,8 @@ func (c *C7000) Psus() (psus []*devices.Psu, err error) {
 
 // Nics returns all found Nics in the device
 func (c *C7000) Nics() (nics []*devices.Nic, err error) {
+	defer c.wrapError("Nics", &err)
+
 	for _, manager := range c.Rimp.Infra2.Managers {
 		if nics == nil {
 			nics = make([]*devices.Nic, 0)
@@ -181,6 +332,8 @@ func (c *C7000) Nics() (nics []*devices.Nic, err error) {
 
 // Fans returns all found fans in the device
 func (c *C7000) Fans() (fans []*devices.Fan, err error) {
+	defer c.wrapError("Fans", &err)
+
 	serial, err := c.Serial()
 	if err != nil {
 		return fans, err
@@ -193,8 +346,10 @@ func (c *C7000) Fans() (fans []*devices.Fan, err error) {
 
 		f := &devices.Fan{
 			Serial:     fmt.Sprintf("%d_%s", fan.Bay.Connection, serial),
+			Name:       fmt.Sprintf("Fan %d", fan.Bay.Connection),
 			Status:     fan.Status,
 			Position:   fan.Bay.Connection,
+			Present:    true,
 			Model:      fan.ProducName,
 			CurrentRPM: fan.RpmCUR,
 			PowerKw:    float64(fan.PowerUsed) / 1000,
@@ -207,9 +362,23 @@ func (c *C7000) Fans() (fans []*devices.Fan, err error) {
 
 // Status returns health string status from the bmc
 func (c *C7000) Status() (status string, err error) {
+	defer c.wrapError("Status", &err)
+
 	return c.Rimp.Infra2.Status, err
 }
 
+// Health returns the normalized health from the bmc
+func (c *C7000) Health() (health devices.Health, err error) {
+	defer c.wrapError("Health", &err)
+
+	status, err := c.Status()
+	if err != nil {
+		return devices.HealthUnknown, err
+	}
+
+	return devices.NormalizeHealth(status), err
+}
+
 // IsActive returns health string status from the bmc
 func (c *C7000) IsActive() bool {
 	for _, manager := range c.Rimp.Infra2.Managers {
@@ -222,11 +391,15 @@ func (c *C7000) IsActive() bool {
 
 // Version returns the current firmware version of the bmc
 func (c *C7000) Version() (version string, err error) {
+	defer c.wrapError("Version", &err)
+
 	return c.Rimp.MP.Fwri, err
 }
 
 // PassThru returns the type of switch we have for this chassis
 func (c *C7000) PassThru() (passthru string, err error) {
+	defer c.wrapError("PassThru", &err)
+
 	passthru = "1G"
 	for _, hpswitch := range c.Rimp.Infra2.Switches {
 		if strings.Contains(hpswitch.Spn, "10G") {
@@ -237,14 +410,63 @@ func (c *C7000) PassThru() (passthru string, err error) {
 	return passthru, err
 }
 
+// Interconnects returns the switches and pass-thru modules installed in the interconnect bays
+func (c *C7000) Interconnects() (interconnects []*devices.Interconnect, err error) {
+	defer c.wrapError("Interconnects", &err)
+
+	for _, hpswitch := range c.Rimp.Infra2.Switches {
+		interconnect := &devices.Interconnect{
+			Serial:     devices.NormalizeSerial(hpswitch.Bsn),
+			Model:      hpswitch.Spn,
+			PartNumber: hpswitch.Pn,
+			Vendor:     hpswitch.Manufacturer,
+			FabricType: strings.TrimPrefix(hpswitch.FabricType, "INTERCONNECT_TYPE_"),
+			Status:     hpswitch.Status,
+		}
+
+		if hpswitch.Bay != nil {
+			interconnect.Position = hpswitch.Bay.Connection
+		}
+
+		// modules without a management interface report 0.0.0.0
+		if hpswitch.MgmtIPAddr != "0.0.0.0" {
+			interconnect.MgmtAddress = hpswitch.MgmtIPAddr
+		}
+
+		interconnects = append(interconnects, interconnect)
+	}
+
+	return interconnects, err
+}
+
+// TemperatureSensors returns the enclosure temperature sensors
+func (c *C7000) TemperatureSensors() (sensors []*devices.TemperatureSensor, err error) {
+	defer c.wrapError("TemperatureSensors", &err)
+
+	if c.Rimp.Infra2.Temp == nil {
+		return sensors, err
+	}
+
+	sensors = append(sensors, &devices.TemperatureSensor{
+		Name:     c.Rimp.Infra2.Temp.Desc,
+		Location: "Enclosure",
+		Reading:  float64(c.Rimp.Infra2.Temp.C),
+		Unit:     devices.TemperatureUnitCelsius,
+	})
+
+	return sensors, err
+}
+
 // StorageBlades returns all StorageBlades found in this chassis
 func (c *C7000) StorageBlades() (storageBlades []*devices.StorageBlade, err error) {
+	defer c.wrapError("StorageBlades", &err)
+
 	if c.Rimp.Infra2.Blades != nil {
 		chassisSerial, _ := c.Serial()
 		for _, hpStorageBlade := range c.Rimp.Infra2.Blades {
 			if hpStorageBlade.Type == "STORAGE" {
 				storageBlade := devices.StorageBlade{}
-				storageBlade.Serial = strings.ToLower(strings.TrimSpace(hpStorageBlade.Bsn))
+				storageBlade.Serial = devices.NormalizeSerial(hpStorageBlade.Bsn)
 				storageBlade.BladePosition = hpStorageBlade.Bay.Connection
 				storageBlade.Status = hpStorageBlade.Status
 				storageBlade.PowerKw = hpStorageBlade.Power.PowerConsumed / 1000.00
@@ -255,7 +477,7 @@ func (c *C7000) StorageBlades() (storageBlades []*devices.StorageBlade, err erro
 				storageBlade.ChassisSerial = chassisSerial
 				for _, hpBlade := range c.Rimp.Infra2.Blades {
 					if hpStorageBlade.AssociatedBlade == hpBlade.Bay.Connection {
-						storageBlade.BladeSerial = strings.ToLower(strings.TrimSpace(hpBlade.Bsn))
+						storageBlade.BladeSerial = devices.NormalizeSerial(hpBlade.Bsn)
 					}
 				}
 				storageBlades = append(storageBlades, &storageBlade)
@@ -267,6 +489,8 @@ func (c *C7000) StorageBlades() (storageBlades []*devices.StorageBlade, err erro
 
 // Blades returns all StorageBlades found in this chassis
 func (c *C7000) Blades() (blades []*devices.Blade, err error) {
+	defer c.wrapError("Blades", &err)
+
 	if c.Rimp.Infra2.Blades != nil {
 		chassisSerial, _ := c.Serial()
 		for _, hpBlade := range c.Rimp.Infra2.Blades {
@@ -274,7 +498,7 @@ func (c *C7000) Blades() (blades []*devices.Blade, err error) {
 				blade := devices.Blade{}
 				blade.BladePosition = hpBlade.Bay.Connection
 				blade.Status = hpBlade.Status
-				blade.Serial = strings.ToLower(strings.TrimSpace(hpBlade.Bsn))
+				blade.Serial = devices.NormalizeSerial(hpBlade.Bsn)
 				blade.ChassisSerial = chassisSerial
 				blade.PowerKw = hpBlade.Power.PowerConsumed / 1000.00
 				blade.PowerState = strings.ToLower(hpBlade.Power.PowerState)
@@ -304,7 +528,9 @@ func (c *C7000) Vendor() (vendor string) {
 
 // ChassisSnapshot do best effort to populate the server data and returns a blade or discrete
 func (c *C7000) ChassisSnapshot() (chassis *devices.Chassis, err error) {
-	chassis = &devices.Chassis{}
+	defer c.wrapError("ChassisSnapshot", &err)
+
+	chassis = &devices.Chassis{CollectedAt: time.Now().UTC()}
 	chassis.Vendor = c.Vendor()
 	chassis.BmcAddress = c.ip
 	chassis.Name, err = c.Name()
@@ -359,6 +585,14 @@ func (c *C7000) ChassisSnapshot() (chassis *devices.Chassis, err error) {
 	if err != nil {
 		return nil, err
 	}
+	chassis.Interconnects, err = c.Interconnects()
+	if err != nil {
+		return nil, err
+	}
+	chassis.TemperatureSensors, err = c.TemperatureSensors()
+	if err != nil {
+		return nil, err
+	}
 	chassis.PsuRedundancyMode, err = c.PsuRedundancyMode()
 	if err != nil {
 		return nil, err
@@ -377,8 +611,100 @@ func (c *C7000) UpdateCredentials(username string, password string) {
 	c.password = password
 }
 
+// GetOANetwork returns the network configuration of the active OA,
+// for dual OA enclosures the standby OA is listed in the Peers of the active one.
+func (c *C7000) GetOANetwork() (network devices.BMCNetwork, err error) {
+	defer c.wrapError("GetOANetwork", &err)
+
+	var peers []*devices.BMCNetwork
+	var active, bayOne *devices.BMCNetwork
+
+	for _, manager := range c.Rimp.Infra2.Managers {
+		if manager.Bay == nil {
+			continue
+		}
+
+		oa, err := c.oaNetwork(manager.Bay.Connection)
+		if err != nil {
+			return network, err
+		}
+
+		oa.Name = manager.Name
+		oa.Role = strings.ToLower(manager.Role)
+
+		if manager.Bay.Connection == 1 {
+			bayOne = oa
+		}
+
+		if manager.Role == "ACTIVE" && active == nil {
+			active = oa
+			continue
+		}
+		peers = append(peers, oa)
+	}
+
+	if active == nil {
+		return network, errors.ErrUnableToReadData
+	}
+
+	statusCode, body, err := c.postXML(getEnclosureNetworkInfo{})
+	if err != nil {
+		return network, err
+	}
+
+	if statusCode != 200 {
+		return network, fmt.Errorf("getEnclosureNetworkInfo: %w", c.xmlError(statusCode, body))
+	}
+
+	var enclosureNetwork EnvelopeEnclosureNetworkInfo
+	err = xml.Unmarshal(body, &enclosureNetwork)
+	if err != nil {
+		return network, err
+	}
+
+	// with the Enclosure IP Mode the address of the OA in bay 1 follows the active OA,
+	// whichever bay it sits in.
+	if enclosureNetwork.Body.GetEnclosureNetworkInfoResponse.EnclosureNetworkInfo.IPSwap && bayOne != nil {
+		active.VirtualIP = bayOne.IPAddress
+	}
+
+	active.Peers = peers
+
+	return *active, nil
+}
+
+// oaNetwork returns the network configuration of the OA in the given bay
+func (c *C7000) oaNetwork(bay int) (network *devices.BMCNetwork, err error) {
+	statusCode, body, err := c.postXML(getOaNetworkInfo{BayNumber: bay})
+	if err != nil {
+		return network, err
+	}
+
+	if statusCode != 200 {
+		return network, fmt.Errorf("getOaNetworkInfo: %w", c.xmlError(statusCode, body))
+	}
+
+	var oaNetwork EnvelopeOaNetworkInfo
+	err = xml.Unmarshal(body, &oaNetwork)
+	if err != nil {
+		return network, err
+	}
+
+	info := oaNetwork.Body.GetOaNetworkInfoResponse.OaNetworkInfo
+
+	return &devices.BMCNetwork{
+		Position:  bay,
+		IPAddress: info.IPAddress,
+		Netmask:   info.Netmask,
+		Gateway:   info.Gateway,
+		DHCP:      info.DhcpEnabled,
+	}, nil
+}
+
 // IsPsuRedundant informs whether or not the power is currently redundant
 func (c *C7000) IsPsuRedundant() (state bool, err error) {
+	defer c.wrapError("IsPsuRedundant", &err)
+
 	if c.Rimp.Infra2.ChassisPower.Redundancy == "REDUNDANT" {
 		return true, err
 	}
@@ -387,6 +713,8 @@ func (c *C7000) IsPsuRedundant() (state bool, err error) {
 
 // PsuRedundancyMode returns the current redundancy mode is configured for the chassis
 func (c *C7000) PsuRedundancyMode() (mode string, err error) {
+	defer c.wrapError("PsuRedundancyMode", &err)
+
 	switch c.Rimp.Infra2.ChassisPower.RedundancyMode {
 	case "AC_REDUNDANT":
 		return devices.Grid, err
@@ -398,3 +726,17 @@ func (c *C7000) PsuRedundancyMode() (mode string, err error) {
 		return devices.Unknown, err
 	}
 }
+
+// client returns the HTTP client shared by every request made to the BMC,
+// reusing it keeps the connections to the BMC pooled.
+func (c *C7000) client() (*http.Client, error) {
+	if c.baseClient == nil {
+		client, err := httpclient.Build(c.httpClientSetupFuncs...)
+		if err != nil {
+			return nil, err
+		}
+		c.baseClient = client
+	}
+
+	return c.baseClient, nil
+}
@@ -1,13 +1,21 @@
 package c7000
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	stderrors "errors"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bombsimon/logrusr/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
@@ -2407,6 +2415,121 @@ var (
 	}
 )
 
+// soapAnswers are the responses to SOAP operations posted to /hpoa,
+// the key is the name of the operation followed by its bay number, if any.
+var soapAnswers = map[string][]byte{
+	"getOaNetworkInfo 1": []byte(`<?xml version="1.0" encoding="UTF-8"?>
+		<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://www.w3.org/2003/05/soap-envelope" xmlns:hpoa="hpoa.xsd">
+			<SOAP-ENV:Body>
+				<hpoa:getOaNetworkInfoResponse>
+					<hpoa:oaNetworkInfo>
+						<hpoa:bayNumber>1</hpoa:bayNumber>
+						<hpoa:dhcpEnabled>false</hpoa:dhcpEnabled>
+						<hpoa:dynDnsEnabled>false</hpoa:dynDnsEnabled>
+						<hpoa:macAddress>1C:98:EC:1F:82:73</hpoa:macAddress>
+						<hpoa:ipAddress>10.193.251.22</hpoa:ipAddress>
+						<hpoa:netmask>255.255.255.0</hpoa:netmask>
+						<hpoa:gateway>10.193.251.254</hpoa:gateway>
+					</hpoa:oaNetworkInfo>
+				</hpoa:getOaNetworkInfoResponse>
+			</SOAP-ENV:Body>
+		</SOAP-ENV:Envelope>`),
+	"getOaNetworkInfo 2": []byte(`<?xml version="1.0" encoding="UTF-8"?>
+		<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://www.w3.org/2003/05/soap-envelope" xmlns:hpoa="hpoa.xsd">
+			<SOAP-ENV:Body>
+				<hpoa:getOaNetworkInfoResponse>
+					<hpoa:oaNetworkInfo>
+						<hpoa:bayNumber>2</hpoa:bayNumber>
+						<hpoa:dhcpEnabled>true</hpoa:dhcpEnabled>
+						<hpoa:dynDnsEnabled>false</hpoa:dynDnsEnabled>
+						<hpoa:macAddress>94:18:82:72:E9:F5</hpoa:macAddress>
+						<hpoa:ipAddress>10.193.251.23</hpoa:ipAddress>
+						<hpoa:netmask>255.255.255.0</hpoa:netmask>
+						<hpoa:gateway>10.193.251.254</hpoa:gateway>
+					</hpoa:oaNetworkInfo>
+				</hpoa:getOaNetworkInfoResponse>
+			</SOAP-ENV:Body>
+		</SOAP-ENV:Envelope>`),
+	"getBladeStatus 1": []byte(`<?xml version="1.0" encoding="UTF-8"?>
+		<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://www.w3.org/2003/05/soap-envelope" xmlns:hpoa="hpoa.xsd">
+			<SOAP-ENV:Body>
+				<hpoa:getBladeStatusResponse>
+					<hpoa:bladeStatus>
+						<hpoa:bayNumber>1</hpoa:bayNumber>
+						<hpoa:presence>PRESENT</hpoa:presence>
+						<hpoa:operationalStatus>OP_STATUS_OK</hpoa:operationalStatus>
+						<hpoa:thermal>SENSOR_STATUS_OK</hpoa:thermal>
+						<hpoa:powered>POWER_ON</hpoa:powered>
+						<hpoa:powerState>POWER_ON</hpoa:powerState>
+						<hpoa:shutdown>SHUTDOWN_OK</hpoa:shutdown>
+						<hpoa:uid>UID_OFF</hpoa:uid>
+					</hpoa:bladeStatus>
+				</hpoa:getBladeStatusResponse>
+			</SOAP-ENV:Body>
+		</SOAP-ENV:Envelope>`),
+	"getBladeStatus 2": []byte(`<?xml version="1.0" encoding="UTF-8"?>
+		<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://www.w3.org/2003/05/soap-envelope" xmlns:hpoa="hpoa.xsd">
+			<SOAP-ENV:Body>
+				<hpoa:getBladeStatusResponse>
+					<hpoa:bladeStatus>
+						<hpoa:bayNumber>2</hpoa:bayNumber>
+						<hpoa:presence>ABSENT</hpoa:presence>
+						<hpoa:powered>POWER_UNKNOWN</hpoa:powered>
+					</hpoa:bladeStatus>
+				</hpoa:getBladeStatusResponse>
+			</SOAP-ENV:Body>
+		</SOAP-ENV:Envelope>`),
+	"getEnclosureNetworkInfo": []byte(`<?xml version="1.0" encoding="UTF-8"?>
+		<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://www.w3.org/2003/05/soap-envelope" xmlns:hpoa="hpoa.xsd">
+			<SOAP-ENV:Body>
+				<hpoa:getEnclosureNetworkInfoResponse>
+					<hpoa:enclosureNetworkInfo>
+						<hpoa:ipSwap>true</hpoa:ipSwap>
+					</hpoa:enclosureNetworkInfo>
+				</hpoa:getEnclosureNetworkInfoResponse>
+			</SOAP-ENV:Body>
+		</SOAP-ENV:Envelope>`),
+}
+
+var soapOperation = regexp.MustCompile(`<hpoa:(\w+)>(?:\s*<hpoa:bayNumber>(\d+)</hpoa:bayNumber>)?`)
+
+// setupSOAP returns a C7000 whose /hpoa endpoint answers with the soapAnswers
+// fixture matching the posted operation, falling back to the login response.
+func setupSOAP() (r *C7000, err error) {
+	mux = http.NewServeMux()
+	server = httptest.NewTLSServer(mux)
+	ip := strings.TrimPrefix(server.URL, "https://")
+
+	mux.HandleFunc("/xmldata", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(answers["/xmldata"])
+	})
+	mux.HandleFunc("/hpoa", func(w http.ResponseWriter, r *http.Request) {
+		payload, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		body := string(payload)
+		if i := strings.Index(body, "<SOAP-ENV:Body>"); i >= 0 {
+			body = body[i:]
+		}
+
+		match := soapOperation.FindStringSubmatch(body)
+		if match != nil {
+			key := strings.TrimSpace(match[1] + " " + match[2])
+			if answer, ok := soapAnswers[key]; ok {
+				_, _ = w.Write(answer)
+				return
+			}
+		}
+
+		_, _ = w.Write(answers["/hpoa"])
+	})
+
+	return NewWithOptions(context.TODO(), ip, "super", "test", logrusr.New(logrus.New()), WithInsecureTLS())
+}
+
 func init() {
 	if viper.GetBool("debug") != true {
 		viper.SetDefault("debug", true)
@@ -2430,7 +2553,7 @@ func setup() (r *C7000, err error) {
 	}
 
 	testLogger := logrus.New()
-	r, err = New(context.TODO(), ip, username, password, logrusr.New(testLogger))
+	r, err = NewWithOptions(context.TODO(), ip, username, password, logrusr.New(testLogger), WithInsecureTLS())
 	if err != nil {
 		return r, err
 	}
@@ -2462,6 +2585,37 @@ func TestHpChassisFwVersion(t *testing.T) {
 	tearDown()
 }
 
+func TestHpChassisFirmwareInventory(t *testing.T) {
+	expectedAnswer := []devices.Firmware{
+		{Component: "OA", Installed: "4.70", Updatable: true},
+		{Component: "Blade 1 iLO4", Installed: "2.55"},
+		{Component: "Blade 1 BIOS", Installed: "I36 02/17/2017"},
+		{Component: "Blade 2 iLO4", Installed: "2.54"},
+	}
+
+	chassis, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	answer, err := chassis.GetFirmwareInventory()
+	if err != nil {
+		t.Fatalf("Found errors calling chassis.GetFirmwareInventory %v", err)
+	}
+
+	if len(answer) != 15 {
+		t.Fatalf("Expected answer %v: found %v", 15, len(answer))
+	}
+
+	for i, firmware := range expectedAnswer {
+		if !reflect.DeepEqual(answer[i], firmware) {
+			t.Errorf("Expected answer %v: found %v", firmware, answer[i])
+		}
+	}
+
+	tearDown()
+}
+
 func TestHpChassisPassThru(t *testing.T) {
 	expectedAnswer := "10G"
 
@@ -2738,6 +2892,58 @@ func TestHpChassisFans(t *testing.T) {
 	tearDown()
 }
 
+func TestHpChassisInterconnects(t *testing.T) {
+	expectedAnswer := []*devices.Interconnect{
+		{
+			Position:   1,
+			Serial:     "y2h70101n0",
+			Model:      "HP 10GbE Pass-Thru Module",
+			PartNumber: "538113-B21",
+			Vendor:     "HP",
+			FabricType: "ETH",
+			Status:     "OK",
+		},
+	}
+
+	chassis, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	interconnects, err := chassis.Interconnects()
+	if err != nil {
+		t.Fatalf("Found errors calling chassis.Interconnects %v", err)
+	}
+
+	if !reflect.DeepEqual(interconnects, expectedAnswer) {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, interconnects)
+	}
+
+	tearDown()
+}
+
+func TestHpChassisTemperatureSensors(t *testing.T) {
+	chassis, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	sensors, err := chassis.TemperatureSensors()
+	if err != nil {
+		t.Fatalf("Found errors calling chassis.TemperatureSensors %v", err)
+	}
+
+	if len(sensors) != 1 {
+		t.Fatalf("Expected 1 sensor: found %v", len(sensors))
+	}
+
+	if sensors[0].Location != "Enclosure" || sensors[0].Unit != devices.TemperatureUnitCelsius || sensors[0].Reading != float64(chassis.Rimp.Infra2.Temp.C) {
+		t.Errorf("Unexpected enclosure sensor %v", sensors[0])
+	}
+
+	tearDown()
+}
+
 func TestHpChassisPsu(t *testing.T) {
 	expectedAnswer := []*devices.Psu{
 		{
@@ -2905,3 +3111,412 @@ func TestChassisRedundancyMode(t *testing.T) {
 
 	tearDown()
 }
+
+func TestPostXMLGzipEncoded(t *testing.T) {
+	var compressed bytes.Buffer
+	gzWriter := gzip.NewWriter(&compressed)
+	if _, err := gzWriter.Write(answers["/hpoa"]); err != nil {
+		t.Fatalf("Found errors compressing the fixture %v", err)
+	}
+	if err := gzWriter.Close(); err != nil {
+		t.Fatalf("Found errors compressing the fixture %v", err)
+	}
+
+	mux = http.NewServeMux()
+	server = httptest.NewTLSServer(mux)
+	ip := strings.TrimPrefix(server.URL, "https://")
+
+	mux.HandleFunc("/xmldata", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(answers["/xmldata"])
+	})
+	mux.HandleFunc("/hpoa", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(compressed.Bytes())
+	})
+
+	chassis, err := NewWithOptions(context.TODO(), ip, "super", "test", logrusr.New(logrus.New()), WithInsecureTLS())
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	statusCode, body, err := chassis.postXML(UserLogout{})
+	if err != nil {
+		t.Fatalf("Found errors calling chassis.postXML %v", err)
+	}
+
+	if statusCode != 200 {
+		t.Errorf("Expected status code 200: found %d", statusCode)
+	}
+
+	if !bytes.Equal(body, answers["/hpoa"]) {
+		t.Errorf("Expected the decompressed body: found %q", body)
+	}
+
+	if chassis.XMLToken != "a8223b7caad9ea0e" {
+		t.Errorf("Expected the session key to be read from the gzip encoded login response: found %q", chassis.XMLToken)
+	}
+
+	tearDown()
+}
+
+func TestReadBodyLimit(t *testing.T) {
+	defer func(limit int64) { responseBodyLimit = limit }(responseBodyLimit)
+	responseBodyLimit = 1024
+
+	// a small gzip body inflating past the limit
+	var compressed bytes.Buffer
+	gzWriter := gzip.NewWriter(&compressed)
+	if _, err := gzWriter.Write(bytes.Repeat([]byte("0"), 1<<20)); err != nil {
+		t.Fatalf("Found errors compressing the body %v", err)
+	}
+	if err := gzWriter.Close(); err != nil {
+		t.Fatalf("Found errors compressing the body %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+		wantErr  bool
+	}{
+		{"gzip bomb", "gzip", compressed.Bytes(), true},
+		{"plain body over the limit", "", bytes.Repeat([]byte("0"), 1025), true},
+		{"plain body at the limit", "", bytes.Repeat([]byte("0"), 1024), false},
+	}
+
+	for _, tc := range tests {
+		resp := &http.Response{
+			Header: http.Header{},
+			Body:   ioutil.NopCloser(bytes.NewReader(tc.body)),
+		}
+		if tc.encoding != "" {
+			resp.Header.Set("Content-Encoding", tc.encoding)
+		}
+
+		body, err := readBody(resp)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: Expected error %v: found %v", tc.name, tc.wantErr, err)
+		}
+		if !tc.wantErr && len(body) != len(tc.body) {
+			t.Errorf("%s: Expected answer %d bytes: found %d", tc.name, len(tc.body), len(body))
+		}
+	}
+}
+
+func TestNewWithOptionsCanceledContext(t *testing.T) {
+	mux = http.NewServeMux()
+	server = httptest.NewTLSServer(mux)
+	ip := strings.TrimPrefix(server.URL, "https://")
+
+	mux.HandleFunc("/xmldata", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(answers["/xmldata"])
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewWithOptions(ctx, ip, "super", "test", logrusr.New(logrus.New()))
+	if err == nil {
+		t.Errorf("Expected an error when the base context is canceled")
+	}
+
+	tearDown()
+}
+
+func setupHPOA(handler http.HandlerFunc) (chassis *C7000, err error) {
+	mux = http.NewServeMux()
+	server = httptest.NewTLSServer(mux)
+	ip := strings.TrimPrefix(server.URL, "https://")
+
+	mux.HandleFunc("/xmldata", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(answers["/xmldata"])
+	})
+	mux.HandleFunc("/hpoa", handler)
+
+	return NewWithOptions(context.TODO(), ip, "super", "test", logrusr.New(logrus.New()), WithInsecureTLS())
+}
+
+func TestLoginRetryOnTransientFailure(t *testing.T) {
+	loginRetryBackoff = time.Millisecond
+	defer func() { loginRetryBackoff = 2 * time.Second }()
+
+	var attempts int
+	chassis, err := setupHPOA(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write(soapFault("SOAP-ENV:Receiver", "Internal error."))
+			return
+		}
+		_, _ = w.Write(answers["/hpoa"])
+	})
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	err = chassis.CheckCredentials()
+	if err != nil {
+		t.Fatalf("Found errors calling chassis.CheckCredentials %v", err)
+	}
+
+	if attempts != 2 {
+		t.Errorf("Expected 2 login attempts: found %d", attempts)
+	}
+
+	tearDown()
+}
+
+func TestLoginNoRetryOnRejectedCredentials(t *testing.T) {
+	loginRetryBackoff = time.Millisecond
+	defer func() { loginRetryBackoff = 2 * time.Second }()
+
+	var attempts int
+	chassis, err := setupHPOA(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write(soapFault("SOAP-ENV:Sender", "The user could not be authenticated."))
+	})
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	err = chassis.CheckCredentials()
+	if !errors.IsLoginFailed(err) {
+		t.Errorf("Expected error %v: found %v", errors.ErrLoginFailed, err)
+	}
+
+	if attempts != 1 {
+		t.Errorf("Expected 1 login attempt: found %d", attempts)
+	}
+
+	tearDown()
+}
+
+func TestOANetworkBMCError(t *testing.T) {
+	chassis, err := setupHPOA(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := ioutil.ReadAll(r.Body)
+		if strings.Contains(string(payload), "hpoa:userLogIn") {
+			_, _ = w.Write(answers["/hpoa"])
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write(soapFault("SOAP-ENV:Receiver", "Internal error."))
+	})
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	_, err = chassis.GetOANetwork()
+
+	var bmcErr *errors.BMCError
+	if !stderrors.As(err, &bmcErr) || bmcErr.Vendor != BMCType || bmcErr.Operation != "GetOANetwork" {
+		t.Errorf("Expected the error to carry the bmc identity: found %v", err)
+	}
+}
+
+func soapFault(code, reason string) []byte {
+	return []byte(`<?xml version="1.0" encoding="UTF-8"?>
+		<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://www.w3.org/2003/05/soap-envelope" xmlns:hpoa="hpoa.xsd">
+			<SOAP-ENV:Body>
+				<SOAP-ENV:Fault>
+					<SOAP-ENV:Code>
+						<SOAP-ENV:Value>` + code + `</SOAP-ENV:Value>
+					</SOAP-ENV:Code>
+					<SOAP-ENV:Reason>
+						<SOAP-ENV:Text>` + reason + `</SOAP-ENV:Text>
+					</SOAP-ENV:Reason>
+				</SOAP-ENV:Fault>
+			</SOAP-ENV:Body>
+		</SOAP-ENV:Envelope>`)
+}
+
+func TestGetOANetwork(t *testing.T) {
+	expectedAnswer := devices.BMCNetwork{
+		Name:      "OA-1C98EC1F8273",
+		Position:  1,
+		Role:      "active",
+		IPAddress: "10.193.251.22",
+		Netmask:   "255.255.255.0",
+		Gateway:   "10.193.251.254",
+		VirtualIP: "10.193.251.22",
+		Peers: []*devices.BMCNetwork{
+			{
+				Name:      "OA-94188272E9F5",
+				Position:  2,
+				Role:      "standby",
+				IPAddress: "10.193.251.23",
+				Netmask:   "255.255.255.0",
+				Gateway:   "10.193.251.254",
+				DHCP:      true,
+			},
+		},
+	}
+
+	chassis, err := setupSOAP()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	answer, err := chassis.GetOANetwork()
+	if err != nil {
+		t.Fatalf("Found errors calling chassis.GetOANetwork %v", err)
+	}
+
+	if !reflect.DeepEqual(answer, expectedAnswer) {
+		t.Errorf("Expected answer %+v: found %+v", expectedAnswer, answer)
+	}
+
+	tearDown()
+}
+
+func TestGetOANetworkActiveInBayTwo(t *testing.T) {
+	expectedAnswer := devices.BMCNetwork{
+		Name:      "OA-94188272E9F5",
+		Position:  2,
+		Role:      "active",
+		IPAddress: "10.193.251.23",
+		Netmask:   "255.255.255.0",
+		Gateway:   "10.193.251.254",
+		DHCP:      true,
+		VirtualIP: "10.193.251.22",
+		Peers: []*devices.BMCNetwork{
+			{
+				Name:      "OA-1C98EC1F8273",
+				Position:  1,
+				Role:      "standby",
+				IPAddress: "10.193.251.22",
+				Netmask:   "255.255.255.0",
+				Gateway:   "10.193.251.254",
+			},
+		},
+	}
+
+	chassis, err := setupSOAP()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	// the OA in bay 2 took over
+	for _, manager := range chassis.Rimp.Infra2.Managers {
+		if manager.Bay != nil && manager.Bay.Connection == 1 {
+			manager.Role = "STANDBY"
+		} else {
+			manager.Role = "ACTIVE"
+		}
+	}
+
+	answer, err := chassis.GetOANetwork()
+	if err != nil {
+		t.Fatalf("Found errors calling chassis.GetOANetwork %v", err)
+	}
+
+	if !reflect.DeepEqual(answer, expectedAnswer) {
+		t.Errorf("Expected answer %+v: found %+v", expectedAnswer, answer)
+	}
+}
+
+func TestHpChassisHealthSensors(t *testing.T) {
+	chassis, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	fans, err := chassis.Fans()
+	if err != nil {
+		t.Fatalf("Found errors calling chassis.Fans %v", err)
+	}
+
+	psus, err := chassis.PSUs()
+	if err != nil {
+		t.Fatalf("Found errors calling chassis.PSUs %v", err)
+	}
+
+	sensors, err := chassis.HealthSensors()
+	if err != nil {
+		t.Fatalf("Found errors calling chassis.HealthSensors %v", err)
+	}
+
+	if len(sensors) != len(fans)+len(psus) {
+		t.Fatalf("Expected %v sensors: found %v", len(fans)+len(psus), len(sensors))
+	}
+
+	for _, sensor := range sensors {
+		if sensor.Health != devices.HealthOK {
+			t.Errorf("Expected answer %v: found %v", devices.HealthOK, sensor)
+		}
+	}
+}
+
+func TestPing(t *testing.T) {
+	chassis, err := setupHPOA(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := ioutil.ReadAll(r.Body)
+		if strings.Contains(string(payload), "<hpoa:getEnclosureStatus>") {
+			_, _ = w.Write([]byte(`<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://www.w3.org/2003/05/soap-envelope" xmlns:hpoa="hpoa.xsd"><SOAP-ENV:Body><hpoa:getEnclosureStatusResponse><hpoa:enclosureStatus><hpoa:operationalStatus>OP_STATUS_OK</hpoa:operationalStatus><hpoa:uid>UID_OFF</hpoa:uid></hpoa:enclosureStatus></hpoa:getEnclosureStatusResponse></SOAP-ENV:Body></SOAP-ENV:Envelope>`))
+			return
+		}
+		_, _ = w.Write(answers["/hpoa"])
+	})
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	err = chassis.Ping(context.TODO())
+	if err != nil {
+		t.Fatalf("Found errors calling chassis.Ping %v", err)
+	}
+
+	// the OA going away after the chassis was set up
+	tearDown()
+	err = chassis.Ping(context.TODO())
+	if err == nil {
+		t.Errorf("Expected an error pinging an unreachable OA")
+	}
+}
+
+func TestPingRejected(t *testing.T) {
+	chassis, err := setupHPOA(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write(soapFault("SOAP-ENV:Sender", "User credentials are invalid."))
+	})
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	err = chassis.Ping(context.TODO())
+	if !errors.IsLoginFailed(err) {
+		t.Errorf("Expected error %v: found %v", errors.ErrLoginFailed, err)
+	}
+}
+
+func TestPingDeadlineOnLogin(t *testing.T) {
+	release := make(chan struct{})
+	chassis, err := setupHPOA(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-time.After(5 * time.Second):
+		}
+		_, _ = w.Write(answers["/hpoa"])
+	})
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = chassis.Ping(ctx)
+	if !errors.IsTimeout(err) {
+		t.Errorf("Expected error %v: found %v", errors.ErrTimeout, err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the login to stop at the Ping deadline: took %v", elapsed)
+	}
+}
@@ -0,0 +1,25 @@
+package c7000
+
+import (
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/providers"
+	"github.com/jacobweinstock/registrar"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the CapabilityReporter interface.
+var _ devices.CapabilityReporter = (*C7000)(nil)
+
+// Capabilities returns the operations supported by the chassis, the enclosure can't be
+// powered on or off, PowerCycle restarts its Onboard Administrator.
+func (c *C7000) Capabilities() registrar.Features {
+	return registrar.Features{
+		providers.FeaturePowerState,
+		providers.FeatureBmcReset,
+		providers.FeaturePowerCap,
+		providers.FeatureFirmwareInstall,
+		providers.FeatureEventLogRead,
+		providers.FeatureSensorRead,
+		providers.FeatureFirmwareInventory,
+	}
+}
@@ -56,11 +56,15 @@ func (c *C7000) isRoleValid(role string) bool {
 
 // ApplyCfg implements the Cmc interface
 func (c *C7000) ApplyCfg(config *cfgresources.ResourcesConfig) (err error) {
+	defer c.wrapError("ApplyCfg", &err)
+
 	return nil
 }
 
 // Power implemented the Configure interface
 func (c *C7000) Power(cfg *cfgresources.Power) (err error) {
+	defer c.wrapError("Power", &err)
+
 	return nil
 }
 
@@ -70,6 +74,8 @@ func (c *C7000) Power(cfg *cfgresources.Power) (err error) {
 // 2. Enable LDAP auth
 // 3. Apply LDAP server params
 func (c *C7000) Ldap(cfg *cfgresources.Ldap) (err error) {
+	defer c.wrapError("Ldap", &err)
+
 	err = c.applysetLdapInfo4(cfg)
 	if err != nil {
 		c.log.V(1).Error(err, "applyLdapParams returned error.",
@@ -211,6 +217,8 @@ func (c *C7000) applyEnableLdapAuth(enable bool) (err error) {
 // 2.  setLdapGroupBayACL
 // 3.  addLdapGroupBayAccess (done)
 func (c *C7000) LdapGroups(cfgGroups []*cfgresources.LdapGroup, cfgLdap *cfgresources.Ldap) (err error) {
+	defer c.wrapError("LdapGroups", &err)
+
 	for _, group := range cfgGroups {
 		if group.Group == "" {
 			c.log.V(1).Info("Ldap resource parameter Group required but not declared.",
@@ -484,6 +492,8 @@ func (c *C7000) applyAddLdapGroupBayAccess(group string) (err error) {
 // Implements the Configure interface.
 // If the user exists, updates their password.
 func (c *C7000) User(users []*cfgresources.User) (err error) {
+	defer c.wrapError("User", &err)
+
 	// Sanity checks come first.
 	// This reduces the probability of succeeding with some users and failing with others.
 	for _, cfg := range users {
@@ -519,6 +529,8 @@ func (c *C7000) User(users []*cfgresources.User) (err error) {
 	// Actual work.
 	allErrors := ""
 	for _, cfg := range users {
+		c.log.V(2).Info("applying user resource", "step", "applyUserParams", "IP", c.ip, "User", cfg.Redacted())
+
 		username := Username{Text: cfg.Name}
 		password := Password{Text: cfg.Password}
 
@@ -740,6 +752,8 @@ func (c *C7000) applyAddUserBayAccess(user string) (err error) {
 //  <hpoa:timeZone>CET</hpoa:timeZone>
 // </hpoa:setEnclosureTimeZone>
 func (c *C7000) Ntp(cfg *cfgresources.Ntp) (err error) {
+	defer c.wrapError("Ntp", &err)
+
 	if cfg.Server1 == "" {
 		c.log.V(1).Info("NTP resource expects parameter: server1.",
 			"step", "applyNtpParams",
@@ -837,6 +851,8 @@ func (c *C7000) applyNtpTimezoneParam(timezone string) (err error) {
 // 3. enable syslog
 // theres no option to set the port
 func (c *C7000) Syslog(cfg *cfgresources.Syslog) (err error) {
+	defer c.wrapError("Syslog", &err)
+
 	var port int
 	if cfg.Server == "" {
 		c.log.V(1).Info("Syslog resource expects parameter: Server.",
@@ -959,29 +975,39 @@ func (c *C7000) applySyslogEnabled(enabled bool) {
 }
 
 // Network method implements the Configure interface
-func (c *C7000) Network(cfg *cfgresources.Network) (bool, error) {
+func (c *C7000) Network(cfg *cfgresources.Network) (reset bool, err error) {
+	defer c.wrapError("Network", &err)
+
 	return false, nil
 }
 
 // SetLicense implements the Configure interface
-func (c *C7000) SetLicense(*cfgresources.License) error {
+func (c *C7000) SetLicense(*cfgresources.License) (err error) {
+	defer c.wrapError("SetLicense", &err)
+
 	return nil
 }
 
 // Bios method implements the Configure interface
-func (c *C7000) Bios(cfg *cfgresources.Bios) error {
+func (c *C7000) Bios(cfg *cfgresources.Bios) (err error) {
+	defer c.wrapError("Bios", &err)
+
 	return nil
 }
 
 // GenerateCSR generates a CSR request on the BMC.
 // GenerateCSR implements the Configure interface.
-func (c *C7000) GenerateCSR(cert *cfgresources.HTTPSCertAttributes) ([]byte, error) {
+func (c *C7000) GenerateCSR(cert *cfgresources.HTTPSCertAttributes) (csr []byte, err error) {
+	defer c.wrapError("GenerateCSR", &err)
+
 	return []byte{}, nil
 }
 
 // UploadHTTPSCert uploads the given CRT cert,
 // UploadHTTPSCert implements the Configure interface.
-func (c *C7000) UploadHTTPSCert(cert []byte, certFileName string, key []byte, keyFileName string) (bool, error) {
+func (c *C7000) UploadHTTPSCert(cert []byte, certFileName string, key []byte, keyFileName string) (reset bool, err error) {
+	defer c.wrapError("UploadHTTPSCert", &err)
+
 	return false, nil
 }
 
@@ -989,5 +1015,7 @@ func (c *C7000) UploadHTTPSCert(cert []byte, certFileName string, key []byte, ke
 // The bool value returned indicates if the BMC supports CSR generation.
 // CurrentHTTPSCert implements the Configure interface.
 func (c *C7000) CurrentHTTPSCert() (x []*x509.Certificate, b bool, e error) {
+	defer c.wrapError("CurrentHTTPSCert", &e)
+
 	return x, b, e
 }
@@ -0,0 +1,99 @@
+package c7000
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the EventLogReader interface.
+var _ devices.EventLogReader = (*C7000)(nil)
+
+// syslogTimestamp is the layout of the timestamps prefixing the OA syslog lines, they carry no year
+const syslogTimestamp = "Jan _2 15:04:05"
+
+// syslogSeverities maps keywords of the OA syslog messages to a severity, the OA
+// doesn't report one. Messages without a known keyword are informational.
+var syslogSeverities = []struct {
+	keyword  string
+	severity devices.Severity
+}{
+	{"critical", devices.SeverityCritical},
+	{"fail", devices.SeverityCritical},
+	{"error", devices.SeverityCritical},
+	{"degraded", devices.SeverityWarning},
+	{"warning", devices.SeverityWarning},
+	{"lost", devices.SeverityWarning},
+}
+
+// GetEventLog returns the syslog of the active OA
+func (c *C7000) GetEventLog() (entries []devices.EventLogEntry, err error) {
+	defer c.wrapError("GetEventLog", &err)
+
+	output, err := c.sshClient.Run("SHOW SYSLOG OA")
+	if err != nil {
+		return entries, fmt.Errorf("output: %q: %w", output, err)
+	}
+
+	return parseSyslog(output, time.Now()), nil
+}
+
+// ClearEventLog clears the syslog of the active OA
+func (c *C7000) ClearEventLog() (err error) {
+	defer c.wrapError("ClearEventLog", &err)
+
+	output, err := c.sshClient.Run("CLEAR SYSLOG OA")
+	if err != nil {
+		return fmt.Errorf("output: %q: %w", output, err)
+	}
+
+	return nil
+}
+
+// parseSyslog parses the timestamped lines of the OA syslog, the year of the
+// entries is guessed so no entry is dated after now.
+func parseSyslog(output string, now time.Time) (entries []devices.EventLogEntry) {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) <= len(syslogTimestamp) {
+			continue
+		}
+
+		timestamp, err := time.ParseInLocation(syslogTimestamp, line[:len(syslogTimestamp)], now.Location())
+		if err != nil {
+			continue
+		}
+		timestamp = timestamp.AddDate(now.Year(), 0, 0)
+		if timestamp.After(now) {
+			timestamp = timestamp.AddDate(-1, 0, 0)
+		}
+
+		message := strings.TrimSpace(line[len(syslogTimestamp):])
+		entry := devices.EventLogEntry{
+			ID:        strconv.Itoa(len(entries) + 1),
+			Timestamp: timestamp,
+			Severity:  devices.SeverityOK,
+			Message:   message,
+		}
+		if sensor := strings.SplitN(message, ":", 2); len(sensor) == 2 && !strings.Contains(sensor[0], " ") {
+			entry.Sensor = sensor[0]
+			entry.Message = strings.TrimSpace(sensor[1])
+		}
+
+		lower := strings.ToLower(entry.Message)
+		for _, s := range syslogSeverities {
+			if strings.Contains(lower, s.keyword) {
+				entry.Severity = s.severity
+				break
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
@@ -0,0 +1,82 @@
+package c7000
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+func Test_GetEventLog(t *testing.T) {
+	tearDown, bmc, err := setupBMC()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	expected := []struct {
+		message  string
+		severity devices.Severity
+	}{
+		{"Enclosure Status changed from OK to Degraded.", devices.SeverityWarning},
+		{"Power supply 3 failed.", devices.SeverityCritical},
+		{`User "Administrator" logged into the Onboard Administrator.`, devices.SeverityOK},
+	}
+
+	entries, err := bmc.GetEventLog()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.GetEventLog %v", err)
+	}
+
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %v entries: found %v", len(expected), len(entries))
+	}
+
+	for pos, entry := range entries {
+		if entry.Sensor != "OA" {
+			t.Errorf("Expected answer %v: found %v", "OA", entry.Sensor)
+		}
+		if entry.Message != expected[pos].message {
+			t.Errorf("Expected answer %v: found %v", expected[pos].message, entry.Message)
+		}
+		if entry.Severity != expected[pos].severity {
+			t.Errorf("Expected answer %v: found %v", expected[pos].severity, entry.Severity)
+		}
+	}
+
+	if err := bmc.ClearEventLog(); err != nil {
+		t.Errorf("Found errors calling bmc.ClearEventLog %v", err)
+	}
+}
+
+func Test_parseSyslog(t *testing.T) {
+	tests := []struct {
+		name      string
+		now       time.Time
+		timestamp time.Time
+	}{
+		{
+			name:      "same year",
+			now:       time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			timestamp: time.Date(2021, 1, 2, 10, 15, 22, 0, time.UTC),
+		},
+		{
+			name:      "previous year",
+			now:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			timestamp: time.Date(2020, 1, 2, 10, 15, 22, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := parseSyslog("Jan  2 10:15:22 OA: Enclosure Status changed from OK to Degraded.", tt.now)
+			if len(entries) != 1 {
+				t.Fatalf("Expected %v entries: found %v", 1, len(entries))
+			}
+
+			if !entries[0].Timestamp.Equal(tt.timestamp) {
+				t.Errorf("Expected answer %v: found %v", tt.timestamp, entries[0].Timestamp)
+			}
+		})
+	}
+}
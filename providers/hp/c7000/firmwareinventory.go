@@ -0,0 +1,45 @@
+package c7000
+
+import (
+	"fmt"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the FirmwareInventorier interface.
+var _ devices.FirmwareInventorier = (*C7000)(nil)
+
+// GetFirmwareInventory returns the firmware of the Onboard Administrator along with the iLO and BIOS
+// firmware of the server blades, named after their bay. Only the OA firmware can be updated through the chassis.
+func (c *C7000) GetFirmwareInventory() (inventory []devices.Firmware, err error) {
+	defer c.wrapError("GetFirmwareInventory", &err)
+
+	version, err := c.Version()
+	if err != nil {
+		return inventory, err
+	}
+	inventory = append(inventory, devices.Firmware{Component: "OA", Installed: version, Updatable: true})
+
+	blades, err := c.Blades()
+	if err != nil {
+		return inventory, err
+	}
+
+	for _, blade := range blades {
+		if blade.BmcVersion != "" {
+			inventory = append(inventory, devices.Firmware{
+				Component: fmt.Sprintf("Blade %d %s", blade.BladePosition, blade.BmcType),
+				Installed: blade.BmcVersion,
+			})
+		}
+		if blade.BiosVersion != "" {
+			inventory = append(inventory, devices.Firmware{
+				Component: fmt.Sprintf("Blade %d BIOS", blade.BladePosition),
+				Installed: blade.BiosVersion,
+			})
+		}
+	}
+
+	return inventory, nil
+}
@@ -0,0 +1,82 @@
+package c7000
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the PowerCapper interface.
+var _ devices.PowerCapper = (*C7000)(nil)
+
+// powerConfigInfo returns the power configuration of the enclosure
+func (c *C7000) powerConfigInfo(ctx context.Context) (config EnvelopePowerConfigInfo, err error) {
+	statusCode, body, err := c.postXMLContext(ctx, getPowerConfigInfo{})
+	if err != nil {
+		return config, err
+	}
+
+	if statusCode != 200 {
+		return config, fmt.Errorf("getPowerConfigInfo: %w", c.xmlError(statusCode, body))
+	}
+
+	err = xml.Unmarshal(body, &config)
+	return config, err
+}
+
+// GetPowerCap returns the enclosure power cap, the power ceiling of the OA
+func (c *C7000) GetPowerCap(ctx context.Context) (powerCap devices.PowerCap, err error) {
+	defer c.wrapError("GetPowerCap", &err)
+
+	config, err := c.powerConfigInfo(ctx)
+	if err != nil {
+		return powerCap, err
+	}
+
+	ceiling := config.Body.GetPowerConfigInfoResponse.PowerConfigInfo.PowerCeiling
+	if ceiling <= 0 {
+		return devices.PowerCap{}, nil
+	}
+
+	return devices.PowerCap{Enabled: true, Watts: ceiling}, nil
+}
+
+// SetPowerCap sets the enclosure power cap, the redundancy mode and
+// dynamic power saver are set along with it and keep their current values.
+func (c *C7000) SetPowerCap(ctx context.Context, powerCap devices.PowerCap) (err error) {
+	defer c.wrapError("SetPowerCap", &err)
+
+	err = powerCap.Validate()
+	if err != nil {
+		return err
+	}
+
+	config, err := c.powerConfigInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	current := config.Body.GetPowerConfigInfoResponse.PowerConfigInfo
+	payload := setPowerConfigInfo{
+		RedundancyMode:           current.RedundancyMode,
+		DynamicPowerSaverEnabled: current.DynamicPowerSaverEnabled,
+	}
+	if powerCap.Enabled {
+		payload.PowerCeiling = powerCap.Watts
+	}
+
+	statusCode, body, err := c.postXMLContext(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	if statusCode != 200 {
+		return fmt.Errorf("setPowerConfigInfo: %v: %w", c.xmlError(statusCode, body), errors.ErrPowerCapSet)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package c7000
+
+import (
+	"fmt"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the SensorReader interface.
+var _ devices.SensorReader = (*C7000)(nil)
+
+// PSUs returns the power supplies of the chassis
+func (c *C7000) PSUs() (psus []*devices.Psu, err error) {
+	defer c.wrapError("PSUs", &err)
+
+	return c.Psus()
+}
+
+// Temperatures returns the enclosure temperature sensors
+func (c *C7000) Temperatures() (sensors []*devices.TemperatureSensor, err error) {
+	defer c.wrapError("Temperatures", &err)
+
+	return c.TemperatureSensors()
+}
+
+// HealthSensors returns the health of the fans and power supplies of the chassis,
+// the OA doesn't expose the other sensors.
+func (c *C7000) HealthSensors() (sensors []*devices.HealthSensor, err error) {
+	defer c.wrapError("HealthSensors", &err)
+
+	fans, err := c.Fans()
+	if err != nil {
+		return sensors, err
+	}
+
+	for _, fan := range fans {
+		sensors = append(sensors, &devices.HealthSensor{
+			Name:    fan.Name,
+			Type:    "Fan",
+			Reading: float64(fan.CurrentRPM),
+			Health:  devices.NormalizeHealth(fan.Status),
+		})
+	}
+
+	psus, err := c.Psus()
+	if err != nil {
+		return sensors, err
+	}
+
+	for _, psu := range psus {
+		sensors = append(sensors, &devices.HealthSensor{
+			Name:    fmt.Sprintf("Power Supply %d", psu.Position),
+			Type:    "Power Supply",
+			Reading: psu.PowerKw * 1000,
+			Health:  devices.NormalizeHealth(psu.Status),
+		})
+	}
+
+	return sensors, nil
+}
@@ -2,25 +2,39 @@ package c7000
 
 import (
 	"bytes"
+	"context"
 	"encoding/xml"
 	"fmt"
-	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
+	"time"
 
+	"github.com/bmc-toolbox/bmclib/devices"
 	"github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bmc-toolbox/bmclib/internal/httpclient"
 	multierror "github.com/hashicorp/go-multierror"
 )
 
+var (
+	// loginAttempts is the number of times the login is attempted when the OA returns a transient failure.
+	loginAttempts = 3
+	// loginRetryBackoff is the base wait between login attempts, it grows linearly with each attempt.
+	loginRetryBackoff = 2 * time.Second
+)
+
 // Login initiates the connection to a chassis device
 func (c *C7000) httpLogin() (err error) {
+	return c.httpLoginContext(c.context())
+}
+
+// httpLoginContext initiates the connection bounded by ctx instead of the base context of the C7000,
+// the retries of a transient failure included.
+func (c *C7000) httpLoginContext(ctx context.Context) (err error) {
 	if c.httpClient != nil {
 		return
 	}
 
-	httpClient, err := httpclient.Build(c.httpClientSetupFuncs...)
+	httpClient, err := c.client()
 	if err != nil {
 		return err
 	}
@@ -38,54 +52,139 @@ func (c *C7000) httpLogin() (err error) {
 		return err
 	}
 
+	// An overloaded OA may answer the login with a spurious 500 or drop the connection,
+	// retry those but never a fault where the credentials were rejected.
+	for attempt := 1; ; attempt++ {
+		retry, err := c.login(ctx, httpClient, payload)
+		if err == nil {
+			break
+		}
+
+		if !retry || attempt >= loginAttempts {
+			return err
+		}
+
+		c.log.V(1).Info("login failed with a transient error, retrying",
+			"step", "httpLogin",
+			"IP", c.ip,
+			"HardwareType", c.HardwareType(),
+			"attempt", attempt,
+			"error", err.Error(),
+		)
+
+		delay, ok := errors.RetryDelayWithin(ctx, err, loginRetryBackoff*time.Duration(attempt))
+		if !ok {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	c.httpClient = httpClient
+
+	return nil
+}
+
+// login posts the login payload and stores the session key returned by the OA,
+// the returned bool indicates if the failure is transient and the login can be retried.
+func (c *C7000) login(ctx context.Context, httpClient *http.Client, payload []byte) (retry bool, err error) {
 	u, err := url.Parse(fmt.Sprintf("https://%s/hpoa", c.ip))
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(payload))
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), bytes.NewReader(payload))
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	//req.Header.Add("Content-Type", "application/soap+xml; charset=utf-8")
 	req.Header.Add("Content-Type", "text/plain;charset=UTF-8")
+	req.Header.Add("Accept-Encoding", "gzip")
 
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	// the payload carries the credentials, only the headers are dumped
+	reqDump, _ := httpclient.DumpRequestOut(req, false)
 	c.log.V(2).Info("requestTrace", "requestDump", string(reqDump), "url", fmt.Sprintf("https://%s/hpoa", c.ip))
 
 	resp, err := httpClient.Do(req)
-	if err != nil || resp.StatusCode != 200 {
-		return errors.ErrLoginFailed
+	if err != nil {
+		return errors.IsRetryable(err), errors.WrapRequestError(err)
 	}
 	defer resp.Body.Close()
 
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	c.log.V(2).Info("responseTrace", "responseDump", string(respDump))
 
-	responseBody, err := ioutil.ReadAll(resp.Body)
+	responseBody, err := readBody(resp)
 	if err != nil {
-		return err
+		return false, err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true, errors.NewHTTPErrorFromResponse(resp, responseBody)
+	}
+
+	if resp.StatusCode != 200 {
+		// the OA reports a rejected login as a sender fault,
+		// anything else returned with a 5xx is the OA failing to process the request.
+		var fault EnvelopeFault
+		if xml.Unmarshal(responseBody, &fault) == nil && fault.isSenderFault() {
+			return false, fmt.Errorf("%s: %w", fault.Body.Fault.Reason.Text, errors.ErrLoginFailed)
+		}
+
+		return resp.StatusCode >= 500, fmt.Errorf("login returned status code %d: %w", resp.StatusCode, errors.ErrLoginFailed)
 	}
 
 	var loginResponse EnvelopeLoginResponse
 	err = xml.Unmarshal(responseBody, &loginResponse)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	c.XMLToken = loginResponse.Body.UserLogInResponse.HpOaSessionKeyToken.OaSessionKey.Text
 	if c.XMLToken == "" {
-		return errors.ErrLoginFailed
+		return false, fmt.Errorf("no session key returned: %w", errors.ErrLoginFailed)
 	}
 
-	c.httpClient = httpClient
+	return false, nil
+}
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the Pinger interface.
+var _ devices.Pinger = (*C7000)(nil)
+
+// Ping checks the OA is reachable and accepts the credentials by querying the enclosure status,
+// logging in first when there's no session yet. The login and its retries are bounded by ctx as well.
+func (c *C7000) Ping(ctx context.Context) (err error) {
+	defer c.wrapError("Ping", &err)
 
-	return err
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	statusCode, body, err := c.postXMLContext(ctx, getEnclosureStatus{})
+	if err != nil {
+		return err
+	}
+
+	if statusCode != 200 {
+		return fmt.Errorf("getEnclosureStatus: %w", c.xmlError(statusCode, body))
+	}
+
+	return nil
 }
 
 // Close closes the connection properly
-func (c *C7000) Close() error {
+func (c *C7000) Close() (err error) {
+	defer c.wrapError("Close", &err)
+
 	var miltiErr error
 
 	if c.httpClient != nil {
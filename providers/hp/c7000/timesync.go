@@ -0,0 +1,20 @@
+package c7000
+
+import (
+	"context"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the TimeSyncVerifier interface.
+var _ devices.TimeSyncVerifier = (*C7000)(nil)
+
+// VerifyTimeSync isn't supported on C7000 yet
+func (c *C7000) VerifyTimeSync(ctx context.Context, tolerance time.Duration) (offset time.Duration, inSync bool, err error) {
+	defer c.wrapError("VerifyTimeSync", &err)
+
+	return 0, false, errors.NewFeatureUnsupportedError("time sync verification", c.Vendor(), c.HardwareType())
+}
@@ -0,0 +1,45 @@
+package c7000
+
+import (
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the UserManager interface.
+var _ devices.UserManager = (*C7000)(nil)
+
+// CreateUser isn't supported on C7000 yet
+func (c *C7000) CreateUser(user devices.User) (err error) {
+	defer c.wrapError("CreateUser", &err)
+
+	return errors.NewFeatureUnsupportedError("user management", c.Vendor(), c.HardwareType())
+}
+
+// ModifyUser isn't supported on C7000 yet
+func (c *C7000) ModifyUser(user devices.User) (err error) {
+	defer c.wrapError("ModifyUser", &err)
+
+	return errors.NewFeatureUnsupportedError("user management", c.Vendor(), c.HardwareType())
+}
+
+// DeleteUser isn't supported on C7000 yet
+func (c *C7000) DeleteUser(name string) (err error) {
+	defer c.wrapError("DeleteUser", &err)
+
+	return errors.NewFeatureUnsupportedError("user management", c.Vendor(), c.HardwareType())
+}
+
+// ListUsers isn't supported on C7000 yet
+func (c *C7000) ListUsers() (users []devices.User, err error) {
+	defer c.wrapError("ListUsers", &err)
+
+	return nil, errors.NewFeatureUnsupportedError("user management", c.Vendor(), c.HardwareType())
+}
+
+// ChangePassword isn't supported on C7000 yet
+func (c *C7000) ChangePassword(name string, password string) (err error) {
+	defer c.wrapError("ChangePassword", &err)
+
+	return errors.NewFeatureUnsupportedError("user management", c.Vendor(), c.HardwareType())
+}
@@ -0,0 +1,33 @@
+package c7000
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the VirtualMediaController interface.
+var _ devices.VirtualMediaController = (*C7000)(nil)
+
+// MountVirtualMedia isn't supported on the chassis, the OA doesn't mount images for the blades
+func (c *C7000) MountVirtualMedia(ctx context.Context, kind devices.MediaKind, image string) (err error) {
+	defer c.wrapError("MountVirtualMedia", &err)
+
+	return errors.NewFeatureUnsupportedError("virtual media", c.Vendor(), c.HardwareType())
+}
+
+// UnmountVirtualMedia isn't supported on the chassis, the OA doesn't mount images for the blades
+func (c *C7000) UnmountVirtualMedia(ctx context.Context, kind devices.MediaKind) (err error) {
+	defer c.wrapError("UnmountVirtualMedia", &err)
+
+	return errors.NewFeatureUnsupportedError("virtual media", c.Vendor(), c.HardwareType())
+}
+
+// VirtualMediaStatus isn't supported on the chassis, the OA doesn't mount images for the blades
+func (c *C7000) VirtualMediaStatus(ctx context.Context) (media []devices.VirtualMedia, err error) {
+	defer c.wrapError("VirtualMediaStatus", &err)
+
+	return nil, errors.NewFeatureUnsupportedError("virtual media", c.Vendor(), c.HardwareType())
+}
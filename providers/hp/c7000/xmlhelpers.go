@@ -2,15 +2,19 @@ package c7000
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/bmc-toolbox/bmclib/errors"
+	"github.com/bmc-toolbox/bmclib/internal/httpclient"
 )
 
 // wraps the XML to be sent in the SOAP envelope
@@ -41,7 +45,12 @@ func wrapXML(element interface{}, sessionKey string) (doc Envelope) {
 }
 
 func (c *C7000) postXML(data interface{}) (statusCode int, body []byte, err error) {
-	err = c.httpLogin()
+	return c.postXMLContext(c.context(), data)
+}
+
+// postXMLContext posts the SOAP request bounded by ctx instead of the base context of the C7000
+func (c *C7000) postXMLContext(ctx context.Context, data interface{}) (statusCode int, body []byte, err error) {
+	err = c.httpLoginContext(ctx)
 	if err != nil {
 		return statusCode, body, err
 	}
@@ -66,36 +75,75 @@ func (c *C7000) postXML(data interface{}) (statusCode int, body []byte, err erro
 		return 0, []byte{}, err
 	}
 
-	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(xmlPayload))
-	if err != nil {
-		return 0, []byte{}, err
-	}
-
 	// Setup a context to cancel the request if it takes long.
 	// This prevents the http.Client.Timeout deadline from kicking in and causing a panic.
-	ctx, cancel := context.WithTimeout(req.Context(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	req = req.WithContext(ctx)
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), bytes.NewReader(xmlPayload))
+	if err != nil {
+		return 0, []byte{}, err
+	}
 
 	//	req.Header.Add("Content-Type", "application/soap+xml; charset=utf-8")
 	req.Header.Add("Content-Type", "text/plain;charset=UTF-8")
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	req.Header.Add("Accept-Encoding", "gzip")
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
 	c.log.V(1).Info("requestDebug", "requestDump", string(reqDump), "url", fmt.Sprintf("https://%s/hpoa", c.ip))
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return 0, []byte{}, err
+		return 0, []byte{}, errors.WrapRequestError(err)
 	}
 	defer resp.Body.Close()
 
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	c.log.V(1).Info("responseTrace", "responseDump", string(respDump))
 
-	body, err = ioutil.ReadAll(resp.Body)
+	body, err = readBody(resp)
 	if err != nil {
 		return 0, []byte{}, err
 	}
 
 	return resp.StatusCode, body, err
 }
+
+// xmlError returns the HTTPError for a SOAP request answered with an unexpected status code,
+// postXML itself returns the status and body as is since callers inspect the SOAP faults.
+func (c *C7000) xmlError(statusCode int, body []byte) error {
+	return errors.NewHTTPError("POST", fmt.Sprintf("https://%s/hpoa", c.ip), statusCode, body)
+}
+
+// responseBodyLimit bounds the size of a decompressed SOAP response, the largest answers of the OA,
+// the blade and firmware listings of a full enclosure, stay well under a few MB, a body decompressing
+// past the limit is refused rather than filling the memory.
+var responseBodyLimit int64 = 32 << 20
+
+// readBody returns the response payload, decompressing it when the OA
+// has gzip encoded the body.
+// The http.Transport only decompresses transparently when it added the
+// Accept-Encoding header itself, which isn't the case for our requests.
+func readBody(resp *http.Response) (body []byte, err error) {
+	var reader io.Reader = resp.Body
+
+	if !resp.Uncompressed && strings.EqualFold(strings.TrimSpace(resp.Header.Get("Content-Encoding")), "gzip") {
+		gzReader, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gzReader.Close()
+
+		reader = gzReader
+	}
+
+	body, err = ioutil.ReadAll(io.LimitReader(reader, responseBodyLimit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(body)) > responseBodyLimit {
+		return nil, fmt.Errorf("response body bigger than %d bytes", responseBodyLimit)
+	}
+
+	return body, nil
+}
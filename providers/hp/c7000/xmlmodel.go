@@ -2,6 +2,7 @@ package c7000
 
 import (
 	"encoding/xml"
+	"strings"
 )
 
 // Username struct to Un/Marshal Username payload.
@@ -53,6 +54,35 @@ type EnvelopeLoginResponse struct {
 	} `xml:"Body"`
 }
 
+// EnvelopeFault struct to Unmarshal SOAP fault responses.
+// <SOAP-ENV:Fault>
+//   <SOAP-ENV:Code>
+//     <SOAP-ENV:Value>SOAP-ENV:Sender</SOAP-ENV:Value>
+//   </SOAP-ENV:Code>
+//   <SOAP-ENV:Reason>
+//     <SOAP-ENV:Text>The user could not be authenticated.</SOAP-ENV:Text>
+//   </SOAP-ENV:Reason>
+// </SOAP-ENV:Fault>
+type EnvelopeFault struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		Fault struct {
+			Code struct {
+				Value string `xml:"Value"`
+			} `xml:"Code"`
+			Reason struct {
+				Text string `xml:"Text"`
+			} `xml:"Reason"`
+		} `xml:"Fault"`
+	} `xml:"Body"`
+}
+
+// isSenderFault returns true when the OA blames the request itself for the fault,
+// as opposed to a receiver fault where the OA failed to process a valid request.
+func (f *EnvelopeFault) isSenderFault() bool {
+	return strings.HasSuffix(f.Body.Fault.Code.Value, ":Sender") || f.Body.Fault.Code.Value == "Sender"
+}
+
 // OaSessionKey struct to Un/marshal OA session key payload.
 type OaSessionKey struct {
 	XMLName xml.Name `xml:"hpoa:oaSessionKey"`
@@ -314,9 +344,135 @@ type SearchContext struct {
 // manage power config
 //<hpoa:setPowerConfigInfo><hpoa:redundancyMode>AC_REDUNDANT</hpoa:redundancyMode><hpoa:powerCeiling>0</hpoa:powerCeiling><hpoa:dynamicPowerSaverEnabled>false</hpoa:dynamicPowerSaverEnabled></hpoa:setPowerConfigInfo>
 
+// getPowerConfigInfo declares payload to query the enclosure power configuration.
+type getPowerConfigInfo struct {
+	XMLName xml.Name `xml:"hpoa:getPowerConfigInfo"`
+}
+
+// setPowerConfigInfo declares payload to set the enclosure power configuration,
+// a powerCeiling of 0 removes the enclosure power cap.
+type setPowerConfigInfo struct {
+	XMLName                  xml.Name `xml:"hpoa:setPowerConfigInfo"`
+	RedundancyMode           string   `xml:"hpoa:redundancyMode"`
+	PowerCeiling             int      `xml:"hpoa:powerCeiling"`
+	DynamicPowerSaverEnabled bool     `xml:"hpoa:dynamicPowerSaverEnabled"`
+}
+
+// EnvelopePowerConfigInfo struct to Unmarshal getPowerConfigInfo responses.
+type EnvelopePowerConfigInfo struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		GetPowerConfigInfoResponse struct {
+			PowerConfigInfo struct {
+				PowerCeiling             int    `xml:"powerCeiling"`
+				RedundancyMode           string `xml:"redundancyMode"`
+				DynamicPowerSaverEnabled bool   `xml:"dynamicPowerSaverEnabled"`
+			} `xml:"powerConfigInfo"`
+		} `xml:"getPowerConfigInfoResponse"`
+	} `xml:"Body"`
+}
+
 //mark setup wizard complete - required if the chassis was reset.
 //<hpoa:setWizardComplete><hpoa:wizardStatus>WIZARD_SETUP_COMPLETE</hpoa:wizardStatus></hpoa:setWizardComplete>
 
+// getOaNetworkInfo declares payload to query the network configuration of an OA.
+// <hpoa:getOaNetworkInfo>
+//   <hpoa:bayNumber>1</hpoa:bayNumber>
+// </hpoa:getOaNetworkInfo>
+type getOaNetworkInfo struct {
+	XMLName   xml.Name `xml:"hpoa:getOaNetworkInfo"`
+	BayNumber int      `xml:"hpoa:bayNumber"`
+}
+
+// EnvelopeOaNetworkInfo struct to Unmarshal getOaNetworkInfo responses.
+type EnvelopeOaNetworkInfo struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		GetOaNetworkInfoResponse struct {
+			OaNetworkInfo struct {
+				BayNumber   int    `xml:"bayNumber"`
+				DhcpEnabled bool   `xml:"dhcpEnabled"`
+				MacAddress  string `xml:"macAddress"`
+				IPAddress   string `xml:"ipAddress"`
+				Netmask     string `xml:"netmask"`
+				Gateway     string `xml:"gateway"`
+			} `xml:"oaNetworkInfo"`
+		} `xml:"getOaNetworkInfoResponse"`
+	} `xml:"Body"`
+}
+
+// getEnclosureNetworkInfo declares payload to query the enclosure wide network configuration.
+type getEnclosureNetworkInfo struct {
+	XMLName xml.Name `xml:"hpoa:getEnclosureNetworkInfo"`
+}
+
+// EnvelopeEnclosureNetworkInfo struct to Unmarshal getEnclosureNetworkInfo responses.
+// ipSwap is set when the Enclosure IP Mode is enabled,
+// in which case the active OA always answers on the address of the OA in bay 1.
+type EnvelopeEnclosureNetworkInfo struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		GetEnclosureNetworkInfoResponse struct {
+			EnclosureNetworkInfo struct {
+				IPSwap bool `xml:"ipSwap"`
+			} `xml:"enclosureNetworkInfo"`
+		} `xml:"getEnclosureNetworkInfoResponse"`
+	} `xml:"Body"`
+}
+
+// getBladeStatus declares payload to query the status of a blade.
+// <hpoa:getBladeStatus>
+//   <hpoa:bayNumber>1</hpoa:bayNumber>
+// </hpoa:getBladeStatus>
+type getBladeStatus struct {
+	XMLName   xml.Name `xml:"hpoa:getBladeStatus"`
+	BayNumber int      `xml:"hpoa:bayNumber"`
+}
+
+// EnvelopeBladeStatus struct to Unmarshal getBladeStatus responses.
+// presence is one of PRESENT, ABSENT, SUBSUMED
+// powered is one of POWER_ON, POWER_OFF, POWER_STAGED, POWER_REBOOT, POWER_UNKNOWN
+type EnvelopeBladeStatus struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		GetBladeStatusResponse struct {
+			BladeStatus struct {
+				BayNumber int    `xml:"bayNumber"`
+				Presence  string `xml:"presence"`
+				Powered   string `xml:"powered"`
+			} `xml:"bladeStatus"`
+		} `xml:"getBladeStatusResponse"`
+	} `xml:"Body"`
+}
+
+// setEnclosureUID declares payload to switch the enclosure UID LED.
+// <hpoa:setEnclosureUid>
+//   <hpoa:uid>UID_CMD_ON</hpoa:uid>
+// </hpoa:setEnclosureUid>
+type setEnclosureUID struct {
+	XMLName xml.Name `xml:"hpoa:setEnclosureUid"`
+	UID     string   `xml:"hpoa:uid"`
+}
+
+// getEnclosureStatus declares payload to query the enclosure status.
+type getEnclosureStatus struct {
+	XMLName xml.Name `xml:"hpoa:getEnclosureStatus"`
+}
+
+// EnvelopeEnclosureStatus struct to Unmarshal getEnclosureStatus responses.
+// uid is one of UID_ON, UID_OFF, UID_BLINK
+type EnvelopeEnclosureStatus struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		GetEnclosureStatusResponse struct {
+			EnclosureStatus struct {
+				OperationalStatus string `xml:"operationalStatus"`
+				UID               string `xml:"uid"`
+			} `xml:"enclosureStatus"`
+		} `xml:"getEnclosureStatusResponse"`
+	} `xml:"Body"`
+}
+
 // UserLogout declares payload to log out.
 type UserLogout struct {
 	XMLName xml.Name `xml:"hpoa:userLogOut"`
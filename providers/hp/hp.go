@@ -75,7 +75,14 @@ type MP struct {
 
 // Switch contains the type of the switch
 type Switch struct {
-	Spn string `xml:"SPN,omitempty"`
+	Bay          *Bay   `xml:"BAY,omitempty"`
+	Bsn          string `xml:"BSN,omitempty"`
+	Pn           string `xml:"PN,omitempty"`
+	Spn          string `xml:"SPN,omitempty"`
+	MgmtIPAddr   string `xml:"MGMTIPADDR,omitempty"`
+	FabricType   string `xml:"FABRICTYPE,omitempty"`
+	Manufacturer string `xml:"MANUFACTURER,omitempty"`
+	Status       string `xml:"STATUS,omitempty"`
 }
 
 // Power contains the power information of a blade
@@ -100,6 +107,7 @@ type Rimp struct {
 
 // Manager hold the information of the manager board of the chassis
 type Manager struct {
+	Bay        *Bay   `xml:"BAY,omitempty"`
 	MgmtIPAddr string `xml:"MGMTIPADDR,omitempty"`
 	Role       string `xml:"ROLE,omitempty"`
 	MacAddr    string `xml:"MACADDR,omitempty"`
@@ -270,8 +278,10 @@ type Temperature struct {
 
 // IloLicense is the struct used to render the data from https://$ip/json/license, it contains the license information of the ilo
 type IloLicense struct {
-	Name string `json:"name"`
-	Type string `json:"type"`
+	Key     string `json:"key"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Expires string `json:"expires"`
 }
 
 // IloPowerSupply holds the information of power supplies exposed via ilo
@@ -321,5 +331,11 @@ type IloDisks struct {
 			PhysIdx        int    `json:"phys_idx"`
 			DriveMediatype string `json:"drive_mediatype"`
 		} `json:"physical_drives"`
+		StorageType string `json:"storage_type"`
+		Name        string `json:"name"`
+		Status      string `json:"status"`
+		SerialNo    string `json:"serial_no"`
+		Model       string `json:"model"`
+		FwVersion   string `json:"fw_version"`
 	} `json:"phy_drive_arrays"`
 }
@@ -107,6 +107,8 @@ func (i *Ilo) User(users []*cfgresources.User) (err error) {
 	}
 
 	for _, user := range users {
+		i.log.V(2).Info("applying user resource", "step", "applyUserParams", "IP", i.ip, "User", user.Redacted())
+
 		var postPayload bool
 
 		userinfo, uexists := userExists(user.Name, existingUsers)
@@ -175,7 +177,7 @@ func (i *Ilo) User(users []*cfgresources.User) (err error) {
 				if err == nil {
 					err = fmt.Errorf("Received a %d status code from the POST request to %s.", statusCode, endpoint)
 				} else {
-					err = fmt.Errorf("POST request to %s failed with error: %s", endpoint, err.Error())
+					err = fmt.Errorf("POST request to %s failed with error: %w", endpoint, err)
 				}
 
 				i.log.V(1).Error(err, "POST request to set User config failed.",
@@ -250,7 +252,7 @@ func (i *Ilo) Syslog(cfg *cfgresources.Syslog) (err error) {
 		if err == nil {
 			err = fmt.Errorf("Received a %d status code from the POST request to %s.", statusCode, endpoint)
 		} else {
-			err = fmt.Errorf("POST request to %s failed with error: %s", endpoint, err.Error())
+			err = fmt.Errorf("POST request to %s failed with error: %w", endpoint, err)
 		}
 
 		i.log.V(1).Error(err, "POST request to set Syslog config failed.",
@@ -272,6 +274,8 @@ func (i *Ilo) Syslog(cfg *cfgresources.Syslog) (err error) {
 // SetLicense applies license configuration params
 // SetLicense implements the Configure interface.
 func (i *Ilo) SetLicense(cfg *cfgresources.License) (err error) {
+	i.log.V(2).Info("applying license resource", "step", helper.WhosCalling(), "IP", i.ip, "License", cfg.Redacted())
+
 	if cfg.Key == "" {
 		msg := "License resource expects parameter: Key."
 		i.log.V(1).Info(msg, "step", helper.WhosCalling())
@@ -301,7 +305,7 @@ func (i *Ilo) SetLicense(cfg *cfgresources.License) (err error) {
 		if err == nil {
 			err = fmt.Errorf("Received a %d status code from the POST request to %s.", statusCode, endpoint)
 		} else {
-			err = fmt.Errorf("POST request to %s failed with error: %s", endpoint, err.Error())
+			err = fmt.Errorf("POST request to %s failed with error: %w", endpoint, err)
 		}
 
 		msg := "POST request to set License failed."
@@ -408,7 +412,7 @@ func (i *Ilo) Ntp(cfg *cfgresources.Ntp) (err error) {
 		if err == nil {
 			err = fmt.Errorf("Received a %d status code from the POST request to %s.", statusCode, endpoint)
 		} else {
-			err = fmt.Errorf("POST request to %s failed with error: %s", endpoint, err.Error())
+			err = fmt.Errorf("POST request to %s failed with error: %w", endpoint, err)
 		}
 
 		i.log.V(1).Error(err, "POST request to set NTP config failed.",
@@ -465,7 +469,7 @@ func (i *Ilo) LdapGroups(cfgGroups []*cfgresources.LdapGroup, cfgLdap *cfgresour
 			if err == nil {
 				err = fmt.Errorf("Received a %d status code from the POST request to %s.", statusCode, endpoint)
 			} else {
-				err = fmt.Errorf("POST request to %s failed with error: %s", endpoint, err.Error())
+				err = fmt.Errorf("POST request to %s failed with error: %w", endpoint, err)
 			}
 
 			i.log.V(1).Error(err, "POST request to delete LDAP groups failed.",
@@ -555,7 +559,7 @@ func (i *Ilo) LdapGroups(cfgGroups []*cfgresources.LdapGroup, cfgLdap *cfgresour
 			if err == nil {
 				err = fmt.Errorf("Received a %d status code from the POST request to %s.", statusCode, endpoint)
 			} else {
-				err = fmt.Errorf("POST request to %s failed with error: %s", endpoint, err.Error())
+				err = fmt.Errorf("POST request to %s failed with error: %w", endpoint, err)
 			}
 
 			i.log.V(1).Error(err, "POST request to set LDAP group failed.",
@@ -649,7 +653,7 @@ func (i *Ilo) Ldap(cfg *cfgresources.Ldap) (err error) {
 		if err == nil {
 			err = fmt.Errorf("Received a %d status code from the POST request to %s.", statusCode, endpoint)
 		} else {
-			err = fmt.Errorf("POST request to %s failed with error: %s", endpoint, err.Error())
+			err = fmt.Errorf("POST request to %s failed with error: %w", endpoint, err)
 		}
 
 		i.log.V(1).Error(err, "POST request to set Ldap config failed.",
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/bmc-toolbox/bmclib/cfgresources"
+	"github.com/bmc-toolbox/bmclib/errors"
+	"github.com/bmc-toolbox/bmclib/internal/helper"
 )
 
 // cmdPowerSettings
@@ -79,7 +81,9 @@ func (i *Ilo) cmpNetworkIPv4Settings(cfg *cfgresources.Network) (NetworkIPv4, bo
 	return currentConfig, true, nil
 }
 
-func setSnmpSettings(hardwareType string, snmpEnable bool) *SNMPSettings {
+// setSnmpSettings returns the SNMP settings for the ilo generation, the SNMP fields differ between iLO4 and iLO5
+// and aren't known for the older generations.
+func setSnmpSettings(hardwareType string, snmpEnable bool) (*SNMPSettings, error) {
 	snmpSettings := new(SNMPSettings)
 	snmpSettings.SnmpPort = 161 // TODO: Change this to something user-configurable
 	snmpSettings.TrapPort = 162 // TODO: Change this to something user-configurable
@@ -101,10 +105,11 @@ func setSnmpSettings(hardwareType string, snmpEnable bool) *SNMPSettings {
 			*snmpEnabled = 0
 		}
 		snmpSettings.SnmpExternalEnabledIlo5 = snmpEnabled
+	default:
+		return nil, fmt.Errorf("snmp settings for %s: %w", hardwareType, errors.ErrUnsupportedModel)
 	}
 
-	return snmpSettings
-
+	return snmpSettings, nil
 }
 
 func isUpdateRequiredSNMPIlo4(snmpEnable bool, bmcSettings SNMPSettings) bool {
@@ -203,7 +208,22 @@ func (i *Ilo) cmpAccessSettings(cfg *cfgresources.Network) (AccessSettings, bool
 	currentConfig.IpmiPort = cfg.IpmiPort
 	currentConfig.SSHStatus = sshEnable
 	currentConfig.SSHPort = cfg.SSHPort
-	currentConfig.SNMPSettings = *setSnmpSettings(i.HardwareType(), cfg.SNMPEnable)
+	// the SNMP settings are only known for iLO4 and iLO5, the older generations keep theirs
+	// and get the other access settings applied.
+	snmpSettings, err := setSnmpSettings(i.HardwareType(), cfg.SNMPEnable)
+	switch {
+	case err == nil:
+		currentConfig.SNMPSettings = *snmpSettings
+	case errors.IsUnsupportedModel(err):
+		i.log.V(1).Info("SNMP settings not applied, unsupported on this generation",
+			"IP", i.ip,
+			"HardwareType", i.HardwareType(),
+			"step", helper.WhosCalling(),
+		)
+	default:
+		return AccessSettings{}, false, err
+	}
+
 	currentConfig.RemoteConsolePort = cfg.KVMConsolePort
 	currentConfig.VirtualMediaPort = cfg.KVMMediaPort
 	currentConfig.IpmiLanStatus = ipmiEnable
@@ -9,14 +9,15 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/bmc-toolbox/bmclib/devices"
 	"github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bmc-toolbox/bmclib/internal/httpclient"
 	"github.com/bmc-toolbox/bmclib/internal/sshclient"
+	"github.com/bmc-toolbox/bmclib/providers"
 	"github.com/bmc-toolbox/bmclib/providers/hp"
 	"github.com/go-logr/logr"
 )
@@ -41,7 +42,8 @@ type Ilo struct {
 	username             string
 	password             string
 	sessionKey           string
-	httpClient           *http.Client
+	httpClient           *http.Client // set once logged in
+	baseClient           *http.Client // shared by every request made to the BMC
 	sshClient            *sshclient.SSHClient
 	loginURL             *url.URL
 	rimpBlade            *hp.RimpBlade
@@ -61,6 +63,109 @@ func WithSecureTLS(rootCAs *x509.CertPool) IloOption {
 	}
 }
 
+// WithInsecureTLS skips the verification of the BMC certificate, which is otherwise
+// verified against the system CAs. Use it for BMCs serving self-signed certificates.
+func WithInsecureTLS() IloOption {
+	return func(i *Ilo) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithInsecureTLS())
+	}
+}
+
+// WithSecureTLSFromFile enforces trusted TLS connections using the CA certificates of a PEM file.
+// Requests fail with a loading error when the file is missing or holds no valid certificate.
+func WithSecureTLSFromFile(caCertFile string) IloOption {
+	return func(i *Ilo) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithCACertFile(caCertFile))
+	}
+}
+
+// WithClientCert authenticates to the BMC with the given PEM encoded client certificate and key.
+func WithClientCert(certPEM, keyPEM []byte) IloOption {
+	return func(i *Ilo) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithClientCert(certPEM, keyPEM))
+	}
+}
+
+// WithObserver calls observer after each HTTP round-trip made to the BMC,
+// to collect metrics or logs.
+func WithObserver(observer func(providers.RequestInfo)) IloOption {
+	return func(i *Ilo) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithObserver(observer))
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the HTTP requests made to the BMC,
+// it defaults to bmclib/<version>.
+func WithUserAgent(ua string) IloOption {
+	return func(i *Ilo) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithUserAgent(ua))
+	}
+}
+
+// WithForceHTTP1 restricts the HTTP requests made to the BMC to HTTP/1.1,
+// for BMC web servers returning garbled responses over HTTP/2.
+func WithForceHTTP1() IloOption {
+	return func(i *Ilo) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithForceHTTP1())
+	}
+}
+
+// WithMaxResponseBytes bounds the size of the responses read from the BMC,
+// it defaults to httpclient.DefaultMaxResponseBytes.
+func WithMaxResponseBytes(n int64) IloOption {
+	return func(i *Ilo) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithMaxResponseBytes(n))
+	}
+}
+
+// WithDigestAuth answers the HTTP Digest authentication challenges of the BMC
+// with the credentials of the Ilo, for firmware requiring digest auth.
+func WithDigestAuth() IloOption {
+	return func(i *Ilo) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithDigestAuth(i.username, i.password))
+	}
+}
+
+// WithRequestLogging logs the method, host, path, status and duration of each HTTP request
+// made to the BMC at V(1) with the logger of the Ilo, lighter than the dumps logged at V(2).
+func WithRequestLogging() IloOption {
+	return func(i *Ilo) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithRequestLogging(i.log))
+	}
+}
+
+// WithTimeout sets the overall timeout of the HTTP requests made to the BMC,
+// it defaults to httpclient.DefaultTimeout.
+func WithTimeout(d time.Duration) IloOption {
+	return func(i *Ilo) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithTimeout(d))
+	}
+}
+
+// WithProxy routes the HTTP requests made to the BMC through the given proxy,
+// by default the proxy settings of the environment are honored.
+func WithProxy(proxyURL string) IloOption {
+	return func(i *Ilo) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithProxy(proxyURL))
+	}
+}
+
+// WithRetry retries the idempotent HTTP requests made to the BMC on transient failures,
+// making up to attempts attempts with an exponential backoff.
+func WithRetry(attempts int, backoff time.Duration) IloOption {
+	return func(i *Ilo) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithRetry(attempts, backoff))
+	}
+}
+
+// WithRateLimit paces the HTTP requests made to the BMC to requestsPerSecond,
+// allowing bursts of up to burst requests.
+func WithRateLimit(requestsPerSecond float64, burst int) IloOption {
+	return func(i *Ilo) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithRateLimit(requestsPerSecond, burst))
+	}
+}
+
 // New returns a new Ilo ready to be used
 func New(ctx context.Context, host string, username string, password string, log logr.Logger) (*Ilo, error) {
 	return NewWithOptions(ctx, host, username, password, log)
@@ -84,7 +189,7 @@ func NewWithOptions(ctx context.Context, host string, username string, password
 		return nil, err
 	}
 
-	client, err := httpclient.Build(ilo.httpClientSetupFuncs...)
+	client, err := ilo.client()
 	if err != nil {
 		return nil, err
 	}
@@ -162,7 +267,7 @@ func (i *Ilo) get(endpoint string, useSession bool) (int, []byte, error) {
 		req.SetBasicAuth(i.username, i.password)
 	}
 
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
 	i.log.V(2).Info("requestTrace", "requestDump", string(reqDump), "url", fmt.Sprintf("%s/%s", bmcURL, endpoint))
 
 	resp, err := i.httpClient.Do(req)
@@ -170,7 +275,7 @@ func (i *Ilo) get(endpoint string, useSession bool) (int, []byte, error) {
 		return 0, nil, err
 	}
 	defer resp.Body.Close()
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	i.log.V(2).Info("responseTrace", "responseDump", string(respDump))
 
 	payload, err := ioutil.ReadAll(resp.Body)
@@ -179,12 +284,21 @@ func (i *Ilo) get(endpoint string, useSession bool) (int, []byte, error) {
 	}
 
 	if resp.StatusCode == 404 {
-		return 404, nil, errors.ErrPageNotFound
+		return 404, nil, errors.NewHTTPErrorFromResponse(resp, payload)
+	}
+
+	if useSession && sessionLost(payload) {
+		return resp.StatusCode, nil, fmt.Errorf("GET %s: %w", endpoint, errors.ErrSessionExpired)
 	}
 
 	return resp.StatusCode, payload, nil
 }
 
+// sessionLost returns true when the ilo answered with the error it reports for an expired or invalid session key
+func sessionLost(payload []byte) bool {
+	return bytes.Contains(payload, []byte("JS_ERR_LOST_SESSION"))
+}
+
 // posts the payload to the given endpoint
 func (i *Ilo) post(endpoint string, data []byte) (statusCode int, body []byte, err error) {
 	u, err := url.Parse(fmt.Sprintf("https://%s/%s", i.ip, endpoint))
@@ -207,7 +321,7 @@ func (i *Ilo) post(endpoint string, data []byte) (statusCode int, body []byte, e
 		}
 	}
 
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
 	i.log.V(2).Info("requestTrace", "requestDump", string(reqDump), "url", fmt.Sprintf("%s/%s", i.ip, endpoint))
 
 	resp, err := i.httpClient.Do(req)
@@ -217,7 +331,7 @@ func (i *Ilo) post(endpoint string, data []byte) (statusCode int, body []byte, e
 
 	defer resp.Body.Close()
 
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	i.log.V(2).Info("responseTrace", "responseDump", string(respDump))
 
 	body, err = ioutil.ReadAll(resp.Body)
@@ -225,12 +339,16 @@ func (i *Ilo) post(endpoint string, data []byte) (statusCode int, body []byte, e
 		return 0, []byte{}, err
 	}
 
+	if sessionLost(body) {
+		return resp.StatusCode, body, fmt.Errorf("POST %s: %w", endpoint, errors.ErrSessionExpired)
+	}
+
 	return resp.StatusCode, body, err
 }
 
 // Serial returns the device serial
 func (i *Ilo) Serial() (serial string, err error) {
-	return strings.ToLower(strings.TrimSpace(i.rimpBlade.HSI.Sbsn)), nil
+	return devices.NormalizeSerial(i.rimpBlade.HSI.Sbsn), nil
 }
 
 // Returns the serial number of the chassis where the blade is attached.
@@ -262,15 +380,15 @@ func (i *Ilo) ChassisSerial() (string, error) {
 			return "", err
 		}
 
-		return strings.ToLower(chassisInfo.ChassisSn), nil
+		return devices.NormalizeSerial(chassisInfo.ChassisSn), nil
 	}
 
-	return strings.ToLower(rckInfo.EncSn), err
+	return devices.NormalizeSerial(rckInfo.EncSn), err
 }
 
 // Model returns the device model
 func (i *Ilo) Model() (model string, err error) {
-	return i.rimpBlade.HSI.Spn, nil
+	return devices.NormalizeModel(devices.HP, i.rimpBlade.HSI.Spn), nil
 }
 
 // HardwareType returns the type of bmc we are talking to
@@ -348,6 +466,16 @@ func (i *Ilo) Status() (health string, err error) {
 	return overview.SystemHealth, err
 }
 
+// Health returns the normalized health from the bmc
+func (i *Ilo) Health() (health devices.Health, err error) {
+	status, err := i.Status()
+	if err != nil {
+		return devices.HealthUnknown, err
+	}
+
+	return devices.NormalizeHealth(status), err
+}
+
 // Returns the total amount of memory of the server.
 func (i *Ilo) Memory() (mem int, err error) {
 	err = i.httpLogin()
@@ -554,11 +682,24 @@ func (i *Ilo) Nics() (nics []*devices.Nic, err error) {
 
 // Returns the ILO's license information.
 func (i *Ilo) License() (name string, licType string, err error) {
-	err = i.httpLogin()
+	license, err := i.LicenseDetails()
 	if err != nil {
 		return "", "", err
 	}
 
+	return license.Name, license.Type, nil
+}
+
+// iloLicenseExpiryLayouts are the date formats seen in the expires field of time-limited ilo licenses
+var iloLicenseExpiryLayouts = []string{time.RFC3339, "2006-01-02", "Jan 2, 2006", "01/02/2006"}
+
+// LicenseDetails returns the ILO's license including the key and, for time-limited licenses, the expiry date.
+func (i *Ilo) LicenseDetails() (license *devices.License, err error) {
+	err = i.httpLogin()
+	if err != nil {
+		return nil, err
+	}
+
 	endpoint := "json/license"
 	statusCode, payload, err := i.get(endpoint, true)
 	if err != nil || statusCode != 200 {
@@ -566,16 +707,30 @@ func (i *Ilo) License() (name string, licType string, err error) {
 			err = fmt.Errorf("Received a %d status code from the GET request to %s.", statusCode, endpoint)
 		}
 
-		return "", "", err
+		return nil, err
 	}
 
 	hpIloLicense := &hp.IloLicense{}
 	err = json.Unmarshal(payload, hpIloLicense)
 	if err != nil {
-		return "", "", err
+		return nil, err
+	}
+
+	license = &devices.License{Name: hpIloLicense.Name, Type: hpIloLicense.Type}
+	if hpIloLicense.Key != "" {
+		license.Keys = []string{hpIloLicense.Key}
+	}
+
+	if expires := strings.TrimSpace(hpIloLicense.Expires); expires != "" {
+		for _, layout := range iloLicenseExpiryLayouts {
+			if expiresAt, err := time.Parse(layout, expires); err == nil {
+				license.ExpiresAt = &expiresAt
+				break
+			}
+		}
 	}
 
-	return hpIloLicense.Name, hpIloLicense.Type, nil
+	return license, nil
 }
 
 func (i *Ilo) parseChassisInfo() (*hp.ChassisInfo, error) {
@@ -629,7 +784,7 @@ func (i *Ilo) parseChassisInfo() (*hp.ChassisInfo, error) {
 		return chassisInfo, nil
 	}
 
-	if err != errors.ErrPageNotFound {
+	if !errors.IsNotFound(err) {
 		// This is a real error, just give up...
 		return nil, err
 	}
@@ -687,7 +842,7 @@ func (i *Ilo) Psus() (psus []*devices.Psu, err error) {
 		}
 
 		p := &devices.Psu{
-			Serial:     strings.ToLower(psu.PsSerialNum),
+			Serial:     devices.NormalizeSerial(psu.PsSerialNum),
 			Status:     status,
 			PowerKw:    float64(psu.PsOutputWatts) / 1000.00,
 			CapacityKw: float64(psu.PsMaxCapWatts) / 1000.00,
@@ -701,11 +856,28 @@ func (i *Ilo) Psus() (psus []*devices.Psu, err error) {
 
 // Disks returns a list of disks installed on the device
 func (i *Ilo) Disks() (disks []*devices.Disk, err error) {
-	err = i.httpLogin()
+	controllers, err := i.StorageControllers()
 	if err != nil {
 		return disks, err
 	}
 
+	for _, controller := range controllers {
+		if disks == nil && len(controller.Disks) > 0 {
+			disks = make([]*devices.Disk, 0)
+		}
+		disks = append(disks, controller.Disks...)
+	}
+
+	return disks, err
+}
+
+// StorageControllers returns the storage controllers of the device with their attached disks
+func (i *Ilo) StorageControllers() (controllers []*devices.StorageController, err error) {
+	err = i.httpLogin()
+	if err != nil {
+		return controllers, err
+	}
+
 	endpoint := "json/health_phy_drives"
 	statusCode, payload, err := i.get(endpoint, true)
 	if err != nil || statusCode != 200 {
@@ -713,27 +885,34 @@ func (i *Ilo) Disks() (disks []*devices.Disk, err error) {
 			err = fmt.Errorf("Received a %d status code from the GET request to %s.", statusCode, endpoint)
 		}
 
-		return disks, err
+		return controllers, err
 	}
 
 	hpIloDisks := &hp.IloDisks{}
 	err = json.Unmarshal(payload, hpIloDisks)
 	if err != nil {
-		return disks, err
+		return controllers, err
 	}
 
 	for _, disksArray := range hpIloDisks.PhyDriveArrays {
-		for _, physicalDrive := range disksArray.PhysicalDrives {
-			if disks == nil {
-				disks = make([]*devices.Disk, 0)
-			}
-			var status string
-			if physicalDrive.Status == "OP_STATUS_OK" {
-				status = "OK"
-			} else {
-				status = physicalDrive.Status
-			}
+		if controllers == nil {
+			controllers = make([]*devices.StorageController, 0)
+		}
+
+		controller := &devices.StorageController{
+			Description: disksArray.Name,
+			Vendor:      i.Vendor(),
+			Model:       disksArray.Model,
+			Serial:      devices.NormalizeSerial(disksArray.SerialNo),
+			Status:      &devices.Status{Health: iloStatus(disksArray.Status)},
+			Firmware:    &devices.Firmware{Installed: disksArray.FwVersion},
+		}
 
+		if disksArray.StorageType == "SMART_ARRAY_CONTROLLER_TYPE" {
+			controller.Mode = devices.StorageControllerModeRAID
+		}
+
+		for _, physicalDrive := range disksArray.PhysicalDrives {
 			var diskType string
 			if strings.Contains(physicalDrive.DriveMediatype, "HDD") {
 				diskType = "HDD"
@@ -744,8 +923,8 @@ func (i *Ilo) Disks() (disks []*devices.Disk, err error) {
 			}
 
 			disk := &devices.Disk{
-				Serial:    strings.ToLower(physicalDrive.SerialNo),
-				Status:    status,
+				Serial:    devices.NormalizeSerial(physicalDrive.SerialNo),
+				Status:    iloStatus(physicalDrive.Status),
 				Model:     strings.ToLower(physicalDrive.Model),
 				Size:      physicalDrive.Capacity,
 				Location:  physicalDrive.Location,
@@ -753,11 +932,22 @@ func (i *Ilo) Disks() (disks []*devices.Disk, err error) {
 				FwVersion: strings.ToLower(physicalDrive.FwVersion),
 			}
 
-			disks = append(disks, disk)
+			controller.Disks = append(controller.Disks, disk)
 		}
+
+		controllers = append(controllers, controller)
 	}
 
-	return disks, err
+	return controllers, err
+}
+
+// iloStatus translates the iLO operational status to the status string we expose
+func iloStatus(status string) string {
+	if status == "OP_STATUS_OK" {
+		return "OK"
+	}
+
+	return status
 }
 
 // Returns whether the current hardware is a blade.
@@ -805,7 +995,7 @@ func (i *Ilo) ServerSnapshot() (server interface{}, err error) { // nolint: gocy
 	}
 
 	if isBlade, _ := i.IsBlade(); isBlade {
-		blade := &devices.Blade{}
+		blade := &devices.Blade{CollectedAt: time.Now().UTC()}
 		blade.Vendor = i.Vendor()
 		blade.BmcAddress = i.ip
 		blade.BmcType = i.HardwareType()
@@ -826,10 +1016,13 @@ func (i *Ilo) ServerSnapshot() (server interface{}, err error) { // nolint: gocy
 		if err != nil {
 			return nil, err
 		}
-		blade.Disks, err = i.Disks()
+		blade.StorageControllers, err = i.StorageControllers()
 		if err != nil {
 			return nil, err
 		}
+		for _, controller := range blade.StorageControllers {
+			blade.Disks = append(blade.Disks, controller.Disks...)
+		}
 		blade.BiosVersion, err = i.BiosVersion()
 		if err != nil {
 			return nil, err
@@ -862,10 +1055,11 @@ func (i *Ilo) ServerSnapshot() (server interface{}, err error) { // nolint: gocy
 		if err != nil {
 			return nil, err
 		}
-		blade.BmcLicenceType, blade.BmcLicenceStatus, err = i.License()
+		blade.BmcLicense, err = i.LicenseDetails()
 		if err != nil {
 			return nil, err
 		}
+		blade.BmcLicenceType, blade.BmcLicenceStatus = blade.BmcLicense.Name, blade.BmcLicense.Type
 		blade.BladePosition, err = i.Slot()
 		if err != nil {
 			return nil, err
@@ -876,7 +1070,7 @@ func (i *Ilo) ServerSnapshot() (server interface{}, err error) { // nolint: gocy
 		}
 		server = blade
 	} else {
-		discrete := &devices.Discrete{}
+		discrete := &devices.Discrete{CollectedAt: time.Now().UTC()}
 		discrete.Vendor = i.Vendor()
 		discrete.BmcAddress = i.ip
 		discrete.BmcType = i.HardwareType()
@@ -897,10 +1091,13 @@ func (i *Ilo) ServerSnapshot() (server interface{}, err error) { // nolint: gocy
 		if err != nil {
 			return nil, err
 		}
-		discrete.Disks, err = i.Disks()
+		discrete.StorageControllers, err = i.StorageControllers()
 		if err != nil {
 			return nil, err
 		}
+		for _, controller := range discrete.StorageControllers {
+			discrete.Disks = append(discrete.Disks, controller.Disks...)
+		}
 		discrete.BiosVersion, err = i.BiosVersion()
 		if err != nil {
 			return nil, err
@@ -929,10 +1126,11 @@ func (i *Ilo) ServerSnapshot() (server interface{}, err error) { // nolint: gocy
 		if err != nil {
 			return nil, err
 		}
-		discrete.BmcLicenceType, discrete.BmcLicenceStatus, err = i.License()
+		discrete.BmcLicense, err = i.LicenseDetails()
 		if err != nil {
 			return nil, err
 		}
+		discrete.BmcLicenceType, discrete.BmcLicenceStatus = discrete.BmcLicense.Name, discrete.BmcLicense.Type
 		discrete.PowerState, err = i.PowerState()
 		if err != nil {
 			return nil, err
@@ -967,3 +1165,17 @@ func (i *Ilo) GetBMCVersion(ctx context.Context) (string, error) {
 func (i *Ilo) FirmwareUpdateBMC(ctx context.Context, filePath string) error {
 	return errors.ErrNotImplemented
 }
+
+// client returns the HTTP client shared by every request made to the BMC,
+// reusing it keeps the connections to the BMC pooled.
+func (i *Ilo) client() (*http.Client, error) {
+	if i.baseClient == nil {
+		client, err := httpclient.Build(i.httpClientSetupFuncs...)
+		if err != nil {
+			return nil, err
+		}
+		i.baseClient = client
+	}
+
+	return i.baseClient, nil
+}
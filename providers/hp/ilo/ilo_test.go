@@ -2,12 +2,16 @@ package ilo
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/bmc-toolbox/bmclib/cfgresources"
 	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bombsimon/logrusr/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
@@ -172,7 +176,7 @@ func setup() (bmc *Ilo, err error) {
 	}
 
 	testLog := logrus.New()
-	bmc, err = New(context.TODO(), ip, username, password, logrusr.New(testLog))
+	bmc, err = NewWithOptions(context.TODO(), ip, username, password, logrusr.New(testLog), WithInsecureTLS())
 	if err != nil {
 		return bmc, err
 	}
@@ -519,6 +523,30 @@ func TestIloLicense(t *testing.T) {
 	tearDown()
 }
 
+func TestIloLicenseDetails(t *testing.T) {
+	expectedAnswer := &devices.License{
+		Name: "iLO Advanced",
+		Type: "Perpetual",
+		Keys: []string{"3353M-XKMML-D7H3P-XV794-3DXMM"},
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	answer, err := bmc.LicenseDetails()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.LicenseDetails %v", err)
+	}
+
+	if !reflect.DeepEqual(answer, expectedAnswer) {
+		t.Errorf("Expected answer %+v: found %+v", expectedAnswer, answer)
+	}
+
+	tearDown()
+}
+
 func TestIloPsu(t *testing.T) {
 	expectedAnswer := []*devices.Psu{
 		{
@@ -609,6 +637,38 @@ func TestIloDisks(t *testing.T) {
 	tearDown()
 }
 
+func TestIloStorageControllers(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	controllers, err := bmc.StorageControllers()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.StorageControllers %v", err)
+	}
+
+	if len(controllers) != 1 {
+		t.Fatalf("Expected 1 storage controller: found %v", len(controllers))
+	}
+
+	controller := controllers[0]
+	if controller.Description != "Controller on System Board" ||
+		controller.Model != "Smart Array P246br Controller" ||
+		controller.Serial != "pdnlu0mlm55058" ||
+		controller.Mode != devices.StorageControllerModeRAID ||
+		controller.Status.Health != "OK" ||
+		controller.Firmware.Installed != "5.52" {
+		t.Errorf("Unexpected storage controller %+v", controller)
+	}
+
+	if len(controller.Disks) != 2 {
+		t.Errorf("Expected 2 disks attached to the controller: found %v", len(controller.Disks))
+	}
+
+	tearDown()
+}
+
 func TestIloIsBlade(t *testing.T) {
 	expectedAnswer := true
 
@@ -697,3 +757,92 @@ func TestUpdateCredentials(t *testing.T) {
 
 	tearDown()
 }
+
+func TestIloSessionExpired(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	mux.HandleFunc("/json/lost_session", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"JS_ERR_LOST_SESSION","details":null}`))
+	})
+
+	err = bmc.httpLogin()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.httpLogin %v", err)
+	}
+
+	_, _, err = bmc.get("json/lost_session", true)
+	if !bmclibErrs.IsSessionExpired(err) {
+		t.Errorf("Expected error %v: found %v", bmclibErrs.ErrSessionExpired, err)
+	}
+
+	tearDown()
+}
+
+func TestSetSnmpSettingsUnsupportedModel(t *testing.T) {
+	_, err := setSnmpSettings(Ilo3, true)
+	if !bmclibErrs.IsUnsupportedModel(err) {
+		t.Errorf("Expected error %v: found %v", bmclibErrs.ErrUnsupportedModel, err)
+	}
+
+	snmpSettings, err := setSnmpSettings(Ilo5, true)
+	if err != nil {
+		t.Fatalf("Found errors calling setSnmpSettings %v", err)
+	}
+
+	if snmpSettings.SnmpExternalEnabledIlo5 == nil || *snmpSettings.SnmpExternalEnabledIlo5 != 1 {
+		t.Errorf("Expected SNMP to be enabled for %s", Ilo5)
+	}
+}
+
+func TestCmpAccessSettingsIlo3(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	mux.HandleFunc("/json/access_settings", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ssh_status":1,"ssh_port":22,"ipmi_lan_status":1,"ipmi_port":623,"remote_console_port":17990,"virtual_media_port":17988,"snmp_settings":{"snmp_port":161,"trap_port":162}}`))
+	})
+
+	err = bmc.httpLogin()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.httpLogin %v", err)
+	}
+	bmc.rimpBlade.MP.Pn = "Integrated Lights-Out 3 (iLO 3)"
+
+	cfg := &cfgresources.Network{SSHEnable: true, SSHPort: 2222, IpmiEnable: true, IpmiPort: 623, KVMConsolePort: 17990, KVMMediaPort: 17988, SNMPEnable: true}
+	settings, update, err := bmc.cmpAccessSettings(cfg)
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.cmpAccessSettings %v", err)
+	}
+
+	// the SNMP settings are skipped, the other settings are still applied
+	if !update || settings.SSHPort != 2222 || settings.SNMPSettings.SnmpPort != 161 {
+		t.Errorf("Expected answer %v %v: found %v %v", true, 2222, update, settings.SSHPort)
+	}
+}
+
+func TestIloScreenshotUnsupported(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	_, _, err = bmc.Screenshot()
+
+	var featureErr *bmclibErrs.FeatureUnsupportedError
+	if !errors.As(err, &featureErr) {
+		t.Fatalf("Expected a FeatureUnsupportedError: found %v", err)
+	}
+
+	if featureErr.Model != Ilo4 {
+		t.Errorf("Expected answer %v: found %v", Ilo4, featureErr.Model)
+	}
+
+	tearDown()
+}
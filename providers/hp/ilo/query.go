@@ -34,7 +34,7 @@ func (i *Ilo) CurrentHTTPSCert() ([]*x509.Certificate, bool, error) {
 func (i *Ilo) Screenshot() (response []byte, extension string, err error) {
 	// Screen thumbnails are only available in ILO5.
 	if i.HardwareType() != "ilo5" {
-		return nil, "", errors.ErrFeatureUnavailable
+		return nil, "", errors.NewFeatureUnsupportedError("screenshot", i.Vendor(), i.HardwareType())
 	}
 
 	err = i.httpLogin()
@@ -7,7 +7,6 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"strings"
 
@@ -24,7 +23,7 @@ func (i *Ilo) httpLogin() (err error) {
 		return
 	}
 
-	httpClient, err := httpclient.Build(i.httpClientSetupFuncs...)
+	httpClient, err := i.client()
 	if err != nil {
 		return err
 	}
@@ -39,7 +38,8 @@ func (i *Ilo) httpLogin() (err error) {
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	// the payload carries the credentials, only the headers are dumped
+	reqDump, _ := httpclient.DumpRequestOut(req, false)
 	i.log.V(2).Info("requestTrace", "requestDump", string(reqDump), "url", i.loginURL.String())
 
 	resp, err := httpClient.Do(req)
@@ -71,7 +71,7 @@ func (i *Ilo) httpLogin() (err error) {
 		return err
 	}
 	defer resp.Body.Close()
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	i.log.V(2).Info("responseTrace", "responseDump", string(respDump))
 
 	if strings.Contains(string(payload), "Invalid login attempt") {
@@ -98,7 +98,7 @@ func (i *Ilo) Close(ctx context.Context) error {
 		} else {
 			req.Header.Set("Content-Type", "application/json")
 
-			reqDump, _ := httputil.DumpRequestOut(req, true)
+			reqDump, _ := httpclient.DumpRequestOut(req, true)
 			i.log.V(2).Info("requestTrace", "requestDump", string(reqDump), "url", i.loginURL.String())
 
 			resp, err := i.httpClient.Do(req)
@@ -108,7 +108,7 @@ func (i *Ilo) Close(ctx context.Context) error {
 				defer resp.Body.Close()
 				defer io.Copy(ioutil.Discard, resp.Body) // nolint
 
-				respDump, _ := httputil.DumpResponse(resp, true)
+				respDump, _ := httpclient.DumpResponse(resp, true)
 				i.log.V(2).Info("responseTrace", "responseDump", string(respDump))
 			}
 		}
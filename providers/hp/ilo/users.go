@@ -0,0 +1,35 @@
+package ilo
+
+import (
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the UserManager interface.
+var _ devices.UserManager = (*Ilo)(nil)
+
+// CreateUser isn't supported on iLO yet
+func (i *Ilo) CreateUser(user devices.User) error {
+	return errors.NewFeatureUnsupportedError("user management", i.Vendor(), i.HardwareType())
+}
+
+// ModifyUser isn't supported on iLO yet
+func (i *Ilo) ModifyUser(user devices.User) error {
+	return errors.NewFeatureUnsupportedError("user management", i.Vendor(), i.HardwareType())
+}
+
+// DeleteUser isn't supported on iLO yet
+func (i *Ilo) DeleteUser(name string) error {
+	return errors.NewFeatureUnsupportedError("user management", i.Vendor(), i.HardwareType())
+}
+
+// ListUsers isn't supported on iLO yet
+func (i *Ilo) ListUsers() ([]devices.User, error) {
+	return nil, errors.NewFeatureUnsupportedError("user management", i.Vendor(), i.HardwareType())
+}
+
+// ChangePassword isn't supported on iLO yet
+func (i *Ilo) ChangePassword(name string, password string) error {
+	return errors.NewFeatureUnsupportedError("user management", i.Vendor(), i.HardwareType())
+}
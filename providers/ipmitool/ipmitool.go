@@ -5,6 +5,8 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/bmc-toolbox/bmclib/bmc"
+	"github.com/bmc-toolbox/bmclib/devices"
 	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bmc-toolbox/bmclib/internal/ipmi"
 	"github.com/bmc-toolbox/bmclib/providers"
@@ -27,9 +29,22 @@ var (
 		providers.FeatureUserRead,
 		providers.FeatureBmcReset,
 		providers.FeatureBootDeviceSet,
+		providers.FeatureEventLogRead,
 	}
 )
 
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the bmc and devices interfaces.
+var (
+	_ bmc.PowerStateGetter    = (*Conn)(nil)
+	_ bmc.PowerSetter         = (*Conn)(nil)
+	_ devices.EventLogReader  = (*Conn)(nil)
+	_ devices.PowerController = (*Conn)(nil)
+)
+
+// ChassisStatus is the chassis status reported by the BMC
+type ChassisStatus = ipmi.ChassisStatus
+
 // Conn for Ipmitool connection details
 type Conn struct {
 	Host string
@@ -37,7 +52,19 @@ type Conn struct {
 	User string
 	Pass string
 	Log  logr.Logger
-	con  *ipmi.Ipmi
+	// Ctx bounds the calls of the devices interfaces, which don't take a context,
+	// context.Background is used when it isn't set.
+	Ctx context.Context
+	con *ipmi.Ipmi
+}
+
+// context returns the context bounding the calls that don't take one
+func (c *Conn) context() context.Context {
+	if c.Ctx == nil {
+		return context.Background()
+	}
+
+	return c.Ctx
 }
 
 // Open a connection to a BMC
@@ -132,3 +159,51 @@ func (c *Conn) PowerSet(ctx context.Context, state string) (ok bool, err error)
 
 	return ok, err
 }
+
+// PowerState returns the power state of the machine,
+// PowerState implements the PowerController interface.
+func (c *Conn) PowerState() (state string, err error) {
+	return c.con.PowerState(c.context())
+}
+
+// PowerOn powers on the machine,
+// PowerOn implements the PowerController interface.
+func (c *Conn) PowerOn() (ok bool, err error) {
+	return c.PowerSet(c.context(), "on")
+}
+
+// PowerOff powers off the machine,
+// PowerOff implements the PowerController interface.
+func (c *Conn) PowerOff() (ok bool, err error) {
+	return c.PowerSet(c.context(), "off")
+}
+
+// PowerCycle power cycles the machine,
+// PowerCycle implements the PowerController interface.
+func (c *Conn) PowerCycle() (ok bool, err error) {
+	return c.PowerSet(c.context(), "cycle")
+}
+
+// PowerReset resets the machine,
+// PowerReset implements the PowerController interface.
+func (c *Conn) PowerReset() (ok bool, err error) {
+	return c.PowerSet(c.context(), "reset")
+}
+
+// GetEventLog returns the entries of the system event log (SEL),
+// GetEventLog implements the EventLogReader interface.
+func (c *Conn) GetEventLog() (entries []devices.EventLogEntry, err error) {
+	return c.con.ReadSystemEventLog(c.context())
+}
+
+// ClearEventLog clears the system event log (SEL),
+// ClearEventLog implements the EventLogReader interface.
+func (c *Conn) ClearEventLog() (err error) {
+	_, err = c.con.ClearSystemEventLog(c.context())
+	return err
+}
+
+// ChassisStatus returns the chassis power and fault status
+func (c *Conn) ChassisStatus(ctx context.Context) (status *ChassisStatus, err error) {
+	return c.con.ChassisStatus(ctx)
+}
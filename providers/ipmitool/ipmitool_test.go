@@ -2,34 +2,81 @@ package ipmitool
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"io/ioutil"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bmc-toolbox/bmclib/internal/ipmi"
 	"github.com/bmc-toolbox/bmclib/logging"
+	"github.com/go-logr/logr"
 )
 
+// fakeIpmitool answers the ipmitool commands used by the provider with captured outputs,
+// the connection flags preceding the command are skipped.
+const fakeIpmitool = `#!/bin/sh
+while [ $# -gt 0 ]; do
+	case "$1" in
+	-I|-U|-N|-H|-p) shift 2 ;;
+	-E) shift ;;
+	*) break ;;
+	esac
+done
+
+case "$*" in
+"chassis power status") echo "Chassis Power is on" ;;
+"chassis power on") echo "Chassis Power Control: Up/On" ;;
+"chassis power off") echo "Chassis Power Control: Down/Off" ;;
+"chassis power reset") echo "Chassis Power Control: Reset" ;;
+"chassis power cycle") echo "Chassis Power Control: Cycle" ;;
+"chassis power soft") echo "Chassis Power Control: Soft" ;;
+"chassis status")
+	echo "System Power         : on"
+	echo "Power Overload       : false"
+	echo "Power Interlock      : inactive"
+	echo "Main Power Fault     : false"
+	echo "Power Control Fault  : false"
+	echo "Power Restore Policy : always-off"
+	echo "Last Power Event     : ac-failed"
+	echo "Chassis Intrusion    : active"
+	echo "Front-Panel Lockout  : inactive"
+	echo "Drive Fault          : false"
+	echo "Cooling/Fan Fault    : false"
+	;;
+"sel elist")
+	echo "   1 | Pre-Init  |0000000000| System Event #0x83 | Timestamp Clock Sync | Asserted"
+	echo "   2 | 06/09/2021 | 09:34:55 | Power Supply PS2 Status | Power Supply AC lost | Asserted"
+	echo "   3 | 06/09/2021 | 09:36:12 | Power Supply PS2 Status | Power Supply AC lost | Deasserted"
+	echo "   4 | 06/10/2021 | 14:02:41 | Temperature CPU1 Temp | Upper Non-critical going high | Asserted | Reading 86 > Threshold 85 degrees C"
+	echo "   5 | 06/10/2021 | 14:05:03 | Memory #0x53 | Uncorrectable ECC | Asserted"
+	echo "   6 | 06/11/2021 | 08:00:00 | Event Logging Disabled #0x07 | Log area reset/cleared | Asserted"
+	;;
+"sel clear") echo "Clearing SEL.  Please allow a few seconds to erase." ;;
+*)
+	echo "Invalid command: $*"
+	exit 1
+	;;
+esac
+`
+
 func TestMain(m *testing.M) {
-	var tempDir string
-	_, err := exec.LookPath("ipmitool")
+	// the fake ipmitool goes first in the PATH so the tests never reach a real BMC
+	tempDir, err := ioutil.TempDir("", "")
 	if err != nil {
-		tempDir, err = ioutil.TempDir("/tmp", "")
-		if err != nil {
-			os.Exit(2)
-		}
-		path := os.Getenv("PATH") + ":" + tempDir
-		os.Setenv("PATH", path)
-		fmt.Println(os.Getenv("PATH"))
-		f := filepath.Join(tempDir, "ipmitool")
-		err = ioutil.WriteFile(f, []byte{}, 0755)
-		if err != nil {
-			os.RemoveAll(tempDir)
-			os.Exit(3)
-		}
+		os.Exit(2)
+	}
+	os.Setenv("PATH", tempDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	f := filepath.Join(tempDir, "ipmitool")
+	err = ioutil.WriteFile(f, []byte(fakeIpmitool), 0755)
+	if err != nil {
+		os.RemoveAll(tempDir)
+		os.Exit(3)
 	}
 
 	code := m.Run()
@@ -37,6 +84,138 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
+func newTestConn(t *testing.T) *Conn {
+	c := &Conn{
+		Host: "127.0.0.1",
+		Port: "623",
+		User: "ADMIN",
+		Pass: "ADMIN",
+		Log:  logr.Discard(),
+	}
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func TestOpenIpmitoolNotFound(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	c := Conn{Host: "127.0.0.1", Port: "623", User: "ADMIN", Pass: "ADMIN", Log: logr.Discard()}
+	err := c.Open(context.Background())
+	if !errors.Is(err, bmclibErrs.ErrIpmitoolNotFound) {
+		t.Errorf("Expected answer %v: found %v", bmclibErrs.ErrIpmitoolNotFound, err)
+	}
+}
+
+func TestPowerControl(t *testing.T) {
+	c := newTestConn(t)
+
+	state, err := c.PowerStateGet(context.Background())
+	if err != nil {
+		t.Fatalf("Found errors calling c.PowerStateGet %v", err)
+	}
+	if !strings.Contains(state, "Chassis Power is on") {
+		t.Errorf("Expected answer %v: found %v", "Chassis Power is on", state)
+	}
+
+	tt := []struct {
+		state    string
+		expected bool
+		wantErr  bool
+	}{
+		{"on", true, false},
+		{"off", true, false},
+		{"soft", true, false},
+		{"reset", true, false},
+		{"cycle", true, false},
+		{"unknown", false, true},
+	}
+
+	for _, tc := range tt {
+		ok, err := c.PowerSet(context.Background(), tc.state)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("Found errors calling c.PowerSet(%s) %v", tc.state, err)
+		}
+		if ok != tc.expected {
+			t.Errorf("Expected answer %v: found %v", tc.expected, ok)
+		}
+	}
+}
+
+func TestPowerController(t *testing.T) {
+	c := newTestConn(t)
+
+	state, err := c.PowerState()
+	if err != nil {
+		t.Fatalf("Found errors calling c.PowerState %v", err)
+	}
+	if !strings.Contains(state, "Chassis Power is on") {
+		t.Errorf("Expected answer %v: found %v", "Chassis Power is on", state)
+	}
+
+	for name, power := range map[string]func() (bool, error){
+		"PowerOn":    c.PowerOn,
+		"PowerOff":   c.PowerOff,
+		"PowerCycle": c.PowerCycle,
+		"PowerReset": c.PowerReset,
+	} {
+		ok, err := power()
+		if err != nil {
+			t.Fatalf("Found errors calling c.%s %v", name, err)
+		}
+		if !ok {
+			t.Errorf("Expected answer %v: found %v", true, ok)
+		}
+	}
+}
+
+func TestChassisStatus(t *testing.T) {
+	c := newTestConn(t)
+
+	status, err := c.ChassisStatus(context.Background())
+	if err != nil {
+		t.Fatalf("Found errors calling c.ChassisStatus %v", err)
+	}
+
+	expected := &ChassisStatus{
+		PowerOn:            true,
+		PowerRestorePolicy: "always-off",
+		LastPowerEvent:     "ac-failed",
+		ChassisIntrusion:   true,
+	}
+	if !reflect.DeepEqual(expected, status) {
+		t.Errorf("Expected answer %+v: found %+v", expected, status)
+	}
+}
+
+func TestGetEventLog(t *testing.T) {
+	c := newTestConn(t)
+
+	entries, err := c.GetEventLog()
+	if err != nil {
+		t.Fatalf("Found errors calling c.GetEventLog %v", err)
+	}
+
+	expected := []devices.EventLogEntry{
+		{ID: "1", Sensor: "System Event #0x83", EventType: "Asserted", Severity: devices.SeverityOK, Message: "Timestamp Clock Sync"},
+		{ID: "2", Timestamp: time.Date(2021, 6, 9, 9, 34, 55, 0, time.UTC), Sensor: "Power Supply PS2 Status", EventType: "Asserted", Severity: devices.SeverityCritical, Message: "Power Supply AC lost"},
+		{ID: "3", Timestamp: time.Date(2021, 6, 9, 9, 36, 12, 0, time.UTC), Sensor: "Power Supply PS2 Status", EventType: "Deasserted", Severity: devices.SeverityOK, Message: "Power Supply AC lost"},
+		{ID: "4", Timestamp: time.Date(2021, 6, 10, 14, 2, 41, 0, time.UTC), Sensor: "Temperature CPU1 Temp", EventType: "Asserted", Severity: devices.SeverityWarning, Message: "Upper Non-critical going high: Reading 86 > Threshold 85 degrees C"},
+		{ID: "5", Timestamp: time.Date(2021, 6, 10, 14, 5, 3, 0, time.UTC), Sensor: "Memory #0x53", EventType: "Asserted", Severity: devices.SeverityCritical, Message: "Uncorrectable ECC"},
+		{ID: "6", Timestamp: time.Date(2021, 6, 11, 8, 0, 0, 0, time.UTC), Sensor: "Event Logging Disabled #0x07", EventType: "Asserted", Severity: devices.SeverityOK, Message: "Log area reset/cleared"},
+	}
+	if !reflect.DeepEqual(expected, entries) {
+		t.Errorf("Expected answer %+v: found %+v", expected, entries)
+	}
+
+	err = c.ClearEventLog()
+	if err != nil {
+		t.Fatalf("Found errors calling c.ClearEventLog %v", err)
+	}
+}
+
 func TestPowerState(t *testing.T) {
 	t.Skip("need real ipmi server")
 	user := "ADMIN"
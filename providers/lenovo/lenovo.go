@@ -0,0 +1,8 @@
+package lenovo
+
+import "github.com/bmc-toolbox/bmclib/devices"
+
+const (
+	// VendorID represents the id of the vendor across all packages
+	VendorID = devices.Lenovo
+)
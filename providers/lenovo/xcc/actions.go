@@ -0,0 +1,122 @@
+package xcc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the PowerController interface.
+var _ devices.PowerController = (*XCC)(nil)
+
+// reset calls the ComputerSystem.Reset action of the server with the given ResetType
+func (x *XCC) reset(resetType string) (status bool, err error) {
+	_, err = x.post(systemURI+"/Actions/ComputerSystem.Reset", &ResetRequest{ResetType: resetType})
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", resetType, err)
+	}
+
+	return true, nil
+}
+
+// PowerState returns the current power state of the machine
+func (x *XCC) PowerState() (state string, err error) {
+	defer x.wrapError("PowerState", &err)
+
+	system, err := x.system()
+	if err != nil {
+		return state, err
+	}
+
+	return strings.ToLower(system.PowerState), nil
+}
+
+// IsOn tells if a machine is currently powered on
+func (x *XCC) IsOn() (status bool, err error) {
+	state, err := x.PowerState()
+	if err != nil {
+		return false, err
+	}
+
+	return state == "on", nil
+}
+
+// PowerOn power on the machine via bmc
+func (x *XCC) PowerOn() (status bool, err error) {
+	defer x.wrapError("PowerOn", &err)
+
+	return x.reset("On")
+}
+
+// PowerOff power off the machine via bmc
+func (x *XCC) PowerOff() (status bool, err error) {
+	defer x.wrapError("PowerOff", &err)
+
+	return x.reset("ForceOff")
+}
+
+// PowerCycle reboots the machine via bmc
+func (x *XCC) PowerCycle() (status bool, err error) {
+	defer x.wrapError("PowerCycle", &err)
+
+	return x.reset("PowerCycle")
+}
+
+// PowerReset resets the machine via bmc without cutting its power
+func (x *XCC) PowerReset() (status bool, err error) {
+	defer x.wrapError("PowerReset", &err)
+
+	return x.reset("ForceRestart")
+}
+
+// PowerCycleBmc reboots the bmc we are connected to
+func (x *XCC) PowerCycleBmc() (status bool, err error) {
+	defer x.wrapError("PowerCycleBmc", &err)
+
+	_, err = x.post(managerURI+"/Actions/Manager.Reset", &ResetRequest{ResetType: "GracefulRestart"})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// PxeOnce makes the machine to boot via pxe once, restarting it or powering it on
+func (x *XCC) PxeOnce() (status bool, err error) {
+	defer x.wrapError("PxeOnce", &err)
+
+	override := &BootOverrideRequest{}
+	override.Boot.BootSourceOverrideEnabled = "Once"
+	override.Boot.BootSourceOverrideTarget = "Pxe"
+
+	_, err = x.patch(systemURI, override)
+	if err != nil {
+		return false, err
+	}
+
+	isOn, err := x.IsOn()
+	if err != nil {
+		return false, err
+	}
+
+	if isOn {
+		return x.reset("ForceRestart")
+	}
+
+	return x.reset("On")
+}
+
+// UpdateFirmware isn't supported on XCC yet
+func (x *XCC) UpdateFirmware(source, file string) (status bool, output string, err error) {
+	return false, "", errors.NewFeatureUnsupportedError("firmware update", x.Vendor(), x.HardwareType())
+}
+
+// CheckFirmwareVersion returns the version of the bmc firmware
+func (x *XCC) CheckFirmwareVersion() (version string, err error) {
+	defer x.wrapError("CheckFirmwareVersion", &err)
+
+	return x.Version()
+}
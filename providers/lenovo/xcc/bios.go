@@ -0,0 +1,27 @@
+package xcc
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the BIOSConfigurator interface.
+var _ devices.BIOSConfigurator = (*XCC)(nil)
+
+// GetBIOSSettings isn't supported on XCC yet
+func (x *XCC) GetBIOSSettings(ctx context.Context) (*devices.BIOSSettings, error) {
+	return nil, errors.NewFeatureUnsupportedError("BIOS settings", x.Vendor(), x.HardwareType())
+}
+
+// SetBIOSSettings isn't supported on XCC yet
+func (x *XCC) SetBIOSSettings(ctx context.Context, settings *devices.BIOSSettings) (bool, error) {
+	return false, errors.NewFeatureUnsupportedError("BIOS settings", x.Vendor(), x.HardwareType())
+}
+
+// PendingBIOSSettings isn't supported on XCC yet
+func (x *XCC) PendingBIOSSettings(ctx context.Context) (*devices.BIOSSettings, error) {
+	return nil, errors.NewFeatureUnsupportedError("BIOS settings", x.Vendor(), x.HardwareType())
+}
@@ -0,0 +1,28 @@
+package xcc
+
+import (
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/providers"
+	"github.com/jacobweinstock/registrar"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the CapabilityReporter interface.
+var _ devices.CapabilityReporter = (*XCC)(nil)
+
+// Capabilities returns the operations supported by the XCC
+func (x *XCC) Capabilities() registrar.Features {
+	return registrar.Features{
+		providers.FeaturePowerState,
+		providers.FeaturePowerSet,
+		providers.FeatureBmcReset,
+		providers.FeatureEventLogRead,
+		providers.FeatureSensorRead,
+		providers.FeatureFirmwareInventory,
+		providers.FeatureTimeSyncVerify,
+		providers.FeatureUserCreate,
+		providers.FeatureUserDelete,
+		providers.FeatureUserUpdate,
+		providers.FeatureUserRead,
+	}
+}
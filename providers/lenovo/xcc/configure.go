@@ -0,0 +1,101 @@
+package xcc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/cfgresources"
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the Configure interface.
+var _ devices.Configure = (*XCC)(nil)
+
+// Resources returns a slice of supported resources and
+// the order they are to be applied in, none is supported on XCC yet.
+func (x *XCC) Resources() []string {
+	return []string{}
+}
+
+// ApplyCfg implements the Bmc interface
+// this is to be deprecated.
+func (x *XCC) ApplyCfg(config *cfgresources.ResourcesConfig) (err error) {
+	return err
+}
+
+// User isn't supported on XCC yet, the accounts are managed through the UserManager interface
+func (x *XCC) User(users []*cfgresources.User) error {
+	return errors.NewFeatureUnsupportedError("user configuration", x.Vendor(), x.HardwareType())
+}
+
+// Syslog isn't supported on XCC yet
+func (x *XCC) Syslog(cfg *cfgresources.Syslog) error {
+	return errors.NewFeatureUnsupportedError("syslog configuration", x.Vendor(), x.HardwareType())
+}
+
+// Ntp isn't supported on XCC yet
+func (x *XCC) Ntp(cfg *cfgresources.Ntp) error {
+	return errors.NewFeatureUnsupportedError("ntp configuration", x.Vendor(), x.HardwareType())
+}
+
+// Ldap isn't supported on XCC yet
+func (x *XCC) Ldap(cfg *cfgresources.Ldap) error {
+	return errors.NewFeatureUnsupportedError("ldap configuration", x.Vendor(), x.HardwareType())
+}
+
+// LdapGroups isn't supported on XCC yet
+func (x *XCC) LdapGroups(cfgGroups []*cfgresources.LdapGroup, cfgLdap *cfgresources.Ldap) error {
+	return errors.NewFeatureUnsupportedError("ldap configuration", x.Vendor(), x.HardwareType())
+}
+
+// Network isn't supported on XCC yet
+func (x *XCC) Network(cfg *cfgresources.Network) (bool, error) {
+	return false, errors.NewFeatureUnsupportedError("network configuration", x.Vendor(), x.HardwareType())
+}
+
+// SetLicense isn't supported on XCC yet
+func (x *XCC) SetLicense(cfg *cfgresources.License) error {
+	return errors.NewFeatureUnsupportedError("license", x.Vendor(), x.HardwareType())
+}
+
+// Bios isn't supported on XCC yet
+func (x *XCC) Bios(cfg *cfgresources.Bios) error {
+	return errors.NewFeatureUnsupportedError("BIOS settings", x.Vendor(), x.HardwareType())
+}
+
+// Power isn't supported on XCC yet
+func (x *XCC) Power(cfg *cfgresources.Power) error {
+	return errors.NewFeatureUnsupportedError("power configuration", x.Vendor(), x.HardwareType())
+}
+
+// CurrentHTTPSCert returns the current x509 certficates configured on the BMC
+// the bool value returned is set to true if the BMC support CSR generation.
+// CurrentHTTPSCert implements the Configure interface.
+func (x *XCC) CurrentHTTPSCert() ([]*x509.Certificate, bool, error) {
+	dialer := &net.Dialer{
+		Timeout: time.Duration(10) * time.Second,
+	}
+
+	conn, err := tls.DialWithDialer(dialer, "tcp", x.ip+":"+"443", &tls.Config{InsecureSkipVerify: true})
+	if err != nil {
+		return []*x509.Certificate{{}}, false, err
+	}
+
+	defer conn.Close()
+
+	return conn.ConnectionState().PeerCertificates, false, nil
+}
+
+// GenerateCSR isn't supported on XCC yet
+func (x *XCC) GenerateCSR(cert *cfgresources.HTTPSCertAttributes) ([]byte, error) {
+	return nil, errors.NewFeatureUnsupportedError("CSR generation", x.Vendor(), x.HardwareType())
+}
+
+// UploadHTTPSCert isn't supported on XCC yet
+func (x *XCC) UploadHTTPSCert(cert []byte, certFileName string, key []byte, keyFileName string) (bool, error) {
+	return false, errors.NewFeatureUnsupportedError("HTTPS certificate upload", x.Vendor(), x.HardwareType())
+}
@@ -0,0 +1,49 @@
+package xcc
+
+import (
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+// selURI is the Redfish LogService of the system event log (SEL)
+const selURI = systemURI + "/LogServices/SEL"
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the EventLogReader interface.
+var _ devices.EventLogReader = (*XCC)(nil)
+
+// GetEventLog returns the entries of the system event log (SEL) of the bmc
+func (x *XCC) GetEventLog() (entries []devices.EventLogEntry, err error) {
+	defer x.wrapError("GetEventLog", &err)
+
+	logEntries := &LogEntries{}
+	err = x.getJSON(selURI+"/Entries", logEntries)
+	if err != nil {
+		return entries, err
+	}
+
+	for _, e := range logEntries.Members {
+		entry := devices.EventLogEntry{
+			ID:        e.ID,
+			Sensor:    e.SensorType,
+			EventType: e.EntryType,
+			Severity:  devices.NormalizeSeverity(e.Severity),
+			Message:   e.Message,
+		}
+		if timestamp, err := time.Parse(time.RFC3339, e.Created); err == nil {
+			entry.Timestamp = timestamp.UTC()
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// ClearEventLog clears the system event log (SEL) of the bmc
+func (x *XCC) ClearEventLog() (err error) {
+	defer x.wrapError("ClearEventLog", &err)
+
+	_, err = x.post(selURI+"/Actions/LogService.ClearLog", struct{}{})
+	return err
+}
@@ -0,0 +1,29 @@
+package xcc
+
+import (
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the FirmwareInventorier interface.
+var _ devices.FirmwareInventorier = (*XCC)(nil)
+
+// GetFirmwareInventory returns the firmware of the XCC and the UEFI of the server
+func (x *XCC) GetFirmwareInventory() (inventory []devices.Firmware, err error) {
+	defer x.wrapError("GetFirmwareInventory", &err)
+
+	bmcVersion, err := x.Version()
+	if err != nil {
+		return inventory, err
+	}
+
+	biosVersion, err := x.BiosVersion()
+	if err != nil {
+		return inventory, err
+	}
+
+	return []devices.Firmware{
+		{Component: "BMC", Installed: bmcVersion},
+		{Component: "BIOS", Installed: biosVersion},
+	}, nil
+}
@@ -0,0 +1,115 @@
+package xcc
+
+import "github.com/bmc-toolbox/bmclib/devices"
+
+// Chassis is the Redfish Chassis resource of the server, /redfish/v1/Chassis/1
+type Chassis struct {
+	devices.RedfishChassis
+	ChassisType string `json:"ChassisType"`
+}
+
+// Processor is a Redfish Processor of the server
+type Processor struct {
+	ID            string                `json:"Id"`
+	Socket        string                `json:"Socket"`
+	ProcessorType string                `json:"ProcessorType"`
+	Manufacturer  string                `json:"Manufacturer"`
+	Model         string                `json:"Model"`
+	MaxSpeedMHz   int64                 `json:"MaxSpeedMHz"`
+	TotalCores    int                   `json:"TotalCores"`
+	TotalThreads  int                   `json:"TotalThreads"`
+	Status        devices.RedfishStatus `json:"Status"`
+}
+
+// EthernetInterface is a Redfish EthernetInterface of the XCC or of the server
+type EthernetInterface struct {
+	ID         string `json:"Id"`
+	MACAddress string `json:"MACAddress"`
+	SpeedMbps  int    `json:"SpeedMbps"`
+	MTUSize    int    `json:"MTUSize"`
+	LinkStatus string `json:"LinkStatus"`
+}
+
+// Manager is the Redfish Manager resource of the XCC, /redfish/v1/Managers/1
+type Manager struct {
+	DateTime        string                `json:"DateTime"`
+	FirmwareVersion string                `json:"FirmwareVersion"`
+	Model           string                `json:"Model"`
+	Status          devices.RedfishStatus `json:"Status"`
+}
+
+// Power is the Redfish Power resource of the chassis, /redfish/v1/Chassis/1/Power
+type Power struct {
+	PowerControl []struct {
+		PowerConsumedWatts float64 `json:"PowerConsumedWatts"`
+	} `json:"PowerControl"`
+	PowerSupplies []struct {
+		MemberID           string                `json:"MemberId"`
+		Name               string                `json:"Name"`
+		SerialNumber       string                `json:"SerialNumber"`
+		PartNumber         string                `json:"PartNumber"`
+		PowerCapacityWatts float64               `json:"PowerCapacityWatts"`
+		PowerOutputWatts   float64               `json:"PowerOutputWatts"`
+		Status             devices.RedfishStatus `json:"Status"`
+	} `json:"PowerSupplies"`
+}
+
+// Thermal is the Redfish Thermal resource of the chassis, /redfish/v1/Chassis/1/Thermal
+type Thermal struct {
+	Temperatures []struct {
+		Name            string                `json:"Name"`
+		PhysicalContext string                `json:"PhysicalContext"`
+		ReadingCelsius  float64               `json:"ReadingCelsius"`
+		Status          devices.RedfishStatus `json:"Status"`
+	} `json:"Temperatures"`
+	Fans []struct {
+		Name         string                `json:"Name"`
+		Reading      int64                 `json:"Reading"`
+		ReadingUnits string                `json:"ReadingUnits"`
+		Status       devices.RedfishStatus `json:"Status"`
+	} `json:"Fans"`
+}
+
+// Link is a reference to another Redfish resource
+type Link struct {
+	ODataID string `json:"@odata.id"`
+}
+
+// Collection is a Redfish resource collection
+type Collection struct {
+	Members []Link `json:"Members"`
+}
+
+// LogEntries is a Redfish LogEntry collection, as listed by the Entries of a LogService
+type LogEntries struct {
+	Members []*LogEntry `json:"Members"`
+}
+
+// LogEntry is a Redfish LogEntry
+type LogEntry struct {
+	ID         string `json:"Id"`
+	Created    string `json:"Created"`
+	EntryType  string `json:"EntryType"`
+	SensorType string `json:"SensorType"`
+	Severity   string `json:"Severity"`
+	Message    string `json:"Message"`
+}
+
+// BootOverrideRequest is the payload overriding the boot source of the server
+type BootOverrideRequest struct {
+	Boot struct {
+		BootSourceOverrideEnabled string `json:"BootSourceOverrideEnabled"`
+		BootSourceOverrideTarget  string `json:"BootSourceOverrideTarget"`
+	} `json:"Boot"`
+}
+
+// ResetRequest is the payload of the Reset actions
+type ResetRequest struct {
+	ResetType string `json:"ResetType"`
+}
+
+// SessionRequest is the payload creating a Redfish session
+type SessionRequest struct {
+	UserName string `json:"UserName"`
+	Password string `json:"Password"`
+}
@@ -0,0 +1,22 @@
+package xcc
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the PowerCapper interface.
+var _ devices.PowerCapper = (*XCC)(nil)
+
+// GetPowerCap isn't supported on XCC yet
+func (x *XCC) GetPowerCap(ctx context.Context) (devices.PowerCap, error) {
+	return devices.PowerCap{}, errors.NewFeatureUnsupportedError("power cap", x.Vendor(), x.HardwareType())
+}
+
+// SetPowerCap isn't supported on XCC yet
+func (x *XCC) SetPowerCap(ctx context.Context, powerCap devices.PowerCap) error {
+	return errors.NewFeatureUnsupportedError("power cap", x.Vendor(), x.HardwareType())
+}
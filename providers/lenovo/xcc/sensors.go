@@ -0,0 +1,124 @@
+package xcc
+
+import (
+	"strings"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the SensorReader interface.
+var _ devices.SensorReader = (*XCC)(nil)
+
+// Fans returns the fans listed by the Redfish Thermal resource of the chassis,
+// the empty fan bays are left out
+func (x *XCC) Fans() (fans []*devices.Fan, err error) {
+	defer x.wrapError("Fans", &err)
+
+	thermal, err := x.thermal()
+	if err != nil {
+		return fans, err
+	}
+
+	for idx, fan := range thermal.Fans {
+		if fan.Status.State == "Absent" {
+			continue
+		}
+
+		fans = append(fans, &devices.Fan{
+			Name:       fan.Name,
+			Status:     fan.Status.Health,
+			Position:   idx + 1,
+			Present:    true,
+			CurrentRPM: fan.Reading,
+		})
+	}
+
+	return fans, nil
+}
+
+// PSUs returns the power supplies of the server
+func (x *XCC) PSUs() (psus []*devices.Psu, err error) {
+	defer x.wrapError("PSUs", &err)
+
+	return x.Psus()
+}
+
+// Temperatures returns the temperature sensors listed by the Redfish Thermal resource of the chassis
+func (x *XCC) Temperatures() (temperatures []*devices.TemperatureSensor, err error) {
+	defer x.wrapError("Temperatures", &err)
+
+	thermal, err := x.thermal()
+	if err != nil {
+		return temperatures, err
+	}
+
+	for _, t := range thermal.Temperatures {
+		if t.Status.State == "Absent" {
+			continue
+		}
+
+		temperatures = append(temperatures, &devices.TemperatureSensor{
+			Name:     t.Name,
+			Location: strings.TrimSpace(strings.TrimSuffix(t.Name, "Temp")),
+			Reading:  t.ReadingCelsius,
+			Unit:     devices.TemperatureUnitCelsius,
+			Status:   t.Status.Health,
+		})
+	}
+
+	return temperatures, nil
+}
+
+// HealthSensors returns the health of the temperature sensors, fans and power supplies of the server
+func (x *XCC) HealthSensors() (healthSensors []*devices.HealthSensor, err error) {
+	defer x.wrapError("HealthSensors", &err)
+
+	temperatures, err := x.Temperatures()
+	if err != nil {
+		return healthSensors, err
+	}
+
+	for _, t := range temperatures {
+		healthSensors = append(healthSensors, &devices.HealthSensor{
+			Name:    t.Name,
+			Type:    "Temperature",
+			Reading: t.Reading,
+			Health:  devices.NormalizeHealth(t.Status),
+		})
+	}
+
+	fans, err := x.Fans()
+	if err != nil {
+		return healthSensors, err
+	}
+
+	for _, fan := range fans {
+		healthSensors = append(healthSensors, &devices.HealthSensor{
+			Name:    fan.Name,
+			Type:    "Fan",
+			Reading: float64(fan.CurrentRPM),
+			Health:  devices.NormalizeHealth(fan.Status),
+		})
+	}
+
+	power, err := x.power()
+	if err != nil {
+		return healthSensors, err
+	}
+
+	for _, psu := range power.PowerSupplies {
+		if psu.Status.State == "Absent" {
+			continue
+		}
+
+		healthSensors = append(healthSensors, &devices.HealthSensor{
+			Name:    psu.Name,
+			Type:    "Power Supply",
+			Reading: psu.PowerOutputWatts,
+			Health:  devices.NormalizeHealth(psu.Status.Health),
+		})
+	}
+
+	return healthSensors, nil
+}
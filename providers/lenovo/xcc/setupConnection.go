@@ -0,0 +1,210 @@
+package xcc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/bmc-toolbox/bmclib/errors"
+	"github.com/bmc-toolbox/bmclib/internal/httpclient"
+	"github.com/bmc-toolbox/bmclib/providers/lenovo"
+)
+
+const (
+	// sessionHeader is the header holding the Redfish session token
+	sessionHeader = "X-Auth-Token"
+	// sessionsURI is the Redfish collection where the sessions are created
+	sessionsURI = "redfish/v1/SessionService/Sessions"
+)
+
+// httpLogin initiates the connection to an XCC device with a Redfish session
+func (x *XCC) httpLogin() (err error) {
+	if x.httpClient != nil {
+		return
+	}
+
+	httpClient, err := httpclient.Build(x.httpClientSetupFuncs...)
+	if err != nil {
+		return err
+	}
+
+	x.log.V(1).Info("connecting to bmc", "step", "bmc connection", "vendor", lenovo.VendorID, "ip", x.ip)
+
+	data, err := json.Marshal(&SessionRequest{UserName: x.username, Password: x.password})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("https://%s/%s", x.ip, sessionsURI), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return errors.WrapRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	payload, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode {
+	case 200, 201:
+	case 401:
+		return fmt.Errorf("login to %s rejected: %w", x.ip, errors.ErrInvalidCredentials)
+	case 404:
+		return errors.ErrPageNotFound
+	default:
+		return fmt.Errorf("login to %s: %w", x.ip, errors.NewHTTPErrorFromResponse(resp, payload))
+	}
+
+	x.sessionToken = resp.Header.Get(sessionHeader)
+	if x.sessionToken == "" {
+		return fmt.Errorf("login to %s returned no session token: %w", x.ip, errors.ErrLoginFailed)
+	}
+	x.sessionURI = resp.Header.Get("Location")
+	x.httpClient = httpClient
+
+	return err
+}
+
+// sessionURL returns the absolute URL of the session, the Location header is usually a path
+func (x *XCC) sessionURL() string {
+	if strings.HasPrefix(x.sessionURI, "https://") {
+		return x.sessionURI
+	}
+
+	return fmt.Sprintf("https://%s/%s", x.ip, strings.TrimPrefix(x.sessionURI, "/"))
+}
+
+// get calls a given Redfish endpoint of the XCC and returns the data
+func (x *XCC) get(endpoint string) (payload []byte, err error) {
+	err = x.httpLogin()
+	if err != nil {
+		return nil, err
+	}
+
+	bmcURL := fmt.Sprintf("https://%s/%s", x.ip, endpoint)
+	req, err := http.NewRequest("GET", bmcURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	x.sessionAuth.Authorize(req, x.sessionToken)
+
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
+	x.log.V(2).Info("", "request", bmcURL, "requestDump", string(reqDump))
+
+	resp, err := x.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.WrapRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	respDump, _ := httpclient.DumpResponse(resp, true)
+	x.log.V(2).Info("", "responseDump", string(respDump))
+
+	payload, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.NewHTTPErrorFromResponse(resp, payload)
+	}
+
+	return payload, nil
+}
+
+// post sends the json encoding of data to the given Redfish endpoint of the XCC
+func (x *XCC) post(endpoint string, data interface{}) (statusCode int, err error) {
+	return x.send("POST", endpoint, data)
+}
+
+// patch updates the given Redfish resource of the XCC with the json encoding of data
+func (x *XCC) patch(endpoint string, data interface{}) (statusCode int, err error) {
+	return x.send("PATCH", endpoint, data)
+}
+
+// send sends the json encoding of data to the given Redfish endpoint of the XCC with method
+func (x *XCC) send(method, endpoint string, data interface{}) (statusCode int, err error) {
+	err = x.httpLogin()
+	if err != nil {
+		return statusCode, err
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return statusCode, err
+	}
+
+	bmcURL := fmt.Sprintf("https://%s/%s", x.ip, endpoint)
+	req, err := http.NewRequest(method, bmcURL, bytes.NewReader(body))
+	if err != nil {
+		return statusCode, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	x.sessionAuth.Authorize(req, x.sessionToken)
+
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
+	x.log.V(2).Info("", "url", bmcURL, "requestDump", string(reqDump))
+
+	resp, err := x.httpClient.Do(req)
+	if err != nil {
+		return statusCode, errors.WrapRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	respDump, _ := httpclient.DumpResponse(resp, true)
+	x.log.V(2).Info("", "responseDump", string(respDump))
+
+	statusCode = resp.StatusCode
+	payload, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return statusCode, err
+	}
+
+	// the Redfish actions and updates answer 200 with a body or 204 without
+	if statusCode != 200 && statusCode != 204 {
+		return statusCode, errors.NewHTTPErrorFromResponse(resp, payload)
+	}
+
+	return statusCode, nil
+}
+
+// Close closes the connection properly by deleting the Redfish session
+func (x *XCC) Close(ctx context.Context) (err error) {
+	if x.httpClient == nil || x.sessionURI == "" {
+		return err
+	}
+
+	x.log.V(1).Info("logout from bmc", "step", "bmc connection", "vendor", lenovo.VendorID, "ip", x.ip)
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", x.sessionURL(), nil)
+	if err != nil {
+		return err
+	}
+	x.sessionAuth.Authorize(req, x.sessionToken)
+
+	resp, err := x.httpClient.Do(req)
+	if err != nil {
+		return errors.WrapRequestError(err)
+	}
+	defer resp.Body.Close()
+	defer io.Copy(ioutil.Discard, resp.Body) // nolint
+
+	x.httpClient = nil
+	x.sessionToken = ""
+	x.sessionURI = ""
+
+	return err
+}
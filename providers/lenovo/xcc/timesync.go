@@ -0,0 +1,37 @@
+package xcc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the TimeSyncVerifier interface.
+var _ devices.TimeSyncVerifier = (*XCC)(nil)
+
+// dateTime returns the current time of the XCC
+func (x *XCC) dateTime() (time.Time, error) {
+	manager, err := x.manager()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	dateTime, err := time.Parse(time.RFC3339, manager.DateTime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("DateTime %q: %w", manager.DateTime, errors.ErrUnableToReadData)
+	}
+
+	return dateTime, nil
+}
+
+// VerifyTimeSync compares the DateTime of the XCC to the reference clock set with WithTimeReference,
+// the local clock by default, and returns the offset of the XCC clock and whether it is within the tolerance.
+func (x *XCC) VerifyTimeSync(ctx context.Context, tolerance time.Duration) (offset time.Duration, inSync bool, err error) {
+	defer x.wrapError("VerifyTimeSync", &err)
+
+	return devices.MeasureTimeOffset(x.timeReference, tolerance, x.dateTime)
+}
@@ -0,0 +1,207 @@
+package xcc
+
+import (
+	"fmt"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// accountsURI is the Redfish collection of the user accounts of the XCC
+const accountsURI = "redfish/v1/AccountService/Accounts"
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the UserManager interface.
+var _ devices.UserManager = (*XCC)(nil)
+
+// accounts returns the user account slots of the Redfish AccountService in the order the XCC lists them,
+// uris holds the location of each slot. The XCC has a fixed number of slots, the free ones have no user name.
+func (x *XCC) accounts() (uris []string, accounts []*devices.RedfishManagerAccount, err error) {
+	members, err := x.members(accountsURI)
+	if err != nil {
+		return uris, accounts, fmt.Errorf("%w: %s", errors.ErrRetrievingUserAccounts, err)
+	}
+
+	for _, member := range members {
+		account := &devices.RedfishManagerAccount{}
+		err = x.getJSON(member.ODataID, account)
+		if err != nil {
+			return uris, accounts, fmt.Errorf("%w: %s", errors.ErrRetrievingUserAccounts, err)
+		}
+		uris = append(uris, member.ODataID)
+		accounts = append(accounts, account)
+	}
+
+	return uris, accounts, nil
+}
+
+// findUser returns the location of the user account with the given name
+func (x *XCC) findUser(name string) (uri string, err error) {
+	uris, accounts, err := x.accounts()
+	if err != nil {
+		return uri, err
+	}
+
+	for idx, account := range accounts {
+		if account.UserName == name {
+			return uris[idx], nil
+		}
+	}
+
+	return uri, fmt.Errorf("%w: %s", errors.ErrUserAccountNotFound, name)
+}
+
+// ListUsers returns the user accounts configured on the XCC,
+// ListUsers implements the UserManager interface.
+func (x *XCC) ListUsers() (users []devices.User, err error) {
+	defer x.wrapError("ListUsers", &err)
+
+	_, accounts, err := x.accounts()
+	if err != nil {
+		return users, err
+	}
+
+	for _, account := range accounts {
+		if account.UserName == "" {
+			continue
+		}
+		users = append(users, account.ToUser())
+	}
+
+	return users, nil
+}
+
+// CreateUser creates a user account in the first free slot of the XCC,
+// CreateUser implements the UserManager interface.
+func (x *XCC) CreateUser(user devices.User) (err error) {
+	defer x.wrapError("CreateUser", &err)
+
+	if user.Name == "" || user.Password == "" {
+		return errors.ErrUserParamsRequired
+	}
+
+	err = devices.ValidatePassword(user.Password, x.passwordPolicy)
+	if err != nil {
+		return err
+	}
+
+	roleID, ok := devices.RedfishRoleID(user.Role)
+	if !ok {
+		return fmt.Errorf("%w: %s", errors.ErrInvalidUserRole, user.Role)
+	}
+
+	uris, accounts, err := x.accounts()
+	if err != nil {
+		return err
+	}
+
+	var slot string
+	for idx, account := range accounts {
+		if account.UserName == user.Name {
+			return fmt.Errorf("%w: %s", errors.ErrUserAccountExists, user.Name)
+		}
+		if account.UserName == "" && slot == "" {
+			slot = uris[idx]
+		}
+	}
+
+	if slot == "" {
+		return errors.ErrNoUserSlotsAvailable
+	}
+
+	_, err = x.patch(slot, &devices.RedfishManagerAccount{
+		UserName: user.Name,
+		Password: user.Password,
+		RoleID:   roleID,
+		Enabled:  true,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %s", errors.ErrUserAccountUpdate, err)
+	}
+
+	return nil
+}
+
+// ModifyUser sets the role of an existing user account, its password is changed as well
+// when user.Password is set and kept otherwise, ModifyUser implements the UserManager interface.
+func (x *XCC) ModifyUser(user devices.User) (err error) {
+	defer x.wrapError("ModifyUser", &err)
+
+	if user.Name == "" {
+		return errors.ErrUserParamsRequired
+	}
+
+	if user.Password != "" {
+		err = devices.ValidatePassword(user.Password, x.passwordPolicy)
+		if err != nil {
+			return err
+		}
+	}
+
+	roleID, ok := devices.RedfishRoleID(user.Role)
+	if !ok {
+		return fmt.Errorf("%w: %s", errors.ErrInvalidUserRole, user.Role)
+	}
+
+	uri, err := x.findUser(user.Name)
+	if err != nil {
+		return err
+	}
+
+	update := map[string]interface{}{"RoleId": roleID}
+	if user.Password != "" {
+		update["Password"] = user.Password
+	}
+
+	_, err = x.patch(uri, update)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errors.ErrUserAccountUpdate, err)
+	}
+
+	return nil
+}
+
+// DeleteUser removes a user account from the XCC by clearing its slot,
+// DeleteUser implements the UserManager interface.
+func (x *XCC) DeleteUser(name string) (err error) {
+	defer x.wrapError("DeleteUser", &err)
+
+	uri, err := x.findUser(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = x.patch(uri, map[string]interface{}{"UserName": "", "Enabled": false})
+	if err != nil {
+		return fmt.Errorf("%w: %s", errors.ErrUserAccountUpdate, err)
+	}
+
+	return nil
+}
+
+// ChangePassword sets the password of a user account, keeping its role,
+// ChangePassword implements the UserManager interface.
+func (x *XCC) ChangePassword(name string, password string) (err error) {
+	defer x.wrapError("ChangePassword", &err)
+
+	if name == "" || password == "" {
+		return errors.ErrUserParamsRequired
+	}
+
+	err = devices.ValidatePassword(password, x.passwordPolicy)
+	if err != nil {
+		return err
+	}
+
+	uri, err := x.findUser(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = x.patch(uri, map[string]string{"Password": password})
+	if err != nil {
+		return fmt.Errorf("%w: %s", errors.ErrUserAccountUpdate, err)
+	}
+
+	return nil
+}
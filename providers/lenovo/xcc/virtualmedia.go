@@ -0,0 +1,27 @@
+package xcc
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the VirtualMediaController interface.
+var _ devices.VirtualMediaController = (*XCC)(nil)
+
+// MountVirtualMedia isn't supported on XCC yet
+func (x *XCC) MountVirtualMedia(ctx context.Context, kind devices.MediaKind, image string) error {
+	return errors.NewFeatureUnsupportedError("virtual media", x.Vendor(), x.HardwareType())
+}
+
+// UnmountVirtualMedia isn't supported on XCC yet
+func (x *XCC) UnmountVirtualMedia(ctx context.Context, kind devices.MediaKind) error {
+	return errors.NewFeatureUnsupportedError("virtual media", x.Vendor(), x.HardwareType())
+}
+
+// VirtualMediaStatus isn't supported on XCC yet
+func (x *XCC) VirtualMediaStatus(ctx context.Context) ([]devices.VirtualMedia, error) {
+	return nil, errors.NewFeatureUnsupportedError("virtual media", x.Vendor(), x.HardwareType())
+}
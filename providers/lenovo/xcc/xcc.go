@@ -0,0 +1,628 @@
+package xcc
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+	"github.com/bmc-toolbox/bmclib/internal/httpclient"
+	"github.com/bmc-toolbox/bmclib/providers"
+	"github.com/go-logr/logr"
+
+	"github.com/bmc-toolbox/bmclib/providers/lenovo"
+)
+
+const (
+	// BmcType defines the bmc model that is supported by this package
+	BmcType = "xcc"
+
+	// the XCC exposes a single system, chassis and manager
+	systemURI  = "redfish/v1/Systems/1"
+	managerURI = "redfish/v1/Managers/1"
+	chassisURI = "redfish/v1/Chassis/1"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the Bmc interface.
+var _ devices.Bmc = (*XCC)(nil)
+
+// XCC holds the status and properties of a connection to a Lenovo XClarity Controller
+type XCC struct {
+	ip                   string
+	username             string
+	password             string
+	httpClient           *http.Client
+	ctx                  context.Context
+	log                  logr.Logger
+	httpClientSetupFuncs []func(*http.Client)
+	// sessionAuth places the Redfish session token on the requests
+	sessionAuth  httpclient.SessionAuth
+	sessionToken string
+	// sessionURI is the location of the Redfish session, deleted on Close
+	sessionURI string
+	// timeReference is the clock VerifyTimeSync compares the XCC clock to, time.Now when nil
+	timeReference func() time.Time
+	// passwordPolicy is checked by the user management before a password is sent to the BMC
+	passwordPolicy devices.PasswordPolicy
+}
+
+// XCCOption is a type that can configure a *XCC
+type XCCOption func(*XCC)
+
+// WithSecureTLS enforces trusted TLS connections, with an optional CA certificate pool.
+// Using this option with an nil pool uses the system CAs.
+func WithSecureTLS(rootCAs *x509.CertPool) XCCOption {
+	return func(x *XCC) {
+		x.httpClientSetupFuncs = append(x.httpClientSetupFuncs, httpclient.SecureTLSOption(rootCAs))
+	}
+}
+
+// WithInsecureTLS skips the verification of the BMC certificate, which is otherwise
+// verified against the system CAs. Use it for BMCs serving self-signed certificates.
+func WithInsecureTLS() XCCOption {
+	return func(x *XCC) {
+		x.httpClientSetupFuncs = append(x.httpClientSetupFuncs, httpclient.WithInsecureTLS())
+	}
+}
+
+// WithSecureTLSFromFile enforces trusted TLS connections using the CA certificates of a PEM file.
+// Requests fail with a loading error when the file is missing or holds no valid certificate.
+func WithSecureTLSFromFile(caCertFile string) XCCOption {
+	return func(x *XCC) {
+		x.httpClientSetupFuncs = append(x.httpClientSetupFuncs, httpclient.WithCACertFile(caCertFile))
+	}
+}
+
+// WithClientCert authenticates to the BMC with the given PEM encoded client certificate and key.
+func WithClientCert(certPEM, keyPEM []byte) XCCOption {
+	return func(x *XCC) {
+		x.httpClientSetupFuncs = append(x.httpClientSetupFuncs, httpclient.WithClientCert(certPEM, keyPEM))
+	}
+}
+
+// WithObserver calls observer after each HTTP round-trip made to the BMC,
+// to collect metrics or logs.
+func WithObserver(observer func(providers.RequestInfo)) XCCOption {
+	return func(x *XCC) {
+		x.httpClientSetupFuncs = append(x.httpClientSetupFuncs, httpclient.WithObserver(observer))
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the HTTP requests made to the BMC,
+// it defaults to bmclib/<version>.
+func WithUserAgent(ua string) XCCOption {
+	return func(x *XCC) {
+		x.httpClientSetupFuncs = append(x.httpClientSetupFuncs, httpclient.WithUserAgent(ua))
+	}
+}
+
+// WithForceHTTP1 restricts the HTTP requests made to the BMC to HTTP/1.1,
+// for BMC web servers returning garbled responses over HTTP/2.
+func WithForceHTTP1() XCCOption {
+	return func(x *XCC) {
+		x.httpClientSetupFuncs = append(x.httpClientSetupFuncs, httpclient.WithForceHTTP1())
+	}
+}
+
+// WithMaxResponseBytes bounds the size of the responses read from the BMC,
+// it defaults to httpclient.DefaultMaxResponseBytes.
+func WithMaxResponseBytes(n int64) XCCOption {
+	return func(x *XCC) {
+		x.httpClientSetupFuncs = append(x.httpClientSetupFuncs, httpclient.WithMaxResponseBytes(n))
+	}
+}
+
+// WithRequestLogging logs the method, host, path, status and duration of each HTTP request
+// made to the BMC at V(1) with the logger of the XCC, lighter than the dumps logged at V(2).
+func WithRequestLogging() XCCOption {
+	return func(x *XCC) {
+		x.httpClientSetupFuncs = append(x.httpClientSetupFuncs, httpclient.WithRequestLogging(x.log))
+	}
+}
+
+// WithTimeout sets the overall timeout of the HTTP requests made to the BMC,
+// it defaults to httpclient.DefaultTimeout.
+func WithTimeout(d time.Duration) XCCOption {
+	return func(x *XCC) {
+		x.httpClientSetupFuncs = append(x.httpClientSetupFuncs, httpclient.WithTimeout(d))
+	}
+}
+
+// WithProxy routes the HTTP requests made to the BMC through the given proxy,
+// by default the proxy settings of the environment are honored.
+func WithProxy(proxyURL string) XCCOption {
+	return func(x *XCC) {
+		x.httpClientSetupFuncs = append(x.httpClientSetupFuncs, httpclient.WithProxy(proxyURL))
+	}
+}
+
+// WithRetry retries the idempotent HTTP requests made to the BMC on transient failures,
+// making up to attempts attempts with an exponential backoff.
+func WithRetry(attempts int, backoff time.Duration) XCCOption {
+	return func(x *XCC) {
+		x.httpClientSetupFuncs = append(x.httpClientSetupFuncs, httpclient.WithRetry(attempts, backoff))
+	}
+}
+
+// WithRateLimit paces the HTTP requests made to the BMC to requestsPerSecond,
+// allowing bursts of up to burst requests.
+func WithRateLimit(requestsPerSecond float64, burst int) XCCOption {
+	return func(x *XCC) {
+		x.httpClientSetupFuncs = append(x.httpClientSetupFuncs, httpclient.WithRateLimit(requestsPerSecond, burst))
+	}
+}
+
+// WithTimeReference sets the clock VerifyTimeSync compares the XCC clock to,
+// e.g. one synchronized against the NTP servers the XCC uses, instead of the local clock.
+func WithTimeReference(now func() time.Time) XCCOption {
+	return func(x *XCC) {
+		x.timeReference = now
+	}
+}
+
+// WithPasswordPolicy sets the policy the passwords are checked against before they're sent to the BMC,
+// devices.DefaultPasswordPolicy() by default.
+func WithPasswordPolicy(policy devices.PasswordPolicy) XCCOption {
+	return func(x *XCC) {
+		x.passwordPolicy = policy
+	}
+}
+
+// New returns a new XCC instance ready to be used
+func New(ctx context.Context, ip string, username string, password string, log logr.Logger) (x *XCC, err error) {
+	return NewWithOptions(ctx, ip, username, password, log)
+}
+
+// NewWithOptions returns a new XCC with options ready to be used
+func NewWithOptions(ctx context.Context, ip string, username string, password string, log logr.Logger, opts ...XCCOption) (*XCC, error) {
+	x := &XCC{
+		ip:             ip,
+		username:       username,
+		password:       password,
+		ctx:            ctx,
+		log:            log,
+		sessionAuth:    httpclient.HeaderAuth{Name: sessionHeader},
+		passwordPolicy: devices.DefaultPasswordPolicy(),
+	}
+	for _, opt := range opts {
+		opt(x)
+	}
+	return x, nil
+}
+
+// CheckCredentials verify whether the credentials are valid or not
+func (x *XCC) CheckCredentials() (err error) {
+	defer x.wrapError("CheckCredentials", &err)
+
+	return x.httpLogin()
+}
+
+// wrapError attaches the bmc identity to the error returned by a public method,
+// it's meant to be deferred with the named error result.
+func (x *XCC) wrapError(operation string, err *error) {
+	*err = errors.NewBMCError(BmcType, x.ip, operation, *err)
+}
+
+// getJSON calls a given Redfish endpoint of the XCC and decodes the answer into v
+func (x *XCC) getJSON(endpoint string, v interface{}) (err error) {
+	payload, err := x.get(endpoint)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(payload, v)
+}
+
+// members returns the members of the Redfish collection at the given endpoint
+func (x *XCC) members(endpoint string) (members []Link, err error) {
+	collection := &Collection{}
+	err = x.getJSON(endpoint, collection)
+	if err != nil {
+		return members, err
+	}
+
+	for _, member := range collection.Members {
+		// the endpoints are joined to the address of the XCC, the links are absolute paths
+		member.ODataID = strings.TrimPrefix(member.ODataID, "/")
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+// system returns the Redfish ComputerSystem of the server
+func (x *XCC) system() (system *devices.RedfishComputerSystem, err error) {
+	system = &devices.RedfishComputerSystem{}
+	return system, x.getJSON(systemURI, system)
+}
+
+// chassis returns the Redfish Chassis of the server
+func (x *XCC) chassis() (chassis *Chassis, err error) {
+	chassis = &Chassis{}
+	return chassis, x.getJSON(chassisURI, chassis)
+}
+
+// processors returns the populated processor sockets of the server
+func (x *XCC) processors() (processors []*Processor, err error) {
+	members, err := x.members(systemURI + "/Processors")
+	if err != nil {
+		return processors, err
+	}
+
+	for _, member := range members {
+		processor := &Processor{}
+		err = x.getJSON(member.ODataID, processor)
+		if err != nil {
+			return processors, err
+		}
+
+		// the empty sockets are listed with an Absent state
+		if processor.Status.State == "Absent" || (processor.ProcessorType != "" && processor.ProcessorType != "CPU") {
+			continue
+		}
+
+		processors = append(processors, processor)
+	}
+
+	return processors, nil
+}
+
+// ethernetInterfaces returns the Redfish EthernetInterfaces listed at the given endpoint
+func (x *XCC) ethernetInterfaces(endpoint string) (ifaces []*EthernetInterface, err error) {
+	members, err := x.members(endpoint)
+	if err != nil {
+		return ifaces, err
+	}
+
+	for _, member := range members {
+		iface := &EthernetInterface{}
+		err = x.getJSON(member.ODataID, iface)
+		if err != nil {
+			return ifaces, err
+		}
+		ifaces = append(ifaces, iface)
+	}
+
+	return ifaces, nil
+}
+
+// manager returns the Redfish Manager of the XCC
+func (x *XCC) manager() (manager *Manager, err error) {
+	manager = &Manager{}
+	return manager, x.getJSON(managerURI, manager)
+}
+
+// power returns the Redfish Power resource of the chassis
+func (x *XCC) power() (power *Power, err error) {
+	power = &Power{}
+	return power, x.getJSON(chassisURI+"/Power", power)
+}
+
+// thermal returns the Redfish Thermal resource of the chassis
+func (x *XCC) thermal() (thermal *Thermal, err error) {
+	thermal = &Thermal{}
+	return thermal, x.getJSON(chassisURI+"/Thermal", thermal)
+}
+
+// Serial returns the device serial
+func (x *XCC) Serial() (serial string, err error) {
+	system, err := x.system()
+	if err != nil {
+		return serial, err
+	}
+
+	if system.SerialNumber == "" {
+		return serial, errors.ErrInvalidSerial
+	}
+
+	return devices.NormalizeSerial(system.SerialNumber), nil
+}
+
+// ChassisSerial returns the serial of the chassis of the server
+func (x *XCC) ChassisSerial() (serial string, err error) {
+	chassis, err := x.chassis()
+	if err != nil {
+		return serial, err
+	}
+
+	return devices.NormalizeSerial(chassis.SerialNumber), nil
+}
+
+// HardwareType returns the type of bmc we are talking to
+func (x *XCC) HardwareType() (bmcType string) {
+	return BmcType
+}
+
+// Model returns the device model
+func (x *XCC) Model() (model string, err error) {
+	system, err := x.system()
+	if err != nil {
+		return model, err
+	}
+
+	return system.Model, nil
+}
+
+// Version returns the version of the bmc we are running
+func (x *XCC) Version() (bmcVersion string, err error) {
+	manager, err := x.manager()
+	if err != nil {
+		return bmcVersion, err
+	}
+
+	return manager.FirmwareVersion, nil
+}
+
+// Name returns the hostname of the server
+func (x *XCC) Name() (name string, err error) {
+	system, err := x.system()
+	if err != nil {
+		return name, err
+	}
+
+	return system.HostName, nil
+}
+
+// Status returns health string status from the bmc
+func (x *XCC) Status() (health string, err error) {
+	system, err := x.system()
+	if err != nil {
+		return health, err
+	}
+
+	return system.Status.Health, nil
+}
+
+// Health returns the normalized health from the bmc
+func (x *XCC) Health() (health devices.Health, err error) {
+	status, err := x.Status()
+	if err != nil {
+		return devices.HealthUnknown, err
+	}
+
+	return devices.NormalizeHealth(status), nil
+}
+
+// Memory returns the total amount of memory of the server
+func (x *XCC) Memory() (mem int, err error) {
+	system, err := x.system()
+	if err != nil {
+		return mem, err
+	}
+
+	return int(system.MemorySummary.TotalSystemMemoryGiB), nil
+}
+
+// CPU returns the cpu, cores and hyperthreads of the server
+func (x *XCC) CPU() (cpu string, cpuCount int, coreCount int, hyperthreadCount int, err error) {
+	processors, err := x.processors()
+	if err != nil {
+		return "", 0, 0, 0, err
+	}
+
+	if len(processors) == 0 {
+		return "", 0, 0, 0, nil
+	}
+
+	entry := processors[0]
+	return httpclient.StandardizeProcessorName(entry.Model), len(processors), entry.TotalCores, entry.TotalThreads, nil
+}
+
+// BiosVersion returns the current version of the bios
+func (x *XCC) BiosVersion() (version string, err error) {
+	system, err := x.system()
+	if err != nil {
+		return version, err
+	}
+
+	return system.BiosVersion, nil
+}
+
+// PowerKw returns the current power usage in Kw
+func (x *XCC) PowerKw() (power float64, err error) {
+	p, err := x.power()
+	if err != nil {
+		return power, err
+	}
+
+	for _, control := range p.PowerControl {
+		power += control.PowerConsumedWatts / 1000.00
+	}
+
+	return power, nil
+}
+
+// TempC returns the current inlet temperature of the machine
+func (x *XCC) TempC() (temp int, err error) {
+	thermal, err := x.thermal()
+	if err != nil {
+		return temp, err
+	}
+
+	for _, t := range thermal.Temperatures {
+		if t.PhysicalContext == "Intake" {
+			return int(t.ReadingCelsius), nil
+		}
+	}
+
+	return temp, nil
+}
+
+// Psus returns a list of psus installed on the device
+func (x *XCC) Psus() (psus []*devices.Psu, err error) {
+	serial, err := x.Serial()
+	if err != nil {
+		return psus, err
+	}
+
+	p, err := x.power()
+	if err != nil {
+		return psus, err
+	}
+
+	for _, psu := range p.PowerSupplies {
+		// the empty bays are listed with an Absent state
+		if psu.Status.State == "Absent" {
+			continue
+		}
+
+		psuSerial := devices.NormalizeSerial(psu.SerialNumber)
+		if psuSerial == "" {
+			psuSerial = fmt.Sprintf("%s_%s", serial, strings.ToLower(psu.MemberID))
+		}
+
+		psus = append(psus, &devices.Psu{
+			Serial:     psuSerial,
+			CapacityKw: psu.PowerCapacityWatts / 1000.00,
+			PowerKw:    psu.PowerOutputWatts / 1000.00,
+			Status:     psu.Status.Health,
+			PartNumber: strings.ToLower(strings.TrimSpace(psu.PartNumber)),
+			Position:   len(psus) + 1,
+		})
+	}
+
+	return psus, nil
+}
+
+// IsBlade returns if the current hardware is a blade or not
+func (x *XCC) IsBlade() (isBlade bool, err error) {
+	chassis, err := x.chassis()
+	if err != nil {
+		return isBlade, err
+	}
+
+	return chassis.ChassisType == "Blade", nil
+}
+
+// Slot returns the current slot within the chassis, the Redfish service doesn't expose it
+func (x *XCC) Slot() (slot int, err error) {
+	return -1, nil
+}
+
+// Nics returns all found Nics in the device, the nic of the bmc first
+func (x *XCC) Nics() (nics []*devices.Nic, err error) {
+	ifaces, err := x.ethernetInterfaces(managerURI + "/EthernetInterfaces")
+	if err != nil {
+		return nics, err
+	}
+
+	for _, iface := range ifaces {
+		nics = append(nics, &devices.Nic{
+			Name:       "bmc",
+			MacAddress: strings.ToLower(iface.MACAddress),
+			Up:         iface.LinkStatus == "LinkUp",
+			SpeedMbps:  iface.SpeedMbps,
+			MTU:        iface.MTUSize,
+			BMC:        true,
+		})
+	}
+
+	ifaces, err = x.ethernetInterfaces(systemURI + "/EthernetInterfaces")
+	if err != nil {
+		return nics, err
+	}
+
+	for _, iface := range ifaces {
+		nics = append(nics, &devices.Nic{
+			Name:       iface.ID,
+			MacAddress: strings.ToLower(iface.MACAddress),
+			Up:         iface.LinkStatus == "LinkUp",
+			SpeedMbps:  iface.SpeedMbps,
+			MTU:        iface.MTUSize,
+		})
+	}
+
+	return nics, nil
+}
+
+// Disks isn't supported on XCC yet
+func (x *XCC) Disks() (disks []*devices.Disk, err error) {
+	return disks, errors.NewFeatureUnsupportedError("disks", x.Vendor(), x.HardwareType())
+}
+
+// License isn't supported on XCC yet
+func (x *XCC) License() (name string, licType string, err error) {
+	return name, licType, errors.NewFeatureUnsupportedError("license", x.Vendor(), x.HardwareType())
+}
+
+// Screenshot isn't supported on XCC yet
+func (x *XCC) Screenshot() (response []byte, extension string, err error) {
+	return response, extension, errors.NewFeatureUnsupportedError("screenshot", x.Vendor(), x.HardwareType())
+}
+
+// Vendor returns bmc's vendor
+func (x *XCC) Vendor() (vendor string) {
+	return lenovo.VendorID
+}
+
+// ServerSnapshot do best effort to populate the server data and returns a discrete
+func (x *XCC) ServerSnapshot() (server interface{}, err error) {
+	defer x.wrapError("ServerSnapshot", &err)
+
+	system, err := x.system()
+	if err != nil {
+		return nil, err
+	}
+
+	discrete := &devices.Discrete{
+		CollectedAt:    time.Now().UTC(),
+		Vendor:         x.Vendor(),
+		BmcAddress:     x.ip,
+		BmcType:        x.HardwareType(),
+		BmcAuth:        true,
+		Serial:         devices.NormalizeSerial(system.SerialNumber),
+		Name:           system.HostName,
+		Model:          system.Model,
+		BiosVersion:    system.BiosVersion,
+		PowerState:     strings.ToLower(system.PowerState),
+		Status:         system.Status.Health,
+		Processor:      system.ProcessorSummary.Model,
+		ProcessorCount: system.ProcessorSummary.Count,
+		Memory:         int(system.MemorySummary.TotalSystemMemoryGiB),
+	}
+
+	discrete.BmcVersion, err = x.Version()
+	if err != nil {
+		return nil, err
+	}
+	discrete.PowerKw, err = x.PowerKw()
+	if err != nil {
+		return nil, err
+	}
+	discrete.Psus, err = x.Psus()
+	if err != nil {
+		return nil, err
+	}
+	discrete.TempC, err = x.TempC()
+	if err != nil {
+		return nil, err
+	}
+
+	return discrete, nil
+}
+
+// UpdateCredentials updates login credentials
+func (x *XCC) UpdateCredentials(username string, password string) {
+	x.username = username
+	x.password = password
+}
+
+// GetBIOSVersion returns the BIOS version from the BMC, implements the Firmware interface
+func (x *XCC) GetBIOSVersion(ctx context.Context) (version string, err error) {
+	defer x.wrapError("GetBIOSVersion", &err)
+
+	return x.BiosVersion()
+}
+
+// GetBMCVersion returns the BMC version, implements the Firmware interface
+func (x *XCC) GetBMCVersion(ctx context.Context) (version string, err error) {
+	defer x.wrapError("GetBMCVersion", &err)
+
+	return x.Version()
+}
@@ -0,0 +1,648 @@
+package xcc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+	"github.com/bombsimon/logrusr/v2"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	testToken   = "5ee0b0c4a0b1f2e3"
+	testSession = "/redfish/v1/SessionService/Sessions/42"
+)
+
+var (
+	mux    *http.ServeMux
+	server *httptest.Server
+	// fixtures maps the Redfish endpoints to the files of the fixtures directory
+	fixtures = map[string]string{
+		"/redfish/v1/Systems/1":                         "fixtures/systems.1.json",
+		"/redfish/v1/Systems/1/Processors":              "fixtures/systems.1.processors.json",
+		"/redfish/v1/Systems/1/Processors/1":            "fixtures/systems.1.processors.1.json",
+		"/redfish/v1/Systems/1/Processors/2":            "fixtures/systems.1.processors.2.json",
+		"/redfish/v1/Systems/1/EthernetInterfaces":      "fixtures/systems.1.ethernetinterfaces.json",
+		"/redfish/v1/Systems/1/EthernetInterfaces/NIC1": "fixtures/systems.1.ethernetinterfaces.nic1.json",
+		"/redfish/v1/Managers/1":                        "fixtures/managers.1.json",
+		"/redfish/v1/Managers/1/EthernetInterfaces":     "fixtures/managers.1.ethernetinterfaces.json",
+		"/redfish/v1/Managers/1/EthernetInterfaces/NIC": "fixtures/managers.1.ethernetinterfaces.nic.json",
+		"/redfish/v1/Chassis/1":                         "fixtures/chassis.1.json",
+		"/redfish/v1/Chassis/1/Power":                   "fixtures/chassis.1.power.json",
+		"/redfish/v1/Chassis/1/Thermal":                 "fixtures/chassis.1.thermal.json",
+		"/redfish/v1/Systems/1/LogServices/SEL/Entries": "fixtures/sel.entries.json",
+		"/redfish/v1/AccountService/Accounts":           "fixtures/accountservice.accounts.json",
+		"/redfish/v1/AccountService/Accounts/1":         "fixtures/accountservice.accounts.1.json",
+		"/redfish/v1/AccountService/Accounts/2":         "fixtures/accountservice.accounts.2.json",
+		"/redfish/v1/AccountService/Accounts/3":         "fixtures/accountservice.accounts.3.json",
+	}
+	// posted records the payloads posted to the actions or patched to the resources
+	posted = map[string]string{}
+	// sessionDeleted is set once the session has been deleted
+	sessionDeleted bool
+)
+
+func setup() (x *XCC, err error) {
+	posted = map[string]string{}
+	sessionDeleted = false
+
+	mux = http.NewServeMux()
+	server = httptest.NewTLSServer(mux)
+	ip := strings.TrimPrefix(server.URL, "https://")
+
+	mux.HandleFunc("/redfish/v1/SessionService/Sessions", func(w http.ResponseWriter, r *http.Request) {
+		login := &SessionRequest{}
+		if err := json.NewDecoder(r.Body).Decode(login); err != nil || r.Method != "POST" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if login.UserName != "USERID" || login.Password != "PASSW0RD" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("X-Auth-Token", testToken)
+		w.Header().Set("Location", testSession)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	mux.HandleFunc(testSession, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" && r.Header.Get("X-Auth-Token") == testToken {
+			sessionDeleted = true
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("/redfish/v1/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Auth-Token") != testToken {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		if r.Method == "POST" || r.Method == "PATCH" {
+			payload, _ := ioutil.ReadAll(r.Body)
+			posted[r.URL.Path] = string(payload)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		fixture, ok := fixtures[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		payload, err := ioutil.ReadFile(fixture)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(payload)
+	})
+
+	testLog := logrus.New()
+	return NewWithOptions(context.TODO(), ip, "USERID", "PASSW0RD", logrusr.New(testLog), WithInsecureTLS())
+}
+
+func tearDown() {
+	server.Close()
+}
+
+func TestServerSnapshot(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	answer, err := bmc.ServerSnapshot()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.ServerSnapshot %v", err)
+	}
+
+	discrete, ok := answer.(*devices.Discrete)
+	if !ok {
+		t.Fatalf("Expected a *devices.Discrete: found %T", answer)
+	}
+
+	expected := &devices.Discrete{
+		Serial:         "j303abcd",
+		Name:           "sr630v2-test",
+		Vendor:         devices.Lenovo,
+		Model:          "ThinkSystem SR630 V2 -[7Z71CTO1WW]-",
+		BiosVersion:    "AFE118M-1.80",
+		BmcType:        BmcType,
+		BmcAddress:     bmc.ip,
+		BmcVersion:     "AFBT36Q-3.70",
+		BmcAuth:        true,
+		PowerState:     "on",
+		PowerKw:        0.312,
+		TempC:          23,
+		Status:         "OK",
+		Processor:      "Intel(R) Xeon(R) Gold 6338 CPU @ 2.00GHz",
+		ProcessorCount: 2,
+		Memory:         256,
+		Psus: []*devices.Psu{
+			{Serial: "d1dg05a0123", CapacityKw: 0.75, PowerKw: 0.16, Status: "OK", PartNumber: "sp57a02023", Position: 1},
+			{Serial: "j303abcd_1", CapacityKw: 0.75, PowerKw: 0.152, Status: "Warning", PartNumber: "sp57a02023", Position: 2},
+		},
+	}
+	expected.CollectedAt = discrete.CollectedAt
+
+	expectedJSON, _ := json.Marshal(expected)
+	answerJSON, _ := json.Marshal(discrete)
+	if string(expectedJSON) != string(answerJSON) {
+		t.Errorf("Expected answer %s: found %s", expectedJSON, answerJSON)
+	}
+}
+
+func TestPowerState(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	answer, err := bmc.PowerState()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.PowerState %v", err)
+	}
+
+	if answer != "on" {
+		t.Errorf("Expected answer %v: found %v", "on", answer)
+	}
+
+	isOn, err := bmc.IsOn()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.IsOn %v", err)
+	}
+
+	if !isOn {
+		t.Errorf("Expected answer %v: found %v", true, isOn)
+	}
+}
+
+func TestPowerActions(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	tests := []struct {
+		name      string
+		action    func() (bool, error)
+		endpoint  string
+		resetType string
+	}{
+		{"PowerOn", bmc.PowerOn, "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset", "On"},
+		{"PowerOff", bmc.PowerOff, "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset", "ForceOff"},
+		{"PowerCycle", bmc.PowerCycle, "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset", "PowerCycle"},
+		{"PowerReset", bmc.PowerReset, "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset", "ForceRestart"},
+		{"PowerCycleBmc", bmc.PowerCycleBmc, "/redfish/v1/Managers/1/Actions/Manager.Reset", "GracefulRestart"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := tt.action()
+			if err != nil {
+				t.Fatalf("Found errors calling bmc.%s %v", tt.name, err)
+			}
+			if !status {
+				t.Errorf("Expected answer %v: found %v", true, status)
+			}
+
+			expected := `{"ResetType":"` + tt.resetType + `"}`
+			if posted[tt.endpoint] != expected {
+				t.Errorf("Expected answer %v: found %v", expected, posted[tt.endpoint])
+			}
+		})
+	}
+}
+
+func TestPxeOnce(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	status, err := bmc.PxeOnce()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.PxeOnce %v", err)
+	}
+	if !status {
+		t.Errorf("Expected answer %v: found %v", true, status)
+	}
+
+	expected := `{"Boot":{"BootSourceOverrideEnabled":"Once","BootSourceOverrideTarget":"Pxe"}}`
+	if posted["/redfish/v1/Systems/1"] != expected {
+		t.Errorf("Expected answer %v: found %v", expected, posted["/redfish/v1/Systems/1"])
+	}
+
+	// the server is on, so it's restarted to boot from the network
+	expected = `{"ResetType":"ForceRestart"}`
+	if posted["/redfish/v1/Systems/1/Actions/ComputerSystem.Reset"] != expected {
+		t.Errorf("Expected answer %v: found %v", expected, posted["/redfish/v1/Systems/1/Actions/ComputerSystem.Reset"])
+	}
+}
+
+func TestCPU(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	cpu, cpuCount, coreCount, hyperthreadCount, err := bmc.CPU()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.CPU %v", err)
+	}
+
+	if cpu != "intel(r) xeon(r) gold 6338 cpu" || cpuCount != 2 || coreCount != 32 || hyperthreadCount != 64 {
+		t.Errorf("Expected answer %v %v %v %v: found %v %v %v %v", "intel(r) xeon(r) gold 6338 cpu", 2, 32, 64, cpu, cpuCount, coreCount, hyperthreadCount)
+	}
+}
+
+func TestNics(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	nics, err := bmc.Nics()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.Nics %v", err)
+	}
+
+	expected := []*devices.Nic{
+		{Name: "bmc", MacAddress: "08:94:ef:4a:12:3c", Up: true, SpeedMbps: 1000, MTU: 1500, BMC: true},
+		{Name: "NIC1", MacAddress: "b4:96:91:a2:30:10", Up: true, SpeedMbps: 25000, MTU: 9000},
+	}
+
+	if !reflect.DeepEqual(nics, expected) {
+		t.Errorf("Expected answer %v: found %v", expected, nics)
+	}
+}
+
+func TestChassis(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	serial, err := bmc.ChassisSerial()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.ChassisSerial %v", err)
+	}
+	if serial != "j303abcd" {
+		t.Errorf("Expected answer %v: found %v", "j303abcd", serial)
+	}
+
+	isBlade, err := bmc.IsBlade()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.IsBlade %v", err)
+	}
+	if isBlade {
+		t.Errorf("Expected answer %v: found %v", false, isBlade)
+	}
+}
+
+func TestSensors(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	fans, err := bmc.Fans()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.Fans %v", err)
+	}
+
+	expectedFans := []*devices.Fan{
+		{Name: "Fan 1 Tach", Status: "OK", Position: 1, Present: true, CurrentRPM: 8160},
+		{Name: "Fan 3 Tach", Status: "Critical", Position: 3, Present: true, CurrentRPM: 2040},
+	}
+	if !reflect.DeepEqual(fans, expectedFans) {
+		t.Errorf("Expected answer %v: found %v", expectedFans, fans)
+	}
+
+	temperatures, err := bmc.Temperatures()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.Temperatures %v", err)
+	}
+
+	expectedTemperatures := []*devices.TemperatureSensor{
+		{Name: "CPU 1 Temp", Location: "CPU 1", Reading: 44, Unit: devices.TemperatureUnitCelsius, Status: "OK"},
+		{Name: "Ambient Temp", Location: "Ambient", Reading: 23, Unit: devices.TemperatureUnitCelsius, Status: "OK"},
+	}
+	if !reflect.DeepEqual(temperatures, expectedTemperatures) {
+		t.Errorf("Expected answer %v: found %v", expectedTemperatures, temperatures)
+	}
+
+	psus, err := bmc.PSUs()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.PSUs %v", err)
+	}
+	if len(psus) != 2 {
+		t.Errorf("Expected answer %v: found %v", 2, len(psus))
+	}
+
+	healthSensors, err := bmc.HealthSensors()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.HealthSensors %v", err)
+	}
+
+	expectedHealth := []*devices.HealthSensor{
+		{Name: "CPU 1 Temp", Type: "Temperature", Reading: 44, Health: devices.HealthOK},
+		{Name: "Ambient Temp", Type: "Temperature", Reading: 23, Health: devices.HealthOK},
+		{Name: "Fan 1 Tach", Type: "Fan", Reading: 8160, Health: devices.HealthOK},
+		{Name: "Fan 3 Tach", Type: "Fan", Reading: 2040, Health: devices.HealthCritical},
+		{Name: "PSU1", Type: "Power Supply", Reading: 160, Health: devices.HealthOK},
+		{Name: "PSU2", Type: "Power Supply", Reading: 152, Health: devices.HealthWarning},
+	}
+	if !reflect.DeepEqual(healthSensors, expectedHealth) {
+		t.Errorf("Expected answer %v: found %v", expectedHealth, healthSensors)
+	}
+}
+
+func TestGetEventLog(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	entries, err := bmc.GetEventLog()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.GetEventLog %v", err)
+	}
+
+	expected := []devices.EventLogEntry{
+		{
+			ID:        "1",
+			Timestamp: time.Date(2026, 3, 2, 9, 14, 7, 0, time.UTC),
+			Sensor:    "Power Supply",
+			EventType: "SEL",
+			Severity:  devices.SeverityCritical,
+			Message:   "Power supply 2 has lost input.",
+		},
+		{
+			ID:        "2",
+			Timestamp: time.Date(2026, 3, 2, 9, 20, 41, 0, time.UTC),
+			Sensor:    "Power Supply",
+			EventType: "SEL",
+			Severity:  devices.SeverityOK,
+			Message:   "Power supply 2 input has returned to normal.",
+		},
+	}
+
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected answer %v: found %v", expected, entries)
+	}
+	for i := range expected {
+		if entries[i] != expected[i] {
+			t.Errorf("Expected answer %v: found %v", expected[i], entries[i])
+		}
+	}
+
+	err = bmc.ClearEventLog()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.ClearEventLog %v", err)
+	}
+
+	if _, ok := posted["/redfish/v1/Systems/1/LogServices/SEL/Actions/LogService.ClearLog"]; !ok {
+		t.Errorf("Expected the ClearLog action to be posted")
+	}
+}
+
+func TestGetBMCVersion(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	answer, err := bmc.GetBMCVersion(context.TODO())
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.GetBMCVersion %v", err)
+	}
+
+	if answer != "AFBT36Q-3.70" {
+		t.Errorf("Expected answer %v: found %v", "AFBT36Q-3.70", answer)
+	}
+
+	answer, err = bmc.GetBIOSVersion(context.TODO())
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.GetBIOSVersion %v", err)
+	}
+
+	if answer != "AFE118M-1.80" {
+		t.Errorf("Expected answer %v: found %v", "AFE118M-1.80", answer)
+	}
+}
+
+func TestGetFirmwareInventory(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	inventory, err := bmc.GetFirmwareInventory()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.GetFirmwareInventory %v", err)
+	}
+
+	expected := []devices.Firmware{
+		{Component: "BMC", Installed: "AFBT36Q-3.70"},
+		{Component: "BIOS", Installed: "AFE118M-1.80"},
+	}
+	if !reflect.DeepEqual(inventory, expected) {
+		t.Errorf("Expected answer %v: found %v", expected, inventory)
+	}
+}
+
+func TestVerifyTimeSync(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	// the fixture reports 2026-03-02T10:04:12+00:00, the XCC runs 47s behind the reference
+	WithTimeReference(func() time.Time { return time.Date(2026, 3, 2, 10, 4, 59, 0, time.UTC) })(bmc)
+
+	offset, inSync, err := bmc.VerifyTimeSync(context.TODO(), 30*time.Second)
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.VerifyTimeSync %v", err)
+	}
+
+	if offset != -47*time.Second || inSync {
+		t.Errorf("Expected answer %v %v: found %v %v", -47*time.Second, false, offset, inSync)
+	}
+
+	offset, inSync, err = bmc.VerifyTimeSync(context.TODO(), time.Minute)
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.VerifyTimeSync %v", err)
+	}
+
+	if offset != -47*time.Second || !inSync {
+		t.Errorf("Expected answer %v %v: found %v %v", -47*time.Second, true, offset, inSync)
+	}
+}
+
+func TestSessionLogout(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	err = bmc.CheckCredentials()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.CheckCredentials %v", err)
+	}
+
+	err = bmc.Close(context.TODO())
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.Close %v", err)
+	}
+
+	if !sessionDeleted {
+		t.Errorf("Expected the session %s to be deleted", testSession)
+	}
+}
+
+func TestLoginUnauthorized(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	bmc.UpdateCredentials("USERID", "wrong")
+
+	err = bmc.CheckCredentials()
+	if !errors.Is(err, bmclibErrs.ErrInvalidCredentials) {
+		t.Errorf("Expected answer %v: found %v", bmclibErrs.ErrInvalidCredentials, err)
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	expectedAnswer := []devices.User{
+		{ID: 1, Name: "USERID", Role: devices.UserRoleAdmin, Enabled: true},
+		{ID: 2, Name: "monitoring", Role: devices.UserRoleReadOnly, Enabled: true},
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	answer, err := bmc.ListUsers()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.ListUsers %v", err)
+	}
+
+	if !reflect.DeepEqual(answer, expectedAnswer) {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, answer)
+	}
+}
+
+func TestUserManager(t *testing.T) {
+	tests := []struct {
+		name        string
+		call        func(bmc *XCC) error
+		endpoint    string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name: "create",
+			call: func(bmc *XCC) error {
+				return bmc.CreateUser(devices.User{Name: "operator", Password: "S3cretPass", Role: devices.UserRoleOperator})
+			},
+			endpoint: "/redfish/v1/AccountService/Accounts/3",
+			expected: `{"UserName":"operator","Password":"S3cretPass","RoleId":"Operator","Enabled":true}`,
+		},
+		{
+			name: "create existing",
+			call: func(bmc *XCC) error {
+				return bmc.CreateUser(devices.User{Name: "monitoring", Password: "S3cretPass", Role: devices.UserRoleReadOnly})
+			},
+			expectedErr: bmclibErrs.ErrUserAccountExists,
+		},
+		{
+			name: "create too weak",
+			call: func(bmc *XCC) error {
+				return bmc.CreateUser(devices.User{Name: "operator", Password: "weak", Role: devices.UserRoleOperator})
+			},
+			expectedErr: bmclibErrs.ErrWeakPassword,
+		},
+		{
+			name: "modify",
+			call: func(bmc *XCC) error {
+				return bmc.ModifyUser(devices.User{Name: "monitoring", Password: "N3wSecretPass", Role: devices.UserRoleOperator})
+			},
+			endpoint: "/redfish/v1/AccountService/Accounts/2",
+			expected: `{"Password":"N3wSecretPass","RoleId":"Operator"}`,
+		},
+		{
+			name:     "delete",
+			call:     func(bmc *XCC) error { return bmc.DeleteUser("monitoring") },
+			endpoint: "/redfish/v1/AccountService/Accounts/2",
+			expected: `{"Enabled":false,"UserName":""}`,
+		},
+		{
+			name:        "delete missing",
+			call:        func(bmc *XCC) error { return bmc.DeleteUser("missing") },
+			expectedErr: bmclibErrs.ErrUserAccountNotFound,
+		},
+		{
+			name:     "change password",
+			call:     func(bmc *XCC) error { return bmc.ChangePassword("monitoring", "N3wSecretPass") },
+			endpoint: "/redfish/v1/AccountService/Accounts/2",
+			expected: `{"Password":"N3wSecretPass"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bmc, err := setup()
+			if err != nil {
+				t.Fatalf("Found errors during the test setup %v", err)
+			}
+			defer tearDown()
+
+			err = tt.call(bmc)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Expected error %v: found %v", tt.expectedErr, err)
+			}
+
+			if tt.endpoint == "" {
+				if len(posted) != 0 {
+					t.Errorf("Expected nothing sent to the bmc: found %v", posted)
+				}
+				return
+			}
+
+			payload, ok := posted[tt.endpoint]
+			if !ok || payload != tt.expected {
+				t.Errorf("Expected answer %v: found %v", tt.expected, posted)
+			}
+		})
+	}
+}
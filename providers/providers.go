@@ -1,6 +1,13 @@
 package providers
 
-import "github.com/jacobweinstock/registrar"
+import (
+	"github.com/bmc-toolbox/bmclib/internal/httpclient"
+	"github.com/jacobweinstock/registrar"
+)
+
+// RequestInfo describes an HTTP round-trip made to a BMC, as handed to the observers
+// set with the WithObserver provider options.
+type RequestInfo = httpclient.RequestInfo
 
 const (
 	// FeaturePowerState represents the powerstate functionality
@@ -25,8 +32,24 @@ const (
 	FeatureFirmwareInstall registrar.Feature = "firmwareinstall"
 	// FeatureFirmwareInstallSatus means an implementation that returns the firmware install status
 	FeatureFirmwareInstallStatus registrar.Feature = "firmwareinstallstatus"
+	// FeatureFirmwareInventory means an implementation that lists the firmware installed on each component
+	FeatureFirmwareInventory registrar.Feature = "firmwareinventory"
 	// FeatureInventoryRead means an implementation that returns the hardware and firmware inventory
 	FeatureInventoryRead registrar.Feature = "inventoryread"
 	// FeaturePostCodeRead means an implmentation that returns the boot BIOS/UEFI post code status and value
 	FeaturePostCodeRead registrar.Feature = "postcoderead"
+	// FeatureEventLogRead means an implementation that returns the BMC event log (SEL) entries
+	FeatureEventLogRead registrar.Feature = "eventlogread"
+	// FeatureEventSubscribe means an implementation that streams the BMC events as they happen
+	FeatureEventSubscribe registrar.Feature = "eventsubscribe"
+	// FeatureSensorRead means an implementation that returns the fan, power supply and temperature sensor readings
+	FeatureSensorRead registrar.Feature = "sensorread"
+	// FeatureVirtualMedia means an implementation that mounts and unmounts virtual media images
+	FeatureVirtualMedia registrar.Feature = "virtualmedia"
+	// FeaturePowerCap means an implementation that reads and sets the power consumption limit
+	FeaturePowerCap registrar.Feature = "powercap"
+	// FeatureBIOSConfigure means an implementation that reads and sets the BIOS settings
+	FeatureBIOSConfigure registrar.Feature = "biosconfigure"
+	// FeatureTimeSyncVerify means an implementation that compares the BMC clock to a reference clock
+	FeatureTimeSyncVerify registrar.Feature = "timesyncverify"
 )
@@ -0,0 +1,140 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+	"github.com/pkg/errors"
+	"github.com/stmcginnis/gofish/common"
+	rf "github.com/stmcginnis/gofish/redfish"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the BIOSConfigurator interface.
+var _ devices.BIOSConfigurator = (*Conn)(nil)
+
+// bios returns the BIOS resource of the first system
+func (c *Conn) bios() (*rf.Bios, error) {
+	if c.conn == nil || c.conn.Service == nil {
+		return nil, bmclibErrs.ErrRedfishServiceNil
+	}
+
+	systems, err := c.conn.Service.Systems()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(systems) == 0 {
+		return nil, errors.New("no system found")
+	}
+
+	return systems[0].Bios()
+}
+
+// biosSettingsObject returns the URI of the settings object staging the BIOS changes until the next reboot,
+// empty when the service has none and the changes are patched to the BIOS resource.
+func (c *Conn) biosSettingsObject(bios *rf.Bios) (string, error) {
+	resp, err := c.conn.Get(bios.ODataID)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Settings common.Settings `json:"@Redfish.Settings"`
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&payload)
+	if err != nil {
+		return "", err
+	}
+
+	return string(payload.Settings.SettingsObject), nil
+}
+
+// GetBIOSSettings returns the current BIOS attributes of the system
+func (c *Conn) GetBIOSSettings(ctx context.Context) (*devices.BIOSSettings, error) {
+	bios, err := c.bios()
+	if err != nil {
+		return nil, err
+	}
+
+	return devices.BIOSSettingsFromAttributes(bios.Attributes), nil
+}
+
+// SetBIOSSettings stages the BIOS attributes differing from the current ones. A reboot is required
+// when the service stages them in a settings object, they are then applied on the next reset.
+func (c *Conn) SetBIOSSettings(ctx context.Context, settings *devices.BIOSSettings) (rebootRequired bool, err error) {
+	bios, err := c.bios()
+	if err != nil {
+		return false, err
+	}
+
+	attributes, err := settings.Attributes(bios.Attributes)
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+	for name, value := range attributes {
+		if bios.Attributes[name] != value {
+			changed = true
+		}
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	settingsObject, err := c.biosSettingsObject(bios)
+	if err != nil {
+		return false, err
+	}
+
+	var applyTime common.ApplyTime
+	if settingsObject != "" {
+		for _, allowed := range bios.AllowedAttributeUpdateApplyTimes() {
+			if allowed == common.OnResetApplyTime {
+				applyTime = allowed
+			}
+		}
+	}
+
+	err = bios.UpdateBiosAttributesApplyAt(attributes, applyTime)
+	if err != nil {
+		return false, errors.Wrap(err, "PATCH BIOS attributes")
+	}
+
+	return settingsObject != "", nil
+}
+
+// PendingBIOSSettings returns the staged BIOS attributes that differ from the current ones
+func (c *Conn) PendingBIOSSettings(ctx context.Context) (*devices.BIOSSettings, error) {
+	bios, err := c.bios()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := devices.NewBIOSSettings()
+
+	settingsObject, err := c.biosSettingsObject(bios)
+	if err != nil || settingsObject == "" {
+		return pending, err
+	}
+
+	staged, err := rf.GetBios(c.conn, settingsObject)
+	if err != nil {
+		return nil, err
+	}
+
+	current := devices.BIOSSettingsFromAttributes(bios.Attributes)
+	for _, diff := range devices.BIOSSettingsFromAttributes(staged.Attributes).Diff(current) {
+		if diff.Present {
+			pending.Set(diff.Name, diff.Value)
+		}
+	}
+
+	return pending, nil
+}
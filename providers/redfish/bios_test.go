@@ -0,0 +1,83 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+	"github.com/bmc-toolbox/bmclib/internal/conformance"
+)
+
+// stagedBIOSAttributes are the BIOS attributes staged on the mock server, pending a reboot
+var stagedBIOSAttributes = map[string]interface{}{}
+
+// biosApplyTime is the @Redfish.SettingsApplyTime of the last BIOS settings PATCH
+var biosApplyTime string
+
+// handler registered in redfish_test.go
+func biosSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPatch {
+		var payload struct {
+			Attributes        map[string]interface{}
+			SettingsApplyTime struct {
+				ApplyTime string
+			} `json:"@Redfish.SettingsApplyTime"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		for name, value := range payload.Attributes {
+			stagedBIOSAttributes[name] = value
+		}
+		biosApplyTime = payload.SettingsApplyTime.ApplyTime
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"@odata.id":  "/redfish/v1/Systems/System.Embedded.1/Bios/Settings",
+		"Id":         "Settings",
+		"Attributes": stagedBIOSAttributes,
+	})
+}
+
+func Test_GetBIOSSettings(t *testing.T) {
+	settings, err := mockClient.GetBIOSSettings(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 10, settings.Len())
+	value, _ := settings.Get("BootMode")
+	assert.Equal(t, "Uefi", value)
+}
+
+func Test_SetBIOSSettings(t *testing.T) {
+	stagedBIOSAttributes = map[string]interface{}{}
+
+	conformance.BIOSConfigurator(t, mockClient, "SriovGlobalEnable", "Enabled")
+	assert.Equal(t, "OnReset", biosApplyTime)
+
+	// the settings matching the current ones aren't staged
+	settings := devices.NewBIOSSettings()
+	settings.Set("BootMode", "Uefi")
+
+	rebootRequired, err := mockClient.SetBIOSSettings(context.TODO(), settings)
+	assert.Nil(t, err)
+	assert.False(t, rebootRequired)
+
+	pending, err := mockClient.PendingBIOSSettings(context.TODO())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"SriovGlobalEnable"}, pending.Names())
+
+	settings.Set("UnknownAttribute", "Enabled")
+	_, err = mockClient.SetBIOSSettings(context.TODO(), settings)
+	assert.ErrorIs(t, err, bmclibErrs.ErrInvalidBIOSAttribute)
+}
@@ -0,0 +1,335 @@
+package redfish
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+	"github.com/pkg/errors"
+)
+
+var (
+	// eventStreamMinBackoff is the delay before reconnecting a dropped event stream,
+	// it doubles on every failed attempt up to eventStreamMaxBackoff.
+	eventStreamMinBackoff = time.Second
+	eventStreamMaxBackoff = time.Minute
+)
+
+const (
+	// eventStreamMaxLine bounds the size of a line of the event stream
+	eventStreamMaxLine = 1 << 20
+	// sessionsURI is the collection of the Redfish sessions, its location is fixed by the specification
+	sessionsURI = "/redfish/v1/SessionService/Sessions"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the EventSubscriber interface.
+var _ devices.EventSubscriber = (*Conn)(nil)
+
+// eventPayload is the Redfish Event sent as the data of a server-sent event
+type eventPayload struct {
+	Events []struct {
+		EventID           string `json:"EventId"`
+		EventTimestamp    string `json:"EventTimestamp"`
+		EventType         string `json:"EventType"`
+		MessageID         string `json:"MessageId"`
+		Message           string `json:"Message"`
+		MessageSeverity   string `json:"MessageSeverity"`
+		Severity          string `json:"Severity"`
+		OriginOfCondition struct {
+			ODataID string `json:"@odata.id"`
+		} `json:"OriginOfCondition"`
+	} `json:"Events"`
+}
+
+// entries returns the event log entries of the events of the payload,
+// the sensor is the resource the event originates from.
+func (p *eventPayload) entries() []devices.EventLogEntry {
+	entries := make([]devices.EventLogEntry, 0, len(p.Events))
+	for _, e := range p.Events {
+		severity := e.MessageSeverity
+		if severity == "" {
+			severity = e.Severity
+		}
+
+		eventType := e.EventType
+		if eventType == "" {
+			eventType = e.MessageID
+		}
+
+		entry := devices.EventLogEntry{
+			ID:        e.EventID,
+			Sensor:    e.OriginOfCondition.ODataID,
+			EventType: eventType,
+			Severity:  devices.NormalizeSeverity(severity),
+			Message:   e.Message,
+		}
+		if timestamp, err := time.Parse(time.RFC3339, e.EventTimestamp); err == nil {
+			entry.Timestamp = timestamp.UTC()
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// SubscribeEvents opens the server-sent event stream of the Redfish EventService and returns the events
+// as they are received, until ctx is canceled. A dropped stream is reconnected with an exponential backoff,
+// resuming after the last event received when the BMC supports it.
+func (c *Conn) SubscribeEvents(ctx context.Context) (<-chan devices.EventLogEntry, error) {
+	if c.conn == nil || c.conn.Service == nil {
+		return nil, bmclibErrs.ErrRedfishServiceNil
+	}
+
+	eventService, err := c.conn.Service.EventService()
+	if err != nil {
+		return nil, err
+	}
+
+	if eventService.ServerSentEventURI == "" {
+		return nil, bmclibErrs.NewFeatureUnsupportedError("event subscription", ProviderProtocol, c.conn.Service.Product)
+	}
+
+	// the first request uses the session of the connection, which was just opened
+	var token string
+	if !c.basicAuth && c.User != "" {
+		session, err := c.conn.GetSession()
+		if err != nil {
+			return nil, err
+		}
+		token = session.Token
+	}
+
+	stream, err := c.openEventStream(ctx, eventService.ServerSentEventURI, token, "")
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan devices.EventLogEntry)
+	go c.streamEvents(ctx, eventService.ServerSentEventURI, token, stream, events)
+
+	return events, nil
+}
+
+// openEventStream sends the request of the event stream at uri, which lasts as long as ctx,
+// token authenticates the request unless the basic auth is used.
+func (c *Conn) openEventStream(ctx context.Context, uri, token, lastEventID string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.Host+uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	switch {
+	case c.basicAuth:
+		req.SetBasicAuth(c.User, c.Pass)
+	case token != "":
+		req.Header.Set("X-Auth-Token", token)
+	}
+
+	// the client timeout would cut the stream, it is bounded by ctx instead
+	client := *c.conn.HTTPClient
+	client.Timeout = 0
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, bmclibErrs.WrapRequestError(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, eventStreamMaxLine))
+		return nil, bmclibErrs.NewHTTPErrorFromResponse(resp, body)
+	}
+
+	return resp.Body, nil
+}
+
+// createEventStreamSession logs in a new session for the event stream and returns its token and location,
+// the request doesn't carry the token of the connection since it may have expired.
+func (c *Conn) createEventStreamSession(ctx context.Context) (token, location string, err error) {
+	body, err := json.Marshal(map[string]string{"UserName": c.User, "Password": c.Pass})
+	if err != nil {
+		return "", "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Host+sessionsURI, bytes.NewReader(body))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.conn.HTTPClient.Do(req)
+	if err != nil {
+		return "", "", bmclibErrs.WrapRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, eventStreamMaxLine))
+		return "", "", bmclibErrs.NewHTTPErrorFromResponse(resp, body)
+	}
+
+	token = resp.Header.Get("X-Auth-Token")
+	if token == "" {
+		return "", "", errors.New("no session token returned")
+	}
+
+	return token, resp.Header.Get("Location"), nil
+}
+
+// deleteEventStreamSession logs out a session created for the event stream, the session may have expired
+// already so the errors are only logged.
+func (c *Conn) deleteEventStreamSession(token, location string) {
+	if location == "" {
+		return
+	}
+
+	if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+		location = c.Host + location
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, location, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("X-Auth-Token", token)
+
+	resp, err := c.conn.HTTPClient.Do(req)
+	if err != nil {
+		c.Log.V(1).Info("redfish event stream session logout failed", "host", c.Host, "error", err.Error())
+		return
+	}
+	resp.Body.Close()
+}
+
+// reconnectEventStream opens the event stream again, logging in a new session first since the previous one
+// may have expired while the stream was open. The new session is returned along with the stream.
+func (c *Conn) reconnectEventStream(ctx context.Context, uri, lastEventID string) (stream io.ReadCloser, token, location string, err error) {
+	if !c.basicAuth && c.User != "" {
+		token, location, err = c.createEventStreamSession(ctx)
+		if err != nil {
+			return nil, "", "", err
+		}
+	}
+
+	stream, err = c.openEventStream(ctx, uri, token, lastEventID)
+	if err != nil {
+		c.deleteEventStreamSession(token, location)
+		return nil, "", "", err
+	}
+
+	return stream, token, location, nil
+}
+
+// streamEvents sends the events of the stream to the channel, reconnecting the stream when it drops,
+// and closes the channel once ctx is done. The sessions logged in by the reconnections are logged out
+// once replaced, the first one belongs to the connection and is left to Close.
+func (c *Conn) streamEvents(ctx context.Context, uri, token string, stream io.ReadCloser, events chan<- devices.EventLogEntry) {
+	defer close(events)
+
+	var location string
+	defer func() { c.deleteEventStreamSession(token, location) }()
+
+	var lastEventID string
+	backoff := eventStreamMinBackoff
+	for {
+		received, err := readEventStream(ctx, stream, &lastEventID, events)
+		stream.Close()
+		if ctx.Err() != nil {
+			return
+		}
+
+		if received {
+			backoff = eventStreamMinBackoff
+		}
+		c.Log.V(1).Info("redfish event stream dropped, reconnecting", "host", c.Host, "error", err.Error())
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(bmclibErrs.RetryDelay(err, backoff)):
+			}
+
+			if backoff *= 2; backoff > eventStreamMaxBackoff {
+				backoff = eventStreamMaxBackoff
+			}
+
+			var newToken, newLocation string
+			stream, newToken, newLocation, err = c.reconnectEventStream(ctx, uri, lastEventID)
+			if err == nil {
+				c.deleteEventStreamSession(token, location)
+				token, location = newToken, newLocation
+				break
+			}
+			c.Log.V(1).Info("redfish event stream reconnect failed", "host", c.Host, "error", err.Error())
+		}
+	}
+}
+
+// readEventStream parses the server-sent events of the stream until it ends, sending the entries of each
+// event to the channel. It returns whether any event was received and the error that ended the stream.
+func readEventStream(ctx context.Context, stream io.Reader, lastEventID *string, events chan<- devices.EventLogEntry) (received bool, err error) {
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 4096), eventStreamMaxLine)
+
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// a blank line dispatches the event, the lines starting with a colon are comments used as keepalives
+		if line == "" {
+			if len(data) == 0 {
+				continue
+			}
+
+			payload := &eventPayload{}
+			err := json.Unmarshal([]byte(strings.Join(data, "\n")), payload)
+			data = data[:0]
+			if err != nil {
+				continue
+			}
+
+			received = true
+			for _, entry := range payload.entries() {
+				select {
+				case events <- entry:
+				case <-ctx.Done():
+					return received, ctx.Err()
+				}
+			}
+			continue
+		}
+
+		field, value := line, ""
+		if i := strings.Index(line, ":"); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+
+		switch field {
+		case "data":
+			data = append(data, value)
+		case "id":
+			*lastEventID = value
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return received, err
+	}
+
+	return received, io.EOF
+}
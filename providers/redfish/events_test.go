@@ -0,0 +1,208 @@
+package redfish
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	// eventStreamConnections counts the connections to the event stream of the mock server
+	eventStreamConnections int32
+	// eventStreamLastEventID is the Last-Event-ID sent when reconnecting the event stream
+	eventStreamLastEventID atomic.Value
+)
+
+// handler registered in redfish_test.go, the first connection sends two events and drops,
+// the next ones send a single event and stay open until the client goes away.
+func eventStream(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Accept") != "text/event-stream" {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+
+	if atomic.AddInt32(&eventStreamConnections, 1) == 1 {
+		fmt.Fprint(w, ": keepalive\n\n")
+		fmt.Fprint(w, "id: 1\n")
+		fmt.Fprint(w, `data: {"@odata.type": "#Event.v1_4_0.Event", "Id": "1", "Events": [{"EventId": "2162", "EventTimestamp": "2026-10-12T08:30:05-05:00", "EventType": "Alert",`+"\n")
+		fmt.Fprint(w, `data: "MessageId": "PSU0003", "Message": "The power input for power supply 2 is lost.", "MessageSeverity": "Critical", "OriginOfCondition": {"@odata.id": "/redfish/v1/Chassis/System.Embedded.1/Power/PowerSupplies/PSU.Slot.2"}}]}`+"\n\n")
+		fmt.Fprint(w, "id: 2\n")
+		fmt.Fprint(w, `data: {"Events": [{"EventId": "2163", "EventTimestamp": "2026-10-12T13:31:40Z", "MessageId": "FAN0001", "Message": "Fan 1 RPM is within range.", "Severity": "OK"}]}`+"\n\n")
+		return
+	}
+
+	eventStreamLastEventID.Store(r.Header.Get("Last-Event-ID"))
+	fmt.Fprint(w, "id: 3\n")
+	fmt.Fprint(w, `data: {"Events": [{"EventId": "2164", "EventTimestamp": "2026-10-12T13:35:00Z", "EventType": "Alert", "Message": "The system inlet temperature is greater than the upper warning threshold.", "MessageSeverity": "Warning"}]}`+"\n\n")
+	w.(http.Flusher).Flush()
+
+	<-r.Context().Done()
+}
+
+func Test_SubscribeEvents(t *testing.T) {
+	defer func(min time.Duration) { eventStreamMinBackoff = min }(eventStreamMinBackoff)
+	eventStreamMinBackoff = 10 * time.Millisecond
+	atomic.StoreInt32(&eventStreamConnections, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := mockClient.SubscribeEvents(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []devices.EventLogEntry{
+		{
+			ID:        "2162",
+			Timestamp: time.Date(2026, 10, 12, 13, 30, 5, 0, time.UTC),
+			Sensor:    "/redfish/v1/Chassis/System.Embedded.1/Power/PowerSupplies/PSU.Slot.2",
+			EventType: "Alert",
+			Severity:  devices.SeverityCritical,
+			Message:   "The power input for power supply 2 is lost.",
+		},
+		{
+			ID:        "2163",
+			Timestamp: time.Date(2026, 10, 12, 13, 31, 40, 0, time.UTC),
+			EventType: "FAN0001",
+			Severity:  devices.SeverityOK,
+			Message:   "Fan 1 RPM is within range.",
+		},
+		{
+			ID:        "2164",
+			Timestamp: time.Date(2026, 10, 12, 13, 35, 0, 0, time.UTC),
+			EventType: "Alert",
+			Severity:  devices.SeverityWarning,
+			Message:   "The system inlet temperature is greater than the upper warning threshold.",
+		},
+	}
+
+	for _, e := range expected {
+		select {
+		case entry := <-events:
+			assert.Equal(t, e, entry)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for event %s", e.ID)
+		}
+	}
+
+	// the stream was reconnected once, resuming after the last event received
+	assert.Equal(t, int32(2), atomic.LoadInt32(&eventStreamConnections))
+	assert.Equal(t, "2", eventStreamLastEventID.Load())
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the events channel to be closed")
+	}
+}
+
+func Test_SubscribeEventsNotOpen(t *testing.T) {
+	client := New("127.0.0.1", "", "root", "calvin", logr.Discard())
+
+	_, err := client.SubscribeEvents(context.Background())
+	if !errors.Is(err, bmclibErrs.ErrRedfishServiceNil) {
+		t.Errorf("Expected error %v: found %v", bmclibErrs.ErrRedfishServiceNil, err)
+	}
+}
+
+func Test_SubscribeEventsReauthenticates(t *testing.T) {
+	defer func(min time.Duration) { eventStreamMinBackoff = min }(eventStreamMinBackoff)
+	eventStreamMinBackoff = 10 * time.Millisecond
+
+	// the session of the connection expires once the first stream drops,
+	// the stream is only accepted again with a new session
+	var (
+		mu          sync.Mutex
+		logins      int
+		staleLogin  bool
+		expired     = map[string]bool{}
+		loggedOut   []string
+		streamToken []string
+	)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == sessionsURI:
+			logins++
+			staleLogin = staleLogin || r.Header.Get("X-Auth-Token") != ""
+			w.Header().Set("X-Auth-Token", fmt.Sprintf("token-%d", logins))
+			w.Header().Set("Location", fmt.Sprintf("%s/%d", sessionsURI, logins))
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodDelete:
+			loggedOut = append(loggedOut, r.URL.Path)
+		case r.URL.Path == "/redfish/v1/SSE":
+			token := r.Header.Get("X-Auth-Token")
+			if expired[token] {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			streamToken = append(streamToken, token)
+
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, "id: %d\n", len(streamToken))
+			fmt.Fprintf(w, `data: {"Events": [{"EventId": "%d", "Message": "event", "Severity": "OK"}]}`+"\n\n", len(streamToken))
+			expired[token] = true
+		default:
+			_, _ = w.Write(jsonResponse(r.RequestURI))
+		}
+	}))
+	defer server.Close()
+
+	client := New(server.URL, "", "root", "calvin", logr.Discard(), WithInsecureTLS())
+	err := client.Open(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.SubscribeEvents(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"1", "2", "3"} {
+		select {
+		case entry := <-events:
+			assert.Equal(t, id, entry.ID)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for event %s", id)
+		}
+	}
+
+	cancel()
+	for range events {
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// each reconnection logged in without the expired token and logged out the session it replaced,
+	// the last one is logged out once the subscription ends
+	assert.Equal(t, []string{"token-1", "token-2", "token-3"}, streamToken[:3])
+	assert.False(t, staleLogin)
+	assert.Contains(t, loggedOut, sessionsURI+"/2")
+	assert.Contains(t, loggedOut, sessionsURI+"/3")
+	assert.NotContains(t, loggedOut, sessionsURI+"/1")
+}
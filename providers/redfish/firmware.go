@@ -237,7 +237,9 @@ func multipartPayloadSize(payload []map[string]io.Reader) (int64, *bytes.Buffer,
 // Content-Type: application/json
 //
 // {"Targets": [], "@Redfish.OperationApplyTime": "OnReset", "Oem":
-//  {}}
+//
+//	{}}
+//
 // --------------------------1771f60800cb2801
 // Content-Disposition: form-data; name="UpdateFile"; filename="dum
 // myfile"
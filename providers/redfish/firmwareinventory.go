@@ -0,0 +1,70 @@
+package redfish
+
+import (
+	"strings"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+	"github.com/pkg/errors"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the FirmwareInventorier interface.
+var _ devices.FirmwareInventorier = (*Conn)(nil)
+
+// softwareInventoryKey returns the key matching the previous firmware of a component to the installed one,
+// the software ID alone doesn't tell apart the components sharing a firmware, e.g.
+// Installed-0-22.31.6__NIC.Integrated.1-1-1 and Installed-0-22.31.6__NIC.Integrated.1-1-2.
+func softwareInventoryKey(inv *redfish.SoftwareInventory) string {
+	if i := strings.Index(inv.ID, "__"); i >= 0 {
+		return inv.SoftwareID + inv.ID[i:]
+	}
+
+	return inv.SoftwareID
+}
+
+// GetFirmwareInventory returns the firmware installed on each component listed by the UpdateService
+// FirmwareInventory, with the firmware it previously ran when the BMC keeps track of it.
+func (c *Conn) GetFirmwareInventory() ([]devices.Firmware, error) {
+	inv := &inventory{conn: c.conn}
+	softwareInventory, err := inv.collectSoftwareInventory()
+	if err != nil {
+		return nil, errors.Wrap(bmclibErrs.ErrRedfishSoftwareInventory, err.Error())
+	}
+
+	firmware := []devices.Firmware{}
+	installed := map[string]int{}
+	for _, item := range softwareInventory {
+		if strings.HasPrefix(item.ID, "Previous") {
+			continue
+		}
+
+		installed[softwareInventoryKey(item)] = len(firmware)
+		firmware = append(firmware, devices.Firmware{
+			Component:  item.Name,
+			Updatable:  item.Updateable,
+			Installed:  item.Version,
+			SoftwareID: item.SoftwareID,
+			Metadata:   map[string]string{"id": item.ID},
+		})
+	}
+
+	for _, item := range softwareInventory {
+		if !strings.HasPrefix(item.ID, "Previous") {
+			continue
+		}
+
+		i, ok := installed[softwareInventoryKey(item)]
+		if !ok || firmware[i].Installed == item.Version {
+			continue
+		}
+
+		firmware[i].Previous = append(firmware[i].Previous, &devices.Firmware{
+			Installed:  item.Version,
+			SoftwareID: item.SoftwareID,
+		})
+	}
+
+	return firmware, nil
+}
@@ -0,0 +1,63 @@
+package redfish
+
+import (
+	"testing"
+
+	"github.com/stmcginnis/gofish/redfish"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+func Test_GetFirmwareInventory(t *testing.T) {
+	inventory, err := mockClient.GetFirmwareInventory()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []devices.Firmware{
+		{
+			Component:  "BIOS",
+			Updatable:  true,
+			Installed:  "2.13.3",
+			SoftwareID: "159",
+			Previous:   []*devices.Firmware{{Installed: "2.12.2", SoftwareID: "159"}},
+			Metadata:   map[string]string{"id": "Installed-159-2.13.3"},
+		},
+		{
+			Component:  "Integrated Dell Remote Access Controller",
+			Updatable:  true,
+			Installed:  "5.10.00.00",
+			SoftwareID: "25227",
+			Metadata:   map[string]string{"id": "Installed-25227-5.10.00.00"},
+		},
+		{
+			Component:  "Mellanox ConnectX-4 Lx 25GbE SFP Adapter - 0C:42:A1:D2:5B:3E",
+			Installed:  "22.31.6",
+			SoftwareID: "0",
+			Metadata:   map[string]string{"id": "Installed-0-22.31.6__NIC.Integrated.1-1-1"},
+		},
+	}
+	assert.Equal(t, expected, inventory)
+}
+
+func Test_softwareInventoryKey(t *testing.T) {
+	tests := []struct {
+		id         string
+		softwareID string
+		expected   string
+	}{
+		{"Installed-159-2.13.3", "159", "159"},
+		{"Previous-159-2.12.2", "159", "159"},
+		{"Installed-0-22.31.6__NIC.Integrated.1-1-1", "0", "0__NIC.Integrated.1-1-1"},
+		{"Previous-0-22.31.4__NIC.Integrated.1-1-1", "0", "0__NIC.Integrated.1-1-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			inv := &redfish.SoftwareInventory{SoftwareID: tt.softwareID}
+			inv.ID = tt.id
+			assert.Equal(t, tt.expected, softwareInventoryKey(inv))
+		})
+	}
+}
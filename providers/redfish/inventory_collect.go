@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/stmcginnis/gofish/common"
 	"github.com/stmcginnis/gofish/redfish"
 )
 
@@ -44,14 +45,18 @@ func (i *inventory) collectPSUs(ch *redfish.Chassis, device *devices.Device) (er
 		return nil
 	}
 
-	for _, psu := range power.PowerSupplies {
+	for idx, psu := range power.PowerSupplies {
 		p := &devices.PSU{
 			ID:                 psu.ID,
 			Description:        psu.Name,
 			Vendor:             psu.Manufacturer,
 			Model:              psu.Model,
 			Serial:             psu.SerialNumber,
+			Position:           idx + 1,
+			Present:            psu.Status.State != common.AbsentState,
 			PowerCapacityWatts: int64(psu.PowerCapacityWatts),
+			InputVoltage:       float64(psu.LineInputVoltage),
+			OutputWatts:        int64(psu.PowerOutputWatts),
 			Status: &devices.Status{
 				Health: string(psu.Status.Health),
 				State:  string(psu.Status.State),
@@ -70,11 +75,26 @@ func (i *inventory) collectPSUs(ch *redfish.Chassis, device *devices.Device) (er
 	return nil
 }
 
+// tpmVersion returns the TPM specification version for the module interface type
+func tpmVersion(interfaceType redfish.InterfaceType) string {
+	switch interfaceType {
+	case redfish.TPM1_2InterfaceType:
+		return "1.2"
+	case redfish.TPM2_0InterfaceType:
+		return "2.0"
+	default:
+		return ""
+	}
+}
+
 // collectTPMs collects Trusted Platform Module component information
 func (i *inventory) collectTPMs(sys *redfish.ComputerSystem, device *devices.Device) (err error) {
 	for _, module := range sys.TrustedModules {
 
 		tpm := &devices.TPM{
+			Present:       module.Status.State != common.AbsentState,
+			Enabled:       module.Status.State == common.EnabledState,
+			Version:       tpmVersion(module.InterfaceType),
 			InterfaceType: string(module.InterfaceType),
 			Firmware: &devices.Firmware{
 				Installed: module.FirmwareVersion,
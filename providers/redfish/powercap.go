@@ -0,0 +1,93 @@
+package redfish
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+	"github.com/pkg/errors"
+	rf "github.com/stmcginnis/gofish/redfish"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the PowerCapper interface.
+var _ devices.PowerCapper = (*Conn)(nil)
+
+// powerPatch is the payload setting the limit of the power control of a chassis
+type powerPatch struct {
+	PowerControl []powerControlPatch
+}
+
+type powerControlPatch struct {
+	PowerLimit powerLimitPatch
+}
+
+// powerLimitPatch is the limit of a power control, a nil limit removes the power cap
+type powerLimitPatch struct {
+	LimitInWatts *int
+}
+
+// chassisPower returns the Power resource of the first chassis with a power control
+func (c *Conn) chassisPower() (*rf.Power, error) {
+	chassis, err := c.conn.Service.Chassis()
+	if err != nil {
+		return nil, err
+	}
+
+	var model string
+	for _, ch := range chassis {
+		model = ch.Model
+
+		power, err := ch.Power()
+		if err != nil {
+			return nil, err
+		}
+
+		if power != nil && len(power.PowerControl) > 0 {
+			return power, nil
+		}
+	}
+
+	return nil, bmclibErrs.NewFeatureUnsupportedError("power cap", ProviderProtocol, model)
+}
+
+// GetPowerCap returns the power limit of the chassis, a null limit is reported as disabled
+func (c *Conn) GetPowerCap(ctx context.Context) (powerCap devices.PowerCap, err error) {
+	power, err := c.chassisPower()
+	if err != nil {
+		return powerCap, err
+	}
+
+	limit := int(power.PowerControl[0].PowerLimit.LimitInWatts)
+	if limit <= 0 {
+		return devices.PowerCap{}, nil
+	}
+
+	return devices.PowerCap{Enabled: true, Watts: limit}, nil
+}
+
+// SetPowerCap sets the power limit of the chassis, disabling the cap sets a null limit
+func (c *Conn) SetPowerCap(ctx context.Context, powerCap devices.PowerCap) error {
+	err := powerCap.Validate()
+	if err != nil {
+		return err
+	}
+
+	power, err := c.chassisPower()
+	if err != nil {
+		return err
+	}
+
+	payload := powerPatch{PowerControl: []powerControlPatch{{}}}
+	if powerCap.Enabled {
+		payload.PowerControl[0].PowerLimit.LimitInWatts = &powerCap.Watts
+	}
+
+	resp, err := c.conn.Patch(power.ODataID, payload)
+	if err != nil {
+		return errors.Wrap(bmclibErrs.ErrPowerCapSet, err.Error())
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
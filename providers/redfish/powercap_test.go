@@ -0,0 +1,38 @@
+package redfish
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/bmc-toolbox/bmclib/internal/conformance"
+)
+
+// powerLimit is the LimitInWatts of the chassis power control of the mock server
+var powerLimit = "null"
+
+// handler registered in redfish_test.go
+func chassisPower(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPatch {
+		payload := powerPatch{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || len(payload.PowerControl) != 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		powerLimit = "null"
+		if limit := payload.PowerControl[0].PowerLimit.LimitInWatts; limit != nil {
+			powerLimit = strconv.Itoa(*limit)
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	_, _ = w.Write(bytes.Replace(jsonResponse(r.RequestURI), []byte(`"LimitInWatts": null`), []byte(`"LimitInWatts": `+powerLimit), 1))
+}
+
+func Test_PowerCap(t *testing.T) {
+	conformance.PowerCapper(t, mockClient, 450)
+}
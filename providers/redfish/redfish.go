@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/bmc-toolbox/bmclib/devices"
 	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bmc-toolbox/bmclib/internal/httpclient"
 	"github.com/bmc-toolbox/bmclib/providers"
@@ -16,6 +17,7 @@ import (
 	"github.com/jacobweinstock/registrar"
 	"github.com/pkg/errors"
 	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
 	rf "github.com/stmcginnis/gofish/redfish"
 )
 
@@ -37,7 +39,13 @@ var (
 		providers.FeatureInventoryRead,
 		providers.FeatureFirmwareInstall,
 		providers.FeatureFirmwareInstallStatus,
+		providers.FeatureFirmwareInventory,
 		providers.FeatureBmcReset,
+		providers.FeatureVirtualMedia,
+		providers.FeaturePowerCap,
+		providers.FeatureBIOSConfigure,
+		providers.FeatureEventSubscribe,
+		providers.FeatureTimeSyncVerify,
 	}
 )
 
@@ -48,9 +56,14 @@ type Conn struct {
 	User                 string
 	Pass                 string
 	conn                 *gofish.APIClient
+	basicAuth            bool
 	Log                  logr.Logger
 	httpClient           *http.Client
 	httpClientSetupFuncs []func(*http.Client)
+	// timeReference is the clock VerifyTimeSync compares the BMC clock to, time.Now when nil
+	timeReference func() time.Time
+	// passwordPolicy is checked by the user management before a password is sent to the BMC
+	passwordPolicy devices.PasswordPolicy
 }
 
 // Option is a function applied to a *Conn
@@ -70,6 +83,30 @@ func WithSecureTLS(rootCAs *x509.CertPool) Option {
 	}
 }
 
+// WithInsecureTLS skips the verification of the BMC certificate, which is otherwise
+// verified against the system CAs. Use it for BMCs serving self-signed certificates.
+func WithInsecureTLS() Option {
+	return func(c *Conn) {
+		c.httpClientSetupFuncs = append(c.httpClientSetupFuncs, httpclient.WithInsecureTLS())
+	}
+}
+
+// WithTimeReference returns an option that sets the clock VerifyTimeSync compares the BMC clock to,
+// e.g. one synchronized against the NTP servers the BMC uses, instead of the local clock.
+func WithTimeReference(now func() time.Time) Option {
+	return func(c *Conn) {
+		c.timeReference = now
+	}
+}
+
+// WithPasswordPolicy returns an option that sets the policy the passwords are checked against
+// before they're sent to the BMC, devices.DefaultPasswordPolicy() by default.
+func WithPasswordPolicy(policy devices.PasswordPolicy) Option {
+	return func(c *Conn) {
+		c.passwordPolicy = policy
+	}
+}
+
 // New returns a redfish *Conn
 func New(host, port, user, pass string, log logr.Logger, opts ...Option) *Conn {
 	conn := &Conn{
@@ -78,6 +115,8 @@ func New(host, port, user, pass string, log logr.Logger, opts ...Option) *Conn {
 		User: user,
 		Pass: pass,
 		Log:  log,
+
+		passwordPolicy: devices.DefaultPasswordPolicy(),
 	}
 	for _, opt := range opts {
 		opt(conn)
@@ -115,15 +154,69 @@ func (c *Conn) Open(ctx context.Context) (err error) {
 		config.DumpWriter = os.Stdout
 	}
 
+	c.basicAuth = false
+	c.conn, err = gofish.ConnectContext(ctx, config)
+	if err == nil || c.User == "" || !sessionUnavailable(err) {
+		return err
+	}
+
+	// some BMCs don't implement the SessionService, or refuse to create more sessions
+	// once their limit is reached, the basic auth still lets them be managed
+	c.Log.V(1).Info("redfish session login failed, falling back to basic auth", "host", c.Host, "error", err.Error())
+
+	config.BasicAuth = true
 	c.conn, err = gofish.ConnectContext(ctx, config)
 	if err != nil {
 		return err
 	}
+
+	// gofish doesn't send the basic auth credentials until the first authenticated request,
+	// query the systems so a wrong password fails here instead of being reported as a success
+	if _, err = c.conn.Service.Systems(); err != nil {
+		return errors.Wrap(err, "redfish basic auth login failed")
+	}
+	c.basicAuth = true
+
 	return nil
 }
 
+// sessionUnavailable returns true when the session login failed because the BMC doesn't
+// implement the SessionService or has reached its session limit, the rejected credentials
+// and the transport errors are not, retrying them with the basic auth would only hide them.
+func sessionUnavailable(err error) bool {
+	var rfErr *common.Error
+	if !errors.As(err, &rfErr) {
+		return false
+	}
+
+	switch rfErr.HTTPReturnedStatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		return true
+	case 0:
+		// gofish didn't get a Sessions link from the service root
+		return strings.Contains(rfErr.Error(), "no target provided")
+	}
+
+	if strings.Contains(rfErr.Code, "SessionLimitExceeded") {
+		return true
+	}
+
+	for _, info := range rfErr.ExtendedInfos {
+		if strings.Contains(info.MessageID, "SessionLimitExceeded") {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Close a connection to a BMC via redfish
 func (c *Conn) Close(ctx context.Context) error {
+	// there is no session to delete with the basic auth
+	if c.conn == nil || c.basicAuth {
+		return nil
+	}
+
 	c.conn.Logout()
 	return nil
 }
@@ -30,10 +30,26 @@ func jsonResponse(endpoint string) []byte {
 		"/redfish/v1/":              fixturesDir + "/v1/serviceroot.json",
 		"/redfish/v1/UpdateService": fixturesDir + "/v1/updateservice.json",
 		"/redfish/v1/Systems":       fixturesDir + "/v1/systems.json",
+		"/redfish/v1/Chassis":       fixturesDir + "/v1/chassis.json",
+		"/redfish/v1/Managers":      fixturesDir + "/v1/managers.json",
+		"/redfish/v1/EventService":  fixturesDir + "/v1/eventservice.json",
 
 		"/redfish/v1/Systems/System.Embedded.1":                                    fixturesDir + "/v1/dell/system.embedded.1.json",
+		"/redfish/v1/Chassis/System.Embedded.1":                                    fixturesDir + "/v1/dell/chassis.system.embedded.1.json",
+		"/redfish/v1/Chassis/System.Embedded.1/Power":                              fixturesDir + "/v1/dell/chassis.system.embedded.1.power.json",
+		"/redfish/v1/Systems/System.Embedded.1/Bios":                               fixturesDir + "/v1/dell/bios.json",
+		"/redfish/v1/Managers/iDRAC.Embedded.1":                                    fixturesDir + "/v1/dell/manager.idrac.embedded.1.json",
 		"/redfish/v1/Managers/iDRAC.Embedded.1/Oem/Dell/Jobs?$expand=*($levels=1)": fixturesDir + "/v1/dell/jobs.json",
 		"/redfish/v1/Managers/iDRAC.Embedded.1/Oem/Dell/Jobs/JID_467762674724":     fixturesDir + "/v1/dell/job_delete_ok.json",
+		"/redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia":                       fixturesDir + "/v1/dell/virtualmedia.json",
+		"/redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia/CD":                    fixturesDir + "/v1/dell/virtualmedia.cd.json",
+		"/redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia/RemovableDisk":         fixturesDir + "/v1/dell/virtualmedia.removabledisk.json",
+
+		"/redfish/v1/UpdateService/FirmwareInventory":                                           fixturesDir + "/v1/dell/firmwareinventory.json",
+		"/redfish/v1/UpdateService/FirmwareInventory/Installed-159-2.13.3":                      fixturesDir + "/v1/dell/firmwareinventory.installed-159.json",
+		"/redfish/v1/UpdateService/FirmwareInventory/Previous-159-2.12.2":                       fixturesDir + "/v1/dell/firmwareinventory.previous-159.json",
+		"/redfish/v1/UpdateService/FirmwareInventory/Installed-25227-5.10.00.00":                fixturesDir + "/v1/dell/firmwareinventory.installed-25227.json",
+		"/redfish/v1/UpdateService/FirmwareInventory/Installed-0-22.31.6__NIC.Integrated.1-1-1": fixturesDir + "/v1/dell/firmwareinventory.installed-0-nic.json",
 	}
 
 	fh, err := os.Open(jsonResponsesMap[endpoint])
@@ -58,13 +74,18 @@ func TestMain(m *testing.M) {
 		handler.HandleFunc("/redfish/v1/", serviceRoot)
 		handler.HandleFunc("/redfish/v1/UpdateService/MultipartUpload", multipartUpload)
 		handler.HandleFunc("/redfish/v1/Managers/iDRAC.Embedded.1/Oem/Dell/Jobs?$expand=*($levels=1)", dellJobs)
+		handler.HandleFunc("/redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia/CD/Actions/", virtualMediaActions)
+		handler.HandleFunc("/redfish/v1/Chassis/System.Embedded.1/Power", chassisPower)
+		handler.HandleFunc("/redfish/v1/Systems/System.Embedded.1/Bios/Settings", biosSettings)
+		handler.HandleFunc("/redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia/RemovableDisk/Actions/", virtualMediaActions)
+		handler.HandleFunc("/redfish/v1/SSE", eventStream)
 
 		return httptest.NewTLSServer(handler)
 	}()
 
 	mockBMCHost, _ = url.Parse(mockServer.URL)
 
-	mockClient = &Conn{Host: mockBMCHost.String()}
+	mockClient = New(mockBMCHost.String(), "", "", "", logr.Discard(), WithInsecureTLS())
 	err := mockClient.Open(context.TODO())
 	if err != nil {
 		log.Fatal(err)
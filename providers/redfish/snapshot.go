@@ -0,0 +1,127 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/pkg/errors"
+	"github.com/stmcginnis/gofish/common"
+
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+)
+
+// GetBMCVersion returns the firmware version of the BMC
+func (c *Conn) GetBMCVersion(ctx context.Context) (version string, err error) {
+	if c.conn == nil || c.conn.Service == nil {
+		return version, bmclibErrs.ErrRedfishServiceNil
+	}
+
+	managers, err := c.conn.Service.Managers()
+	if err != nil {
+		return version, err
+	}
+
+	for _, manager := range managers {
+		if manager.FirmwareVersion != "" {
+			return manager.FirmwareVersion, nil
+		}
+	}
+
+	return version, errors.New("no manager reported a firmware version")
+}
+
+// ServerSnapshot returns a devices.Discrete populated from the Systems, Chassis and Managers resources,
+// unlike Inventory it only relies on the resources every Redfish service exposes.
+func (c *Conn) ServerSnapshot(ctx context.Context) (discrete *devices.Discrete, err error) {
+	if c.conn == nil || c.conn.Service == nil {
+		return nil, bmclibErrs.ErrRedfishServiceNil
+	}
+
+	systems, err := c.conn.Service.Systems()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(systems) == 0 {
+		return nil, errors.New("no system found")
+	}
+
+	system := systems[0]
+	discrete = &devices.Discrete{
+		CollectedAt:          time.Now().UTC(),
+		Serial:               devices.NormalizeSerial(system.SerialNumber),
+		Name:                 system.HostName,
+		Vendor:               system.Manufacturer,
+		Model:                system.Model,
+		BiosVersion:          system.BIOSVersion,
+		BmcType:              ProviderProtocol,
+		BmcAddress:           strings.TrimPrefix(strings.TrimPrefix(c.Host, "https://"), "http://"),
+		BmcAuth:              true,
+		PowerState:           strings.ToLower(string(system.PowerState)),
+		Status:               string(system.Status.Health),
+		Processor:            system.ProcessorSummary.Model,
+		ProcessorCount:       system.ProcessorSummary.Count,
+		ProcessorThreadCount: system.ProcessorSummary.LogicalProcessorCount,
+		Memory:               int(system.MemorySummary.TotalSystemMemoryGiB),
+	}
+
+	// the boot order only holds the references of the boot options, their enabled
+	// state lives in the BootOptions collection, the listed entries are reported as enabled
+	for idx, device := range system.Boot.BootOrder {
+		discrete.BootOrder = append(discrete.BootOrder, devices.BootEntry{Device: device, Enabled: true, Index: idx})
+	}
+
+	discrete.BmcVersion, err = c.GetBMCVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chassis, err := c.conn.Service.Chassis()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ch := range chassis {
+		if discrete.Serial == "" {
+			discrete.Serial = devices.NormalizeSerial(ch.SerialNumber)
+		}
+
+		power, err := ch.Power()
+		if err != nil {
+			return nil, err
+		}
+
+		// chassis without power resource, like the storage enclosures
+		if power == nil {
+			continue
+		}
+
+		for _, control := range power.PowerControl {
+			discrete.PowerKw += float64(control.PowerConsumedWatts) / 1000.00
+		}
+
+		for _, psu := range power.PowerSupplies {
+			serial := devices.NormalizeSerial(psu.SerialNumber)
+			if serial == "" {
+				serial = fmt.Sprintf("%s_%s", discrete.Serial, strings.ToLower(psu.MemberID))
+			}
+
+			discrete.Psus = append(discrete.Psus, &devices.Psu{
+				Serial:       serial,
+				CapacityKw:   float64(psu.PowerCapacityWatts) / 1000.00,
+				PowerKw:      float64(psu.PowerOutputWatts) / 1000.00,
+				Status:       string(psu.Status.Health),
+				PartNumber:   strings.ToLower(psu.PartNumber),
+				Position:     len(discrete.Psus) + 1,
+				Present:      psu.Status.State != common.AbsentState,
+				InputVoltage: float64(psu.LineInputVoltage),
+				OutputWatts:  int64(psu.PowerOutputWatts),
+			})
+		}
+	}
+
+	return discrete, nil
+}
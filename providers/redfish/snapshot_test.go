@@ -0,0 +1,154 @@
+package redfish
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+func Test_GetBMCVersion(t *testing.T) {
+	version, err := mockClient.GetBMCVersion(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "4.40.10.00", version)
+}
+
+func Test_ServerSnapshot(t *testing.T) {
+	discrete, err := mockClient.ServerSnapshot(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "cncmu0005400ij", discrete.Serial)
+	assert.Equal(t, "Dell Inc.", discrete.Vendor)
+	assert.Equal(t, "PowerEdge R6515", discrete.Model)
+	assert.Equal(t, "2.2.4", discrete.BiosVersion)
+	assert.Equal(t, "4.40.10.00", discrete.BmcVersion)
+	assert.Equal(t, "redfish", discrete.BmcType)
+	assert.Equal(t, mockBMCHost.Host, discrete.BmcAddress)
+	assert.Equal(t, "on", discrete.PowerState)
+	assert.Equal(t, "OK", discrete.Status)
+	assert.Equal(t, "AMD EPYC 7402P 24-Core Processor", discrete.Processor)
+	assert.Equal(t, 1, discrete.ProcessorCount)
+	assert.Equal(t, 48, discrete.ProcessorThreadCount)
+	assert.Equal(t, 64, discrete.Memory)
+	assert.InDelta(t, 0.168, discrete.PowerKw, 0.0001)
+	assert.Equal(t, []devices.BootEntry{
+		{Device: "NIC.Slot.3-1-1", Enabled: true, Index: 0},
+		{Device: "HardDisk.List.1-1", Enabled: true, Index: 1},
+	}, discrete.BootOrder)
+
+	expectedPsus := []*devices.Psu{
+		{Serial: "pharp0079g0045", CapacityKw: 0.55, PowerKw: 0.086, Status: "OK", PartNumber: "0pjmdna01", Position: 1, Present: true, InputVoltage: 232, OutputWatts: 86},
+		{Serial: "pharp0079g0046", CapacityKw: 0.55, Status: "Critical", PartNumber: "0pjmdna01", Position: 2, Present: true},
+	}
+
+	if assert.Len(t, discrete.Psus, len(expectedPsus)) {
+		for i, psu := range expectedPsus {
+			assert.Equal(t, psu.Serial, discrete.Psus[i].Serial)
+			assert.InDelta(t, psu.CapacityKw, discrete.Psus[i].CapacityKw, 0.0001)
+			assert.InDelta(t, psu.PowerKw, discrete.Psus[i].PowerKw, 0.0001)
+			assert.Equal(t, psu.Status, discrete.Psus[i].Status)
+			assert.Equal(t, psu.PartNumber, discrete.Psus[i].PartNumber)
+			assert.Equal(t, psu.Position, discrete.Psus[i].Position)
+			assert.Equal(t, psu.Present, discrete.Psus[i].Present)
+			assert.InDelta(t, psu.InputVoltage, discrete.Psus[i].InputVoltage, 0.0001)
+			assert.Equal(t, psu.OutputWatts, discrete.Psus[i].OutputWatts)
+		}
+	}
+}
+
+func Test_OpenBasicAuthFallback(t *testing.T) {
+	// a service without SessionService, only answering the requests carrying basic auth
+	var sessionRequested bool
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			sessionRequested = true
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		user, pass, ok := r.BasicAuth()
+		if r.RequestURI != "/redfish/v1/" && (!ok || user != "root" || pass != "calvin") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write(jsonResponse(r.RequestURI))
+	}))
+	defer server.Close()
+
+	client := New(server.URL, "", "root", "calvin", logr.Discard(), WithInsecureTLS())
+	err := client.Open(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close(context.TODO())
+
+	assert.True(t, sessionRequested)
+	assert.True(t, client.basicAuth)
+
+	state, err := client.PowerStateGet(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "On", state)
+}
+
+func Test_OpenBasicAuthFallbackErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		sessionStatus int
+		pass          string
+		wantErr       bool
+		wantBasicAuth bool
+	}{
+		{"wrong password is not retried", http.StatusUnauthorized, "calvin", true, false},
+		{"forbidden is not retried", http.StatusForbidden, "root", true, false},
+		{"missing session service", http.StatusNotFound, "calvin", false, true},
+		{"missing session service, wrong password", http.StatusNotFound, "wrong", true, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var sessionLogins int
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					sessionLogins++
+					w.WriteHeader(tc.sessionStatus)
+					return
+				}
+
+				user, pass, ok := r.BasicAuth()
+				if r.RequestURI != "/redfish/v1/" && (!ok || user != "root" || pass != "calvin") {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				_, _ = w.Write(jsonResponse(r.RequestURI))
+			}))
+			defer server.Close()
+
+			client := New(server.URL, "", "root", tc.pass, logr.Discard(), WithInsecureTLS())
+			err := client.Open(context.TODO())
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			// the rejected credentials are sent a single time
+			assert.Equal(t, 1, sessionLogins)
+			assert.Equal(t, tc.wantBasicAuth, client.basicAuth)
+		})
+	}
+}
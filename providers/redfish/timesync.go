@@ -0,0 +1,45 @@
+package redfish
+
+import (
+	"context"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+	"github.com/pkg/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the TimeSyncVerifier interface.
+var _ devices.TimeSyncVerifier = (*Conn)(nil)
+
+// managerDateTime returns the current time of the first manager reporting its DateTime
+func (c *Conn) managerDateTime() (time.Time, error) {
+	managers, err := c.conn.Service.Managers()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var model string
+	for _, manager := range managers {
+		model = manager.Model
+		if manager.DateTime == "" {
+			continue
+		}
+
+		dateTime, err := time.Parse(time.RFC3339, manager.DateTime)
+		if err != nil {
+			return time.Time{}, errors.Wrap(bmclibErrs.ErrUnableToReadData, err.Error())
+		}
+
+		return dateTime, nil
+	}
+
+	return time.Time{}, bmclibErrs.NewFeatureUnsupportedError("time sync verification", ProviderProtocol, model)
+}
+
+// VerifyTimeSync compares the DateTime of the manager to the reference clock set with WithTimeReference,
+// the local clock by default, and returns the offset of the BMC clock and whether it is within the tolerance.
+func (c *Conn) VerifyTimeSync(ctx context.Context, tolerance time.Duration) (offset time.Duration, inSync bool, err error) {
+	return devices.MeasureTimeOffset(c.timeReference, tolerance, c.managerDateTime)
+}
@@ -0,0 +1,58 @@
+package redfish
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_VerifyTimeSync(t *testing.T) {
+	// the manager fixture reports 2021-06-09T09:34:55-05:00
+	bmcTime := time.Date(2021, 6, 9, 14, 34, 55, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		reference func() time.Time
+		offset    time.Duration
+		inSync    bool
+	}{
+		{
+			"in sync with the reference",
+			func() time.Time { return bmcTime.Add(-3 * time.Second) },
+			3 * time.Second,
+			true,
+		},
+		{
+			"behind the reference",
+			func() time.Time { return bmcTime.Add(2 * time.Minute) },
+			-2 * time.Minute,
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := *mockClient
+			WithTimeReference(tt.reference)(&conn)
+
+			offset, inSync, err := conn.VerifyTimeSync(context.TODO(), 5*time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tt.offset, offset)
+			assert.Equal(t, tt.inSync, inSync)
+		})
+	}
+}
+
+func Test_VerifyTimeSyncLocalClock(t *testing.T) {
+	// the BMC clock of the fixture is years behind the local clock
+	offset, inSync, err := mockClient.VerifyTimeSync(context.TODO(), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, inSync)
+	assert.Less(t, int64(offset), int64(-365*24*time.Hour))
+}
@@ -3,6 +3,7 @@ package redfish
 import (
 	"context"
 
+	"github.com/bmc-toolbox/bmclib/devices"
 	"github.com/bmc-toolbox/bmclib/internal"
 	"github.com/pkg/errors"
 	"github.com/stmcginnis/gofish/redfish"
@@ -48,6 +49,13 @@ func (c *Conn) UserRead(ctx context.Context) (users []map[string]string, err err
 
 // UserUpdate updates a user password and role
 func (c *Conn) UserUpdate(ctx context.Context, user, pass, role string) (ok bool, err error) {
+	if pass != "" {
+		err = devices.ValidatePassword(pass, c.passwordPolicy)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	service, err := c.conn.Service.AccountService()
 	if err != nil {
 		return false, err
@@ -93,6 +101,11 @@ func (c *Conn) UserCreate(ctx context.Context, user, pass, role string) (ok bool
 		return false, ErrUserPassParams
 	}
 
+	err = devices.ValidatePassword(pass, c.passwordPolicy)
+	if err != nil {
+		return false, err
+	}
+
 	service, err := c.conn.Service.AccountService()
 	if err != nil {
 		return false, err
@@ -0,0 +1,122 @@
+package redfish
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+	"github.com/pkg/errors"
+	rf "github.com/stmcginnis/gofish/redfish"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the VirtualMediaController interface.
+var _ devices.VirtualMediaController = (*Conn)(nil)
+
+// mediaKind returns the kind of media a virtual media device presents, from its Redfish media types
+func mediaKind(mediaTypes []rf.VirtualMediaType) (devices.MediaKind, bool) {
+	for _, mediaType := range mediaTypes {
+		switch mediaType {
+		case rf.CDMediaType, rf.DVDMediaType:
+			return devices.MediaCD, true
+		case rf.USBStickMediaType:
+			return devices.MediaUSB, true
+		}
+	}
+
+	return "", false
+}
+
+// virtualMedia returns the virtual media device of the managers presenting the given kind of media
+func (c *Conn) virtualMedia(kind devices.MediaKind) (*rf.VirtualMedia, error) {
+	managers, err := c.conn.Service.Managers()
+	if err != nil {
+		return nil, err
+	}
+
+	var model string
+	for _, manager := range managers {
+		model = manager.Model
+
+		medias, err := manager.VirtualMedia()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, media := range medias {
+			if k, ok := mediaKind(media.MediaTypes); ok && k == kind {
+				return media, nil
+			}
+		}
+	}
+
+	return nil, bmclibErrs.NewFeatureUnsupportedError("virtual media "+string(kind), ProviderProtocol, model)
+}
+
+// MountVirtualMedia inserts the image at the given URI in the virtual media device of the kind,
+// ejecting the image it held.
+func (c *Conn) MountVirtualMedia(ctx context.Context, kind devices.MediaKind, image string) error {
+	media, err := c.virtualMedia(kind)
+	if err != nil {
+		return err
+	}
+
+	if media.Inserted {
+		err = media.EjectMedia()
+		if err != nil {
+			return errors.Wrap(err, "eject "+media.Image)
+		}
+	}
+
+	err = media.InsertMedia(image, true, true)
+	if err != nil {
+		return errors.Wrap(err, "insert "+image)
+	}
+
+	return nil
+}
+
+// UnmountVirtualMedia ejects the image of the virtual media device of the kind, if any
+func (c *Conn) UnmountVirtualMedia(ctx context.Context, kind devices.MediaKind) error {
+	media, err := c.virtualMedia(kind)
+	if err != nil {
+		return err
+	}
+
+	if !media.Inserted {
+		return nil
+	}
+
+	err = media.EjectMedia()
+	if err != nil {
+		return errors.Wrap(err, "eject "+media.Image)
+	}
+
+	return nil
+}
+
+// VirtualMediaStatus returns the state of the CD and USB virtual media devices of the managers
+func (c *Conn) VirtualMediaStatus(ctx context.Context) (status []devices.VirtualMedia, err error) {
+	managers, err := c.conn.Service.Managers()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, manager := range managers {
+		medias, err := manager.VirtualMedia()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, media := range medias {
+			kind, ok := mediaKind(media.MediaTypes)
+			if !ok {
+				continue
+			}
+
+			status = append(status, devices.VirtualMedia{Kind: kind, Image: media.Image, Inserted: media.Inserted})
+		}
+	}
+
+	return status, nil
+}
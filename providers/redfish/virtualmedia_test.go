@@ -0,0 +1,103 @@
+package redfish
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+)
+
+// virtualMediaPosts records the virtual media actions posted to the mock server, in order
+var virtualMediaPosts []string
+
+// handler registered in redfish_test.go
+func virtualMediaActions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	body, _ := ioutil.ReadAll(r.Body)
+	virtualMediaPosts = append(virtualMediaPosts, r.URL.Path+" "+string(body))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func Test_VirtualMediaStatus(t *testing.T) {
+	status, err := mockClient.VirtualMediaStatus(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []devices.VirtualMedia{
+		{Kind: devices.MediaUSB},
+		{Kind: devices.MediaCD, Image: "http://10.0.0.1/images/rescue.iso", Inserted: true},
+	}
+	assert.Equal(t, expected, status)
+}
+
+func Test_MountVirtualMedia(t *testing.T) {
+	const actions = "/redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia/"
+
+	tests := []struct {
+		name     string
+		action   func() error
+		expected []string
+	}{
+		{
+			"mount ejects the inserted image",
+			func() error {
+				return mockClient.MountVirtualMedia(context.TODO(), devices.MediaCD, "http://10.0.0.1/images/install.iso")
+			},
+			[]string{
+				actions + "CD/Actions/VirtualMedia.EjectMedia {}",
+				actions + `CD/Actions/VirtualMedia.InsertMedia {"Image":"http://10.0.0.1/images/install.iso","Inserted":true,"WriteProtected":true}`,
+			},
+		},
+		{
+			"mount usb",
+			func() error {
+				return mockClient.MountVirtualMedia(context.TODO(), devices.MediaUSB, "http://10.0.0.1/images/install.img")
+			},
+			[]string{
+				actions + `RemovableDisk/Actions/VirtualMedia.InsertMedia {"Image":"http://10.0.0.1/images/install.img","Inserted":true,"WriteProtected":true}`,
+			},
+		},
+		{
+			"unmount",
+			func() error {
+				return mockClient.UnmountVirtualMedia(context.TODO(), devices.MediaCD)
+			},
+			[]string{actions + "CD/Actions/VirtualMedia.EjectMedia {}"},
+		},
+		{
+			"unmount without image",
+			func() error {
+				return mockClient.UnmountVirtualMedia(context.TODO(), devices.MediaUSB)
+			},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			virtualMediaPosts = nil
+
+			err := tt.action()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expected, virtualMediaPosts)
+		})
+	}
+}
+
+func Test_MountVirtualMediaUnsupported(t *testing.T) {
+	err := mockClient.MountVirtualMedia(context.TODO(), devices.MediaKind("Floppy"), "http://10.0.0.1/images/floppy.img")
+	assert.ErrorIs(t, err, bmclibErrs.ErrFeatureUnavailable)
+}
@@ -0,0 +1,508 @@
+package supermicro
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+	"github.com/go-logr/logr"
+)
+
+// FirmwareClient is the connection to an X10 or X11 BMC the firmware updates go through,
+// the generations run the same updates but write their ipmi.cgi queries differently.
+type FirmwareClient interface {
+	// Query runs the ipmi.cgi query op with its arguments, e.g. FW_UPDATE_ENTER.XML and 0,0
+	Query(op, args string) (*IPMI, error)
+	// Post posts body to the cgi endpoint, form is urlencoded as the body when contentType is empty
+	Post(endpoint string, form url.Values, body []byte, contentType string) error
+	// PowerState returns the power state of the host, on or off
+	PowerState() (string, error)
+	// BiosVersion returns the version of the BIOS the host went through POST with
+	BiosVersion() (string, error)
+	// Version returns the version of the BMC firmware
+	Version() (string, error)
+	// Reconnect drops the session, the next request logs in again
+	Reconnect()
+}
+
+// Flasher goes through the updates of the BMC firmware and of the BIOS of the X10 and X11 BMCs,
+// the errors are returned as errors.FirmwareUpdateError telling the failed phase.
+type Flasher struct {
+	Client FirmwareClient
+	Log    logr.Logger
+	IP     string
+	// PreserveConfig keeps the BMC configuration or the BIOS settings across the update
+	PreserveConfig bool
+	// ChunkSize is the size of the parts the image is uploaded in, the web server of the BMC
+	// rejects the requests carrying a whole image.
+	ChunkSize int
+	// PollInterval is the wait between the checks of the flash progress, of the host power and of the BMC return
+	PollInterval time.Duration
+	// FlashTimeout bounds the wait for the BMC to report the flash as complete
+	FlashTimeout time.Duration
+	// RebootTimeout bounds the wait for the BMC to answer again once it restarts into the new firmware
+	RebootTimeout time.Duration
+	// PowerTimeout bounds the wait for the host to reach the requested power state
+	PowerTimeout time.Duration
+	// PostTimeout bounds the wait for the host to report the new BIOS version once it's powered back on
+	PostTimeout time.Duration
+}
+
+// firmwareTarget names the queries and endpoints updating the firmware of a component,
+// the queries are <prefix>_ENTER.XML, <prefix>_CHECK.XML, <prefix>_PROGRESS.XML and <prefix>_EXIT.XML.
+type firmwareTarget struct {
+	component string
+	prefix    string
+	upload    string
+	flashOp   string
+}
+
+var (
+	bmcFirmware  = firmwareTarget{component: "bmc", prefix: "FW_UPDATE", upload: "upload_firmware.cgi", flashOp: "main_fwupdate"}
+	biosFirmware = firmwareTarget{component: "bios", prefix: "BIOS_UPDATE", upload: "upload_bios.cgi", flashOp: "main_biosupdate"}
+)
+
+// firmwareImageLimit bounds the size of the images read from a file or downloaded, the X10 and X11
+// BMC firmware and BIOS images are 32MB at most, anything bigger isn't an image for these BMCs.
+var firmwareImageLimit int64 = 64 << 20
+
+// ReadFirmware reads the image file at filePath
+func ReadFirmware(filePath string) (image []byte, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return readFirmwareImage(file, filePath)
+}
+
+// DownloadFirmware fetches the image file from the http(s) URL source with client, the BMC takes
+// the image in the upload requests and can't fetch it itself. The client carries the TLS, proxy
+// and timeout options of the provider.
+func DownloadFirmware(ctx context.Context, client *http.Client, source, file string) (image []byte, err error) {
+	u, err := url.Parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("firmware source %q isn't an http(s) URL", source)
+	}
+	u.Path = path.Join(u.Path, file)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.WrapRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		// only the start of the body is kept in the error
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("downloading %s: %w", u.String(), errors.NewHTTPErrorFromResponse(resp, body))
+	}
+
+	return readFirmwareImage(resp.Body, u.String())
+}
+
+// readFirmwareImage reads an image from r, name tells where it's read from in the errors
+func readFirmwareImage(r io.Reader, name string) (image []byte, err error) {
+	image, err = ioutil.ReadAll(io.LimitReader(r, firmwareImageLimit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(image)) > firmwareImageLimit {
+		return nil, fmt.Errorf("firmware image %s is bigger than %d bytes", name, firmwareImageLimit)
+	}
+
+	return image, nil
+}
+
+// UpdateBMC updates the BMC firmware with image and returns the version running once the BMC is back.
+//  1. switch the BMC to the update mode, which stops its other services
+//  2. upload the image to upload_firmware.cgi in chunks
+//  3. have the BMC check the image
+//  4. flash it, keeping or resetting the configuration
+//  5. restart the BMC and wait for it to report the version of the image
+func (f *Flasher) UpdateBMC(ctx context.Context, image []byte, progress func(devices.FirmwareProgress)) (version string, err error) {
+	preserve := f.preserve()
+
+	form := url.Values{}
+	form.Set("preserve_config", preserve)
+	form.Set("preserve_sdr", preserve)
+	form.Set("preserve_ssl", preserve)
+
+	version, err = f.flashImage(ctx, bmcFirmware, image, form, progress)
+	if err != nil {
+		return version, err
+	}
+
+	version, err = f.rebootBMC(ctx, version, progress)
+	if err != nil {
+		return version, errors.NewFirmwareUpdateError(errors.FirmwareUpdatePhaseReboot, err)
+	}
+
+	return version, nil
+}
+
+// UpdateBIOS updates the BIOS with image and returns the version of the image.
+//  1. power off the host, the BIOS can't be written while it runs
+//  2. upload, check and flash the image like the BMC firmware, keeping or resetting the BIOS settings
+//  3. power the host on and wait for it to report the new version, powering it back off when it was off
+func (f *Flasher) UpdateBIOS(ctx context.Context, image []byte, progress func(devices.FirmwareProgress)) (version string, err error) {
+	state, err := f.Client.PowerState()
+	if err != nil {
+		return version, errors.NewFirmwareUpdateError(errors.FirmwareUpdatePhasePrepare, fmt.Errorf("reading the host power state: %w", err))
+	}
+
+	wasOn := state == "on"
+	if wasOn {
+		err = f.setHostPower(ctx, false)
+		if err != nil {
+			return version, errors.NewFirmwareUpdateError(errors.FirmwareUpdatePhasePrepare, err)
+		}
+	}
+
+	preserve := f.preserve()
+
+	form := url.Values{}
+	form.Set("preserve_nvram", preserve)
+	form.Set("preserve_smbios", preserve)
+
+	version, err = f.flashImage(ctx, biosFirmware, image, form, progress)
+	if err != nil {
+		// the host is left off when the flash broke off, it may not boot a partly written BIOS
+		if updateErr, ok := err.(*errors.FirmwareUpdateError); ok && wasOn && updateErr.RetrySafe() {
+			if powerErr := f.setHostPower(ctx, true); powerErr != nil {
+				f.Log.V(1).Error(powerErr, "powering the host back on failed", "step", "FirmwareUpdateBIOS", "ip", f.IP)
+			}
+		}
+		return version, err
+	}
+
+	// the BMC only learns the new version once the host goes through POST, a host that was off
+	// is booted for the check and powered off again
+	err = f.verifyBIOSVersion(ctx, version, progress)
+	if err != nil {
+		return version, errors.NewFirmwareUpdateError(errors.FirmwareUpdatePhaseReboot, err)
+	}
+
+	if !wasOn {
+		err = f.setHostPower(ctx, false)
+		if err != nil {
+			return version, errors.NewFirmwareUpdateError(errors.FirmwareUpdatePhaseReboot, err)
+		}
+	}
+
+	return version, nil
+}
+
+// preserve returns the value of the preserve flags of the flash requests
+func (f *Flasher) preserve() string {
+	if f.PreserveConfig {
+		return "1"
+	}
+
+	return "0"
+}
+
+// flashImage uploads, checks and flashes the image for target and returns the version of the image,
+// the BMC is taken out of the update mode when the image isn't flashed.
+func (f *Flasher) flashImage(ctx context.Context, target firmwareTarget, image []byte, flashForm url.Values, progress func(devices.FirmwareProgress)) (version string, err error) {
+	f.Log.V(1).Info("updating the firmware", "step", "FirmwareUpdate", "ip", f.IP, "component", target.component, "size", len(image))
+
+	err = f.firmwareStep(target.prefix + "_ENTER.XML")
+	if err != nil {
+		return version, errors.NewFirmwareUpdateError(errors.FirmwareUpdatePhasePrepare, fmt.Errorf("entering the update mode: %w", err))
+	}
+	devices.ReportFirmwareProgress(progress, errors.FirmwareUpdatePhasePrepare, 100, "update mode entered")
+
+	// the BMC stays in the update mode until it's restarted, leave it when the image isn't flashed
+	flashed := false
+	defer func() {
+		if !flashed {
+			if exitErr := f.firmwareStep(target.prefix + "_EXIT.XML"); exitErr != nil {
+				f.Log.V(1).Error(exitErr, "leaving the update mode failed", "step", "FirmwareUpdate", "ip", f.IP, "component", target.component)
+			}
+		}
+	}()
+
+	err = f.uploadFirmware(ctx, target, image, progress)
+	if err != nil {
+		return version, errors.NewFirmwareUpdateError(errors.FirmwareUpdatePhaseUpload, err)
+	}
+
+	ipmi, err := f.Client.Query(target.prefix+"_CHECK.XML", "0,0")
+	if err == nil {
+		err = firmwareStatus(ipmi)
+	}
+	if err != nil {
+		return version, errors.NewFirmwareUpdateError(errors.FirmwareUpdatePhaseVerify, err)
+	}
+	version = ipmi.FwUpdate.Version
+	devices.ReportFirmwareProgress(progress, errors.FirmwareUpdatePhaseVerify, 100, fmt.Sprintf("image %s accepted", version))
+	f.Log.V(1).Info("firmware image accepted", "step", "FirmwareUpdate", "ip", f.IP, "component", target.component, "version", version)
+
+	flashed = true
+	err = f.flashFirmware(ctx, target, flashForm, progress)
+	if err != nil {
+		return version, errors.NewFirmwareUpdateError(errors.FirmwareUpdatePhaseFlash, err)
+	}
+
+	return version, nil
+}
+
+// setHostPower powers the host on or off through POWER_INFO.XML and waits for it to reach the state
+func (f *Flasher) setHostPower(ctx context.Context, on bool) error {
+	args, want := "1,0", "off"
+	if on {
+		args, want = "1,1", "on"
+	}
+
+	_, err := f.Client.Query("POWER_INFO.XML", args)
+	if err != nil {
+		return fmt.Errorf("powering the host %s: %w", want, err)
+	}
+	f.Log.V(1).Info("host power", "step", "FirmwareUpdateBIOS", "ip", f.IP, "requested", want)
+
+	ctx, cancel := context.WithTimeout(ctx, f.PowerTimeout)
+	defer cancel()
+
+	for {
+		state, err := f.Client.PowerState()
+		if err != nil {
+			return fmt.Errorf("reading the host power state: %w", err)
+		}
+
+		if state == want {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for the host to power %s: %w", want, ctx.Err())
+		case <-time.After(f.PollInterval):
+		}
+	}
+}
+
+// verifyBIOSVersion powers the host on and waits for the BIOS to report version, the BMC only
+// learns the running BIOS version once the host went through POST.
+func (f *Flasher) verifyBIOSVersion(ctx context.Context, version string, progress func(devices.FirmwareProgress)) error {
+	err := f.setHostPower(ctx, true)
+	if err != nil {
+		return err
+	}
+	devices.ReportFirmwareProgress(progress, errors.FirmwareUpdatePhaseReboot, 0, "host booting")
+
+	ctx, cancel := context.WithTimeout(ctx, f.PostTimeout)
+	defer cancel()
+
+	var current string
+	for {
+		current, err = f.Client.BiosVersion()
+		if err == nil && current == version {
+			devices.ReportFirmwareProgress(progress, errors.FirmwareUpdatePhaseReboot, 100, fmt.Sprintf("host running bios %s", version))
+			f.Log.V(1).Info("bios updated", "step", "FirmwareUpdateBIOS", "ip", f.IP, "version", version)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("host reports bios %q instead of %q: %w", current, version, ctx.Err())
+		case <-time.After(f.PollInterval):
+		}
+	}
+}
+
+// firmwareStep runs one of the <prefix>_*.XML update queries and checks the status it returns
+func (f *Flasher) firmwareStep(op string) error {
+	ipmi, err := f.Client.Query(op, "0,0")
+	if err != nil {
+		return err
+	}
+
+	return firmwareStatus(ipmi)
+}
+
+// firmwareStatus returns an error when the BMC refused a step of the update
+func firmwareStatus(ipmi *IPMI) error {
+	if ipmi.FwUpdate == nil {
+		return fmt.Errorf("no update status returned: %w", errors.ErrFirmwareInstallStatus)
+	}
+
+	if ipmi.FwUpdate.Status != "OK" {
+		return fmt.Errorf("step refused by the bmc: %s", ipmi.FwUpdate.Status)
+	}
+
+	return nil
+}
+
+// uploadFirmware posts the image to the upload endpoint of target in ChunkSize parts, each part
+// tells its offset and the total size so the BMC reassembles the image.
+func (f *Flasher) uploadFirmware(ctx context.Context, target firmwareTarget, image []byte, progress func(devices.FirmwareProgress)) error {
+	for offset := 0; offset < len(image); offset += f.ChunkSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		end := offset + f.ChunkSize
+		if end > len(image) {
+			end = len(image)
+		}
+
+		var form bytes.Buffer
+		w := multipart.NewWriter(&form)
+
+		err := w.WriteField("offset", strconv.Itoa(offset))
+		if err != nil {
+			return err
+		}
+		err = w.WriteField("total_size", strconv.Itoa(len(image)))
+		if err != nil {
+			return err
+		}
+
+		part, err := w.CreateFormFile("fw_image", "fw_image.bin")
+		if err != nil {
+			return err
+		}
+		_, err = part.Write(image[offset:end])
+		if err != nil {
+			return err
+		}
+
+		// close multipart writer - adds the teminating boundary.
+		w.Close()
+
+		err = f.Client.Post(target.upload, url.Values{}, form.Bytes(), w.FormDataContentType())
+		if err != nil {
+			return fmt.Errorf("uploading bytes %d-%d: %w", offset, end, err)
+		}
+
+		percent := end * 100 / len(image)
+		devices.ReportFirmwareProgress(progress, errors.FirmwareUpdatePhaseUpload, percent, fmt.Sprintf("uploaded %d of %d bytes", end, len(image)))
+		f.Log.V(1).Info("firmware upload", "step", "FirmwareUpdate", "ip", f.IP, "component", target.component, "uploaded", end, "size", len(image))
+	}
+
+	return nil
+}
+
+// flashFirmware starts writing the checked image of target and waits for the flash to complete
+func (f *Flasher) flashFirmware(ctx context.Context, target firmwareTarget, flashForm url.Values, progress func(devices.FirmwareProgress)) error {
+	form := url.Values{}
+	for key := range flashForm {
+		form.Set(key, flashForm.Get(key))
+	}
+	form.Set("op", target.flashOp)
+
+	err := f.Client.Post("op.cgi", form, nil, "")
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, f.FlashTimeout)
+	defer cancel()
+
+	for {
+		ipmi, err := f.Client.Query(target.prefix+"_PROGRESS.XML", "0,0")
+		if err != nil {
+			return err
+		}
+
+		err = firmwareStatus(ipmi)
+		if err != nil {
+			return err
+		}
+
+		// an unreadable progress is retried until the timeout, the BMC may not report it right away
+		percent, _ := strconv.Atoi(ipmi.FwUpdate.Progress)
+		devices.ReportFirmwareProgress(progress, errors.FirmwareUpdatePhaseFlash, percent, fmt.Sprintf("flashing the %s", target.component))
+		f.Log.V(1).Info("firmware flash", "step", "FirmwareUpdate", "ip", f.IP, "component", target.component, "progress", percent)
+
+		if percent >= 100 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for the flash to complete, last progress %q: %w", ipmi.FwUpdate.Progress, ctx.Err())
+		case <-time.After(f.PollInterval):
+		}
+	}
+}
+
+// rebootBMC restarts the BMC into the new firmware and waits for it to report version, the old
+// firmware may still answer for a while after the restart is requested, its version isn't taken as the update.
+func (f *Flasher) rebootBMC(ctx context.Context, version string, progress func(devices.FirmwareProgress)) (current string, err error) {
+	form := url.Values{}
+	form.Set("op", "main_fwupdate_reboot")
+
+	err = f.Client.Post("op.cgi", form, nil, "")
+	if err != nil {
+		return current, err
+	}
+	devices.ReportFirmwareProgress(progress, errors.FirmwareUpdatePhaseReboot, 0, "bmc restarting")
+	f.Log.V(1).Info("bmc restarting", "step", "FirmwareUpdateBMC", "ip", f.IP, "version", version)
+
+	// the session doesn't survive the restart
+	f.Client.Reconnect()
+
+	ctx, cancel := context.WithTimeout(ctx, f.RebootTimeout)
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return current, fmt.Errorf("waiting for the bmc to return with firmware %q, last reported %q: %w", version, current, ctx.Err())
+		case <-time.After(f.PollInterval):
+		}
+
+		var running string
+		running, err = f.Client.Version()
+		if err != nil {
+			// the login fails until the BMC is up again
+			f.Client.Reconnect()
+			f.Log.V(1).Info("waiting for the bmc to return", "step", "FirmwareUpdateBMC", "ip", f.IP, "error", err.Error())
+			continue
+		}
+
+		current = running
+		if sameFirmwareVersion(current, version) {
+			devices.ReportFirmwareProgress(progress, errors.FirmwareUpdatePhaseReboot, 100, fmt.Sprintf("bmc back with firmware %s", current))
+			f.Log.V(1).Info("bmc back", "step", "FirmwareUpdateBMC", "ip", f.IP, "version", current)
+			return current, nil
+		}
+
+		f.Log.V(1).Info("waiting for the bmc to run the new firmware", "step", "FirmwareUpdateBMC", "ip", f.IP, "running", current, "expected", version)
+	}
+}
+
+// sameFirmwareVersion compares the version the image check reports, e.g. 03.88, with the one
+// GENERIC_INFO.XML reports, e.g. 0388, a check that returned no version takes any version.
+func sameFirmwareVersion(running, image string) bool {
+	if image == "" {
+		return true
+	}
+
+	return strings.ReplaceAll(strings.TrimSpace(running), ".", "") == strings.ReplaceAll(strings.TrimSpace(image), ".", "")
+}
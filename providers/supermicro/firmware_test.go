@@ -0,0 +1,62 @@
+package supermicro
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDownloadFirmware(t *testing.T) {
+	image := []byte("BMC_FIRMWARE")
+	images := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/firmware/SMT_X10_388.bin" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(image)
+	}))
+	defer images.Close()
+
+	answer, err := DownloadFirmware(context.Background(), images.Client(), images.URL+"/firmware", "SMT_X10_388.bin")
+	if err != nil {
+		t.Fatalf("Found errors calling DownloadFirmware %v", err)
+	}
+	if string(answer) != string(image) {
+		t.Errorf("Expected answer %s: found %s", image, answer)
+	}
+
+	// the images bigger than the limit aren't read
+	defer func(limit int64) { firmwareImageLimit = limit }(firmwareImageLimit)
+	firmwareImageLimit = int64(len(image) - 1)
+
+	_, err = DownloadFirmware(context.Background(), images.Client(), images.URL+"/firmware", "SMT_X10_388.bin")
+	if err == nil || !strings.Contains(err.Error(), "bigger than") {
+		t.Errorf("Expected the oversized image to be refused: found %v", err)
+	}
+}
+
+func TestReadFirmware(t *testing.T) {
+	path := t.TempDir() + "/SMT_X10_388.bin"
+	if err := ioutil.WriteFile(path, []byte("BMC_FIRMWARE"), 0o600); err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	image, err := ReadFirmware(path)
+	if err != nil {
+		t.Fatalf("Found errors calling ReadFirmware %v", err)
+	}
+	if string(image) != "BMC_FIRMWARE" {
+		t.Errorf("Expected answer %s: found %s", "BMC_FIRMWARE", image)
+	}
+
+	defer func(limit int64) { firmwareImageLimit = limit }(firmwareImageLimit)
+	firmwareImageLimit = 4
+
+	_, err = ReadFirmware(path)
+	if err == nil || !strings.Contains(err.Error(), "bigger than") {
+		t.Errorf("Expected the oversized image to be refused: found %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package supermicro
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+// SelInfo holds the system event log returned by the SEL_INFO.XML query,
+// the first SEL element carries the number of records, the others a raw record each.
+type SelInfo struct {
+	Records []*SelRecord `xml:"SEL,omitempty"`
+}
+
+// SelRecord holds a raw ipmi SEL record, hex encoded
+type SelRecord struct {
+	Total string `xml:"TOTAL_NUMBER,attr"`
+	Raw   string `xml:"SEL_RD,attr"`
+}
+
+// selSystemEvent is the record type of the standard ipmi system event records
+const selSystemEvent = 0x02
+
+// selSensorTypes names the ipmi sensor types found in the event logs
+var selSensorTypes = map[byte]string{
+	0x01: "Temperature",
+	0x02: "Voltage",
+	0x03: "Current",
+	0x04: "Fan",
+	0x05: "Physical Security",
+	0x06: "Platform Security",
+	0x07: "Processor",
+	0x08: "Power Supply",
+	0x09: "Power Unit",
+	0x0c: "Memory",
+	0x0d: "Drive Slot",
+	0x0f: "System Firmware Progress",
+	0x10: "Event Logging Disabled",
+	0x12: "System Event",
+	0x13: "Critical Interrupt",
+	0x14: "Button / Switch",
+	0x19: "Chipset",
+	0x1d: "System Boot Initiated",
+	0x20: "OS Stop / Shutdown",
+	0x21: "Slot / Connector",
+	0x23: "Watchdog",
+	0x2b: "Version Change",
+}
+
+// selThresholds describes the offsets of the threshold events,
+// even offsets are the going low events and odd offsets the going high ones.
+var selThresholds = []struct {
+	description string
+	severity    devices.Severity
+}{
+	{"Lower Non-critical going low", devices.SeverityWarning},
+	{"Lower Non-critical going high", devices.SeverityWarning},
+	{"Lower Critical going low", devices.SeverityCritical},
+	{"Lower Critical going high", devices.SeverityCritical},
+	{"Lower Non-recoverable going low", devices.SeverityCritical},
+	{"Lower Non-recoverable going high", devices.SeverityCritical},
+	{"Upper Non-critical going low", devices.SeverityWarning},
+	{"Upper Non-critical going high", devices.SeverityWarning},
+	{"Upper Critical going low", devices.SeverityCritical},
+	{"Upper Critical going high", devices.SeverityCritical},
+	{"Upper Non-recoverable going low", devices.SeverityCritical},
+	{"Upper Non-recoverable going high", devices.SeverityCritical},
+}
+
+// selSensorSpecific maps the sensor specific events reporting a failure to their severity,
+// keyed by sensor type and event offset. The other sensor specific events are informational.
+var selSensorSpecific = map[[2]byte]devices.Severity{
+	{0x07, 0x00}: devices.SeverityCritical, // processor IERR
+	{0x07, 0x01}: devices.SeverityCritical, // processor thermal trip
+	{0x08, 0x01}: devices.SeverityCritical, // power supply failure
+	{0x08, 0x02}: devices.SeverityWarning,  // power supply predictive failure
+	{0x08, 0x03}: devices.SeverityCritical, // power supply input lost
+	{0x0c, 0x00}: devices.SeverityWarning,  // memory correctable ECC
+	{0x0c, 0x01}: devices.SeverityCritical, // memory uncorrectable ECC
+	{0x0c, 0x05}: devices.SeverityWarning,  // memory correctable ECC logging limit reached
+	{0x0d, 0x01}: devices.SeverityCritical, // drive fault
+	{0x0d, 0x02}: devices.SeverityWarning,  // drive predictive failure
+	{0x13, 0x04}: devices.SeverityCritical, // PCI PERR
+	{0x13, 0x05}: devices.SeverityCritical, // PCI SERR
+	{0x23, 0x01}: devices.SeverityCritical, // watchdog hard reset
+}
+
+// DecodeSelRecord decodes a hex encoded 16 bytes ipmi SEL record into an event log entry,
+// the severity is derived from the event type and offset. Deasserted events are informational.
+func DecodeSelRecord(raw string) (entry devices.EventLogEntry, err error) {
+	record, err := hex.DecodeString(strings.Join(strings.Fields(raw), ""))
+	if err != nil {
+		return entry, fmt.Errorf("invalid SEL record %q: %w", raw, err)
+	}
+
+	if len(record) != 16 {
+		return entry, fmt.Errorf("invalid SEL record %q: expected 16 bytes, found %d", raw, len(record))
+	}
+
+	entry.ID = strconv.Itoa(int(binary.LittleEndian.Uint16(record[0:2])))
+
+	// the OEM records don't follow the system event layout
+	if record[2] != selSystemEvent {
+		if record[2] < 0xe0 {
+			entry.Timestamp = time.Unix(int64(binary.LittleEndian.Uint32(record[3:7])), 0).UTC()
+		}
+		entry.Severity = devices.SeverityUnknown
+		entry.Message = fmt.Sprintf("OEM record type 0x%02x: %x", record[2], record[7:])
+		return entry, nil
+	}
+
+	entry.Timestamp = time.Unix(int64(binary.LittleEndian.Uint32(record[3:7])), 0).UTC()
+
+	sensorType, sensorNumber := record[10], record[11]
+	sensor, ok := selSensorTypes[sensorType]
+	if !ok {
+		sensor = fmt.Sprintf("Sensor type 0x%02x", sensorType)
+	}
+	entry.Sensor = fmt.Sprintf("%s #0x%02x", sensor, sensorNumber)
+
+	deasserted := record[12]&0x80 != 0
+	eventType := record[12] & 0x7f
+	offset := record[13] & 0x0f
+
+	entry.EventType = "Asserted"
+	if deasserted {
+		entry.EventType = "Deasserted"
+	}
+
+	entry.Severity = devices.SeverityOK
+	switch {
+	case eventType == 0x01 && int(offset) < len(selThresholds):
+		entry.Message = fmt.Sprintf("%s: %s", sensor, selThresholds[offset].description)
+		entry.Severity = selThresholds[offset].severity
+	case eventType == 0x6f:
+		entry.Message = fmt.Sprintf("%s: event offset 0x%02x", sensor, offset)
+		if severity, ok := selSensorSpecific[[2]byte{sensorType, offset}]; ok {
+			entry.Severity = severity
+		}
+	default:
+		entry.Message = fmt.Sprintf("%s: event type 0x%02x offset 0x%02x", sensor, eventType, offset)
+	}
+
+	if deasserted {
+		entry.Severity = devices.SeverityOK
+	}
+
+	return entry, nil
+}
+
+// EventLog decodes the records of the system event log
+func (s *SelInfo) EventLog() (entries []devices.EventLogEntry, err error) {
+	for _, record := range s.Records {
+		if record.Raw == "" {
+			continue
+		}
+
+		entry, err := DecodeSelRecord(record.Raw)
+		if err != nil {
+			return entries, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
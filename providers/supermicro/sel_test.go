@@ -0,0 +1,89 @@
+package supermicro
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+func TestDecodeSelRecord(t *testing.T) {
+	timestamp := time.Unix(1600000000, 0).UTC()
+
+	tests := []struct {
+		name     string
+		raw      string
+		expected devices.EventLogEntry
+		wantErr  bool
+	}{
+		{
+			name: "threshold asserted",
+			raw:  "01000200105e5f20000401010159ffff",
+			expected: devices.EventLogEntry{
+				ID:        "1",
+				Timestamp: timestamp,
+				Sensor:    "Temperature #0x01",
+				EventType: "Asserted",
+				Severity:  devices.SeverityCritical,
+				Message:   "Temperature: Upper Critical going high",
+			},
+		},
+		{
+			name: "sensor specific deasserted",
+			raw:  "02000200105e5f200004 0c 08 ef 01 ffff",
+			expected: devices.EventLogEntry{
+				ID:        "2",
+				Timestamp: timestamp,
+				Sensor:    "Memory #0x08",
+				EventType: "Deasserted",
+				Severity:  devices.SeverityOK,
+				Message:   "Memory: event offset 0x01",
+			},
+		},
+		{
+			name: "sensor specific asserted",
+			raw:  "03000200105e5f20000408c86f01ffff",
+			expected: devices.EventLogEntry{
+				ID:        "3",
+				Timestamp: timestamp,
+				Sensor:    "Power Supply #0xc8",
+				EventType: "Asserted",
+				Severity:  devices.SeverityCritical,
+				Message:   "Power Supply: event offset 0x01",
+			},
+		},
+		{
+			name: "oem record",
+			raw:  "0400c000105e5f570100000000000000",
+			expected: devices.EventLogEntry{
+				ID:        "4",
+				Timestamp: timestamp,
+				Severity:  devices.SeverityUnknown,
+				Message:   "OEM record type 0xc0: 570100000000000000",
+			},
+		},
+		{
+			name:    "invalid hex",
+			raw:     "zz",
+			wantErr: true,
+		},
+		{
+			name:    "short record",
+			raw:     "0100",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := DecodeSelRecord(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v: found %v", tt.wantErr, err)
+			}
+
+			if entry != tt.expected {
+				t.Errorf("Expected answer %v: found %v", tt.expected, entry)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package supermicro
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+const (
+	// SensorTypeTemperature is the ipmi sensor type of the temperature sensors
+	SensorTypeTemperature = 0x01
+	// SensorTypeFan is the ipmi sensor type of the fans
+	SensorTypeFan = 0x04
+	// SensorTypePowerSupply is the ipmi sensor type of the power supplies
+	SensorTypePowerSupply = 0x08
+
+	// sensorThreshold is the reading type of the threshold sensors, the others are discrete
+	sensorThreshold = 0x01
+)
+
+// Sensor is a decoded SENSOR_INFO entry
+type Sensor struct {
+	Name string
+	Type byte // ipmi sensor type
+	// Reading is the converted reading of a threshold sensor, e.g. degrees or RPM
+	Reading float64
+	// States holds the asserted states of a discrete sensor, bit n is the event offset n
+	States    byte
+	Threshold bool
+	Health    devices.Health
+}
+
+// TypeName returns the name of the ipmi sensor type
+func (s *Sensor) TypeName() string {
+	if name, ok := selSensorTypes[s.Type]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Sensor type 0x%02x", s.Type)
+}
+
+// Sensors decodes the sensors holding a reading, the absent ones and
+// the ones the bmc isn't scanning are skipped.
+func (s *SensorInfo) Sensors() (sensors []*Sensor, err error) {
+	for _, elem := range s.SENSOR {
+		reading, err := hex.DecodeString(elem.READING)
+		if err != nil || len(reading) < 3 {
+			return sensors, fmt.Errorf("invalid reading %q for sensor %s", elem.READING, elem.NAME)
+		}
+
+		// the second byte flags the sensors without a reading
+		if reading[1]&0x40 == 0 || reading[1]&0x20 != 0 {
+			continue
+		}
+
+		sensorType, err := hexByte(elem.STYPE)
+		if err != nil {
+			return sensors, fmt.Errorf("invalid type %q for sensor %s", elem.STYPE, elem.NAME)
+		}
+		readingType, _ := hexByte(elem.RTYPE)
+
+		sensor := &Sensor{
+			Name:      elem.NAME,
+			Type:      sensorType,
+			Threshold: readingType == sensorThreshold,
+		}
+
+		if sensor.Threshold {
+			sensor.Reading = convertReading(reading[0], elem.UNIT1, elem.M, elem.B, elem.RB)
+			sensor.Health = thresholdHealth(reading[2])
+		} else {
+			sensor.States = reading[2]
+			sensor.Health = discreteHealth(sensorType, reading[2])
+		}
+
+		sensors = append(sensors, sensor)
+	}
+
+	return sensors, nil
+}
+
+// convertReading applies the linear conversion y = (M*x + B*10^Bexp) * 10^Rexp of the
+// sensor data record to a raw reading, the non linear sensors are reported raw.
+func convertReading(raw byte, unit, m, b, rb string) float64 {
+	x := float64(raw)
+	if u, err := hexByte(unit); err == nil && u>>6 == 0x02 {
+		// 2's complement reading
+		x = float64(int8(raw))
+	}
+
+	mBytes, errM := hex.DecodeString(m)
+	bBytes, errB := hex.DecodeString(b)
+	exponents, errRB := hexByte(rb)
+	if errM != nil || errB != nil || errRB != nil || len(mBytes) < 2 || len(bBytes) < 2 {
+		return x
+	}
+
+	return (float64(tenBits(mBytes))*x + float64(tenBits(bBytes))*math.Pow10(fourBits(exponents))) *
+		math.Pow10(fourBits(exponents>>4))
+}
+
+// thresholdHealth maps the crossed thresholds of a threshold sensor to a health
+func thresholdHealth(state byte) devices.Health {
+	switch {
+	// lower critical, lower non-recoverable, upper critical, upper non-recoverable
+	case state&0x36 != 0:
+		return devices.HealthCritical
+	// lower and upper non-critical
+	case state&0x09 != 0:
+		return devices.HealthWarning
+	default:
+		return devices.HealthOK
+	}
+}
+
+// discreteHealth maps the asserted states of a discrete sensor to a health,
+// using the severities of the matching event log entries.
+func discreteHealth(sensorType byte, states byte) devices.Health {
+	health := devices.HealthOK
+	for offset := byte(0); offset < 8; offset++ {
+		if states&(1<<offset) == 0 {
+			continue
+		}
+
+		switch selSensorSpecific[[2]byte{sensorType, offset}] {
+		case devices.SeverityCritical:
+			return devices.HealthCritical
+		case devices.SeverityWarning:
+			health = devices.HealthWarning
+		}
+	}
+
+	return health
+}
+
+// tenBits returns the signed 10 bits value stored in the 8 bits of the first
+// byte and the 2 most significant bits of the second one
+func tenBits(b []byte) int {
+	v := int(b[0]) | int(b[1]&0xc0)<<2
+	if v&0x200 != 0 {
+		v -= 0x400
+	}
+
+	return v
+}
+
+// fourBits returns the signed 4 bits value stored in the low nibble of b
+func fourBits(b byte) int {
+	v := int(b & 0x0f)
+	if v&0x08 != 0 {
+		v -= 0x10
+	}
+
+	return v
+}
+
+func hexByte(s string) (byte, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(b) != 1 {
+		return 0, fmt.Errorf("expected a single byte, found %q", s)
+	}
+
+	return b[0], nil
+}
@@ -0,0 +1,57 @@
+package supermicro
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+func TestSensors(t *testing.T) {
+	payload := []byte(`<?xml version="1.0"?>
+		<IPMI>
+		  <SENSOR_INFO>
+			<SENSOR ID="001" NUMBER="01" NAME="CPU Temp" READING="1ec000" OPTION="c0" STYPE="01" RTYPE="01" ERTYPE="01" UNIT1="00" UNIT="01" L="00" M="0100" B="0000" RB="00"/>
+			<SENSOR ID="002" NUMBER="0b" NAME="System Temp" READING="50c010" OPTION="c0" STYPE="01" RTYPE="01" ERTYPE="01" UNIT1="00" UNIT="01" L="00" M="0100" B="0000" RB="00"/>
+			<SENSOR ID="003" NUMBER="41" NAME="FAN1" READING="1cc000" OPTION="c0" STYPE="04" RTYPE="01" ERTYPE="01" UNIT1="00" UNIT="12" L="00" M="6400" B="0000" RB="00"/>
+			<SENSOR ID="004" NUMBER="42" NAME="FAN2" READING="000000" OPTION="00" STYPE="04" RTYPE="01" ERTYPE="01" UNIT1="00" UNIT="12" L="00" M="6400" B="0000" RB="00"/>
+			<SENSOR ID="005" NUMBER="30" NAME="12V" READING="c1c008" OPTION="c0" STYPE="02" RTYPE="01" ERTYPE="01" UNIT1="00" UNIT="04" L="00" M="1000" B="0000" RB="d0"/>
+			<SENSOR ID="006" NUMBER="c8" NAME="PS1 Status" READING="01c001" OPTION="c0" STYPE="08" RTYPE="6f" ERTYPE="6f" UNIT1="00" UNIT="00" L="00" M="0000" B="0000" RB="00"/>
+			<SENSOR ID="007" NUMBER="c9" NAME="PS2 Status" READING="01c003" OPTION="c0" STYPE="08" RTYPE="6f" ERTYPE="6f" UNIT1="00" UNIT="00" L="00" M="0000" B="0000" RB="00"/>
+		  </SENSOR_INFO>
+		</IPMI>`)
+
+	expected := []Sensor{
+		{Name: "CPU Temp", Type: SensorTypeTemperature, Reading: 30, Threshold: true, Health: devices.HealthOK},
+		{Name: "System Temp", Type: SensorTypeTemperature, Reading: 80, Threshold: true, Health: devices.HealthCritical},
+		{Name: "FAN1", Type: SensorTypeFan, Reading: 2800, Threshold: true, Health: devices.HealthOK},
+		{Name: "12V", Type: 0x02, Reading: 0.193 * 16, Threshold: true, Health: devices.HealthWarning},
+		{Name: "PS1 Status", Type: SensorTypePowerSupply, States: 0x01, Health: devices.HealthOK},
+		{Name: "PS2 Status", Type: SensorTypePowerSupply, States: 0x03, Health: devices.HealthCritical},
+	}
+
+	ipmi := &IPMI{}
+	if err := xml.Unmarshal(payload, ipmi); err != nil {
+		t.Fatalf("Found errors unmarshalling the payload %v", err)
+	}
+
+	sensors, err := ipmi.SensorInfo.Sensors()
+	if err != nil {
+		t.Fatalf("Found errors calling Sensors %v", err)
+	}
+
+	if len(sensors) != len(expected) {
+		t.Fatalf("Expected %v sensors: found %v", len(expected), len(sensors))
+	}
+
+	for pos, sensor := range sensors {
+		e := expected[pos]
+		if sensor.Name != e.Name || sensor.Type != e.Type || sensor.States != e.States ||
+			sensor.Threshold != e.Threshold || sensor.Health != e.Health {
+			t.Errorf("Expected answer %+v: found %+v", e, *sensor)
+		}
+		if diff := sensor.Reading - e.Reading; diff > 0.0001 || diff < -0.0001 {
+			t.Errorf("Expected answer %v: found %v", e.Reading, sensor.Reading)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package supermicro
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+)
+
+// RedfishLink is a reference to another Redfish resource
+type RedfishLink struct {
+	ODataID string `json:"@odata.id"`
+}
+
+// StorageCollection lists the Redfish storage subsystems of a server, /redfish/v1/Systems/{id}/Storage
+type StorageCollection struct {
+	Members []RedfishLink `json:"Members"`
+}
+
+// Storage is a Redfish storage subsystem, listing its drives
+type Storage struct {
+	ID     string        `json:"Id"`
+	Drives []RedfishLink `json:"Drives"`
+}
+
+// Drive is a Redfish Drive attached to a storage subsystem
+type Drive struct {
+	ID            string `json:"Id"`
+	Name          string `json:"Name"`
+	Model         string `json:"Model"`
+	SerialNumber  string `json:"SerialNumber"`
+	Revision      string `json:"Revision"`
+	CapacityBytes int64  `json:"CapacityBytes"`
+	MediaType     string `json:"MediaType"`
+	Protocol      string `json:"Protocol"`
+	Status        struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+}
+
+// Disk returns the drive as a devices.Disk
+func (d *Drive) Disk() *devices.Disk {
+	return &devices.Disk{
+		Status:    d.Status.Health,
+		Serial:    devices.NormalizeSerial(d.SerialNumber),
+		Type:      d.MediaType,
+		Size:      devices.FormatDiskSize(d.CapacityBytes),
+		Model:     strings.TrimSpace(d.Model),
+		Location:  d.Name,
+		FwVersion: d.Revision,
+		Interface: d.Protocol,
+	}
+}
+
+// RedfishDisks returns the drives of the storage subsystems listed at storageURI, getJSON decodes
+// the Redfish resource at an endpoint into v. The empty bays, listed with an Absent state, are left out.
+func RedfishDisks(getJSON func(endpoint string, v interface{}) error, storageURI string) (disks []*devices.Disk, err error) {
+	collection := &StorageCollection{}
+	err = getJSON(storageURI, collection)
+	if err != nil {
+		return disks, err
+	}
+
+	for _, member := range collection.Members {
+		storage := &Storage{}
+		err = getJSON(member.ODataID, storage)
+		if err != nil {
+			return disks, err
+		}
+
+		for _, link := range storage.Drives {
+			drive := &Drive{}
+			err = getJSON(link.ODataID, drive)
+			if err != nil {
+				return disks, err
+			}
+
+			if drive.Status.State == "Absent" {
+				continue
+			}
+
+			disks = append(disks, drive.Disk())
+		}
+	}
+
+	return disks, nil
+}
+
+// NoRedfishStorage tells whether err shows the firmware doesn't serve the Redfish storage, the firmware
+// without it answers 404, 401 or 500, or its web login page instead of the Redfish resources.
+func NoRedfishStorage(err error) bool {
+	var syntaxErr *json.SyntaxError
+	return bmclibErrs.IsNotFound(err) || bmclibErrs.IsUnauthorized(err) || errors.Is(err, bmclibErrs.Err500) || errors.As(err, &syntaxErr)
+}
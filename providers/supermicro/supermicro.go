@@ -20,6 +20,9 @@ type IPMI struct {
 	BiosLicense  *BiosLicense   `xml:"BIOS_LINCESNE,omitempty"`
 	HealthInfo   *HealthInfo    `xml:"HEALTH_INFO,omitempty"`
 	SensorInfo   *SensorInfo    `xml:"SENSOR_INFO,omitempty"`
+	SelInfo      *SelInfo       `xml:"SEL_INFO,omitempty"`
+	SmartInfo    *SmartInfo     `xml:"SMART_INFO,omitempty"`
+	FwUpdate     *FwUpdate      `xml:"FW_UPDATE,omitempty"`
 }
 
 // HealthInfo holds the health information
@@ -42,8 +45,11 @@ type Bios struct {
 
 // CPU holds the cpu information
 type CPU struct {
-	Core    string `xml:"CORE,attr"`
-	Version string `xml:"VER,attr"`
+	Core         string `xml:"CORE,attr"`
+	Version      string `xml:"VER,attr"`
+	Speed        string `xml:"SPEED,attr"`
+	Socket       string `xml:"SOCKET,attr"`
+	Manufacturer string `xml:"MANUFACTURER,attr"`
 }
 
 // ConfigInfo holds the bmc configuration
@@ -59,12 +65,20 @@ type Hostname struct {
 
 // UserAccounts contains the user account information
 type UserAccounts struct {
-	Name string `xml:"NAME,attr"`
+	Name   string `xml:"NAME,attr"`
+	Access string `xml:"USER_ACCESS,attr"` // ipmi privilege level, 04 == administrator
+	Status string `xml:"U_STATUS,attr"`    // 0 == disabled, not reported by all firmware
 }
 
 // Dimm holds the ram information
 type Dimm struct {
-	Size string `xml:"SIZE,attr"`
+	Type         string `xml:"TYPE,attr"`
+	Speed        string `xml:"SPEED,attr"`
+	Size         string `xml:"SIZE,attr"`
+	Location     string `xml:"LOCATION,attr"`
+	Serial       string `xml:"SN,attr"`
+	PartNumber   string `xml:"PN,attr"`
+	Manufacturer string `xml:"MANUFACTURER,attr"`
 }
 
 // FruInfo holds the fru ipmi information (serial numbers and so on)
@@ -452,6 +466,32 @@ type PowerInfo struct {
 	} `xml:"POWER,omitempty"`
 }
 
+// SmartInfo holds the drives reported by the SMARTINFO.XML query of the firmware without Redfish storage
+type SmartInfo struct {
+	HDD []*SmartHDD `xml:"HDD,omitempty"`
+}
+
+// SmartHDD holds the SMART information of a drive
+type SmartHDD struct {
+	ID           string `xml:"ID,attr"`
+	Model        string `xml:"MODEL,attr"`
+	Serial       string `xml:"SN,attr"`
+	FwVersion    string `xml:"FW_VER,attr"`
+	Capacity     string `xml:"CAPACITY,attr"`
+	Type         string `xml:"TYPE,attr"`
+	Interface    string `xml:"INTERFACE,attr"`
+	Status       string `xml:"STATUS,attr"`
+	PowerOnHours string `xml:"POWER_ON_HOURS,attr"`
+	Temperature  string `xml:"TEMP,attr"`
+}
+
+// FwUpdate holds the state of a firmware update, returned by the FW_UPDATE_*.XML queries
+type FwUpdate struct {
+	Status   string `xml:"STATUS,attr"`   // OK, or the reason the step was refused
+	Version  string `xml:"VERSION,attr"`  // version of the uploaded image
+	Progress string `xml:"PROGRESS,attr"` // completion of the flash, in percent
+}
+
 // SensorInfo for x11 BMCs
 type SensorInfo struct {
 	SENSOR []struct {
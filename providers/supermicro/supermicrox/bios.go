@@ -0,0 +1,33 @@
+package supermicrox
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the BIOSConfigurator interface.
+var _ devices.BIOSConfigurator = (*SupermicroX)(nil)
+
+// GetBIOSSettings isn't supported on SupermicroX yet
+func (s *SupermicroX) GetBIOSSettings(ctx context.Context) (settings *devices.BIOSSettings, err error) {
+	defer s.wrapError("GetBIOSSettings", &err)
+
+	return nil, errors.NewFeatureUnsupportedError("BIOS settings", s.Vendor(), s.HardwareType())
+}
+
+// SetBIOSSettings isn't supported on SupermicroX yet
+func (s *SupermicroX) SetBIOSSettings(ctx context.Context, settings *devices.BIOSSettings) (rebootRequired bool, err error) {
+	defer s.wrapError("SetBIOSSettings", &err)
+
+	return false, errors.NewFeatureUnsupportedError("BIOS settings", s.Vendor(), s.HardwareType())
+}
+
+// PendingBIOSSettings isn't supported on SupermicroX yet
+func (s *SupermicroX) PendingBIOSSettings(ctx context.Context) (settings *devices.BIOSSettings, err error) {
+	defer s.wrapError("PendingBIOSSettings", &err)
+
+	return nil, errors.NewFeatureUnsupportedError("BIOS settings", s.Vendor(), s.HardwareType())
+}
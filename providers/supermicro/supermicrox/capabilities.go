@@ -0,0 +1,60 @@
+package supermicrox
+
+import (
+	"strings"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/providers"
+	"github.com/jacobweinstock/registrar"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the CapabilityReporter interface.
+var _ devices.CapabilityReporter = (*SupermicroX)(nil)
+
+// generation returns the generation of a board, X10 or X11, from its model, or an empty string
+func generation(model string) string {
+	model = strings.ToLower(model)
+	for _, g := range []string{X10, X11} {
+		if strings.HasPrefix(model, g) {
+			return g
+		}
+	}
+
+	return ""
+}
+
+// Capabilities returns the operations supported by the detected board.
+// The power, boot device and bmc reset are driven over ipmi, the event log, sensors and firmware
+// versions are read through ipmi.cgi, the user management relies on the config_user.cgi and the BMC and BIOS
+// updates on the upload and op.cgi endpoints of the X10 and X11 firmwares.
+func (s *SupermicroX) Capabilities() registrar.Features {
+	features := registrar.Features{
+		providers.FeaturePowerState,
+		providers.FeaturePowerSet,
+		providers.FeatureBootDeviceSet,
+		providers.FeatureBmcReset,
+		providers.FeatureEventLogRead,
+		providers.FeatureSensorRead,
+		providers.FeatureFirmwareInventory,
+	}
+
+	model, err := s.Model()
+	if err != nil {
+		s.log.V(1).Error(err, "Capabilities(): Getting the model failed.")
+		return features
+	}
+
+	switch generation(model) {
+	case X10, X11:
+		features = append(features,
+			providers.FeatureUserCreate,
+			providers.FeatureUserDelete,
+			providers.FeatureUserUpdate,
+			providers.FeatureUserRead,
+			providers.FeatureFirmwareInstall,
+		)
+	}
+
+	return features
+}
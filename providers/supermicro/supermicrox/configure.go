@@ -39,21 +39,29 @@ func (s *SupermicroX) Resources() []string {
 // ApplyCfg implements the Bmc interface
 // this is to be deprecated.
 func (s *SupermicroX) ApplyCfg(config *cfgresources.ResourcesConfig) (err error) {
+	defer s.wrapError("ApplyCfg", &err)
+
 	return err
 }
 
 // Power implemented the Configure interface
 func (s *SupermicroX) Power(cfg *cfgresources.Power) (err error) {
+	defer s.wrapError("Power", &err)
+
 	return err
 }
 
 // SetLicense implements the Configure interface.
 func (s *SupermicroX) SetLicense(cfg *cfgresources.License) (err error) {
+	defer s.wrapError("SetLicense", &err)
+
 	return err
 }
 
 // Bios implements the Configure interface.
 func (s *SupermicroX) Bios(cfg *cfgresources.Bios) (err error) {
+	defer s.wrapError("Bios", &err)
+
 	return err
 }
 
@@ -98,18 +106,26 @@ func (s *SupermicroX) queryUserAccounts() (userAccounts map[int]string, err erro
 // supermicro user accounts start with 1, account 0 which is a large empty string :\.
 // nolint: gocyclo
 func (s *SupermicroX) User(users []*cfgresources.User) (err error) {
-	currentUsers, err := s.queryUserAccounts()
-	if err != nil {
-		msg := "SupermicroX User(): Unable to query existing users."
-		s.log.V(1).Error(err, msg,
-			"ip", s.ip,
-			"HardwareType", s.HardwareType(),
-			"step", helper.WhosCalling(),
-		)
-		return errors.New(msg)
+	defer s.wrapError("User", &err)
+
+	// in dry-run the accounts aren't read, the slot of the users is picked when applied
+	currentUsers := map[int]string{}
+	if !s.dryRun {
+		currentUsers, err = s.queryUserAccounts()
+		if err != nil {
+			msg := "SupermicroX User(): Unable to query existing users."
+			s.log.V(1).Error(err, msg,
+				"ip", s.ip,
+				"HardwareType", s.configHardwareType(),
+				"step", helper.WhosCalling(),
+			)
+			return errors.New(msg)
+		}
 	}
 
 	for _, user := range users {
+		s.log.V(2).Info("applying user resource", "step", "applyUserParams", "ip", s.ip, "user", user.Redacted())
+
 		if user.Name == "" {
 			msg := "User resource expects parameter: Name."
 			s.log.V(1).Info(msg, "step", "applyUserParams")
@@ -150,7 +166,7 @@ func (s *SupermicroX) User(users []*cfgresources.User) (err error) {
 				}
 			}
 		}
-		if userID == 0 {
+		if userID == 0 && !s.dryRun {
 			return errors.New("no user slots available")
 		}
 		configUser.UserID = userID
@@ -161,17 +177,22 @@ func (s *SupermicroX) User(users []*cfgresources.User) (err error) {
 
 		endpoint := "config_user.cgi"
 		form, _ := query.Values(configUser)
+		if s.dryRun {
+			s.recordChange("User", endpoint, form)
+			continue
+		}
+
 		statusCode, err := s.post(endpoint, &form, []byte{}, "")
 		if err != nil || statusCode != 200 {
 			if err == nil {
 				err = fmt.Errorf("Received a %d status code from the POST request to %s.", statusCode, endpoint)
 			} else {
-				err = fmt.Errorf("POST request to %s failed with error: %s", endpoint, err.Error())
+				err = fmt.Errorf("POST request to %s failed with error: %w", endpoint, err)
 			}
 
 			s.log.V(1).Error(err, "POST request to set User config failed.",
 				"ip", s.ip,
-				"HardwareType", s.HardwareType(),
+				"HardwareType", s.configHardwareType(),
 				"endpoint", endpoint,
 				"StatusCode", statusCode,
 				"step", helper.WhosCalling(),
@@ -179,7 +200,7 @@ func (s *SupermicroX) User(users []*cfgresources.User) (err error) {
 			return err
 		}
 
-		s.log.V(1).Info("User parameters applied.", "ip", s.ip, "HardwareType", s.HardwareType(), "user", user.Name)
+		s.log.V(1).Info("User parameters applied.", "ip", s.ip, "HardwareType", s.configHardwareType(), "user", user.Name)
 	}
 
 	return err
@@ -188,6 +209,8 @@ func (s *SupermicroX) User(users []*cfgresources.User) (err error) {
 // Network method implements the Configure interface
 // applies various network parameters.
 func (s *SupermicroX) Network(cfg *cfgresources.Network) (reset bool, err error) {
+	defer s.wrapError("Network", &err)
+
 	sshPort := 22
 
 	if cfg.SSHPort != 0 && cfg.SSHPort != sshPort {
@@ -215,17 +238,22 @@ func (s *SupermicroX) Network(cfg *cfgresources.Network) (reset bool, err error)
 
 	endpoint := "op.cgi"
 	form, _ := query.Values(configPort)
+	if s.dryRun {
+		s.recordChange("Network", endpoint, form)
+		return false, nil
+	}
+
 	statusCode, err := s.post(endpoint, &form, []byte{}, "")
 	if err != nil || statusCode != 200 {
 		if err == nil {
 			err = fmt.Errorf("Received a %d status code from the POST request to %s.", statusCode, endpoint)
 		} else {
-			err = fmt.Errorf("POST request to %s failed with error: %s", endpoint, err.Error())
+			err = fmt.Errorf("POST request to %s failed with error: %w", endpoint, err)
 		}
 
 		s.log.V(1).Error(err, "POST request to set Port config failed.",
 			"ip", s.ip,
-			"HardwareType", s.HardwareType(),
+			"HardwareType", s.configHardwareType(),
 			"endpoint", endpoint,
 			"StatusCode", statusCode,
 			"step", helper.WhosCalling(),
@@ -233,25 +261,27 @@ func (s *SupermicroX) Network(cfg *cfgresources.Network) (reset bool, err error)
 		return false, err
 	}
 
-	s.log.V(1).Info("Network config parameters applied.", "ip", s.ip, "HardwareType", s.HardwareType())
+	s.log.V(1).Info("Network config parameters applied.", "ip", s.ip, "HardwareType", s.configHardwareType())
 	return false, err
 }
 
 // Ntp applies NTP configuration params
 // Ntp implements the Configure interface.
 func (s *SupermicroX) Ntp(cfg *cfgresources.Ntp) (err error) {
+	defer s.wrapError("Ntp", &err)
+
 	var enable string
 	if cfg.Server1 == "" {
 		s.log.V(1).Info("NTP resource expects parameter: server1.",
 			"step", "applyNtpParams",
-			"HardwareType", s.HardwareType())
+			"HardwareType", s.configHardwareType())
 		return
 	}
 
 	if cfg.Timezone == "" {
 		s.log.V(1).Info("NTP resource expects parameter: timezone.",
 			"step", "applyNtpParams",
-			"HardwareType", s.HardwareType())
+			"HardwareType", s.configHardwareType())
 		return
 	}
 
@@ -259,7 +289,7 @@ func (s *SupermicroX) Ntp(cfg *cfgresources.Ntp) (err error) {
 	if err != nil {
 		s.log.V(1).Error(err, "Ntp(): Invalid timezone parameter.",
 			"step", "applyNtpParams",
-			"HardwareType", s.HardwareType(),
+			"HardwareType", s.configHardwareType(),
 			"Timezone", cfg.Timezone,
 		)
 		return
@@ -270,7 +300,7 @@ func (s *SupermicroX) Ntp(cfg *cfgresources.Ntp) (err error) {
 	if !cfg.Enable {
 		s.log.V(1).Info("Ntp resource declared with enable: false.",
 			"step", "applyNtpParams",
-			"HardwareType", s.HardwareType())
+			"HardwareType", s.configHardwareType())
 		return
 	}
 
@@ -305,17 +335,22 @@ func (s *SupermicroX) Ntp(cfg *cfgresources.Ntp) (err error) {
 
 	endpoint := "op.cgi"
 	form, _ := query.Values(configDateTime)
+	if s.dryRun {
+		s.recordChange("Ntp", endpoint, form)
+		return nil
+	}
+
 	statusCode, err := s.post(endpoint, &form, []byte{}, "")
 	if err != nil || statusCode != 200 {
 		if err == nil {
 			err = fmt.Errorf("Received a %d status code from the POST request to %s.", statusCode, endpoint)
 		} else {
-			err = fmt.Errorf("POST request to %s failed with error: %s", endpoint, err.Error())
+			err = fmt.Errorf("POST request to %s failed with error: %w", endpoint, err)
 		}
 
 		s.log.V(1).Error(err, "POST request to set NTP config failed.",
 			"ip", s.ip,
-			"HardwareType", s.HardwareType(),
+			"HardwareType", s.configHardwareType(),
 			"endpoint", endpoint,
 			"StatusCode", statusCode,
 			"step", helper.WhosCalling(),
@@ -325,7 +360,7 @@ func (s *SupermicroX) Ntp(cfg *cfgresources.Ntp) (err error) {
 
 	s.log.V(1).Info("NTP config parameters applied.",
 		"ip", s.ip,
-		"HardwareType", s.HardwareType())
+		"HardwareType", s.configHardwareType())
 	return nil
 }
 
@@ -333,7 +368,9 @@ func (s *SupermicroX) Ntp(cfg *cfgresources.Ntp) (err error) {
 // Ldap implements the Configure interface.
 // Configuration for LDAP is applied in the LdapGroup method,
 // since supermicros just support a single LDAP group.
-func (s *SupermicroX) Ldap(cfgLdap *cfgresources.Ldap) error {
+func (s *SupermicroX) Ldap(cfgLdap *cfgresources.Ldap) (err error) {
+	defer s.wrapError("Ldap", &err)
+
 	return nil
 }
 
@@ -342,9 +379,11 @@ func (s *SupermicroX) Ldap(cfgLdap *cfgresources.Ldap) error {
 // Supermicro does not have any separate configuration for Ldap groups just for generic ldap
 // nolint: gocyclo
 func (s *SupermicroX) LdapGroups(cfgGroups []*cfgresources.LdapGroup, cfgLdap *cfgresources.Ldap) (err error) {
+	defer s.wrapError("LdapGroups", &err)
+
 	if cfgLdap.Server == "" {
 		msg := "Ldap resource parameter Server required but not declared."
-		s.log.V(1).Info(msg, "step", helper.WhosCalling(), "HardwareType", s.HardwareType())
+		s.log.V(1).Info(msg, "step", helper.WhosCalling(), "HardwareType", s.configHardwareType())
 		return errors.New(msg)
 	}
 
@@ -352,27 +391,27 @@ func (s *SupermicroX) LdapGroups(cfgGroups []*cfgresources.LdapGroup, cfgLdap *c
 		msg := "Ldap resource parameter Port required but not declared"
 		s.log.V(1).Info(msg,
 			"step", helper.WhosCalling(),
-			"HardwareType", s.HardwareType())
+			"HardwareType", s.configHardwareType())
 		return errors.New(msg)
 	}
 
 	if !cfgLdap.Enable {
 		s.log.V(1).Info("Ldap resource declared with enable: false.",
 			"step", helper.WhosCalling(),
-			"HardwareType", s.HardwareType())
+			"HardwareType", s.configHardwareType())
 		return
 	}
 
 	if cfgLdap.BaseDn == "" {
 		msg := "Ldap resource parameter BaseDn required but not declared."
-		s.log.V(1).Info(msg, "step", helper.WhosCalling(), "HardwareType", s.HardwareType())
+		s.log.V(1).Info(msg, "step", helper.WhosCalling(), "HardwareType", s.configHardwareType())
 		return errors.New(msg)
 	}
 
 	serverIP, err := net.LookupIP(cfgLdap.Server)
 	if err != nil || serverIP == nil {
 		msg := "Unable to lookup the IP for ldap server hostname."
-		s.log.V(1).Info(msg, "step", helper.WhosCalling(), "HardwareType", s.HardwareType())
+		s.log.V(1).Info(msg, "step", helper.WhosCalling(), "HardwareType", s.configHardwareType())
 		return errors.New(msg)
 	}
 
@@ -426,18 +465,23 @@ func (s *SupermicroX) LdapGroups(cfgGroups []*cfgresources.LdapGroup, cfgLdap *c
 
 		endpoint := "op.cgi"
 		form, _ := query.Values(configLdap)
+		if s.dryRun {
+			s.recordChange("LdapGroups", endpoint, form)
+			continue
+		}
+
 		statusCode, err := s.post(endpoint, &form, []byte{}, "")
 		if err != nil || statusCode != 200 {
 			if err == nil {
 				err = fmt.Errorf("Received a %d status code from the POST request to %s.", statusCode, endpoint)
 			} else {
-				err = fmt.Errorf("POST request to %s failed with error: %s", endpoint, err.Error())
+				err = fmt.Errorf("POST request to %s failed with error: %w", endpoint, err)
 			}
 
 			s.log.V(1).Error(err, "POST request to set LDAP group config failed.",
 				"step", helper.WhosCalling(),
 				"ip", s.ip,
-				"HardwareType", s.HardwareType(),
+				"HardwareType", s.configHardwareType(),
 				"endpoint", endpoint,
 				"StatusCode", statusCode,
 				"Group", group.Group,
@@ -446,7 +490,7 @@ func (s *SupermicroX) LdapGroups(cfgGroups []*cfgresources.LdapGroup, cfgLdap *c
 		}
 	}
 
-	s.log.V(1).Info("LDAP config parameters applied.", "ip", s.ip, "HardwareType", s.HardwareType())
+	s.log.V(1).Info("LDAP config parameters applied.", "ip", s.ip, "HardwareType", s.configHardwareType())
 	return err
 }
 
@@ -454,17 +498,19 @@ func (s *SupermicroX) LdapGroups(cfgGroups []*cfgresources.LdapGroup, cfgLdap *c
 // Syslog implements the Configure interface
 // this also enables alerts from the BMC
 func (s *SupermicroX) Syslog(cfg *cfgresources.Syslog) (err error) {
+	defer s.wrapError("Syslog", &err)
+
 	var port int
 
 	if cfg.Server == "" {
 		msg := "Syslog resource expects parameter: Server."
-		s.log.V(1).Info(msg, "step", helper.WhosCalling(), "HardwareType", s.HardwareType())
+		s.log.V(1).Info(msg, "step", helper.WhosCalling(), "HardwareType", s.configHardwareType())
 		return errors.New(msg)
 	}
 
 	if cfg.Port == 0 {
 		msg := "Syslog resource port set to default: 514."
-		s.log.V(1).Info(msg, "step", helper.WhosCalling(), "HardwareType", s.HardwareType())
+		s.log.V(1).Info(msg, "step", helper.WhosCalling(), "HardwareType", s.configHardwareType())
 		port = 514
 	} else {
 		port = cfg.Port
@@ -472,13 +518,13 @@ func (s *SupermicroX) Syslog(cfg *cfgresources.Syslog) (err error) {
 
 	if !cfg.Enable {
 		msg := "Syslog resource declared with disable."
-		s.log.V(1).Info(msg, "step", helper.WhosCalling(), "HardwareType", s.HardwareType())
+		s.log.V(1).Info(msg, "step", helper.WhosCalling(), "HardwareType", s.configHardwareType())
 	}
 
 	serverIP, err := net.LookupIP(cfg.Server)
 	if err != nil || serverIP == nil {
 		msg := "Unable to lookup IP for syslog server hostname, yes supermicros requires the Syslog server IP :|."
-		s.log.V(1).Info(msg, "step", helper.WhosCalling(), "HardwareType", s.HardwareType())
+		s.log.V(1).Info(msg, "step", helper.WhosCalling(), "HardwareType", s.configHardwareType())
 		return errors.New(msg)
 	}
 
@@ -491,19 +537,24 @@ func (s *SupermicroX) Syslog(cfg *cfgresources.Syslog) (err error) {
 
 	endpoint := "op.cgi"
 	form, _ := query.Values(configSyslog)
+	if s.dryRun {
+		s.recordChange("Syslog", endpoint, form)
+		s.recordChange("Syslog", "system_event_log.cgi", url.Values{"enable": []string{"1"}})
+		return nil
+	}
 
 	statusCode, err := s.post(endpoint, &form, []byte{}, "")
 	if err != nil || statusCode != 200 {
 		if err == nil {
 			err = fmt.Errorf("Received a %d status code from the POST request to %s.", statusCode, endpoint)
 		} else {
-			err = fmt.Errorf("POST request to %s failed with error: %s", endpoint, err.Error())
+			err = fmt.Errorf("POST request to %s failed with error: %w", endpoint, err)
 		}
 
 		s.log.V(1).Error(err, "POST request to set Syslog config returned error.",
 			"step", helper.WhosCalling(),
 			"ip", s.ip,
-			"HardwareType", s.HardwareType(),
+			"HardwareType", s.configHardwareType(),
 			"endpoint", endpoint,
 			"StatusCode", statusCode,
 		)
@@ -521,26 +572,28 @@ func (s *SupermicroX) Syslog(cfg *cfgresources.Syslog) (err error) {
 		if err == nil {
 			err = fmt.Errorf("Received a %d status code from the POST request to %s.", statusCode, endpoint)
 		} else {
-			err = fmt.Errorf("POST request to %s failed with error: %s", endpoint, err.Error())
+			err = fmt.Errorf("POST request to %s failed with error: %w", endpoint, err)
 		}
 
 		s.log.V(1).Error(err, "POST request to enable maintenance alerts failed.",
 			"step", helper.WhosCalling(),
 			"ip", s.ip,
-			"HardwareType", s.HardwareType(),
+			"HardwareType", s.configHardwareType(),
 			"endpoint", endpoint,
 			"StatusCode", statusCode,
 		)
 		return err
 	}
 
-	s.log.V(1).Info("Syslog config parameters applied.", "ip", s.ip, "HardwareType", s.HardwareType())
+	s.log.V(1).Info("Syslog config parameters applied.", "ip", s.ip, "HardwareType", s.configHardwareType())
 	return err
 }
 
 // GenerateCSR generates a CSR request on the BMC.
 // GenerateCSR implements the Configure interface.
-func (s *SupermicroX) GenerateCSR(cert *cfgresources.HTTPSCertAttributes) ([]byte, error) {
+func (s *SupermicroX) GenerateCSR(cert *cfgresources.HTTPSCertAttributes) (csr []byte, err error) {
+	defer s.wrapError("GenerateCSR", &err)
+
 	return []byte{}, nil
 }
 
@@ -551,7 +604,9 @@ func (s *SupermicroX) GenerateCSR(cert *cfgresources.HTTPSCertAttributes) ([]byt
 // 3. Get the BMC to validate the certificate: SSL_VALIDATE.XML	(0,0)
 // 4. delay for a second
 // 5. Request for the current: SSL_STATUS.XML	(0,0)
-func (s *SupermicroX) UploadHTTPSCert(cert []byte, certFileName string, key []byte, keyFileName string) (bool, error) {
+func (s *SupermicroX) UploadHTTPSCert(cert []byte, certFileName string, key []byte, keyFileName string) (reset bool, err error) {
+	defer s.wrapError("UploadHTTPSCert", &err)
+
 	endpoint := "upload_ssl.cgi"
 
 	// setup a buffer for our multipart form
@@ -582,6 +637,12 @@ func (s *SupermicroX) UploadHTTPSCert(cert []byte, certFileName string, key []by
 	// close multipart writer - adds the teminating boundary.
 	w.Close()
 
+	// the certificate isn't validated by the bmc in dry-run, so no reset is needed
+	if s.dryRun {
+		s.recordChange("HTTPSCert", endpoint, url.Values{"cert_file": {certFileName}, "key_file": {keyFileName}})
+		return false, nil
+	}
+
 	// 1. upload
 	statusCode, err := s.post(endpoint, &url.Values{}, form.Bytes(), w.FormDataContentType())
 	if err != nil || statusCode != 200 {
@@ -592,7 +653,7 @@ func (s *SupermicroX) UploadHTTPSCert(cert []byte, certFileName string, key []by
 		s.log.V(1).Error(err, "UploadHTTPSCert(): Cert form upload POST request failed.",
 			"step", helper.WhosCalling(),
 			"ip", s.ip,
-			"HardwareType", s.HardwareType(),
+			"HardwareType", s.configHardwareType(),
 			"endpoint", endpoint,
 			"StatusCode", statusCode,
 		)
@@ -636,7 +697,7 @@ func (s *SupermicroX) validateSSL() error {
 		s.log.V(1).Error(err, "Cert validate POST request failed, expected 200.",
 			"step", helper.WhosCalling(),
 			"ip", s.ip,
-			"HardwareType", s.HardwareType(),
+			"HardwareType", s.configHardwareType(),
 			"endpoint", endpoint,
 			"StatusCode", statusCode,
 		)
@@ -664,7 +725,7 @@ func (s *SupermicroX) statusSSL() error {
 		s.log.V(1).Error(err, "statusSSL(): Cert status POST request failed.",
 			"step", helper.WhosCalling(),
 			"ip", s.ip,
-			"HardwareType", s.HardwareType(),
+			"HardwareType", s.configHardwareType(),
 			"endpoint", endpoint,
 			"StatusCode", statusCode,
 		)
@@ -0,0 +1,32 @@
+package supermicrox
+
+import (
+	"net/url"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+// redactedParams are the form parameters holding secrets, not recorded in dry-run
+var redactedParams = map[string]bool{"password": true, "pwd": true, "bind_pwd": true}
+
+// recordChange records and logs the configuration form that would be posted to endpoint,
+// it's called by the configuration methods instead of posting when running in dry-run.
+func (s *SupermicroX) recordChange(resource string, endpoint string, form url.Values) {
+	change := devices.ConfigChange{Resource: resource, Endpoint: endpoint, Params: map[string]string{}}
+	for key := range form {
+		if redactedParams[key] {
+			change.Params[key] = "<redacted>"
+			continue
+		}
+		change.Params[key] = form.Get(key)
+	}
+
+	s.dryRunChanges = append(s.dryRunChanges, change)
+	s.log.V(0).Info("dry-run: configuration not applied", "ip", s.ip, "resource", resource, "endpoint", endpoint, "params", change.Params)
+}
+
+// DryRunChanges returns the configuration changes recorded since the SupermicroX
+// was created with WithDryRun, in the order they would have been applied.
+func (s *SupermicroX) DryRunChanges() []devices.ConfigChange {
+	return append([]devices.ConfigChange{}, s.dryRunChanges...)
+}
@@ -0,0 +1,115 @@
+package supermicrox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/errors"
+	"github.com/bmc-toolbox/bmclib/providers/supermicro"
+)
+
+var (
+	// firmwareChunkSize is the size of the parts the image is uploaded in, the web server of the BMC
+	// rejects the requests carrying a whole image.
+	firmwareChunkSize = 4 * 1024 * 1024
+	// firmwarePollInterval is the wait between the checks of the flash progress and of the BMC return.
+	firmwarePollInterval = 5 * time.Second
+	// firmwareFlashTimeout bounds the wait for the BMC to report the flash as complete.
+	firmwareFlashTimeout = 30 * time.Minute
+	// firmwareRebootTimeout bounds the wait for the BMC to answer again once it restarts into the new firmware.
+	firmwareRebootTimeout = 10 * time.Minute
+	// hostPowerTimeout bounds the wait for the host to reach the requested power state.
+	hostPowerTimeout = 5 * time.Minute
+	// biosPostTimeout bounds the wait for the host to report the new BIOS version once it's powered back on.
+	biosPostTimeout = 10 * time.Minute
+)
+
+// FirmwareUpdateBMC updates the BMC firmware with the image at filePath and waits for the BMC
+// to come back with it, implements the Firmware interface
+func (s *SupermicroX) FirmwareUpdateBMC(ctx context.Context, filePath string) (err error) {
+	defer s.wrapError("FirmwareUpdateBMC", &err)
+
+	image, err := supermicro.ReadFirmware(filePath)
+	if err != nil {
+		return errors.NewFirmwareUpdateError(errors.FirmwareUpdatePhasePrepare, err)
+	}
+
+	_, err = s.flasher().UpdateBMC(ctx, image, nil)
+	return err
+}
+
+// FirmwareUpdateBIOS updates the BIOS with the image at filePath, the host is powered off for the flash
+// and booted to check the BIOS version it reports, a host that was off is powered off again.
+func (s *SupermicroX) FirmwareUpdateBIOS(ctx context.Context, filePath string) (err error) {
+	defer s.wrapError("FirmwareUpdateBIOS", &err)
+
+	image, err := supermicro.ReadFirmware(filePath)
+	if err != nil {
+		return errors.NewFirmwareUpdateError(errors.FirmwareUpdatePhasePrepare, err)
+	}
+
+	_, err = s.flasher().UpdateBIOS(ctx, image, nil)
+	return err
+}
+
+// flasher returns the firmware updates running through this connection
+func (s *SupermicroX) flasher() *supermicro.Flasher {
+	return &supermicro.Flasher{
+		Client:         &firmwareClient{s: s},
+		Log:            s.log,
+		IP:             s.ip,
+		PreserveConfig: s.preserveConfig,
+		ChunkSize:      firmwareChunkSize,
+		PollInterval:   firmwarePollInterval,
+		FlashTimeout:   firmwareFlashTimeout,
+		RebootTimeout:  firmwareRebootTimeout,
+		PowerTimeout:   hostPowerTimeout,
+		PostTimeout:    biosPostTimeout,
+	}
+}
+
+// firmwareClient runs the firmware updates through the X10 queries
+type firmwareClient struct {
+	s *SupermicroX
+}
+
+// Query implements the supermicro.FirmwareClient interface
+func (c *firmwareClient) Query(op, args string) (*supermicro.IPMI, error) {
+	return c.s.query(fmt.Sprintf("%s=(%s)", op, args))
+}
+
+// Post implements the supermicro.FirmwareClient interface, post doesn't check the status so it's done here
+func (c *firmwareClient) Post(endpoint string, form url.Values, body []byte, contentType string) error {
+	statusCode, err := c.s.post(endpoint, &form, body, contentType)
+	if err != nil {
+		return err
+	}
+
+	if statusCode != 200 {
+		return errors.NewHTTPError("POST", fmt.Sprintf("https://%s/cgi/%s", c.s.ip, endpoint), statusCode, nil)
+	}
+
+	return nil
+}
+
+// PowerState implements the supermicro.FirmwareClient interface
+func (c *firmwareClient) PowerState() (string, error) {
+	return c.s.PowerState()
+}
+
+// BiosVersion implements the supermicro.FirmwareClient interface
+func (c *firmwareClient) BiosVersion() (string, error) {
+	return c.s.BiosVersion()
+}
+
+// Version implements the supermicro.FirmwareClient interface
+func (c *firmwareClient) Version() (string, error) {
+	return c.s.Version()
+}
+
+// Reconnect implements the supermicro.FirmwareClient interface
+func (c *firmwareClient) Reconnect() {
+	c.s.httpClient = nil
+}
@@ -0,0 +1,34 @@
+package supermicrox
+
+import (
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the FirmwareInventorier interface.
+var _ devices.FirmwareInventorier = (*SupermicroX)(nil)
+
+// GetFirmwareInventory returns the firmware of the bmc and the BIOS, the only components
+// whose versions are reported by ipmi.cgi
+func (s *SupermicroX) GetFirmwareInventory() (inventory []devices.Firmware, err error) {
+	defer s.wrapError("GetFirmwareInventory", &err)
+
+	bmcVersion, err := s.Version()
+	if err != nil {
+		return inventory, err
+	}
+
+	biosVersion, err := s.BiosVersion()
+	if err != nil {
+		return inventory, err
+	}
+
+	if bmcVersion != "" {
+		inventory = append(inventory, devices.Firmware{Component: "BMC", Installed: bmcVersion})
+	}
+	if biosVersion != "" {
+		inventory = append(inventory, devices.Firmware{Component: "BIOS", Installed: biosVersion})
+	}
+
+	return inventory, nil
+}
@@ -0,0 +1,26 @@
+package supermicrox
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the PowerCapper interface.
+var _ devices.PowerCapper = (*SupermicroX)(nil)
+
+// GetPowerCap isn't supported on SupermicroX yet
+func (s *SupermicroX) GetPowerCap(ctx context.Context) (powerCap devices.PowerCap, err error) {
+	defer s.wrapError("GetPowerCap", &err)
+
+	return devices.PowerCap{}, errors.NewFeatureUnsupportedError("power cap", s.Vendor(), s.HardwareType())
+}
+
+// SetPowerCap isn't supported on SupermicroX yet
+func (s *SupermicroX) SetPowerCap(ctx context.Context, powerCap devices.PowerCap) (err error) {
+	defer s.wrapError("SetPowerCap", &err)
+
+	return errors.NewFeatureUnsupportedError("power cap", s.Vendor(), s.HardwareType())
+}
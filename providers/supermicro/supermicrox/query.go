@@ -5,6 +5,7 @@ import (
 	"crypto/x509"
 	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -15,7 +16,9 @@ import (
 // CurrentHTTPSCert returns the current x509 certficates configured on the BMC
 // the bool value returned is set to true if the BMC support CSR generation.
 // CurrentHTTPSCert implements the Configure interface.
-func (s *SupermicroX) CurrentHTTPSCert() ([]*x509.Certificate, bool, error) {
+func (s *SupermicroX) CurrentHTTPSCert() (certs []*x509.Certificate, renew bool, err error) {
+	defer s.wrapError("CurrentHTTPSCert", &err)
+
 	dialer := &net.Dialer{
 		Timeout: time.Duration(10) * time.Second,
 	}
@@ -35,14 +38,17 @@ func (s *SupermicroX) CurrentHTTPSCert() ([]*x509.Certificate, bool, error) {
 // 2. sleep for 3 seconds to give ikvm time to ensure preview was captured
 // 3. request for preview.
 func (s *SupermicroX) Screenshot() (response []byte, extension string, err error) {
+	defer s.wrapError("Screenshot", &err)
+
 	postEndpoint := "CapturePreview.cgi"
 	getEndpoint := "cgi/url_redirect.cgi?"
 
 	extension = "bmp"
 
-	// allow thumbnails only for supermicro x10s.
-	if s.HardwareType() != "supermicrox" {
-		return nil, "", errors.ErrFeatureUnavailable
+	// allow thumbnails only for supermicro x10s, HardwareType returns the board model.
+	model := s.HardwareType()
+	if !strings.HasPrefix(model, "X10") {
+		return nil, "", errors.NewFeatureUnsupportedError("screenshot", s.Vendor(), model)
 	}
 
 	tzLocation, _ := time.LoadLocation("CET")
@@ -70,7 +76,7 @@ func (s *SupermicroX) Screenshot() (response []byte, extension string, err error
 	}
 
 	if statusCode != 200 {
-		return nil, "", fmt.Errorf("Non-200 response from endpoint %s: %d!", postEndpoint, statusCode)
+		return nil, "", fmt.Errorf("endpoint %s returned status code %d: %w", postEndpoint, statusCode, errors.ErrNon200Response)
 	}
 
 	time.Sleep(3 * time.Second)
@@ -0,0 +1,129 @@
+package supermicrox
+
+import (
+	"strings"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+	"github.com/bmc-toolbox/bmclib/providers/supermicro"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the SensorReader interface.
+var _ devices.SensorReader = (*SupermicroX)(nil)
+
+// sensors returns the decoded sensors of the bmc
+func (s *SupermicroX) sensors() (sensors []*supermicro.Sensor, err error) {
+	ipmi, err := s.query("SENSOR_INFO.XML=(1,ff)")
+	if err != nil {
+		return sensors, err
+	}
+
+	if ipmi.SensorInfo == nil {
+		return sensors, errors.ErrUnableToReadData
+	}
+
+	return ipmi.SensorInfo.Sensors()
+}
+
+// Fans returns the fans reported by the bmc sensors
+func (s *SupermicroX) Fans() (fans []*devices.Fan, err error) {
+	defer s.wrapError("Fans", &err)
+
+	sensors, err := s.sensors()
+	if err != nil {
+		return fans, err
+	}
+
+	for _, sensor := range sensors {
+		if sensor.Type != supermicro.SensorTypeFan || !sensor.Threshold {
+			continue
+		}
+
+		fans = append(fans, &devices.Fan{
+			Name:       sensor.Name,
+			Status:     string(sensor.Health),
+			Position:   len(fans) + 1,
+			Present:    true,
+			CurrentRPM: int64(sensor.Reading),
+		})
+	}
+
+	return fans, nil
+}
+
+// PSUs returns the power supplies reported by the bmc sensors
+func (s *SupermicroX) PSUs() (psus []*devices.Psu, err error) {
+	defer s.wrapError("PSUs", &err)
+
+	sensors, err := s.sensors()
+	if err != nil {
+		return psus, err
+	}
+
+	for _, sensor := range sensors {
+		if sensor.Type != supermicro.SensorTypePowerSupply || sensor.Threshold {
+			continue
+		}
+
+		status := string(sensor.Health)
+		// the first state is the presence of the power supply
+		if sensor.States&0x01 == 0 {
+			status = "Absent"
+		}
+
+		psus = append(psus, &devices.Psu{
+			Status:   status,
+			Position: len(psus) + 1,
+		})
+	}
+
+	return psus, nil
+}
+
+// Temperatures returns the readings of the bmc temperature sensors
+func (s *SupermicroX) Temperatures() (temperatures []*devices.TemperatureSensor, err error) {
+	defer s.wrapError("Temperatures", &err)
+
+	sensors, err := s.sensors()
+	if err != nil {
+		return temperatures, err
+	}
+
+	for _, sensor := range sensors {
+		if sensor.Type != supermicro.SensorTypeTemperature || !sensor.Threshold {
+			continue
+		}
+
+		temperatures = append(temperatures, &devices.TemperatureSensor{
+			Name:     sensor.Name,
+			Location: strings.TrimSpace(strings.TrimSuffix(sensor.Name, "Temp")),
+			Reading:  sensor.Reading,
+			Unit:     devices.TemperatureUnitCelsius,
+			Status:   string(sensor.Health),
+		})
+	}
+
+	return temperatures, nil
+}
+
+// HealthSensors returns the health of all the bmc sensors holding a reading
+func (s *SupermicroX) HealthSensors() (healthSensors []*devices.HealthSensor, err error) {
+	defer s.wrapError("HealthSensors", &err)
+
+	sensors, err := s.sensors()
+	if err != nil {
+		return healthSensors, err
+	}
+
+	for _, sensor := range sensors {
+		healthSensors = append(healthSensors, &devices.HealthSensor{
+			Name:    sensor.Name,
+			Type:    sensor.TypeName(),
+			Reading: sensor.Reading,
+			Health:  sensor.Health,
+		})
+	}
+
+	return healthSensors, nil
+}
@@ -7,17 +7,24 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
-	"net/url"
 	"strings"
 
+	"github.com/bmc-toolbox/bmclib/devices"
 	"github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bmc-toolbox/bmclib/internal/httpclient"
 	"github.com/bmc-toolbox/bmclib/providers/supermicro"
 )
 
+// sessionCookie is the cookie holding the session token handed out by login.cgi
+const sessionCookie = "SID"
+
 // httpLogin initiates the connection to an SupermicroX device
 func (s *SupermicroX) httpLogin() (err error) {
+	return s.httpLoginContext(s.context())
+}
+
+// httpLoginContext initiates the connection bounded by ctx instead of the base context of the SupermicroX
+func (s *SupermicroX) httpLoginContext(ctx context.Context) (err error) {
 	if s.httpClient != nil {
 		return
 	}
@@ -30,38 +37,85 @@ func (s *SupermicroX) httpLogin() (err error) {
 	s.log.V(1).Info("connecting to bmc", "step", "bmc connection", "vendor", supermicro.VendorID, "ip", s.ip)
 
 	data := fmt.Sprintf("name=%s&pwd=%s", s.username, s.password)
-	req, err := http.NewRequest("POST", fmt.Sprintf("https://%s/cgi/login.cgi", s.ip), bytes.NewBufferString(data))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("https://%s/cgi/login.cgi", s.ip), bytes.NewBufferString(data))
 	if err != nil {
 		return err
 	}
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
 	resp, err := httpClient.Do(req)
+	if err != nil {
+		return errors.WrapRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	// the body is read before the status checks so the connection is reused whatever the answer
+	payload, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return err
 	}
 
-	if resp.StatusCode == 404 {
+	switch resp.StatusCode {
+	case 404:
 		return errors.ErrPageNotFound
+	case 401:
+		return fmt.Errorf("login to %s rejected: %w", s.ip, errors.ErrInvalidCredentials)
 	}
 
-	payload, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
+	if httpErr := errors.NewHTTPErrorFromResponse(resp, payload); errors.IsRateLimited(httpErr) {
+		return fmt.Errorf("login to %s: %w", s.ip, httpErr)
 	}
-	defer resp.Body.Close()
 
 	if !strings.Contains(string(payload), "../cgi/url_redirect.cgi?url_name=mainmenu") {
-		return errors.ErrLoginFailed
+		if credentialsRejected(payload) {
+			return fmt.Errorf("login to %s rejected: %w", s.ip, errors.ErrInvalidCredentials)
+		}
+
+		return fmt.Errorf("login to %s rejected: %w", s.ip, errors.ErrLoginFailed)
+	}
+
+	for _, cookie := range httpClient.Jar.Cookies(req.URL) {
+		if cookie.Name == sessionCookie && cookie.Value != "" {
+			s.sessionToken = cookie.Value
+		}
 	}
 
+	// the token goes in a header, the jar must not send it along as a cookie
+	if _, ok := s.sessionAuth.(httpclient.HeaderAuth); ok {
+		httpClient.Jar = httpclient.WithoutCookies(httpClient.Jar, sessionCookie)
+	}
 	s.httpClient = httpClient
 
 	return err
 }
 
+// credentialsRejected returns true when the login page reports the username or password as invalid
+func credentialsRejected(payload []byte) bool {
+	page := strings.ToLower(string(payload))
+	return strings.Contains(page, "url_name=login_fail") || strings.Contains(page, "invalid username or password")
+}
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the Pinger interface.
+var _ devices.Pinger = (*SupermicroX)(nil)
+
+// Ping checks the bmc is reachable and accepts the credentials with a single request,
+// the login when there's no session yet and a query of the power status otherwise.
+func (s *SupermicroX) Ping(ctx context.Context) (err error) {
+	defer s.wrapError("Ping", &err)
+
+	if s.httpClient == nil {
+		return s.httpLoginContext(ctx)
+	}
+
+	_, err = s.queryContext(ctx, "POWER_INFO.XML=(0,0)")
+	return err
+}
+
 // Close closes the connection properly
 func (s *SupermicroX) Close(ctx context.Context) (err error) {
+	defer s.wrapError("Close", &err)
+
 	if s.httpClient != nil {
 		bmcURL := fmt.Sprintf("https://%s/cgi/logout.cgi", s.ip)
 		s.log.V(1).Info("logout from bmc", "step", "bmc connection", "vendor", supermicro.VendorID, "ip", s.ip)
@@ -71,21 +125,13 @@ func (s *SupermicroX) Close(ctx context.Context) (err error) {
 			return err
 		}
 		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-		u, err := url.Parse(bmcURL)
-		if err != nil {
-			return err
-		}
-		for _, cookie := range s.httpClient.Jar.Cookies(u) {
-			if cookie.Name == "SID" && cookie.Value != "" {
-				req.AddCookie(cookie)
-			}
-		}
-		reqDump, _ := httputil.DumpRequestOut(req, true)
+		s.sessionAuth.Authorize(req, s.sessionToken)
+		reqDump, _ := httpclient.DumpRequestOut(req, true)
 		s.log.V(2).Info("request", "url", fmt.Sprintf("https://%s/cgi/%s", bmcURL, s.ip), "requestDump", reqDump)
 
 		resp, err := s.httpClient.Do(req)
 		if err != nil {
-			return err
+			return errors.WrapRequestError(err)
 		}
 
 		defer resp.Body.Close()
@@ -9,14 +9,15 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bmc-toolbox/bmclib/devices"
 	"github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bmc-toolbox/bmclib/internal/httpclient"
+	"github.com/bmc-toolbox/bmclib/providers"
 	"github.com/go-logr/logr"
 
 	"github.com/bmc-toolbox/bmclib/providers/supermicro"
@@ -41,6 +42,16 @@ type SupermicroX struct {
 	ctx                  context.Context
 	log                  logr.Logger
 	httpClientSetupFuncs []func(*http.Client)
+	// sessionAuth places the session token on the requests, the SID cookie by default
+	sessionAuth  httpclient.SessionAuth
+	sessionToken string
+	// dryRun makes the configuration methods record their changes instead of applying them
+	dryRun        bool
+	dryRunChanges []devices.ConfigChange
+	// preserveConfig keeps the BMC configuration, SDR and SSL certificate across firmware updates
+	preserveConfig bool
+	// passwordPolicy is checked by the user management before a password is sent to the BMC
+	passwordPolicy devices.PasswordPolicy
 }
 
 type ChassisInfo struct {
@@ -65,6 +76,141 @@ func WithSecureTLS(rootCAs *x509.CertPool) SupermicroXOption {
 	}
 }
 
+// WithInsecureTLS skips the verification of the BMC certificate, which is otherwise
+// verified against the system CAs. Use it for BMCs serving self-signed certificates.
+func WithInsecureTLS() SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithInsecureTLS())
+	}
+}
+
+// WithSecureTLSFromFile enforces trusted TLS connections using the CA certificates of a PEM file.
+// Requests fail with a loading error when the file is missing or holds no valid certificate.
+func WithSecureTLSFromFile(caCertFile string) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithCACertFile(caCertFile))
+	}
+}
+
+// WithClientCert authenticates to the BMC with the given PEM encoded client certificate and key.
+func WithClientCert(certPEM, keyPEM []byte) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithClientCert(certPEM, keyPEM))
+	}
+}
+
+// WithObserver calls observer after each HTTP round-trip made to the BMC,
+// to collect metrics or logs.
+func WithObserver(observer func(providers.RequestInfo)) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithObserver(observer))
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the HTTP requests made to the BMC,
+// it defaults to bmclib/<version>.
+func WithUserAgent(ua string) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithUserAgent(ua))
+	}
+}
+
+// WithForceHTTP1 restricts the HTTP requests made to the BMC to HTTP/1.1,
+// for BMC web servers returning garbled responses over HTTP/2.
+func WithForceHTTP1() SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithForceHTTP1())
+	}
+}
+
+// WithMaxResponseBytes bounds the size of the responses read from the BMC,
+// it defaults to httpclient.DefaultMaxResponseBytes.
+func WithMaxResponseBytes(n int64) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithMaxResponseBytes(n))
+	}
+}
+
+// WithDigestAuth answers the HTTP Digest authentication challenges of the BMC
+// with the credentials of the SupermicroX, for firmware requiring digest auth.
+func WithDigestAuth() SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithDigestAuth(i.username, i.password))
+	}
+}
+
+// WithRequestLogging logs the method, host, path, status and duration of each HTTP request
+// made to the BMC at V(1) with the logger of the SupermicroX, lighter than the dumps logged at V(2).
+func WithRequestLogging() SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithRequestLogging(i.log))
+	}
+}
+
+// WithTimeout sets the overall timeout of the HTTP requests made to the BMC,
+// it defaults to httpclient.DefaultTimeout.
+func WithTimeout(d time.Duration) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithTimeout(d))
+	}
+}
+
+// WithProxy routes the HTTP requests made to the BMC through the given proxy,
+// by default the proxy settings of the environment are honored.
+func WithProxy(proxyURL string) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithProxy(proxyURL))
+	}
+}
+
+// WithRetry retries the idempotent HTTP requests made to the BMC on transient failures,
+// making up to attempts attempts with an exponential backoff.
+func WithRetry(attempts int, backoff time.Duration) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithRetry(attempts, backoff))
+	}
+}
+
+// WithRateLimit paces the HTTP requests made to the BMC to requestsPerSecond,
+// allowing bursts of up to burst requests.
+func WithRateLimit(requestsPerSecond float64, burst int) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithRateLimit(requestsPerSecond, burst))
+	}
+}
+
+// WithSessionTokenHeader sends the session token in the given header (e.g. X-SID-TOKEN)
+// instead of the SID cookie, as expected by newer firmware.
+func WithSessionTokenHeader(header string) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.sessionAuth = httpclient.HeaderAuth{Name: header}
+	}
+}
+
+// WithDryRun makes the configuration methods validate their resources and record the requests
+// they would send, see DryRunChanges, without sending anything to the BMC.
+func WithDryRun() SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.dryRun = true
+	}
+}
+
+// WithPreserveConfig sets whether the BMC configuration, SDR and SSL certificate are kept
+// across the firmware updates, they are kept by default.
+func WithPreserveConfig(preserve bool) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.preserveConfig = preserve
+	}
+}
+
+// WithPasswordPolicy sets the policy the passwords are checked against before they're sent to the BMC,
+// devices.IPMIPasswordPolicy() by default.
+func WithPasswordPolicy(policy devices.PasswordPolicy) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.passwordPolicy = policy
+	}
+}
+
 // New returns a new SupermicroX instance ready to be used
 func New(ctx context.Context, ip string, username string, password string, log logr.Logger) (sm *SupermicroX, err error) {
 	return NewWithOptions(ctx, ip, username, password, log)
@@ -73,11 +219,14 @@ func New(ctx context.Context, ip string, username string, password string, log l
 // NewWithOptions returns a new SupermicroX with options ready to be used
 func NewWithOptions(ctx context.Context, ip string, username string, password string, log logr.Logger, opts ...SupermicroXOption) (*SupermicroX, error) {
 	sm := &SupermicroX{
-		ip:       ip,
-		username: username,
-		password: password,
-		ctx:      ctx,
-		log:      log,
+		ip:             ip,
+		username:       username,
+		password:       password,
+		ctx:            ctx,
+		log:            log,
+		sessionAuth:    httpclient.CookieAuth{Name: sessionCookie},
+		preserveConfig: true,
+		passwordPolicy: devices.IPMIPasswordPolicy(),
 	}
 	for _, opt := range opts {
 		opt(sm)
@@ -87,6 +236,8 @@ func NewWithOptions(ctx context.Context, ip string, username string, password st
 
 // CheckCredentials verify whether the credentials are valid or not
 func (s *SupermicroX) CheckCredentials() (err error) {
+	defer s.wrapError("CheckCredentials", &err)
+
 	err = s.httpLogin()
 	if err != nil {
 		return err
@@ -94,56 +245,73 @@ func (s *SupermicroX) CheckCredentials() (err error) {
 	return err
 }
 
+// context returns the context the SupermicroX was created with, for the calls not taking one
+func (s *SupermicroX) context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+
+	return s.ctx
+}
+
+// wrapError attaches the bmc identity to the error returned by a public method,
+// it's meant to be deferred with the named error result.
+func (s *SupermicroX) wrapError(operation string, err *error) {
+	*err = errors.NewBMCError(BmcType, s.ip, operation, *err)
+}
+
 // get calls a given json endpoint of the ilo and returns the data
 func (s *SupermicroX) get(endpoint string, authentication bool) (payload []byte, err error) {
-	err = s.httpLogin()
+	resp, payload, err := s.getResponse(endpoint, authentication)
 	if err != nil {
 		return nil, err
 	}
 
-	bmcURL := fmt.Sprintf("https://%s", s.ip)
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s", bmcURL, endpoint), nil)
-	if err != nil {
-		return nil, err
+	if resp.StatusCode == 404 {
+		return nil, errors.NewHTTPErrorFromResponse(resp, payload)
 	}
 
-	u, err := url.Parse(bmcURL)
+	return payload, nil
+}
+
+// getResponse calls a given endpoint of the bmc and returns the response along with its payload,
+// leaving the status code checks to the callers.
+func (s *SupermicroX) getResponse(endpoint string, authentication bool) (resp *http.Response, payload []byte, err error) {
+	err = s.httpLogin()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	for _, cookie := range s.httpClient.Jar.Cookies(u) {
-		if cookie.Name == "SID" && cookie.Value != "" {
-			req.AddCookie(cookie)
-		}
+	bmcURL := fmt.Sprintf("https://%s", s.ip)
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s", bmcURL, endpoint), nil)
+	if err != nil {
+		return nil, nil, err
 	}
 
+	s.sessionAuth.Authorize(req, s.sessionToken)
+
 	if authentication {
 		req.SetBasicAuth(s.username, s.password)
 	}
 
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
 	s.log.V(2).Info("", "request", fmt.Sprintf("https://%s/%s", bmcURL, endpoint), "requestDump", string(reqDump))
 
-	resp, err := s.httpClient.Do(req)
+	resp, err = s.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, errors.WrapRequestError(err)
 	}
 	defer resp.Body.Close()
 
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	s.log.V(2).Info("", "responseDump", string(respDump))
 
 	payload, err = ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	if resp.StatusCode == 404 {
-		return nil, errors.ErrPageNotFound
-	}
-
-	return payload, nil
+	return resp, payload, nil
 }
 
 // posts a urlencoded form to the given endpoint
@@ -176,22 +344,18 @@ func (s *SupermicroX) post(endpoint string, urlValues *url.Values, form []byte,
 		req.Header.Set("Content-Type", formDataContentType)
 	}
 
-	for _, cookie := range s.httpClient.Jar.Cookies(u) {
-		if cookie.Name == "SID" && cookie.Value != "" {
-			req.AddCookie(cookie)
-		}
-	}
+	s.sessionAuth.Authorize(req, s.sessionToken)
 
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
 	s.log.V(2).Info("", "url", fmt.Sprintf("https://%s/cgi/%s", s.ip, endpoint), "requestDump", string(reqDump))
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return statusCode, err
+		return statusCode, errors.WrapRequestError(err)
 	}
 	defer resp.Body.Close()
 
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	s.log.V(2).Info("", "responseDump", string(respDump))
 
 	statusCode = resp.StatusCode
@@ -203,7 +367,12 @@ func (s *SupermicroX) post(endpoint string, urlValues *url.Values, form []byte,
 }
 
 func (s *SupermicroX) query(requestType string) (ipmi *supermicro.IPMI, err error) {
-	err = s.httpLogin()
+	return s.queryContext(s.context(), requestType)
+}
+
+// queryContext runs the ipmi.cgi query bounded by ctx instead of the base context of the SupermicroX
+func (s *SupermicroX) queryContext(ctx context.Context, requestType string) (ipmi *supermicro.IPMI, err error) {
+	err = s.httpLoginContext(ctx)
 	if err != nil {
 		return ipmi, err
 	}
@@ -211,26 +380,18 @@ func (s *SupermicroX) query(requestType string) (ipmi *supermicro.IPMI, err erro
 	bmcURL := fmt.Sprintf("https://%s/cgi/ipmi.cgi", s.ip)
 	s.log.V(1).Info("retrieving data from bmc", "step", "bmc connection", "vendor", string(supermicro.VendorID), "ip", s.ip)
 
-	req, err := http.NewRequest("POST", bmcURL, bytes.NewBufferString(requestType))
+	req, err := http.NewRequestWithContext(ctx, "POST", bmcURL, bytes.NewBufferString(requestType))
 	if err != nil {
 		return ipmi, err
 	}
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	u, err := url.Parse(bmcURL)
-	if err != nil {
-		return ipmi, err
-	}
-	for _, cookie := range s.httpClient.Jar.Cookies(u) {
-		if cookie.Name == "SID" && cookie.Value != "" {
-			req.AddCookie(cookie)
-		}
-	}
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	s.sessionAuth.Authorize(req, s.sessionToken)
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
 	s.log.V(2).Info("trace", "url", fmt.Sprintf("https://%s/cgi/%s", bmcURL, s.ip), "requestDump", string(reqDump))
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return ipmi, err
+		return ipmi, errors.WrapRequestError(err)
 	}
 	defer resp.Body.Close()
 
@@ -239,9 +400,13 @@ func (s *SupermicroX) query(requestType string) (ipmi *supermicro.IPMI, err erro
 		return ipmi, err
 	}
 
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	s.log.V(2).Info("", "responseDump", string(respDump))
 
+	if resp.StatusCode != 200 {
+		return ipmi, errors.NewHTTPErrorFromResponse(resp, payload)
+	}
+
 	ipmi = &supermicro.IPMI{}
 	err = xml.Unmarshal(payload, ipmi)
 	if err != nil {
@@ -253,6 +418,8 @@ func (s *SupermicroX) query(requestType string) (ipmi *supermicro.IPMI, err erro
 
 // Serial returns the device serial
 func (s *SupermicroX) Serial() (serial string, err error) {
+	defer s.wrapError("Serial", &err)
+
 	ipmi, err := s.query("FRU_INFO.XML=(0,0)")
 	if err != nil {
 		return "", err
@@ -262,11 +429,13 @@ func (s *SupermicroX) Serial() (serial string, err error) {
 		return "", errors.ErrInvalidSerial
 	}
 
-	return strings.ToLower(ipmi.FruInfo.Board.SerialNum), nil
+	return devices.NormalizeSerial(ipmi.FruInfo.Board.SerialNum), nil
 }
 
 // ChassisSerial returns the serial number of the chassis where the blade is attached
 func (s *SupermicroX) ChassisSerial() (serial string, err error) {
+	defer s.wrapError("ChassisSerial", &err)
+
 	chassisInfo := &ChassisInfo{}
 	payload, err := s.get("redfish/v1/Chassis/1", true)
 	if err != nil {
@@ -283,10 +452,10 @@ func (s *SupermicroX) ChassisSerial() (serial string, err error) {
 		for i, s := range chassisInfo.Error.ExtendedMessage {
 			e += fmt.Sprintf(", Extended[%d]: %s", i, s)
 		}
-		return "", fmt.Errorf(e)
+		return "", fmt.Errorf("%s: %w", e, errors.ErrInvalidSerial)
 	}
 
-	return strings.ToLower(chassisInfo.SerialNumber), nil
+	return devices.NormalizeSerial(chassisInfo.SerialNumber), nil
 }
 
 // HardwareType returns just Model id string - supermicrox
@@ -302,15 +471,27 @@ func (s *SupermicroX) HardwareType() (model string) {
 	return m
 }
 
+// configHardwareType returns the hardware type logged by the configuration methods,
+// the BMC isn't queried for it in dry-run.
+func (s *SupermicroX) configHardwareType() string {
+	if s.dryRun {
+		return BmcType
+	}
+
+	return s.HardwareType()
+}
+
 // Model returns the device model
 func (s *SupermicroX) Model() (model string, err error) {
+	defer s.wrapError("Model", &err)
+
 	ipmi, err := s.query("FRU_INFO.XML=(0,0)")
 	if err != nil {
 		return model, err
 	}
 
 	if ipmi.FruInfo != nil && ipmi.FruInfo.Board != nil {
-		return ipmi.FruInfo.Board.PartNum, nil
+		return devices.NormalizeModel(devices.Supermicro, ipmi.FruInfo.Board.PartNum), nil
 	}
 
 	return "", fmt.Errorf("SupermicroX Model(): Model not found!")
@@ -318,6 +499,8 @@ func (s *SupermicroX) Model() (model string, err error) {
 
 // Version returns the version of the bmc we are running
 func (s *SupermicroX) Version() (bmcVersion string, err error) {
+	defer s.wrapError("Version", &err)
+
 	ipmi, err := s.query("GENERIC_INFO.XML=(0,0)")
 	if err != nil {
 		return bmcVersion, err
@@ -336,6 +519,8 @@ func (s *SupermicroX) Version() (bmcVersion string, err error) {
 
 // Name returns the hostname of the machine
 func (s *SupermicroX) Name() (name string, err error) {
+	defer s.wrapError("Name", &err)
+
 	ipmi, err := s.query("CONFIG_INFO.XML=(0,0)")
 	if err != nil {
 		return name, err
@@ -350,6 +535,8 @@ func (s *SupermicroX) Name() (name string, err error) {
 
 // Status returns health string status from the bmc
 func (s *SupermicroX) Status() (health string, err error) {
+	defer s.wrapError("Status", &err)
+
 	ipmi, err := s.query("SENSOR_INFO_FOR_SYS_HEALTH.XML=(1,ff)")
 	if err != nil {
 		return health, err
@@ -362,8 +549,60 @@ func (s *SupermicroX) Status() (health string, err error) {
 	return "Unhealthy", err
 }
 
+// Health returns the normalized health from the bmc
+func (s *SupermicroX) Health() (health devices.Health, err error) {
+	defer s.wrapError("Health", &err)
+
+	status, err := s.Status()
+	if err != nil {
+		return devices.HealthUnknown, err
+	}
+
+	return devices.NormalizeHealth(status), err
+}
+
+// dimmTypes maps the SMBIOS memory device type codes to their names
+var dimmTypes = map[string]string{
+	"12h": "DDR",
+	"13h": "DDR2",
+	"18h": "DDR3",
+	"1ah": "DDR4",
+	"22h": "DDR5",
+}
+
+// MemoryModules returns the DIMM slots of the server, empty slots are reported as not populated
+func (s *SupermicroX) MemoryModules() (modules []*devices.MemoryModule, err error) {
+	defer s.wrapError("MemoryModules", &err)
+
+	ipmi, err := s.query("SMBIOS_INFO.XML=(0,0)")
+	if err != nil {
+		return modules, err
+	}
+
+	for _, dimm := range ipmi.Dimm {
+		module := &devices.MemoryModule{Slot: strings.TrimSpace(dimm.Location)}
+
+		size, err := strconv.Atoi(strings.TrimSuffix(dimm.Size, " MB"))
+		if err == nil && size > 0 {
+			module.Populated = true
+			module.SizeMB = size
+			module.SpeedMHz, _ = strconv.Atoi(strings.TrimSuffix(dimm.Speed, " MHz"))
+			module.Type = dimmTypes[strings.ToLower(dimm.Type)]
+			module.Manufacturer = strings.TrimSpace(dimm.Manufacturer)
+			module.PartNumber = strings.TrimSpace(dimm.PartNumber)
+			module.Serial = devices.NormalizeSerial(dimm.Serial)
+		}
+
+		modules = append(modules, module)
+	}
+
+	return modules, nil
+}
+
 // Memory returns the total amount of memory of the server
 func (s *SupermicroX) Memory() (mem int, err error) {
+	defer s.wrapError("Memory", &err)
+
 	ipmi, err := s.query("SMBIOS_INFO.XML=(0,0)")
 
 	for _, dimm := range ipmi.Dimm {
@@ -380,6 +619,8 @@ func (s *SupermicroX) Memory() (mem int, err error) {
 
 // CPU returns the cpu, cores and hyperthreads of the server
 func (s *SupermicroX) CPU() (cpu string, cpuCount int, coreCount int, hyperthreadCount int, err error) {
+	defer s.wrapError("CPU", &err)
+
 	ipmi, err := s.query("SMBIOS_INFO.XML=(0,0)")
 	if err != nil {
 		return "", 0, 0, 0, err
@@ -402,8 +643,40 @@ func (s *SupermicroX) CPU() (cpu string, cpuCount int, coreCount int, hyperthrea
 	return cpu, cpuCount, coreCount, hyperthreadCount, nil
 }
 
+// CPUs returns the processors installed in each socket
+func (s *SupermicroX) CPUs() (cpus []*devices.CPU, err error) {
+	defer s.wrapError("CPUs", &err)
+
+	ipmi, err := s.query("SMBIOS_INFO.XML=(0,0)")
+	if err != nil {
+		return cpus, err
+	}
+
+	for _, entry := range ipmi.CPU {
+		cores, err := strconv.Atoi(entry.Core)
+		if err != nil {
+			return cpus, err
+		}
+
+		speed, _ := strconv.Atoi(strings.TrimSuffix(entry.Speed, " MHz"))
+
+		cpus = append(cpus, &devices.CPU{
+			Vendor:       entry.Manufacturer,
+			Model:        httpclient.StandardizeProcessorName(entry.Version),
+			Slot:         entry.Socket,
+			Cores:        cores,
+			Threads:      cores, // the SMBIOS data doesn't expose threads, matching CPU()
+			ClockSpeedHz: int64(speed) * 1000 * 1000,
+		})
+	}
+
+	return cpus, nil
+}
+
 // BiosVersion returns the current version of the bios
 func (s *SupermicroX) BiosVersion() (version string, err error) {
+	defer s.wrapError("BiosVersion", &err)
+
 	ipmi, err := s.query("SMBIOS_INFO.XML=(0,0)")
 	if err != nil {
 		return version, err
@@ -418,6 +691,8 @@ func (s *SupermicroX) BiosVersion() (version string, err error) {
 
 // PowerKw returns the current power usage in Kw
 func (s *SupermicroX) PowerKw() (power float64, err error) {
+	defer s.wrapError("PowerKw", &err)
+
 	ipmi, err := s.query("Get_NodeInfoReadings.XML=(0,0)")
 	if err != nil {
 		return power, err
@@ -429,7 +704,7 @@ func (s *SupermicroX) PowerKw() (power float64, err error) {
 			return power, err
 		}
 		for _, node := range ipmi.NodeInfo.Nodes {
-			if strings.ToLower(node.NodeSerial) == serial {
+			if devices.NormalizeSerial(node.NodeSerial) == serial {
 				value, err := strconv.Atoi(node.Power)
 				if err != nil {
 					return power, err
@@ -445,6 +720,8 @@ func (s *SupermicroX) PowerKw() (power float64, err error) {
 
 // PowerState returns the current power state of the machine
 func (s *SupermicroX) PowerState() (state string, err error) {
+	defer s.wrapError("PowerState", &err)
+
 	ipmi, err := s.query("POWER_INFO.XML=(0,0)")
 	if err != nil {
 		return state, err
@@ -459,6 +736,8 @@ func (s *SupermicroX) PowerState() (state string, err error) {
 
 // TempC returns the current temperature of the machine
 func (s *SupermicroX) TempC() (temp int, err error) {
+	defer s.wrapError("TempC", &err)
+
 	ipmi, err := s.query("Get_NodeInfoReadings.XML=(0,0)")
 	if err != nil {
 		return temp, err
@@ -470,7 +749,7 @@ func (s *SupermicroX) TempC() (temp int, err error) {
 			return temp, err
 		}
 		for _, node := range ipmi.NodeInfo.Nodes {
-			if strings.ToLower(node.NodeSerial) == serial {
+			if devices.NormalizeSerial(node.NodeSerial) == serial {
 				temp, err := strconv.Atoi(node.SystemTemp)
 				if err != nil {
 					return temp, err
@@ -486,6 +765,8 @@ func (s *SupermicroX) TempC() (temp int, err error) {
 
 // IsBlade returns if the current hardware is a blade or not
 func (s *SupermicroX) IsBlade() (isBlade bool, err error) {
+	defer s.wrapError("IsBlade", &err)
+
 	ipmi, err := s.query("Get_NodeInfoReadings.XML=(0,0)")
 	if err != nil {
 		return isBlade, err
@@ -504,6 +785,8 @@ func (s *SupermicroX) IsBlade() (isBlade bool, err error) {
 
 // Slot returns the current slot within the chassis
 func (s *SupermicroX) Slot() (slot int, err error) {
+	defer s.wrapError("Slot", &err)
+
 	slot = 1
 	ipmi, err := s.query("Get_NodeInfoReadings.XML=(0,0)")
 	if err != nil {
@@ -518,7 +801,7 @@ func (s *SupermicroX) Slot() (slot int, err error) {
 		return slot, err
 	}
 	for _, node := range ipmi.NodeInfo.Nodes {
-		if strings.ToLower(node.NodeSerial) == serial {
+		if devices.NormalizeSerial(node.NodeSerial) == serial {
 			slot = node.ID + 1
 		}
 	}
@@ -528,6 +811,8 @@ func (s *SupermicroX) Slot() (slot int, err error) {
 
 // Nics returns all found Nics in the device
 func (s *SupermicroX) Nics() (nics []*devices.Nic, err error) {
+	defer s.wrapError("Nics", &err)
+
 	ipmi, err := s.query("GENERIC_INFO.XML=(0,0)")
 	if err != nil {
 		return nics, err
@@ -538,12 +823,14 @@ func (s *SupermicroX) Nics() (nics []*devices.Nic, err error) {
 			bmcNic := &devices.Nic{
 				Name:       "bmc",
 				MacAddress: ipmi.GenericInfo.BmcMac,
+				BMC:        true,
 			}
 			nics = append(nics, bmcNic)
 		} else if ipmi.GenericInfo.Generic != nil {
 			bmcNic := &devices.Nic{
 				Name:       "bmc",
 				MacAddress: ipmi.GenericInfo.Generic.BmcMac,
+				BMC:        true,
 			}
 			nics = append(nics, bmcNic)
 		}
@@ -594,6 +881,8 @@ func (s *SupermicroX) Nics() (nics []*devices.Nic, err error) {
 
 // License returns the iLO's license information
 func (s *SupermicroX) License() (name string, licType string, err error) {
+	defer s.wrapError("License", &err)
+
 	ipmi, err := s.query("BIOS_LINCENSE_ACTIVATE.XML=(0,0)")
 	if err != nil {
 		return name, licType, err
@@ -616,153 +905,171 @@ func (s *SupermicroX) Vendor() (vendor string) {
 	return supermicro.VendorID
 }
 
-// ServerSnapshot do best effort to populate the server data and returns a blade or discrete
-// nolint: gocyclo
-func (s *SupermicroX) ServerSnapshot() (server interface{}, err error) {
+// snapshotField populates a field of the snapshot, it's named after the field of the blade or discrete
+type snapshotField struct {
+	name    string
+	collect func() error
+}
+
+// snapshot returns the blade or discrete of the server with the fields populating it, in collection order
+func (s *SupermicroX) snapshot() (server interface{}, fields []snapshotField) {
 	if isBlade, _ := s.IsBlade(); isBlade {
-		blade := &devices.Blade{}
+		blade := &devices.Blade{CollectedAt: time.Now().UTC()}
 		blade.Vendor = s.Vendor()
 		blade.BmcAddress = s.ip
 		blade.BmcType = s.HardwareType()
 
-		blade.Serial, err = s.Serial()
-		if err != nil {
-			return nil, err
-		}
-		blade.BmcVersion, err = s.Version()
-		if err != nil {
-			return nil, err
-		}
-		blade.Model, err = s.Model()
-		if err != nil {
-			return nil, err
-		}
-		blade.Nics, err = s.Nics()
-		if err != nil {
-			return nil, err
-		}
-		blade.Disks, err = s.Disks()
-		if err != nil {
-			return nil, err
+		fields = []snapshotField{
+			{"Serial", func() (err error) { blade.Serial, err = s.Serial(); return err }},
+			{"BmcVersion", func() (err error) { blade.BmcVersion, err = s.Version(); return err }},
+			{"Model", func() (err error) { blade.Model, err = s.Model(); return err }},
+			{"Nics", func() (err error) { blade.Nics, err = s.Nics(); return err }},
+			{"Disks", func() (err error) { blade.Disks, err = s.Disks(); return err }},
+			{"BiosVersion", func() (err error) { blade.BiosVersion, err = s.BiosVersion(); return err }},
+			{"Processor", func() (err error) {
+				blade.Processor, blade.ProcessorCount, blade.ProcessorCoreCount, blade.ProcessorThreadCount, err = s.CPU()
+				return err
+			}},
+			{"CPUs", func() (err error) { blade.CPUs, err = s.CPUs(); return err }},
+			{"Memory", func() (err error) { blade.Memory, err = s.Memory(); return err }},
+			{"MemoryModules", func() (err error) { blade.MemoryModules, err = s.MemoryModules(); return err }},
+			{"Status", func() (err error) { blade.Status, err = s.Status(); return err }},
+			{"Name", func() (err error) { blade.Name, err = s.Name(); return err }},
+			{"TempC", func() (err error) { blade.TempC, err = s.TempC(); return err }},
+			{"PowerKw", func() (err error) { blade.PowerKw, err = s.PowerKw(); return err }},
+			{"PowerState", func() (err error) { blade.PowerState, err = s.PowerState(); return err }},
+			{"BmcLicence", func() (err error) { blade.BmcLicenceType, blade.BmcLicenceStatus, err = s.License(); return err }},
+			{"BladePosition", func() (err error) { blade.BladePosition, err = s.Slot(); return err }},
+			{"ChassisSerial", func() (err error) { blade.ChassisSerial, err = s.ChassisSerial(); return err }},
 		}
-		blade.BiosVersion, err = s.BiosVersion()
-		if err != nil {
-			return nil, err
-		}
-		blade.Processor, blade.ProcessorCount, blade.ProcessorCoreCount, blade.ProcessorThreadCount, err = s.CPU()
-		if err != nil {
-			return nil, err
-		}
-		blade.Memory, err = s.Memory()
-		if err != nil {
-			return nil, err
-		}
-		blade.Status, err = s.Status()
-		if err != nil {
-			return nil, err
-		}
-		blade.Name, err = s.Name()
-		if err != nil {
-			return nil, err
-		}
-		blade.TempC, err = s.TempC()
-		if err != nil {
-			return nil, err
-		}
-		blade.PowerKw, err = s.PowerKw()
-		if err != nil {
-			return nil, err
-		}
-		blade.PowerState, err = s.PowerState()
-		if err != nil {
-			return nil, err
-		}
-		blade.BmcLicenceType, blade.BmcLicenceStatus, err = s.License()
-		if err != nil {
-			return nil, err
-		}
-		blade.BladePosition, err = s.Slot()
-		if err != nil {
-			return nil, err
-		}
-		blade.ChassisSerial, err = s.ChassisSerial()
-		if err != nil {
-			return nil, err
-		}
-		server = blade
-	} else {
-		discrete := &devices.Discrete{}
-		discrete.Vendor = s.Vendor()
-		discrete.BmcAddress = s.ip
-		discrete.BmcType = s.HardwareType()
 
-		discrete.Serial, err = s.Serial()
-		if err != nil {
-			return nil, err
-		}
-		discrete.BmcVersion, err = s.Version()
-		if err != nil {
-			return nil, err
-		}
-		discrete.Model, err = s.Model()
-		if err != nil {
-			return nil, err
-		}
-		discrete.Nics, err = s.Nics()
-		if err != nil {
-			return nil, err
-		}
-		discrete.Disks, err = s.Disks()
-		if err != nil {
-			return nil, err
-		}
-		discrete.BiosVersion, err = s.BiosVersion()
-		if err != nil {
-			return nil, err
-		}
-		discrete.Processor, discrete.ProcessorCount, discrete.ProcessorCoreCount, discrete.ProcessorThreadCount, err = s.CPU()
-		if err != nil {
-			return nil, err
-		}
-		discrete.Memory, err = s.Memory()
-		if err != nil {
-			return nil, err
-		}
-		discrete.Status, err = s.Status()
-		if err != nil {
-			return nil, err
-		}
-		discrete.Name, err = s.Name()
-		if err != nil {
-			return nil, err
-		}
-		discrete.TempC, err = s.TempC()
-		if err != nil {
-			return nil, err
-		}
-		discrete.PowerKw, err = s.PowerKw()
-		if err != nil {
-			return nil, err
-		}
-		discrete.PowerState, err = s.PowerState()
-		if err != nil {
+		return blade, fields
+	}
+
+	discrete := &devices.Discrete{CollectedAt: time.Now().UTC()}
+	discrete.Vendor = s.Vendor()
+	discrete.BmcAddress = s.ip
+	discrete.BmcType = s.HardwareType()
+
+	fields = []snapshotField{
+		{"Serial", func() (err error) { discrete.Serial, err = s.Serial(); return err }},
+		{"BmcVersion", func() (err error) { discrete.BmcVersion, err = s.Version(); return err }},
+		{"Model", func() (err error) { discrete.Model, err = s.Model(); return err }},
+		{"Nics", func() (err error) { discrete.Nics, err = s.Nics(); return err }},
+		{"Disks", func() (err error) { discrete.Disks, err = s.Disks(); return err }},
+		{"BiosVersion", func() (err error) { discrete.BiosVersion, err = s.BiosVersion(); return err }},
+		{"Processor", func() (err error) {
+			discrete.Processor, discrete.ProcessorCount, discrete.ProcessorCoreCount, discrete.ProcessorThreadCount, err = s.CPU()
+			return err
+		}},
+		{"CPUs", func() (err error) { discrete.CPUs, err = s.CPUs(); return err }},
+		{"Memory", func() (err error) { discrete.Memory, err = s.Memory(); return err }},
+		{"MemoryModules", func() (err error) { discrete.MemoryModules, err = s.MemoryModules(); return err }},
+		{"Status", func() (err error) { discrete.Status, err = s.Status(); return err }},
+		{"Name", func() (err error) { discrete.Name, err = s.Name(); return err }},
+		{"TempC", func() (err error) { discrete.TempC, err = s.TempC(); return err }},
+		{"PowerKw", func() (err error) { discrete.PowerKw, err = s.PowerKw(); return err }},
+		{"PowerState", func() (err error) { discrete.PowerState, err = s.PowerState(); return err }},
+		{"BmcLicence", func() (err error) { discrete.BmcLicenceType, discrete.BmcLicenceStatus, err = s.License(); return err }},
+	}
+
+	return discrete, fields
+}
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the PartialSnapshotter interface.
+var _ devices.PartialSnapshotter = (*SupermicroX)(nil)
+
+// ServerSnapshot do best effort to populate the server data and returns a blade or discrete
+func (s *SupermicroX) ServerSnapshot() (server interface{}, err error) {
+	defer s.wrapError("ServerSnapshot", &err)
+
+	server, fields := s.snapshot()
+	for _, field := range fields {
+		if err = field.collect(); err != nil {
 			return nil, err
 		}
-		discrete.BmcLicenceType, discrete.BmcLicenceStatus, err = s.License()
-		if err != nil {
-			return nil, err
+	}
+
+	return server, nil
+}
+
+// ServerSnapshotPartial populates the server data like ServerSnapshot, but carries on when a field
+// can't be read and returns the blade or discrete along with the errors by field name.
+func (s *SupermicroX) ServerSnapshotPartial() (server interface{}, fieldErrs map[string]error) {
+	server, fields := s.snapshot()
+
+	fieldErrs = map[string]error{}
+	for _, field := range fields {
+		if err := field.collect(); err != nil {
+			fieldErrs[field.name] = errors.NewBMCError(BmcType, s.ip, "ServerSnapshot", err)
 		}
-		server = discrete
 	}
 
-	return server, err
+	return server, fieldErrs
 }
 
-// Disks returns a list of disks installed on the device
+// Disks returns a list of disks installed on the device, read from the Redfish storage drives
+// or from the SMART information on the firmware without Redfish storage
 func (s *SupermicroX) Disks() (disks []*devices.Disk, err error) {
+	defer s.wrapError("Disks", &err)
+
+	disks, err = supermicro.RedfishDisks(s.getJSON, "redfish/v1/Systems/1/Storage")
+	if supermicro.NoRedfishStorage(err) {
+		s.log.V(1).Info("redfish storage not available, reading the SMART information", "step", "Disks", "ip", s.ip, "error", err.Error())
+		return s.smartDisks()
+	}
+
 	return disks, err
 }
 
+// getJSON calls a given Redfish endpoint of the bmc and decodes the answer into v,
+// any answer but a 2xx is returned as an HTTPError, the Redfish error bodies decode fine.
+func (s *SupermicroX) getJSON(endpoint string, v interface{}) (err error) {
+	resp, payload, err := s.getResponse(strings.TrimPrefix(endpoint, "/"), true)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.NewHTTPErrorFromResponse(resp, payload)
+	}
+
+	return json.Unmarshal(payload, v)
+}
+
+// smartDisks returns the drives reported by the SMARTINFO.XML query
+func (s *SupermicroX) smartDisks() (disks []*devices.Disk, err error) {
+	ipmi, err := s.query("SMARTINFO.XML=(0,0)")
+	if err != nil {
+		return disks, err
+	}
+
+	if ipmi.SmartInfo == nil {
+		return disks, nil
+	}
+
+	for _, hdd := range ipmi.SmartInfo.HDD {
+		disk := &devices.Disk{
+			Status:      hdd.Status,
+			Serial:      devices.NormalizeSerial(hdd.Serial),
+			Type:        hdd.Type,
+			Size:        strings.TrimSpace(hdd.Capacity),
+			Model:       strings.TrimSpace(hdd.Model),
+			Location:    hdd.ID,
+			FwVersion:   strings.TrimSpace(hdd.FwVersion),
+			Interface:   hdd.Interface,
+			SmartStatus: hdd.Status,
+		}
+		disk.PowerOnHours, _ = strconv.Atoi(hdd.PowerOnHours)
+		disk.Temperature, _ = strconv.Atoi(hdd.Temperature)
+
+		disks = append(disks, disk)
+	}
+
+	return disks, nil
+}
+
 // UpdateCredentials updates login credentials
 func (s *SupermicroX) UpdateCredentials(username string, password string) {
 	s.username = username
@@ -770,16 +1077,15 @@ func (s *SupermicroX) UpdateCredentials(username string, password string) {
 }
 
 // BiosVersion returns the BIOS version from the BMC, implements the Firmware interface
-func (s *SupermicroX) GetBIOSVersion(ctx context.Context) (string, error) {
+func (s *SupermicroX) GetBIOSVersion(ctx context.Context) (version string, err error) {
+	defer s.wrapError("GetBIOSVersion", &err)
+
 	return "", errors.ErrNotImplemented
 }
 
 // BMCVersion returns the BMC version, implements the Firmware interface
-func (s *SupermicroX) GetBMCVersion(ctx context.Context) (string, error) {
-	return "", errors.ErrNotImplemented
-}
+func (s *SupermicroX) GetBMCVersion(ctx context.Context) (version string, err error) {
+	defer s.wrapError("GetBMCVersion", &err)
 
-// Updates the BMC firmware, implements the Firmware interface
-func (s *SupermicroX) FirmwareUpdateBMC(ctx context.Context, filePath string) error {
-	return errors.ErrNotImplemented
+	return "", errors.ErrNotImplemented
 }
@@ -1,24 +1,43 @@
 package supermicrox
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/bmc-toolbox/bmclib/cfgresources"
 	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bombsimon/logrusr/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
 var (
-	mux     *http.ServeMux
-	server  *httptest.Server
-	Answers = map[string][]byte{
+	mux    *http.ServeMux
+	server *httptest.Server
+	// queries records the ipmi.cgi queries answered by the test server
+	queries []string
+	// onQuery lets a test change the answers when the test server receives a query
+	onQuery func(query string)
+	// storageStatus is answered by the Redfish storage endpoints when set
+	storageStatus int
+	Answers       = map[string][]byte{
 		"/redfish/v1/Chassis/1": []byte(`{"@odata.context":"/redfish/v1/$metadata#Chassis.Chassis","@odata.type":"#Chassis.Chassis","@odata.id":"/redfish/v1/Chassis/1","Id":"1","Name":"Computer System Chassis","ChassisType":"RackMount","Manufacturer":"Supermicro","Model":"X10DRFF-CTG","SKU":"","SerialNumber":"CF414AF38N50003","PartNumber":"CSE-F414IS2-R2K04BP","AssetTag":"NONE","IndicatorLED":"Off","Status":{"State":"Enabled","Health":"OK"},"PhysicalSecurity":{"IntrusionSensorNumber":170,"IntrusionSensor":"Normal","IntrusionSensorReArm":"Manual"},"Power":{"@odata.id":"/redfish/v1/Chassis/1/Power"},"Thermal":{"@odata.id":"/redfish/v1/Chassis/1/Thermal"},"Links":{"ComputerSystems":[{"@odata.id":"/redfish/v1/Systems/1"}],"ManagedBy":[{"@odata.id":"/redfish/v1/Managers/1"}],"ContainedBy":{"@odata.id":"/redfish/v1/Chassis/Rack1"}},"Oem":{}}`),
+
+		"/redfish/v1/Systems/1/Storage":            []byte(`{"@odata.type":"#StorageCollection.StorageCollection","@odata.id":"/redfish/v1/Systems/1/Storage","Name":"Storage Collection","Members":[{"@odata.id":"/redfish/v1/Systems/1/Storage/1"}],"Members@odata.count":1}`),
+		"/redfish/v1/Systems/1/Storage/1":          []byte(`{"@odata.type":"#Storage.v1_3_0.Storage","@odata.id":"/redfish/v1/Systems/1/Storage/1","Id":"1","Name":"Intel SATA Controller","Drives":[{"@odata.id":"/redfish/v1/Systems/1/Storage/1/Drives/0"},{"@odata.id":"/redfish/v1/Systems/1/Storage/1/Drives/1"}]}`),
+		"/redfish/v1/Systems/1/Storage/1/Drives/0": []byte(`{"@odata.type":"#Drive.v1_3_0.Drive","@odata.id":"/redfish/v1/Systems/1/Storage/1/Drives/0","Id":"0","Name":"Disk.Bay.0","Model":"INTEL SSDSC2KB480G7 ","SerialNumber":"BTYS802301AB480BGN","Revision":"SCV10100","CapacityBytes":480103981056,"MediaType":"SSD","Protocol":"SATA","Status":{"State":"Enabled","Health":"OK"}}`),
+		"/redfish/v1/Systems/1/Storage/1/Drives/1": []byte(`{"@odata.type":"#Drive.v1_3_0.Drive","@odata.id":"/redfish/v1/Systems/1/Storage/1/Drives/1","Id":"1","Name":"Disk.Bay.1","Status":{"State":"Absent"}}`),
 		"FRU_INFO.XML=(0,0)": []byte(`<?xml version="1.0"?>
 			<IPMI>
 			  <FRU_INFO RES="1">
@@ -63,6 +82,14 @@ var (
 				<LINK_INFO MII_LINK_CONF="0" MII_AUTO_NEGOTIATION="0" MII_DUPLEX="1" MII_SPEED="2" MII_OPERSTATE="1" NCSI_AUTO_NEGOTIATION="0" NCSI_SPEED_AND_DUPLEX="0" NCSI_OPERSTATE="0" DEV_IF_MODE="2" BOND0_PORT="0"/>
 			  </CONFIG_INFO>
 			</IPMI>`),
+		"SEL_INFO.XML=(1,c0)": []byte(`<?xml version="1.0"?>
+			<IPMI>
+			  <SEL_INFO>
+				<SEL TOTAL_NUMBER="0002"/>
+				<SEL SEL_RD="01000200105e5f20000401010159ffff"/>
+				<SEL SEL_RD="02000200105e5f2000040c08ef01ffff"/>
+			  </SEL_INFO>
+			</IPMI>`),
 		"SMBIOS_INFO.XML=(0,0)": []byte(`<?xml version="1.0"?>
 			<IPMI>
 			  <BIOS VENDOR="American Megatrends Inc." VER="2.0" REL_DATE="12/17/2015"/>
@@ -103,7 +130,25 @@ var (
 			<IPMI>
 			  <BIOS_LINCESNE CHECK="0"/>
 			</IPMI>`),
+		"SMARTINFO.XML=(0,0)": []byte(`<?xml version="1.0"?>
+			<IPMI>
+			  <SMART_INFO>
+				<HDD ID="0" MODEL="ST1000NM0033-9ZM173" SN="Z1W4K2AB" FW_VER="SN06" CAPACITY="1000 GB" TYPE="HDD" INTERFACE="SATA" STATUS="OK" POWER_ON_HOURS="31245" TEMP="32"/>
+			  </SMART_INFO>
+			</IPMI>`),
+		"FW_UPDATE_ENTER.XML=(0,0)":    []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="OK"/> </IPMI>`),
+		"FW_UPDATE_CHECK.XML=(0,0)":    []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="OK" VERSION="03.88"/> </IPMI>`),
+		"FW_UPDATE_PROGRESS.XML=(0,0)": []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="OK" PROGRESS="100"/> </IPMI>`),
+		"FW_UPDATE_EXIT.XML=(0,0)":     []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="OK"/> </IPMI>`),
+
+		"BIOS_UPDATE_ENTER.XML=(0,0)":    []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="OK"/> </IPMI>`),
+		"BIOS_UPDATE_CHECK.XML=(0,0)":    []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="OK" VERSION="3.1"/> </IPMI>`),
+		"BIOS_UPDATE_PROGRESS.XML=(0,0)": []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="OK" PROGRESS="100"/> </IPMI>`),
+		"BIOS_UPDATE_EXIT.XML=(0,0)":     []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="OK"/> </IPMI>`),
+
 		"POWER_INFO.XML=(0,0)":                  []byte(`<?xml version="1.0"?>  <IPMI>  <POWER_INFO>  <POWER STATUS="ON"/>  </POWER_INFO>  </IPMI>`),
+		"POWER_INFO.XML=(1,0)":                  []byte(`<?xml version="1.0"?>  <IPMI>  <POWER_INFO>  <POWER STATUS="OFF"/>  </POWER_INFO>  </IPMI>`),
+		"POWER_INFO.XML=(1,1)":                  []byte(`<?xml version="1.0"?>  <IPMI>  <POWER_INFO>  <POWER STATUS="ON"/>  </POWER_INFO>  </IPMI>`),
 		"SENSOR_INFO_FOR_SYS_HEALTH.XML=(1,ff)": []byte(`<?xml version="1.0"?>  <IPMI>  <HEALTH_INFO HEALTH="1"/> </IPMI>`),
 	}
 )
@@ -115,6 +160,9 @@ func init() {
 }
 
 func setup() (r *SupermicroX, err error) {
+	queries = nil
+	onQuery = nil
+	storageStatus = 0
 	mux = http.NewServeMux()
 	server = httptest.NewTLSServer(mux)
 	ip := strings.TrimPrefix(server.URL, "https://")
@@ -130,12 +178,33 @@ func setup() (r *SupermicroX, err error) {
 		_, _ = w.Write(Answers[string("/redfish/v1/Chassis/1")])
 	})
 
+	storage := func(w http.ResponseWriter, r *http.Request) {
+		if storageStatus != 0 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(storageStatus)
+			_, _ = fmt.Fprintf(w, `{"error":{"code":"Base.v1_4_0.GeneralError","message":"%s","@Message.ExtendedInfo":[{"MessageId":"Base.v1_4_0.GeneralError"}]}}`, http.StatusText(storageStatus))
+			return
+		}
+		answer, ok := Answers[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(answer)
+	}
+	mux.HandleFunc("/redfish/v1/Systems/1/Storage", storage)
+	mux.HandleFunc("/redfish/v1/Systems/1/Storage/", storage)
+
 	mux.HandleFunc("/cgi/ipmi.cgi", func(w http.ResponseWriter, r *http.Request) {
 		query, err := ioutil.ReadAll(r.Body)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		queries = append(queries, string(query))
+		if onQuery != nil {
+			onQuery(string(query))
+		}
 		_, _ = w.Write(Answers[string(query)])
 	})
 
@@ -144,7 +213,7 @@ func setup() (r *SupermicroX, err error) {
 	})
 
 	testLog := logrus.New()
-	r, err = New(context.TODO(), ip, username, password, logrusr.New(testLog))
+	r, err = NewWithOptions(context.TODO(), ip, username, password, logrusr.New(testLog), WithInsecureTLS())
 	if err != nil {
 		return r, err
 	}
@@ -196,6 +265,47 @@ func TestChassisSerial(t *testing.T) {
 	tearDown()
 }
 
+func TestChassisSerialRedfishError(t *testing.T) {
+	defer func(answer []byte) { Answers["/redfish/v1/Chassis/1"] = answer }(Answers["/redfish/v1/Chassis/1"])
+	Answers["/redfish/v1/Chassis/1"] = []byte(`{"error":{"code":"Base.v1_4_0.GeneralError","message":"A general error has occurred.","@Message.ExtendedInfo":[{"MessageId":"Base.v1_4_0.InternalError"}]}}`)
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	_, err = bmc.ChassisSerial()
+	if !errors.Is(err, bmclibErrs.ErrInvalidSerial) {
+		t.Errorf("Expected error %v: found %v", bmclibErrs.ErrInvalidSerial, err)
+	}
+
+	if errors.Is(err, bmclibErrs.ErrNon200Response) {
+		t.Errorf("Expected %v not to match %v", err, bmclibErrs.ErrNon200Response)
+	}
+}
+
+func TestScreenshotNon200(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	mux.HandleFunc("/cgi/CapturePreview.cgi", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+	})
+
+	_, _, err = bmc.Screenshot()
+	if !errors.Is(err, bmclibErrs.ErrNon200Response) {
+		t.Errorf("Expected error %v: found %v", bmclibErrs.ErrNon200Response, err)
+	}
+
+	if errors.Is(err, bmclibErrs.ErrInvalidSerial) {
+		t.Errorf("Expected %v not to match %v", err, bmclibErrs.ErrInvalidSerial)
+	}
+}
+
 func TestModel(t *testing.T) {
 	expectedAnswer := "X10DRFF-CTG"
 
@@ -312,6 +422,39 @@ func TestMemory(t *testing.T) {
 	tearDown()
 }
 
+func TestMemoryModules(t *testing.T) {
+	expectedAnswer := &devices.MemoryModule{
+		Slot:         "P2-DIMMH1",
+		Populated:    true,
+		SizeMB:       16384,
+		SpeedMHz:     2133,
+		Type:         "DDR4",
+		Manufacturer: "Hynix Semiconductor",
+		PartNumber:   "HMA42GR7MFR4N-TF",
+		Serial:       "10d12481",
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	modules, err := bmc.MemoryModules()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.MemoryModules %v", err)
+	}
+
+	if len(modules) != 8 {
+		t.Fatalf("Expected 8 memory modules: found %v", len(modules))
+	}
+
+	if *modules[0] != *expectedAnswer {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, modules[0])
+	}
+
+	tearDown()
+}
+
 func TestCPU(t *testing.T) {
 	expectedAnswerCPUType := "intel(r) xeon(r) cpu e5-2630"
 	expectedAnswerCPUCount := 2
@@ -347,6 +490,35 @@ func TestCPU(t *testing.T) {
 	tearDown()
 }
 
+func TestCPUs(t *testing.T) {
+	expectedAnswer := []*devices.CPU{
+		{Vendor: "Intel", Model: "intel(r) xeon(r) cpu e5-2630", Slot: "CPU2", Cores: 10, Threads: 10, ClockSpeedHz: 2200000000},
+		{Vendor: "Intel", Model: "intel(r) xeon(r) cpu e5-2630", Slot: "CPU1", Cores: 10, Threads: 10, ClockSpeedHz: 2200000000},
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	cpus, err := bmc.CPUs()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.CPUs %v", err)
+	}
+
+	if len(cpus) != len(expectedAnswer) {
+		t.Fatalf("Expected %v cpus: found %v cpus", len(expectedAnswer), len(cpus))
+	}
+
+	for pos, cpu := range cpus {
+		if *cpu != *expectedAnswer[pos] {
+			t.Errorf("Expected answer %v: found %v", expectedAnswer[pos], cpu)
+		}
+	}
+
+	tearDown()
+}
+
 func TestBiosVersion(t *testing.T) {
 	expectedAnswer := "2.0"
 
@@ -367,6 +539,29 @@ func TestBiosVersion(t *testing.T) {
 	tearDown()
 }
 
+func TestGetFirmwareInventory(t *testing.T) {
+	expectedAnswer := []devices.Firmware{
+		{Component: "BMC", Installed: "0325"},
+		{Component: "BIOS", Installed: "2.0"},
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	answer, err := bmc.GetFirmwareInventory()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.GetFirmwareInventory %v", err)
+	}
+
+	if !reflect.DeepEqual(answer, expectedAnswer) {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, answer)
+	}
+
+	tearDown()
+}
+
 func TestPowerKW(t *testing.T) {
 	expectedAnswer := 0.284
 
@@ -412,6 +607,7 @@ func TestNics(t *testing.T) {
 		{
 			MacAddress: "0c:c4:7a:b8:22:64",
 			Name:       "bmc",
+			BMC:        true,
 		},
 		{
 			MacAddress: "0c:c4:7a:bc:dc:1a",
@@ -438,7 +634,7 @@ func TestNics(t *testing.T) {
 	}
 
 	for pos, nic := range nics {
-		if nic.MacAddress != expectedAnswer[pos].MacAddress || nic.Name != expectedAnswer[pos].Name {
+		if nic.MacAddress != expectedAnswer[pos].MacAddress || nic.Name != expectedAnswer[pos].Name || nic.BMC != expectedAnswer[pos].BMC {
 			t.Errorf("Expected answer %v: found %v", expectedAnswer[pos], nic)
 		}
 	}
@@ -446,6 +642,110 @@ func TestNics(t *testing.T) {
 	tearDown()
 }
 
+func TestDisks(t *testing.T) {
+	expectedAnswer := []*devices.Disk{
+		{
+			Status:    "OK",
+			Serial:    "btys802301ab480bgn",
+			Type:      "SSD",
+			Size:      "480 GB",
+			Model:     "INTEL SSDSC2KB480G7",
+			Location:  "Disk.Bay.0",
+			FwVersion: "SCV10100",
+			Interface: "SATA",
+		},
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	answer, err := bmc.Disks()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.Disks %v", err)
+	}
+
+	if !reflect.DeepEqual(answer, expectedAnswer) {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, answer)
+	}
+}
+
+func TestDisksSmartInfo(t *testing.T) {
+	expectedAnswer := []*devices.Disk{
+		{
+			Status:       "OK",
+			Serial:       "z1w4k2ab",
+			Type:         "HDD",
+			Size:         "1000 GB",
+			Model:        "ST1000NM0033-9ZM173",
+			Location:     "0",
+			FwVersion:    "SN06",
+			Interface:    "SATA",
+			SmartStatus:  "OK",
+			PowerOnHours: 31245,
+			Temperature:  32,
+		},
+	}
+
+	// the firmware without Redfish storage answers in different ways
+	tests := []struct {
+		name   string
+		status int
+		answer []byte
+	}{
+		{name: "not found", status: http.StatusNotFound},
+		{name: "unauthorized", status: http.StatusUnauthorized},
+		{name: "server error", status: http.StatusInternalServerError},
+		{name: "web page", answer: []byte(`<html><head><title>Supermicro BMC Login</title></head></html>`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(answer []byte) { Answers["/redfish/v1/Systems/1/Storage"] = answer }(Answers["/redfish/v1/Systems/1/Storage"])
+			Answers["/redfish/v1/Systems/1/Storage"] = tt.answer
+
+			bmc, err := setup()
+			if err != nil {
+				t.Fatalf("Found errors during the test setup %v", err)
+			}
+			defer tearDown()
+			storageStatus = tt.status
+
+			answer, err := bmc.Disks()
+			if err != nil {
+				t.Fatalf("Found errors calling bmc.Disks %v", err)
+			}
+
+			if !reflect.DeepEqual(answer, expectedAnswer) {
+				t.Errorf("Expected answer %v: found %v", expectedAnswer, answer)
+			}
+		})
+	}
+}
+
+func TestDisksRedfishError(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+	storageStatus = http.StatusServiceUnavailable
+
+	_, err = bmc.Disks()
+
+	var httpErr *bmclibErrs.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected an HTTPError with status %d: found %v", http.StatusServiceUnavailable, err)
+	}
+
+	var bmcErr *bmclibErrs.BMCError
+	if !errors.As(err, &bmcErr) || bmcErr.Vendor != BmcType || bmcErr.Operation != "Disks" {
+		t.Errorf("Expected the error to carry the bmc identity: found %v", err)
+	}
+}
+
 func TestLicense(t *testing.T) {
 	expectedName := "oob"
 	expectedLicType := "Activated"
@@ -559,3 +859,1064 @@ func TestUpdateCredentials(t *testing.T) {
 
 	tearDown()
 }
+
+func TestGetHTTPError(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	_, err = bmc.get("redfish/v1/Systems/1", false)
+
+	var httpErr *bmclibErrs.HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("Expected an HTTPError: found %v", err)
+	}
+
+	if httpErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected answer %v: found %v", http.StatusNotFound, httpErr.StatusCode)
+	}
+
+	if !errors.Is(err, bmclibErrs.ErrPageNotFound) {
+		t.Errorf("Expected %v to match ErrPageNotFound", err)
+	}
+
+	tearDown()
+}
+
+func TestLoginRejected(t *testing.T) {
+	loginServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html><body>Invalid Username or Password</body></html>"))
+	}))
+	defer loginServer.Close()
+
+	bmc, err := NewWithOptions(context.TODO(), strings.TrimPrefix(loginServer.URL, "https://"), "super", "wrong", logrusr.New(logrus.New()), WithInsecureTLS())
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	err = bmc.CheckCredentials()
+	if !bmclibErrs.IsInvalidCredentials(err) || !bmclibErrs.IsLoginFailed(err) {
+		t.Errorf("Expected error %v: found %v", bmclibErrs.ErrInvalidCredentials, err)
+	}
+
+	var bmcErr *bmclibErrs.BMCError
+	if !errors.As(err, &bmcErr) || bmcErr.Vendor != BmcType || bmcErr.Operation != "CheckCredentials" {
+		t.Errorf("Expected the error to carry the bmc identity: found %v", err)
+	}
+
+	loginServer.Close()
+	bmc, err = NewWithOptions(context.TODO(), strings.TrimPrefix(loginServer.URL, "https://"), "super", "test", logrusr.New(logrus.New()), WithInsecureTLS())
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	err = bmc.CheckCredentials()
+	if err == nil || bmclibErrs.IsLoginFailed(err) {
+		t.Errorf("Expected an unreachable bmc not to be reported as a failed login: found %v", err)
+	}
+}
+
+func TestLoginFailedAmbiguous(t *testing.T) {
+	loginServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html><body>Service is not ready, please try again later</body></html>"))
+	}))
+	defer loginServer.Close()
+
+	bmc, err := NewWithOptions(context.TODO(), strings.TrimPrefix(loginServer.URL, "https://"), "super", "test", logrusr.New(logrus.New()), WithInsecureTLS())
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	err = bmc.CheckCredentials()
+	if !errors.Is(err, bmclibErrs.ErrLoginFailed) || bmclibErrs.IsInvalidCredentials(err) {
+		t.Errorf("Expected error %v: found %v", bmclibErrs.ErrLoginFailed, err)
+	}
+}
+
+func TestLoginUnauthorized(t *testing.T) {
+	loginServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer loginServer.Close()
+
+	bmc, err := NewWithOptions(context.TODO(), strings.TrimPrefix(loginServer.URL, "https://"), "super", "wrong", logrusr.New(logrus.New()), WithInsecureTLS())
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	err = bmc.CheckCredentials()
+	if !bmclibErrs.IsInvalidCredentials(err) {
+		t.Errorf("Expected error %v: found %v", bmclibErrs.ErrInvalidCredentials, err)
+	}
+}
+
+func TestLoginRateLimited(t *testing.T) {
+	loginServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer loginServer.Close()
+
+	bmc, err := NewWithOptions(context.TODO(), strings.TrimPrefix(loginServer.URL, "https://"), "super", "test", logrusr.New(logrus.New()), WithInsecureTLS())
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	err = bmc.CheckCredentials()
+	if !bmclibErrs.IsRateLimited(err) {
+		t.Fatalf("Expected error %v: found %v", bmclibErrs.ErrRateLimited, err)
+	}
+
+	if answer := bmclibErrs.RetryAfter(err); answer != 30*time.Second {
+		t.Errorf("Expected answer %v: found %v", 30*time.Second, answer)
+	}
+}
+
+func TestSessionTokenHeader(t *testing.T) {
+	var header string
+	var cookies []*http.Cookie
+	bmcServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cgi/login.cgi":
+			http.SetCookie(w, &http.Cookie{Name: "SID", Value: "session-token", Path: "/"})
+			_, _ = w.Write([]byte("../cgi/url_redirect.cgi?url_name=mainmenu"))
+		case "/cgi/ipmi.cgi":
+			header = r.Header.Get("X-SID-TOKEN")
+			cookies = r.Cookies()
+			query, _ := ioutil.ReadAll(r.Body)
+			_, _ = w.Write(Answers[string(query)])
+		}
+	}))
+	defer bmcServer.Close()
+
+	bmc, err := NewWithOptions(context.TODO(), strings.TrimPrefix(bmcServer.URL, "https://"), "super", "test", logrusr.New(logrus.New()), WithInsecureTLS(), WithSessionTokenHeader("X-SID-TOKEN"))
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	if _, err = bmc.Serial(); err != nil {
+		t.Fatalf("Found errors calling bmc.Serial %v", err)
+	}
+
+	if header != "session-token" {
+		t.Errorf("Expected answer %v: found %v", "session-token", header)
+	}
+
+	if len(cookies) != 0 {
+		t.Errorf("Expected answer %v: found %v", "no session cookie", cookies)
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	expectedAnswer := []devices.User{
+		{ID: 1, Name: "Administrator", Role: devices.UserRoleAdmin, Enabled: true},
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	answer, err := bmc.ListUsers()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.ListUsers %v", err)
+	}
+
+	if len(answer) != len(expectedAnswer) {
+		t.Fatalf("Expected %v users: found %v", len(expectedAnswer), len(answer))
+	}
+
+	for pos, user := range answer {
+		if user != expectedAnswer[pos] {
+			t.Errorf("Expected answer %v: found %v", expectedAnswer[pos], user)
+		}
+	}
+}
+
+func TestUserManager(t *testing.T) {
+	tests := []struct {
+		name         string
+		call         func(bmc *SupermicroX) error
+		expectedForm map[string]string
+		expectedErr  error
+	}{
+		{
+			name: "create",
+			call: func(bmc *SupermicroX) error {
+				return bmc.CreateUser(devices.User{Name: "operator", Password: "S3cretPass", Role: devices.UserRoleOperator})
+			},
+			expectedForm: map[string]string{"username": "operator", "original_username": "2", "password": "S3cretPass", "new_privilege": "3"},
+		},
+		{
+			name: "create existing",
+			call: func(bmc *SupermicroX) error {
+				return bmc.CreateUser(devices.User{Name: "Administrator", Password: "S3cretPass", Role: devices.UserRoleAdmin})
+			},
+			expectedErr: bmclibErrs.ErrUserAccountExists,
+		},
+		{
+			name: "create invalid role",
+			call: func(bmc *SupermicroX) error {
+				return bmc.CreateUser(devices.User{Name: "nobody", Password: "S3cretPass", Role: devices.UserRoleNone})
+			},
+			expectedErr: bmclibErrs.ErrInvalidUserRole,
+		},
+		{
+			name: "create without password",
+			call: func(bmc *SupermicroX) error {
+				return bmc.CreateUser(devices.User{Name: "operator", Role: devices.UserRoleOperator})
+			},
+			expectedErr: bmclibErrs.ErrUserParamsRequired,
+		},
+		{
+			name: "create with a weak password",
+			call: func(bmc *SupermicroX) error {
+				return bmc.CreateUser(devices.User{Name: "operator", Password: "secret", Role: devices.UserRoleOperator})
+			},
+			expectedErr: bmclibErrs.ErrWeakPassword,
+		},
+		{
+			name: "modify",
+			call: func(bmc *SupermicroX) error {
+				return bmc.ModifyUser(devices.User{Name: "Administrator", Role: devices.NormalizeUserRole("user")})
+			},
+			expectedForm: map[string]string{"username": "Administrator", "original_username": "1", "new_privilege": "2"},
+		},
+		{
+			name: "modify with a password",
+			call: func(bmc *SupermicroX) error {
+				return bmc.ModifyUser(devices.User{Name: "Administrator", Password: "N3wSecretPass", Role: devices.UserRoleOperator})
+			},
+			expectedForm: map[string]string{"username": "Administrator", "original_username": "1", "password": "N3wSecretPass", "new_privilege": "3"},
+		},
+		{
+			name: "modify missing",
+			call: func(bmc *SupermicroX) error {
+				return bmc.ModifyUser(devices.User{Name: "missing", Role: devices.UserRoleAdmin})
+			},
+			expectedErr: bmclibErrs.ErrUserAccountNotFound,
+		},
+		{
+			name: "modify invalid role",
+			call: func(bmc *SupermicroX) error {
+				return bmc.ModifyUser(devices.User{Name: "Administrator", Role: devices.UserRoleNone})
+			},
+			expectedErr: bmclibErrs.ErrInvalidUserRole,
+		},
+		{
+			name:         "delete",
+			call:         func(bmc *SupermicroX) error { return bmc.DeleteUser("Administrator") },
+			expectedForm: map[string]string{"username": "", "original_username": "1"},
+		},
+		{
+			name:        "delete missing",
+			call:        func(bmc *SupermicroX) error { return bmc.DeleteUser("missing") },
+			expectedErr: bmclibErrs.ErrUserAccountNotFound,
+		},
+		{
+			name:         "change password",
+			call:         func(bmc *SupermicroX) error { return bmc.ChangePassword("Administrator", "N3wSecretPass") },
+			expectedForm: map[string]string{"username": "Administrator", "original_username": "1", "password": "N3wSecretPass", "new_privilege": "4"},
+		},
+		{
+			name:        "change password to a weak one",
+			call:        func(bmc *SupermicroX) error { return bmc.ChangePassword("Administrator", "newsecret") },
+			expectedErr: bmclibErrs.ErrWeakPassword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bmc, err := setup()
+			if err != nil {
+				t.Fatalf("Found errors during the test setup %v", err)
+			}
+			defer tearDown()
+
+			var form map[string]string
+			mux.HandleFunc("/cgi/config_user.cgi", func(w http.ResponseWriter, r *http.Request) {
+				_ = r.ParseForm()
+				form = map[string]string{}
+				for key := range r.PostForm {
+					form[key] = r.PostForm.Get(key)
+				}
+			})
+
+			err = tt.call(bmc)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Expected error %v: found %v", tt.expectedErr, err)
+			}
+
+			if len(form) != len(tt.expectedForm) {
+				t.Fatalf("Expected answer %v: found %v", tt.expectedForm, form)
+			}
+			for key, value := range tt.expectedForm {
+				if form[key] != value {
+					t.Errorf("Expected answer %v: found %v", value, form[key])
+				}
+			}
+		})
+	}
+}
+
+func TestGetEventLog(t *testing.T) {
+	timestamp := time.Unix(1600000000, 0).UTC()
+	expectedAnswer := []devices.EventLogEntry{
+		{ID: "1", Timestamp: timestamp, Sensor: "Temperature #0x01", EventType: "Asserted", Severity: devices.SeverityCritical, Message: "Temperature: Upper Critical going high"},
+		{ID: "2", Timestamp: timestamp, Sensor: "Memory #0x08", EventType: "Deasserted", Severity: devices.SeverityOK, Message: "Memory: event offset 0x01"},
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	answer, err := bmc.GetEventLog()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.GetEventLog %v", err)
+	}
+
+	if len(answer) != len(expectedAnswer) {
+		t.Fatalf("Expected %v entries: found %v", len(expectedAnswer), len(answer))
+	}
+
+	for pos, entry := range answer {
+		if entry != expectedAnswer[pos] {
+			t.Errorf("Expected answer %v: found %v", expectedAnswer[pos], entry)
+		}
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	requests := 0
+	bmcServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer bmcServer.Close()
+
+	bmc, err := NewWithOptions(context.TODO(), strings.TrimPrefix(bmcServer.URL, "https://"), "super", "test", logrusr.New(logrus.New()), WithInsecureTLS(), WithDryRun())
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	err = bmc.User([]*cfgresources.User{{Name: "operator", Password: "s3cr3t", Role: "user", Enable: true}})
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.User %v", err)
+	}
+
+	_, err = bmc.Network(&cfgresources.Network{SSHEnable: true, SSHPort: 2222})
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.Network %v", err)
+	}
+
+	err = bmc.Ntp(&cfgresources.Ntp{Enable: true, Server1: "ntp0.example.com", Timezone: "UTC"})
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.Ntp %v", err)
+	}
+
+	err = bmc.Syslog(&cfgresources.Syslog{Enable: true, Server: "127.0.0.1"})
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.Syslog %v", err)
+	}
+
+	reset, err := bmc.UploadHTTPSCert([]byte("cert"), "cert.pem", []byte("key"), "key.pem")
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.UploadHTTPSCert %v", err)
+	}
+
+	if reset {
+		t.Errorf("Expected no bmc reset for a certificate not uploaded")
+	}
+
+	// the resources are still validated
+	err = bmc.User([]*cfgresources.User{{Name: "operator", Role: "user", Enable: true}})
+	if err == nil {
+		t.Errorf("Expected an error for a user without password")
+	}
+
+	if requests != 0 {
+		t.Errorf("Expected answer %v: found %v requests", 0, requests)
+	}
+
+	changes := bmc.DryRunChanges()
+	expected := []struct{ resource, endpoint string }{
+		{"User", "config_user.cgi"},
+		{"Network", "op.cgi"},
+		{"Ntp", "op.cgi"},
+		{"Syslog", "op.cgi"},
+		{"Syslog", "system_event_log.cgi"},
+		{"HTTPSCert", "upload_ssl.cgi"},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected answer %v: found %v", expected, changes)
+	}
+	for i, e := range expected {
+		if changes[i].Resource != e.resource || changes[i].Endpoint != e.endpoint {
+			t.Errorf("Expected answer %v %v: found %v %v", e.resource, e.endpoint, changes[i].Resource, changes[i].Endpoint)
+		}
+	}
+
+	if changes[0].Params["username"] != "operator" || changes[0].Params["password"] != "<redacted>" {
+		t.Errorf("Expected answer %v: found %v", "operator with a redacted password", changes[0].Params)
+	}
+	if changes[1].Params["SSH_PORT"] != "2222" {
+		t.Errorf("Expected answer %v: found %v", "2222", changes[1].Params["SSH_PORT"])
+	}
+	if changes[2].Params["ntp_server_pri"] != "ntp0.example.com" {
+		t.Errorf("Expected answer %v: found %v", "ntp0.example.com", changes[2].Params["ntp_server_pri"])
+	}
+}
+
+func TestDryRunUserManager(t *testing.T) {
+	requests := 0
+	bmcServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer bmcServer.Close()
+
+	bmc, err := NewWithOptions(context.TODO(), strings.TrimPrefix(bmcServer.URL, "https://"), "super", "test", logrusr.New(logrus.New()), WithInsecureTLS(), WithDryRun())
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	err = bmc.CreateUser(devices.User{Name: "operator", Password: "s3cr3tPass", Role: devices.UserRoleOperator})
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.CreateUser %v", err)
+	}
+
+	err = bmc.ModifyUser(devices.User{Name: "operator", Role: devices.UserRoleAdmin})
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.ModifyUser %v", err)
+	}
+
+	err = bmc.ChangePassword("operator", "n3wS3cr3tPass")
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.ChangePassword %v", err)
+	}
+
+	err = bmc.DeleteUser("operator")
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.DeleteUser %v", err)
+	}
+
+	if requests != 0 {
+		t.Errorf("Expected answer %v: found %v requests", 0, requests)
+	}
+
+	changes := bmc.DryRunChanges()
+	if len(changes) != 4 {
+		t.Fatalf("Expected answer %v: found %v", 4, changes)
+	}
+	for _, change := range changes {
+		if change.Resource != "User" || change.Endpoint != "config_user.cgi" {
+			t.Errorf("Expected answer %v %v: found %v %v", "User", "config_user.cgi", change.Resource, change.Endpoint)
+		}
+	}
+
+	if changes[0].Params["username"] != "operator" || changes[0].Params["password"] != "<redacted>" {
+		t.Errorf("Expected answer %v: found %v", "operator with a redacted password", changes[0].Params)
+	}
+	if changes[3].Params["username"] != "" {
+		t.Errorf("Expected answer %v: found %v", "a cleared slot", changes[3].Params)
+	}
+}
+
+func TestPing(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	// the first ping logs in, the next one queries the power status over the session
+	for i := 0; i < 2; i++ {
+		err = bmc.Ping(context.TODO())
+		if err != nil {
+			t.Fatalf("Found errors calling bmc.Ping %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	err = bmc.Ping(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected error %v: found %v", context.Canceled, err)
+	}
+}
+
+func TestPingUnreachable(t *testing.T) {
+	unreachable := httptest.NewTLSServer(http.NotFoundHandler())
+	ip := strings.TrimPrefix(unreachable.URL, "https://")
+	unreachable.Close()
+
+	bmc, err := NewWithOptions(context.TODO(), ip, "super", "test", logrusr.New(logrus.New()), WithInsecureTLS())
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	err = bmc.Ping(context.TODO())
+	if err == nil || bmclibErrs.IsInvalidCredentials(err) {
+		t.Errorf("Expected a connection error: found %v", err)
+	}
+}
+
+func TestPingTimeout(t *testing.T) {
+	slowServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte("../cgi/url_redirect.cgi?url_name=mainmenu"))
+	}))
+	defer slowServer.Close()
+
+	bmc, err := NewWithOptions(context.TODO(), strings.TrimPrefix(slowServer.URL, "https://"), "super", "test", logrusr.New(logrus.New()), WithInsecureTLS())
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 20*time.Millisecond)
+	defer cancel()
+
+	err = bmc.Ping(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected error %v: found %v", context.DeadlineExceeded, err)
+	}
+
+	// the login is abandoned with the request, it doesn't set up the session behind the caller's back
+	time.Sleep(300 * time.Millisecond)
+	if bmc.httpClient != nil {
+		t.Errorf("Expected no session after the ping timed out")
+	}
+}
+
+func TestPingRejected(t *testing.T) {
+	loginServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("../cgi/url_redirect.cgi?url_name=login_fail"))
+	}))
+	defer loginServer.Close()
+
+	bmc, err := NewWithOptions(context.TODO(), strings.TrimPrefix(loginServer.URL, "https://"), "super", "wrong", logrusr.New(logrus.New()), WithInsecureTLS())
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+
+	err = bmc.Ping(context.TODO())
+	if !bmclibErrs.IsInvalidCredentials(err) {
+		t.Errorf("Expected error %v: found %v", bmclibErrs.ErrInvalidCredentials, err)
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	expected := []string{"powerstate", "powerset", "bootdeviceset", "bmcreset", "eventlogread", "sensorread", "firmwareinventory", "usercreate", "userdelete", "userupdate", "userread", "firmwareinstall"}
+
+	answer := bmc.Capabilities()
+	if len(answer) != len(expected) {
+		t.Fatalf("Expected answer %v: found %v", expected, answer)
+	}
+	for i, feature := range answer {
+		if string(feature) != expected[i] {
+			t.Errorf("Expected answer %v: found %v", expected[i], feature)
+		}
+	}
+}
+
+func TestGeneration(t *testing.T) {
+	tests := map[string]string{
+		"X10DRFF-CTG": X10,
+		"X11DPT-B":    X11,
+		"X9DRW-iF":    "",
+		"":            "",
+	}
+
+	for model, expected := range tests {
+		if answer := generation(model); answer != expected {
+			t.Errorf("Expected answer %v for %s: found %v", expected, model, answer)
+		}
+	}
+}
+
+func TestServerSnapshotPartial(t *testing.T) {
+	defer func(answer []byte) { Answers["BIOS_LINCENSE_ACTIVATE.XML=(0,0)"] = answer }(Answers["BIOS_LINCENSE_ACTIVATE.XML=(0,0)"])
+	Answers["BIOS_LINCENSE_ACTIVATE.XML=(0,0)"] = []byte(`<?xml version="1.0"?> <IPMI>`)
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	_, err = bmc.ServerSnapshot()
+	if err == nil {
+		t.Fatalf("Expected an error calling bmc.ServerSnapshot with the license unreadable")
+	}
+
+	server, fieldErrs := bmc.ServerSnapshotPartial()
+	if len(fieldErrs) != 1 || fieldErrs["BmcLicence"] == nil {
+		t.Fatalf("Expected a single BmcLicence error: found %v", fieldErrs)
+	}
+
+	blade, ok := server.(*devices.Blade)
+	if !ok {
+		t.Fatalf("Expected a *devices.Blade: found %T", server)
+	}
+
+	if blade.Serial != "vm158s009467" || blade.Model != "X10DRFF-CTG" || blade.PowerState != "on" {
+		t.Errorf("Expected serial, model and power state to be collected: found %v %v %v", blade.Serial, blade.Model, blade.PowerState)
+	}
+
+	if blade.BmcLicenceType != "" || blade.BmcLicenceStatus != "" {
+		t.Errorf("Expected an empty license: found %v %v", blade.BmcLicenceType, blade.BmcLicenceStatus)
+	}
+}
+
+func TestFirmwareUpdateBMC(t *testing.T) {
+	defer func(size int, interval time.Duration) {
+		firmwareChunkSize, firmwarePollInterval = size, interval
+	}(firmwareChunkSize, firmwarePollInterval)
+	firmwareChunkSize, firmwarePollInterval = 4, time.Millisecond
+
+	image := []byte("BMC_FIRMWARE")
+	images := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/firmware/SMT_X10_388.bin" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(image)
+	}))
+	defer images.Close()
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	var uploaded []byte
+	mux.HandleFunc("/cgi/upload_firmware.cgi", func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("fw_image")
+		if err != nil || r.FormValue("offset") != strconv.Itoa(len(uploaded)) || r.FormValue("total_size") != strconv.Itoa(len(image)) {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		chunk, _ := ioutil.ReadAll(file)
+		uploaded = append(uploaded, chunk...)
+	})
+
+	var ops []string
+	var preserve string
+	var rebooted bool
+	mux.HandleFunc("/cgi/op.cgi", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		ops = append(ops, r.PostForm.Get("op"))
+		switch r.PostForm.Get("op") {
+		case "main_fwupdate":
+			preserve = r.PostForm.Get("preserve_config")
+		case "main_fwupdate_reboot":
+			rebooted = true
+		}
+	})
+
+	// the old firmware still answers once after the restart is requested
+	defer func(answer []byte) { Answers["GENERIC_INFO.XML=(0,0)"] = answer }(Answers["GENERIC_INFO.XML=(0,0)"])
+	var oldAnswers int
+	onQuery = func(query string) {
+		if query != "GENERIC_INFO.XML=(0,0)" || !rebooted {
+			return
+		}
+		oldAnswers++
+		if oldAnswers > 1 {
+			Answers[query] = []byte(strings.Replace(string(Answers[query]), `IPMIFW_VERSION="0325"`, `IPMIFW_VERSION="0388"`, 1))
+		}
+	}
+
+	var phases []bmclibErrs.FirmwareUpdatePhase
+	status, output, err := bmc.UpdateFirmwareWithProgress(images.URL+"/firmware", "SMT_X10_388.bin", func(p devices.FirmwareProgress) {
+		if len(phases) == 0 || phases[len(phases)-1] != p.Phase {
+			phases = append(phases, p.Phase)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.UpdateFirmwareWithProgress %v", err)
+	}
+
+	if !status || output != "bmc firmware updated to 0388" {
+		t.Errorf("Expected answer %v %v: found %v %v", true, "bmc firmware updated to 0388", status, output)
+	}
+
+	if oldAnswers < 2 {
+		t.Errorf("Expected answer %v: found %v version queries after the restart", 2, oldAnswers)
+	}
+
+	if string(uploaded) != string(image) {
+		t.Errorf("Expected answer %s: found %s", image, uploaded)
+	}
+
+	expectedOps := []string{"main_fwupdate", "main_fwupdate_reboot"}
+	if !reflect.DeepEqual(ops, expectedOps) || preserve != "1" {
+		t.Errorf("Expected answer %v %v: found %v %v", expectedOps, "1", ops, preserve)
+	}
+
+	expectedPhases := []bmclibErrs.FirmwareUpdatePhase{
+		bmclibErrs.FirmwareUpdatePhasePrepare,
+		bmclibErrs.FirmwareUpdatePhaseUpload,
+		bmclibErrs.FirmwareUpdatePhaseVerify,
+		bmclibErrs.FirmwareUpdatePhaseFlash,
+		bmclibErrs.FirmwareUpdatePhaseReboot,
+	}
+	if !reflect.DeepEqual(phases, expectedPhases) {
+		t.Errorf("Expected answer %v: found %v", expectedPhases, phases)
+	}
+}
+
+func TestUpdateFirmwareSource(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	images := httptest.NewServer(http.NotFoundHandler())
+	defer images.Close()
+
+	// the source is a URL, a local directory or a missing image fails before the BMC enters the update mode
+	for _, source := range []string{t.TempDir(), images.URL} {
+		_, _, err = bmc.UpdateFirmware(source, "SMT_X10_388.bin")
+
+		var updateErr *bmclibErrs.FirmwareUpdateError
+		if !errors.As(err, &updateErr) || updateErr.Phase != bmclibErrs.FirmwareUpdatePhasePrepare {
+			t.Errorf("Expected answer %v: found %v", bmclibErrs.FirmwareUpdatePhasePrepare, err)
+		}
+	}
+
+	// so does a missing local image
+	err = bmc.FirmwareUpdateBMC(context.TODO(), t.TempDir()+"/SMT_X10_388.bin")
+
+	var updateErr *bmclibErrs.FirmwareUpdateError
+	if !errors.As(err, &updateErr) || updateErr.Phase != bmclibErrs.FirmwareUpdatePhasePrepare {
+		t.Errorf("Expected answer %v: found %v", bmclibErrs.FirmwareUpdatePhasePrepare, err)
+	}
+
+	// the update stops with the context of the SupermicroX
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bmc.ctx = ctx
+	_, _, err = bmc.UpdateFirmware(images.URL, "SMT_X10_388.bin")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected error %v: found %v", context.Canceled, err)
+	}
+
+	if len(queries) != 0 {
+		t.Errorf("Expected no query: found %v", queries)
+	}
+}
+
+func TestFirmwareUpdateBMCFlashTimeout(t *testing.T) {
+	defer func(interval, timeout time.Duration) {
+		firmwarePollInterval, firmwareFlashTimeout = interval, timeout
+	}(firmwarePollInterval, firmwareFlashTimeout)
+	firmwarePollInterval, firmwareFlashTimeout = time.Millisecond, 20*time.Millisecond
+	defer func(answer []byte) { Answers["FW_UPDATE_PROGRESS.XML=(0,0)"] = answer }(Answers["FW_UPDATE_PROGRESS.XML=(0,0)"])
+	Answers["FW_UPDATE_PROGRESS.XML=(0,0)"] = []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="OK" PROGRESS="n/a"/> </IPMI>`)
+
+	path := t.TempDir() + "/SMT_X10_388.bin"
+	if err := ioutil.WriteFile(path, []byte("BMC_FIRMWARE"), 0o600); err != nil {
+		t.Fatalf("Found errors writing the firmware image %v", err)
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	mux.HandleFunc("/cgi/upload_firmware.cgi", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/cgi/op.cgi", func(w http.ResponseWriter, r *http.Request) {})
+
+	err = bmc.FirmwareUpdateBMC(context.TODO(), path)
+
+	var updateErr *bmclibErrs.FirmwareUpdateError
+	if !errors.As(err, &updateErr) || updateErr.Phase != bmclibErrs.FirmwareUpdatePhaseFlash {
+		t.Fatalf("Expected answer %v: found %v", bmclibErrs.FirmwareUpdatePhaseFlash, err)
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected error %v: found %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestFirmwareUpdateBMCRefused(t *testing.T) {
+	defer func(answer []byte) { Answers["FW_UPDATE_CHECK.XML=(0,0)"] = answer }(Answers["FW_UPDATE_CHECK.XML=(0,0)"])
+	Answers["FW_UPDATE_CHECK.XML=(0,0)"] = []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="INVALID_IMAGE"/> </IPMI>`)
+
+	path := t.TempDir() + "/SMT_X10_388.bin"
+	if err := ioutil.WriteFile(path, []byte("BMC_FIRMWARE"), 0o600); err != nil {
+		t.Fatalf("Found errors writing the firmware image %v", err)
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	mux.HandleFunc("/cgi/upload_firmware.cgi", func(w http.ResponseWriter, r *http.Request) {})
+	flashed := false
+	mux.HandleFunc("/cgi/op.cgi", func(w http.ResponseWriter, r *http.Request) { flashed = true })
+
+	err = bmc.FirmwareUpdateBMC(context.TODO(), path)
+
+	var updateErr *bmclibErrs.FirmwareUpdateError
+	if !errors.As(err, &updateErr) {
+		t.Fatalf("Expected a FirmwareUpdateError: found %v", err)
+	}
+
+	if updateErr.Phase != bmclibErrs.FirmwareUpdatePhaseVerify || !updateErr.RetrySafe() {
+		t.Errorf("Expected answer %v: found %v", bmclibErrs.FirmwareUpdatePhaseVerify, updateErr.Phase)
+	}
+
+	if flashed {
+		t.Errorf("Expected the refused image not to be flashed")
+	}
+
+	// the BMC is taken out of the update mode
+	if queries[len(queries)-1] != "FW_UPDATE_EXIT.XML=(0,0)" {
+		t.Errorf("Expected answer %v: found %v", "FW_UPDATE_EXIT.XML=(0,0)", queries[len(queries)-1])
+	}
+}
+
+func TestFirmwareUpdateBMCUpdateModeRefused(t *testing.T) {
+	defer func(answer []byte) { Answers["FW_UPDATE_ENTER.XML=(0,0)"] = answer }(Answers["FW_UPDATE_ENTER.XML=(0,0)"])
+	Answers["FW_UPDATE_ENTER.XML=(0,0)"] = []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="BUSY"/> </IPMI>`)
+
+	path := t.TempDir() + "/SMT_X10_388.bin"
+	if err := ioutil.WriteFile(path, []byte("BMC_FIRMWARE"), 0o600); err != nil {
+		t.Fatalf("Found errors writing the firmware image %v", err)
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	uploaded := false
+	mux.HandleFunc("/cgi/upload_firmware.cgi", func(w http.ResponseWriter, r *http.Request) { uploaded = true })
+
+	err = bmc.FirmwareUpdateBMC(context.TODO(), path)
+
+	var updateErr *bmclibErrs.FirmwareUpdateError
+	if !errors.As(err, &updateErr) {
+		t.Fatalf("Expected a FirmwareUpdateError: found %v", err)
+	}
+
+	// the BMC refusing the update mode is a prepare failure, nothing was written yet
+	if updateErr.Phase != bmclibErrs.FirmwareUpdatePhasePrepare || !updateErr.RetrySafe() || !errors.Is(err, bmclibErrs.ErrFirmwareUpload) {
+		t.Errorf("Expected answer %v: found %v", bmclibErrs.FirmwareUpdatePhasePrepare, updateErr.Phase)
+	}
+
+	if uploaded {
+		t.Errorf("Expected no upload once the update mode is refused")
+	}
+}
+
+// hostPowerControl switches the power state and the BIOS version answered by the test server
+// when the host is powered off and on, the caller restores the answers.
+func hostPowerControl(biosVersion string) func(query string) {
+	return func(query string) {
+		switch query {
+		case "POWER_INFO.XML=(1,0)":
+			Answers["POWER_INFO.XML=(0,0)"] = Answers[query]
+		case "POWER_INFO.XML=(1,1)":
+			Answers["POWER_INFO.XML=(0,0)"] = Answers[query]
+			Answers["SMBIOS_INFO.XML=(0,0)"] = bytes.Replace(Answers["SMBIOS_INFO.XML=(0,0)"], []byte(`VER="2.0"`), []byte(`VER="`+biosVersion+`"`), 1)
+		}
+	}
+}
+
+func TestFirmwareUpdateBIOS(t *testing.T) {
+	defer func(interval time.Duration) { firmwarePollInterval = interval }(firmwarePollInterval)
+	firmwarePollInterval = time.Millisecond
+	defer func(power, smbios []byte) {
+		Answers["POWER_INFO.XML=(0,0)"], Answers["SMBIOS_INFO.XML=(0,0)"] = power, smbios
+	}(Answers["POWER_INFO.XML=(0,0)"], Answers["SMBIOS_INFO.XML=(0,0)"])
+
+	image := []byte("BIOS_IMAGE")
+	path := t.TempDir() + "/X10DRT3_1.bin"
+	if err := ioutil.WriteFile(path, image, 0o600); err != nil {
+		t.Fatalf("Found errors writing the firmware image %v", err)
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+	onQuery = hostPowerControl("3.1")
+
+	var uploaded []byte
+	mux.HandleFunc("/cgi/upload_bios.cgi", func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("fw_image")
+		if err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		chunk, _ := ioutil.ReadAll(file)
+		uploaded = append(uploaded, chunk...)
+	})
+
+	var ops []string
+	var preserve string
+	mux.HandleFunc("/cgi/op.cgi", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		ops = append(ops, r.PostForm.Get("op"))
+		preserve = r.PostForm.Get("preserve_nvram")
+	})
+
+	err = bmc.FirmwareUpdateBIOS(context.TODO(), path)
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.FirmwareUpdateBIOS %v", err)
+	}
+
+	if string(uploaded) != string(image) {
+		t.Errorf("Expected answer %s: found %s", image, uploaded)
+	}
+
+	expectedOps := []string{"main_biosupdate"}
+	if !reflect.DeepEqual(ops, expectedOps) || preserve != "1" {
+		t.Errorf("Expected answer %v %v: found %v %v", expectedOps, "1", ops, preserve)
+	}
+
+	// the host is powered off before the update mode and back on once the image is flashed
+	var power []string
+	for _, query := range queries {
+		switch query {
+		case "POWER_INFO.XML=(1,0)", "POWER_INFO.XML=(1,1)", "BIOS_UPDATE_ENTER.XML=(0,0)", "BIOS_UPDATE_PROGRESS.XML=(0,0)":
+			power = append(power, query)
+		}
+	}
+	expectedPower := []string{"POWER_INFO.XML=(1,0)", "BIOS_UPDATE_ENTER.XML=(0,0)", "BIOS_UPDATE_PROGRESS.XML=(0,0)", "POWER_INFO.XML=(1,1)"}
+	if !reflect.DeepEqual(power, expectedPower) {
+		t.Errorf("Expected answer %v: found %v", expectedPower, power)
+	}
+
+	version, err := bmc.BiosVersion()
+	if err != nil || version != "3.1" {
+		t.Errorf("Expected answer %v: found %v %v", "3.1", version, err)
+	}
+}
+
+func TestFirmwareUpdateBIOSHostOff(t *testing.T) {
+	defer func(interval time.Duration) { firmwarePollInterval = interval }(firmwarePollInterval)
+	firmwarePollInterval = time.Millisecond
+	defer func(power, smbios []byte) {
+		Answers["POWER_INFO.XML=(0,0)"], Answers["SMBIOS_INFO.XML=(0,0)"] = power, smbios
+	}(Answers["POWER_INFO.XML=(0,0)"], Answers["SMBIOS_INFO.XML=(0,0)"])
+	Answers["POWER_INFO.XML=(0,0)"] = Answers["POWER_INFO.XML=(1,0)"]
+
+	path := t.TempDir() + "/X10DRT3_1.bin"
+	if err := ioutil.WriteFile(path, []byte("BIOS_IMAGE"), 0o600); err != nil {
+		t.Fatalf("Found errors writing the firmware image %v", err)
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+	onQuery = hostPowerControl("3.1")
+
+	mux.HandleFunc("/cgi/upload_bios.cgi", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/cgi/op.cgi", func(w http.ResponseWriter, r *http.Request) {})
+
+	err = bmc.FirmwareUpdateBIOS(context.TODO(), path)
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.FirmwareUpdateBIOS %v", err)
+	}
+
+	// the host is booted once the image is flashed to check the version and powered off again
+	var power []string
+	for _, query := range queries {
+		switch query {
+		case "POWER_INFO.XML=(1,0)", "POWER_INFO.XML=(1,1)", "BIOS_UPDATE_ENTER.XML=(0,0)", "BIOS_UPDATE_PROGRESS.XML=(0,0)":
+			power = append(power, query)
+		}
+	}
+	expectedPower := []string{"BIOS_UPDATE_ENTER.XML=(0,0)", "BIOS_UPDATE_PROGRESS.XML=(0,0)", "POWER_INFO.XML=(1,1)", "POWER_INFO.XML=(1,0)"}
+	if !reflect.DeepEqual(power, expectedPower) {
+		t.Errorf("Expected answer %v: found %v", expectedPower, power)
+	}
+
+	version, err := bmc.BiosVersion()
+	if err != nil || version != "3.1" {
+		t.Errorf("Expected answer %v: found %v %v", "3.1", version, err)
+	}
+
+	state, err := bmc.PowerState()
+	if err != nil || state != "off" {
+		t.Errorf("Expected answer %v: found %v %v", "off", state, err)
+	}
+}
+
+func TestFirmwareUpdateBIOSRefused(t *testing.T) {
+	defer func(interval time.Duration) { firmwarePollInterval = interval }(firmwarePollInterval)
+	firmwarePollInterval = time.Millisecond
+	defer func(power, smbios, check []byte) {
+		Answers["POWER_INFO.XML=(0,0)"], Answers["SMBIOS_INFO.XML=(0,0)"], Answers["BIOS_UPDATE_CHECK.XML=(0,0)"] = power, smbios, check
+	}(Answers["POWER_INFO.XML=(0,0)"], Answers["SMBIOS_INFO.XML=(0,0)"], Answers["BIOS_UPDATE_CHECK.XML=(0,0)"])
+	Answers["BIOS_UPDATE_CHECK.XML=(0,0)"] = []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="INVALID_IMAGE"/> </IPMI>`)
+
+	path := t.TempDir() + "/X10DRT3_1.bin"
+	if err := ioutil.WriteFile(path, []byte("BIOS_IMAGE"), 0o600); err != nil {
+		t.Fatalf("Found errors writing the firmware image %v", err)
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+	onQuery = hostPowerControl("2.0")
+
+	mux.HandleFunc("/cgi/upload_bios.cgi", func(w http.ResponseWriter, r *http.Request) {})
+	flashed := false
+	mux.HandleFunc("/cgi/op.cgi", func(w http.ResponseWriter, r *http.Request) { flashed = true })
+
+	err = bmc.FirmwareUpdateBIOS(context.TODO(), path)
+
+	var updateErr *bmclibErrs.FirmwareUpdateError
+	if !errors.As(err, &updateErr) {
+		t.Fatalf("Expected a FirmwareUpdateError: found %v", err)
+	}
+
+	if updateErr.Phase != bmclibErrs.FirmwareUpdatePhaseVerify || !updateErr.RetrySafe() {
+		t.Errorf("Expected answer %v: found %v", bmclibErrs.FirmwareUpdatePhaseVerify, updateErr.Phase)
+	}
+
+	if flashed {
+		t.Errorf("Expected the refused image not to be flashed")
+	}
+
+	// the BMC leaves the update mode and the host is powered back on
+	expected := []string{"BIOS_UPDATE_EXIT.XML=(0,0)", "POWER_INFO.XML=(1,1)"}
+	var found []string
+	for _, query := range queries {
+		if query == expected[0] || query == expected[1] {
+			found = append(found, query)
+		}
+	}
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("Expected answer %v: found %v", expected, found)
+	}
+
+	state, err := bmc.PowerState()
+	if err != nil || state != "on" {
+		t.Errorf("Expected answer %v: found %v %v", "on", state, err)
+	}
+}
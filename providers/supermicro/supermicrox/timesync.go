@@ -0,0 +1,20 @@
+package supermicrox
+
+import (
+	"context"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the TimeSyncVerifier interface.
+var _ devices.TimeSyncVerifier = (*SupermicroX)(nil)
+
+// VerifyTimeSync isn't supported on SupermicroX yet
+func (s *SupermicroX) VerifyTimeSync(ctx context.Context, tolerance time.Duration) (offset time.Duration, inSync bool, err error) {
+	defer s.wrapError("VerifyTimeSync", &err)
+
+	return 0, false, errors.NewFeatureUnsupportedError("time sync verification", s.Vendor(), s.HardwareType())
+}
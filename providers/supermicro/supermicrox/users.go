@@ -0,0 +1,233 @@
+package supermicrox
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+	"github.com/bmc-toolbox/bmclib/providers/supermicro"
+	"github.com/google/go-querystring/query"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the UserManager interface.
+var _ devices.UserManager = (*SupermicroX)(nil)
+
+// userPrivileges maps the user roles to the ipmi privilege levels expected by config_user.cgi
+var userPrivileges = map[devices.UserRole]int{
+	devices.UserRoleAdmin:    4,
+	devices.UserRoleOperator: 3,
+	devices.UserRoleReadOnly: 2,
+}
+
+// userSlots returns all the user account slots of the bmc, slot 0 is reserved.
+func (s *SupermicroX) userSlots() (slots []*supermicro.UserAccounts, err error) {
+	ipmi, err := s.query("CONFIG_INFO.XML=(0,0)")
+	if err != nil {
+		return slots, fmt.Errorf("%w: %s", errors.ErrRetrievingUserAccounts, err)
+	}
+
+	if ipmi.ConfigInfo == nil {
+		return slots, errors.ErrRetrievingUserAccounts
+	}
+
+	return ipmi.ConfigInfo.UserAccounts, nil
+}
+
+// findUser returns the slot of the user account with the given name
+func (s *SupermicroX) findUser(name string) (id int, account *supermicro.UserAccounts, err error) {
+	slots, err := s.userSlots()
+	if err != nil {
+		return id, account, err
+	}
+
+	for id, account := range slots {
+		if id > 0 && strings.TrimSpace(account.Name) == name {
+			return id, account, nil
+		}
+	}
+
+	return id, account, fmt.Errorf("%w: %s", errors.ErrUserAccountNotFound, name)
+}
+
+// lookupUser returns the slot of the user account with the given name, in dry-run the
+// accounts aren't read, as in User, and the slot is picked when the change is applied.
+func (s *SupermicroX) lookupUser(name string) (id int, account *supermicro.UserAccounts, err error) {
+	if s.dryRun {
+		return 0, &supermicro.UserAccounts{}, nil
+	}
+
+	return s.findUser(name)
+}
+
+// configUser posts a user account slot configuration to the bmc,
+// it's recorded instead in dry-run, see DryRunChanges.
+func (s *SupermicroX) configUser(configUser ConfigUser) (err error) {
+	endpoint := "config_user.cgi"
+	form, _ := query.Values(configUser)
+	if s.dryRun {
+		s.recordChange("User", endpoint, form)
+		return nil
+	}
+
+	_, err = s.post(endpoint, &form, []byte{}, "")
+	if err != nil {
+		return fmt.Errorf("%w: %s", errors.ErrUserAccountUpdate, err)
+	}
+
+	return nil
+}
+
+// ListUsers returns the user accounts configured on the bmc,
+// ListUsers implements the UserManager interface.
+func (s *SupermicroX) ListUsers() (users []devices.User, err error) {
+	defer s.wrapError("ListUsers", &err)
+
+	slots, err := s.userSlots()
+	if err != nil {
+		return users, err
+	}
+
+	for id, account := range slots {
+		name := strings.TrimSpace(account.Name)
+		if id == 0 || name == "" {
+			continue
+		}
+
+		users = append(users, devices.User{
+			ID:      id,
+			Name:    name,
+			Role:    devices.NormalizeUserRole(account.Access),
+			Enabled: account.Status != "0",
+		})
+	}
+
+	return users, nil
+}
+
+// CreateUser creates a user account in the first free slot of the bmc,
+// CreateUser implements the UserManager interface.
+func (s *SupermicroX) CreateUser(user devices.User) (err error) {
+	defer s.wrapError("CreateUser", &err)
+
+	if user.Name == "" || user.Password == "" {
+		return errors.ErrUserParamsRequired
+	}
+
+	err = devices.ValidatePassword(user.Password, s.passwordPolicy)
+	if err != nil {
+		return err
+	}
+
+	privilege, ok := userPrivileges[user.Role]
+	if !ok {
+		return fmt.Errorf("%w: %s", errors.ErrInvalidUserRole, user.Role)
+	}
+
+	var userID int
+	if !s.dryRun {
+		slots, err := s.userSlots()
+		if err != nil {
+			return err
+		}
+
+		for id, account := range slots {
+			name := strings.TrimSpace(account.Name)
+			if id > 0 && name == user.Name {
+				return fmt.Errorf("%w: %s", errors.ErrUserAccountExists, user.Name)
+			}
+			if id > 0 && name == "" && userID == 0 {
+				userID = id
+			}
+		}
+
+		if userID == 0 {
+			return errors.ErrNoUserSlotsAvailable
+		}
+	}
+
+	return s.configUser(ConfigUser{
+		Username:     user.Name,
+		UserID:       userID,
+		Password:     user.Password,
+		NewPrivilege: privilege,
+	})
+}
+
+// ModifyUser sets the privilege level of an existing user account, its password is changed as well
+// when user.Password is set and kept otherwise, ModifyUser implements the UserManager interface.
+func (s *SupermicroX) ModifyUser(user devices.User) (err error) {
+	defer s.wrapError("ModifyUser", &err)
+
+	if user.Name == "" {
+		return errors.ErrUserParamsRequired
+	}
+
+	if user.Password != "" {
+		err = devices.ValidatePassword(user.Password, s.passwordPolicy)
+		if err != nil {
+			return err
+		}
+	}
+
+	privilege, ok := userPrivileges[user.Role]
+	if !ok {
+		return fmt.Errorf("%w: %s", errors.ErrInvalidUserRole, user.Role)
+	}
+
+	userID, _, err := s.lookupUser(user.Name)
+	if err != nil {
+		return err
+	}
+
+	return s.configUser(ConfigUser{
+		Username:     user.Name,
+		UserID:       userID,
+		Password:     user.Password,
+		NewPrivilege: privilege,
+	})
+}
+
+// DeleteUser removes a user account from the bmc by clearing its slot,
+// DeleteUser implements the UserManager interface.
+func (s *SupermicroX) DeleteUser(name string) (err error) {
+	defer s.wrapError("DeleteUser", &err)
+
+	userID, _, err := s.lookupUser(name)
+	if err != nil {
+		return err
+	}
+
+	return s.configUser(ConfigUser{UserID: userID})
+}
+
+// ChangePassword sets the password of a user account, keeping its privilege level,
+// ChangePassword implements the UserManager interface.
+func (s *SupermicroX) ChangePassword(name string, password string) (err error) {
+	defer s.wrapError("ChangePassword", &err)
+
+	if name == "" || password == "" {
+		return errors.ErrUserParamsRequired
+	}
+
+	err = devices.ValidatePassword(password, s.passwordPolicy)
+	if err != nil {
+		return err
+	}
+
+	userID, account, err := s.lookupUser(name)
+	if err != nil {
+		return err
+	}
+
+	privilege, _ := strconv.Atoi(account.Access)
+
+	return s.configUser(ConfigUser{
+		Username:     name,
+		UserID:       userID,
+		Password:     password,
+		NewPrivilege: privilege,
+	})
+}
@@ -0,0 +1,33 @@
+package supermicrox
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the VirtualMediaController interface.
+var _ devices.VirtualMediaController = (*SupermicroX)(nil)
+
+// MountVirtualMedia isn't supported on SupermicroX yet
+func (s *SupermicroX) MountVirtualMedia(ctx context.Context, kind devices.MediaKind, image string) (err error) {
+	defer s.wrapError("MountVirtualMedia", &err)
+
+	return errors.NewFeatureUnsupportedError("virtual media", s.Vendor(), s.HardwareType())
+}
+
+// UnmountVirtualMedia isn't supported on SupermicroX yet
+func (s *SupermicroX) UnmountVirtualMedia(ctx context.Context, kind devices.MediaKind) (err error) {
+	defer s.wrapError("UnmountVirtualMedia", &err)
+
+	return errors.NewFeatureUnsupportedError("virtual media", s.Vendor(), s.HardwareType())
+}
+
+// VirtualMediaStatus isn't supported on SupermicroX yet
+func (s *SupermicroX) VirtualMediaStatus(ctx context.Context) (media []devices.VirtualMedia, err error) {
+	defer s.wrapError("VirtualMediaStatus", &err)
+
+	return nil, errors.NewFeatureUnsupportedError("virtual media", s.Vendor(), s.HardwareType())
+}
@@ -4,11 +4,21 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+	"github.com/bmc-toolbox/bmclib/internal/httpclient"
 	"github.com/bmc-toolbox/bmclib/internal/ipmi"
+	"github.com/bmc-toolbox/bmclib/providers/supermicro"
 )
 
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the PowerController interface.
+var _ devices.PowerController = (*SupermicroX)(nil)
+
 // PowerCycle reboots the machine via bmc
 func (s *SupermicroX) PowerCycle() (status bool, err error) {
+	defer s.wrapError("PowerCycle", &err)
+
 	i, err := ipmi.New(s.username, s.password, s.ip)
 	if err != nil {
 		return status, err
@@ -19,6 +29,8 @@ func (s *SupermicroX) PowerCycle() (status bool, err error) {
 
 // PowerCycleBmc reboots the bmc we are connected to
 func (s *SupermicroX) PowerCycleBmc() (status bool, err error) {
+	defer s.wrapError("PowerCycleBmc", &err)
+
 	i, err := ipmi.New(s.username, s.password, s.ip)
 	if err != nil {
 		return status, err
@@ -29,6 +41,8 @@ func (s *SupermicroX) PowerCycleBmc() (status bool, err error) {
 
 // PowerOn power on the machine via bmc
 func (s *SupermicroX) PowerOn() (status bool, err error) {
+	defer s.wrapError("PowerOn", &err)
+
 	i, err := ipmi.New(s.username, s.password, s.ip)
 	if err != nil {
 		return status, err
@@ -39,6 +53,8 @@ func (s *SupermicroX) PowerOn() (status bool, err error) {
 
 // PowerOff power off the machine via bmc
 func (s *SupermicroX) PowerOff() (status bool, err error) {
+	defer s.wrapError("PowerOff", &err)
+
 	i, err := ipmi.New(s.username, s.password, s.ip)
 	if err != nil {
 		return status, err
@@ -47,8 +63,22 @@ func (s *SupermicroX) PowerOff() (status bool, err error) {
 	return status, err
 }
 
+// PowerReset hard resets the machine via bmc, without going through a power off
+func (s *SupermicroX) PowerReset() (status bool, err error) {
+	defer s.wrapError("PowerReset", &err)
+
+	i, err := ipmi.New(s.username, s.password, s.ip)
+	if err != nil {
+		return status, err
+	}
+	status, err = i.PowerReset(context.Background())
+	return status, err
+}
+
 // PxeOnce makes the machine to boot via pxe once
 func (s *SupermicroX) PxeOnce() (status bool, err error) {
+	defer s.wrapError("PxeOnce", &err)
+
 	i, err := ipmi.New(s.username, s.password, s.ip)
 	if err != nil {
 		return status, err
@@ -62,6 +92,8 @@ func (s *SupermicroX) PxeOnce() (status bool, err error) {
 
 // IsOn tells if a machine is currently powered on
 func (s *SupermicroX) IsOn() (status bool, err error) {
+	defer s.wrapError("IsOn", &err)
+
 	i, err := ipmi.New(s.username, s.password, s.ip)
 	if err != nil {
 		return status, err
@@ -70,11 +102,48 @@ func (s *SupermicroX) IsOn() (status bool, err error) {
 	return status, err
 }
 
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the FirmwareUpdater interface.
+var _ devices.FirmwareUpdater = (*SupermicroX)(nil)
+
 // UpdateFirmware updates the bmc firmware
 func (s *SupermicroX) UpdateFirmware(source, file string) (status bool, output string, err error) {
-	return false, "Not yet implemented", fmt.Errorf("not yet implemented")
+	defer s.wrapError("UpdateFirmware", &err)
+
+	return s.UpdateFirmwareWithProgress(source, file, nil)
+}
+
+// UpdateFirmwareWithProgress downloads the bmc firmware image source/file, source being an http(s) URL,
+// and flashes it, reporting its progress to the optional callback. The update stops once the context
+// the SupermicroX was created with is done.
+func (s *SupermicroX) UpdateFirmwareWithProgress(source, file string, progress func(devices.FirmwareProgress)) (status bool, output string, err error) {
+	defer s.wrapError("UpdateFirmwareWithProgress", &err)
+
+	ctx := s.context()
+
+	s.log.V(1).Info("downloading the firmware", "step", "FirmwareUpdate", "ip", s.ip, "source", source, "file", file)
+
+	// the image is fetched with the TLS, proxy and timeout options of the bmc connection, not its session
+	client, err := httpclient.Build(s.httpClientSetupFuncs...)
+	if err != nil {
+		return false, "", errors.NewFirmwareUpdateError(errors.FirmwareUpdatePhasePrepare, err)
+	}
+
+	image, err := supermicro.DownloadFirmware(ctx, client, source, file)
+	if err != nil {
+		return false, "", errors.NewFirmwareUpdateError(errors.FirmwareUpdatePhasePrepare, err)
+	}
+
+	version, err := s.flasher().UpdateBMC(ctx, image, progress)
+	if err != nil {
+		return false, "", err
+	}
+
+	return true, fmt.Sprintf("bmc firmware updated to %s", version), nil
 }
 
 func (s *SupermicroX) CheckFirmwareVersion() (version string, err error) {
+	defer s.wrapError("CheckFirmwareVersion", &err)
+
 	return "Not yet implemented", fmt.Errorf("not yet implemented")
 }
@@ -41,21 +41,29 @@ func (s *SupermicroX) Resources() []string {
 // ApplyCfg implements the Bmc interface
 // this is to be deprecated.
 func (s *SupermicroX) ApplyCfg(config *cfgresources.ResourcesConfig) (err error) {
+	defer s.wrapError("ApplyCfg", &err)
+
 	return err
 }
 
 // Power implemented the Configure interface
 func (s *SupermicroX) Power(cfg *cfgresources.Power) (err error) {
+	defer s.wrapError("Power", &err)
+
 	return err
 }
 
 // SetLicense implements the Configure interface.
 func (s *SupermicroX) SetLicense(cfg *cfgresources.License) (err error) {
+	defer s.wrapError("SetLicense", &err)
+
 	return err
 }
 
 // Bios implements the Configure interface.
 func (s *SupermicroX) Bios(cfg *cfgresources.Bios) (err error) {
+	defer s.wrapError("Bios", &err)
+
 	return err
 }
 
@@ -102,6 +110,8 @@ func (s *SupermicroX) queryUserAccounts() (userAccounts map[string]int, err erro
 // supermicro user accounts start with 1, account 0 which is a large empty string :\.
 // nolint: gocyclo
 func (s *SupermicroX) User(users []*cfgresources.User) (err error) {
+	defer s.wrapError("User", &err)
+
 	currentUsers, err := s.queryUserAccounts()
 	if err != nil {
 		msg := "Unable to query current user accounts."
@@ -116,6 +126,12 @@ func (s *SupermicroX) User(users []*cfgresources.User) (err error) {
 
 	numUsers := len(currentUsers)
 	for _, user := range users {
+		log.WithFields(log.Fields{
+			"IP":   s.ip,
+			"Step": "applyUserParams",
+			"User": user.Redacted(),
+		}).Debug("applying user resource")
+
 		/* TODO x11 only has 10 users available
 		if numUsers > 10 {
 			log.WithFields(log.Fields{
@@ -184,7 +200,7 @@ func (s *SupermicroX) User(users []*cfgresources.User) (err error) {
 			if err == nil {
 				err = fmt.Errorf("Received a %d status code from the POST request to %s.", statusCode, endpoint)
 			} else {
-				err = fmt.Errorf("POST request to %s failed with error: %s", endpoint, err.Error())
+				err = fmt.Errorf("POST request to %s failed with error: %w", endpoint, err)
 			}
 
 			log.WithFields(log.Fields{
@@ -220,6 +236,8 @@ func (s *SupermicroX) User(users []*cfgresources.User) (err error) {
 // Network method implements the Configure interface
 // applies various network parameters.
 func (s *SupermicroX) Network(cfg *cfgresources.Network) (reset bool, err error) {
+	defer s.wrapError("Network", &err)
+
 	sshPort := 22
 
 	if cfg.SSHPort != 0 && cfg.SSHPort != sshPort {
@@ -252,7 +270,7 @@ func (s *SupermicroX) Network(cfg *cfgresources.Network) (reset bool, err error)
 		if err == nil {
 			err = fmt.Errorf("Received a %d status code from the POST request to %s.", statusCode, endpoint)
 		} else {
-			err = fmt.Errorf("POST request to %s failed with error: %s", endpoint, err.Error())
+			err = fmt.Errorf("POST request to %s failed with error: %w", endpoint, err)
 		}
 
 		log.WithFields(log.Fields{
@@ -276,6 +294,8 @@ func (s *SupermicroX) Network(cfg *cfgresources.Network) (reset bool, err error)
 // Ntp applies NTP configuration params
 // Ntp implements the Configure interface.
 func (s *SupermicroX) Ntp(cfg *cfgresources.Ntp) (err error) {
+	defer s.wrapError("Ntp", &err)
+
 	var enable string
 	if cfg.Server1 == "" {
 		log.WithFields(log.Fields{
@@ -350,7 +370,7 @@ func (s *SupermicroX) Ntp(cfg *cfgresources.Ntp) (err error) {
 		if err == nil {
 			err = fmt.Errorf("Received a %d status code from the POST request to %s.", statusCode, endpoint)
 		} else {
-			err = fmt.Errorf("POST request to %s failed with error: %s", endpoint, err.Error())
+			err = fmt.Errorf("POST request to %s failed with error: %w", endpoint, err)
 		}
 
 		log.WithFields(log.Fields{
@@ -375,7 +395,9 @@ func (s *SupermicroX) Ntp(cfg *cfgresources.Ntp) (err error) {
 // Ldap implements the Configure interface.
 // Configuration for LDAP is applied in the LdapGroup method,
 // since supermicros just support a single LDAP group.
-func (s *SupermicroX) Ldap(cfgLdap *cfgresources.Ldap) error {
+func (s *SupermicroX) Ldap(cfgLdap *cfgresources.Ldap) (err error) {
+	defer s.wrapError("Ldap", &err)
+
 	return nil
 }
 
@@ -384,6 +406,8 @@ func (s *SupermicroX) Ldap(cfgLdap *cfgresources.Ldap) error {
 // SuperMicro does not have any separate configuration for LDAP groups, just for generic LDAP.
 // nolint: gocyclo
 func (s *SupermicroX) LdapGroups(cfgGroups []*cfgresources.LdapGroup, cfgLdap *cfgresources.Ldap) (err error) {
+	defer s.wrapError("LdapGroups", &err)
+
 	if cfgLdap.Server == "" {
 		msg := "Ldap resource parameter Server required but not declared."
 		log.WithFields(log.Fields{
@@ -494,7 +518,7 @@ func (s *SupermicroX) LdapGroups(cfgGroups []*cfgresources.LdapGroup, cfgLdap *c
 			if err == nil {
 				err = fmt.Errorf("Received a %d status code from the POST request to %s.", statusCode, endpoint)
 			} else {
-				err = fmt.Errorf("POST request to %s failed with error: %s", endpoint, err.Error())
+				err = fmt.Errorf("POST request to %s failed with error: %w", endpoint, err)
 			}
 			msg := "POST request to set Ldap config returned error."
 			log.WithFields(log.Fields{
@@ -520,6 +544,8 @@ func (s *SupermicroX) LdapGroups(cfgGroups []*cfgresources.LdapGroup, cfgLdap *c
 // Syslog implements the Configure interface
 // this also enables alerts from the BMC
 func (s *SupermicroX) Syslog(cfg *cfgresources.Syslog) (err error) {
+	defer s.wrapError("Syslog", &err)
+
 	var port int
 
 	if cfg.Server == "" {
@@ -573,7 +599,7 @@ func (s *SupermicroX) Syslog(cfg *cfgresources.Syslog) (err error) {
 		if err == nil {
 			err = fmt.Errorf("Received a %d status code from the POST request to %s.", statusCode, endpoint)
 		} else {
-			err = fmt.Errorf("POST request to %s failed with error: %s", endpoint, err.Error())
+			err = fmt.Errorf("POST request to %s failed with error: %w", endpoint, err)
 		}
 
 		log.WithFields(log.Fields{
@@ -598,7 +624,7 @@ func (s *SupermicroX) Syslog(cfg *cfgresources.Syslog) (err error) {
 		if err == nil {
 			err = fmt.Errorf("Received a %d status code from the POST request to %s.", statusCode, endpoint)
 		} else {
-			err = fmt.Errorf("POST request to %s failed with error: %s", endpoint, err.Error())
+			err = fmt.Errorf("POST request to %s failed with error: %w", endpoint, err)
 		}
 
 		log.WithFields(log.Fields{
@@ -621,7 +647,9 @@ func (s *SupermicroX) Syslog(cfg *cfgresources.Syslog) (err error) {
 
 // GenerateCSR generates a CSR request on the BMC.
 // GenerateCSR implements the Configure interface.
-func (s *SupermicroX) GenerateCSR(cert *cfgresources.HTTPSCertAttributes) ([]byte, error) {
+func (s *SupermicroX) GenerateCSR(cert *cfgresources.HTTPSCertAttributes) (csr []byte, err error) {
+	defer s.wrapError("GenerateCSR", &err)
+
 	return []byte{}, nil
 }
 
@@ -632,7 +660,9 @@ func (s *SupermicroX) GenerateCSR(cert *cfgresources.HTTPSCertAttributes) ([]byt
 // 3. Get the BMC to validate the certificate: SSL_VALIDATE.XML	(0,0)
 // 4. delay for a second
 // 5. Request for the current: SSL_STATUS.XML	(0,0)
-func (s *SupermicroX) UploadHTTPSCert(cert []byte, certFileName string, key []byte, keyFileName string) (bool, error) {
+func (s *SupermicroX) UploadHTTPSCert(cert []byte, certFileName string, key []byte, keyFileName string) (reset bool, err error) {
+	defer s.wrapError("UploadHTTPSCert", &err)
+
 	endpoint := "upload_ssl.cgi"
 
 	// setup a buffer for our multipart form
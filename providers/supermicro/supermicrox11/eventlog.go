@@ -0,0 +1,41 @@
+package supermicrox11
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+	"github.com/bmc-toolbox/bmclib/internal/ipmi"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the EventLogReader interface.
+var _ devices.EventLogReader = (*SupermicroX)(nil)
+
+// GetEventLog returns the entries of the system event log (SEL) of the bmc
+func (s *SupermicroX) GetEventLog() (entries []devices.EventLogEntry, err error) {
+	defer s.wrapError("GetEventLog", &err)
+
+	ipmi, err := s.query("op=SEL_INFO.XML&r=(1,c0)")
+	if err != nil {
+		return entries, err
+	}
+
+	if ipmi.SelInfo == nil {
+		return entries, errors.ErrUnableToReadData
+	}
+
+	return ipmi.SelInfo.EventLog()
+}
+
+// ClearEventLog clears the system event log (SEL) of the bmc via ipmi
+func (s *SupermicroX) ClearEventLog() (err error) {
+	defer s.wrapError("ClearEventLog", &err)
+
+	i, err := ipmi.New(s.username, s.password, s.ip)
+	if err != nil {
+		return err
+	}
+	_, err = i.ClearSystemEventLog(context.Background())
+	return err
+}
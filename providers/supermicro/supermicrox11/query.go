@@ -15,7 +15,9 @@ import (
 // CurrentHTTPSCert returns the current x509 certficates configured on the BMC
 // the bool value returned is set to true if the BMC support CSR generation.
 // CurrentHTTPSCert implements the Configure interface.
-func (s *SupermicroX) CurrentHTTPSCert() ([]*x509.Certificate, bool, error) {
+func (s *SupermicroX) CurrentHTTPSCert() (certs []*x509.Certificate, renew bool, err error) {
+	defer s.wrapError("CurrentHTTPSCert", &err)
+
 	dialer := &net.Dialer{
 		Timeout: time.Duration(10) * time.Second,
 	}
@@ -35,6 +37,8 @@ func (s *SupermicroX) CurrentHTTPSCert() ([]*x509.Certificate, bool, error) {
 // 2. sleep for 3 seconds to give ikvm time to ensure preview was captured
 // 3. request for preview.
 func (s *SupermicroX) Screenshot() (response []byte, extension string, err error) {
+	defer s.wrapError("Screenshot", &err)
+
 	postEndpoint := "CapturePreview.cgi"
 	getEndpoint := "cgi/url_redirect.cgi?"
 
@@ -42,7 +46,7 @@ func (s *SupermicroX) Screenshot() (response []byte, extension string, err error
 
 	// allow thumbnails only for supermicro x10s.
 	if s.HardwareType() != BmcType {
-		return response, extension, errors.ErrFeatureUnavailable
+		return response, extension, errors.NewFeatureUnsupportedError("screenshot", s.Vendor(), s.HardwareType())
 	}
 
 	tzLocation, _ := time.LoadLocation("CET")
@@ -70,7 +74,7 @@ func (s *SupermicroX) Screenshot() (response []byte, extension string, err error
 	}
 
 	if statusCode != 200 {
-		return response, extension, fmt.Errorf("Non-200 response from endpoint %s: %d!", postEndpoint, statusCode)
+		return response, extension, fmt.Errorf("endpoint %s returned status code %d: %w", postEndpoint, statusCode, errors.ErrNon200Response)
 	}
 
 	time.Sleep(3 * time.Second)
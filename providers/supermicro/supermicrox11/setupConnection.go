@@ -7,7 +7,6 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"strings"
 
@@ -53,7 +52,7 @@ func (s *SupermicroX) httpLogin() (err error) {
 	defer resp.Body.Close()
 
 	if !strings.Contains(string(payload), "../cgi/url_redirect.cgi?url_name=mainmenu") {
-		return errors.ErrLoginFailed
+		return fmt.Errorf("login to %s rejected: %w", s.ip, errors.ErrLoginFailed)
 	}
 
 	for _, cookie := range resp.Cookies() {
@@ -71,6 +70,8 @@ func (s *SupermicroX) httpLogin() (err error) {
 
 // Close closes the connection properly
 func (s *SupermicroX) Close(ctx context.Context) (err error) {
+	defer s.wrapError("Close", &err)
+
 	if s.httpClient != nil {
 		bmcURL := fmt.Sprintf("https://%s/cgi/logout.cgi", s.ip)
 		log.WithFields(log.Fields{"step": "bmc connection", "vendor": supermicro.VendorID, "ip": s.ip}).Debug("logout from bmc")
@@ -91,7 +92,7 @@ func (s *SupermicroX) Close(ctx context.Context) (err error) {
 		}
 		if log.GetLevel() == log.TraceLevel {
 			log.Trace(fmt.Sprintf("%s/cgi/%s", bmcURL, s.ip))
-			dump, err := httputil.DumpRequestOut(req, true)
+			dump, err := httpclient.DumpRequestOut(req, true)
 			if err == nil {
 				log.WithFields(log.Fields{
 					"type": "Request",
@@ -111,7 +112,7 @@ func (s *SupermicroX) Close(ctx context.Context) (err error) {
 
 		if log.GetLevel() == log.TraceLevel {
 			log.Trace(fmt.Sprintf("%s/cgi/%s", bmcURL, s.ip))
-			dump, err := httputil.DumpRequestOut(req, true)
+			dump, err := httpclient.DumpRequestOut(req, true)
 			if err == nil {
 				log.WithFields(log.Fields{
 					"type": "Request",
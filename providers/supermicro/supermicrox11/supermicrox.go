@@ -8,14 +8,15 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bmc-toolbox/bmclib/devices"
 	"github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bmc-toolbox/bmclib/internal/httpclient"
+	"github.com/bmc-toolbox/bmclib/providers"
 	"github.com/go-logr/logr"
 
 	"github.com/bmc-toolbox/bmclib/providers/supermicro"
@@ -39,6 +40,10 @@ type SupermicroX struct {
 	ctx                  context.Context
 	log                  logr.Logger
 	httpClientSetupFuncs []func(*http.Client)
+	// preserveConfig keeps the BMC configuration, SDR and SSL certificate across firmware updates
+	preserveConfig bool
+	// passwordPolicy is checked by the user management before a password is sent to the BMC
+	passwordPolicy devices.PasswordPolicy
 }
 
 // SupermicroXOption is a type that can configure a *SupermicroX
@@ -52,6 +57,125 @@ func WithSecureTLS(rootCAs *x509.CertPool) SupermicroXOption {
 	}
 }
 
+// WithInsecureTLS skips the verification of the BMC certificate, which is otherwise
+// verified against the system CAs. Use it for BMCs serving self-signed certificates.
+func WithInsecureTLS() SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithInsecureTLS())
+	}
+}
+
+// WithSecureTLSFromFile enforces trusted TLS connections using the CA certificates of a PEM file.
+// Requests fail with a loading error when the file is missing or holds no valid certificate.
+func WithSecureTLSFromFile(caCertFile string) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithCACertFile(caCertFile))
+	}
+}
+
+// WithClientCert authenticates to the BMC with the given PEM encoded client certificate and key.
+func WithClientCert(certPEM, keyPEM []byte) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithClientCert(certPEM, keyPEM))
+	}
+}
+
+// WithObserver calls observer after each HTTP round-trip made to the BMC,
+// to collect metrics or logs.
+func WithObserver(observer func(providers.RequestInfo)) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithObserver(observer))
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the HTTP requests made to the BMC,
+// it defaults to bmclib/<version>.
+func WithUserAgent(ua string) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithUserAgent(ua))
+	}
+}
+
+// WithForceHTTP1 restricts the HTTP requests made to the BMC to HTTP/1.1,
+// for BMC web servers returning garbled responses over HTTP/2.
+func WithForceHTTP1() SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithForceHTTP1())
+	}
+}
+
+// WithMaxResponseBytes bounds the size of the responses read from the BMC,
+// it defaults to httpclient.DefaultMaxResponseBytes.
+func WithMaxResponseBytes(n int64) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithMaxResponseBytes(n))
+	}
+}
+
+// WithDigestAuth answers the HTTP Digest authentication challenges of the BMC
+// with the credentials of the SupermicroX, for firmware requiring digest auth.
+func WithDigestAuth() SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithDigestAuth(i.username, i.password))
+	}
+}
+
+// WithRequestLogging logs the method, host, path, status and duration of each HTTP request
+// made to the BMC at V(1) with the logger of the SupermicroX, lighter than the dumps logged at V(2).
+func WithRequestLogging() SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithRequestLogging(i.log))
+	}
+}
+
+// WithTimeout sets the overall timeout of the HTTP requests made to the BMC,
+// it defaults to httpclient.DefaultTimeout.
+func WithTimeout(d time.Duration) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithTimeout(d))
+	}
+}
+
+// WithProxy routes the HTTP requests made to the BMC through the given proxy,
+// by default the proxy settings of the environment are honored.
+func WithProxy(proxyURL string) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithProxy(proxyURL))
+	}
+}
+
+// WithRetry retries the idempotent HTTP requests made to the BMC on transient failures,
+// making up to attempts attempts with an exponential backoff.
+func WithRetry(attempts int, backoff time.Duration) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithRetry(attempts, backoff))
+	}
+}
+
+// WithRateLimit paces the HTTP requests made to the BMC to requestsPerSecond,
+// allowing bursts of up to burst requests.
+func WithRateLimit(requestsPerSecond float64, burst int) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.httpClientSetupFuncs = append(i.httpClientSetupFuncs, httpclient.WithRateLimit(requestsPerSecond, burst))
+	}
+}
+
+// WithPreserveConfig sets whether the BMC configuration, SDR and SSL certificate are kept
+// across the firmware updates, they are kept by default.
+func WithPreserveConfig(preserve bool) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.preserveConfig = preserve
+	}
+}
+
+// WithPasswordPolicy sets the policy the passwords are checked against before they're sent to the BMC,
+// devices.IPMIPasswordPolicy() by default.
+func WithPasswordPolicy(policy devices.PasswordPolicy) SupermicroXOption {
+	return func(i *SupermicroX) {
+		i.passwordPolicy = policy
+	}
+}
+
 // New returns a new SupermicroX instance ready to be used
 func New(ctx context.Context, ip string, username string, password string, log logr.Logger) (sm *SupermicroX, err error) {
 	return NewWithOptions(ctx, ip, username, password, log)
@@ -60,11 +184,13 @@ func New(ctx context.Context, ip string, username string, password string, log l
 // NewWithOptions returns a new SupermicroX with options ready to be used
 func NewWithOptions(ctx context.Context, ip string, username string, password string, log logr.Logger, opts ...SupermicroXOption) (*SupermicroX, error) {
 	sm := &SupermicroX{
-		ip:       ip,
-		username: username,
-		password: password,
-		ctx:      ctx,
-		log:      log,
+		ip:             ip,
+		username:       username,
+		password:       password,
+		ctx:            ctx,
+		log:            log,
+		preserveConfig: true,
+		passwordPolicy: devices.IPMIPasswordPolicy(),
 	}
 	for _, opt := range opts {
 		opt(sm)
@@ -72,8 +198,16 @@ func NewWithOptions(ctx context.Context, ip string, username string, password st
 	return sm, nil
 }
 
+// wrapError attaches the bmc identity to the error returned by a public method,
+// it's meant to be deferred with the named error result.
+func (s *SupermicroX) wrapError(operation string, err *error) {
+	*err = errors.NewBMCError(BmcType, s.ip, operation, *err)
+}
+
 // CheckCredentials verify whether the credentials are valid or not
 func (s *SupermicroX) CheckCredentials() (err error) {
+	defer s.wrapError("CheckCredentials", &err)
+
 	err = s.httpLogin()
 	if err != nil {
 		return err
@@ -81,6 +215,15 @@ func (s *SupermicroX) CheckCredentials() (err error) {
 	return err
 }
 
+// context returns the context the SupermicroX was created with, for the calls not taking one
+func (s *SupermicroX) context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+
+	return s.ctx
+}
+
 // get calls a given json endpoint of the ilo and returns the data
 func (s *SupermicroX) get(endpoint string) (payload []byte, err error) {
 	bmcURL := fmt.Sprintf("https://%s/%s", s.ip, endpoint)
@@ -103,7 +246,7 @@ func (s *SupermicroX) get(endpoint string) (payload []byte, err error) {
 	*/
 	req.AddCookie(s.sid)
 
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
 	s.log.V(2).Info("trace", "url", bmcURL, "requestDump", string(reqDump))
 
 	resp, err := s.httpClient.Do(req)
@@ -112,7 +255,7 @@ func (s *SupermicroX) get(endpoint string) (payload []byte, err error) {
 	}
 	defer resp.Body.Close()
 
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	s.log.V(2).Info("", "responseDump", string(respDump))
 
 	payload, err = ioutil.ReadAll(resp.Body)
@@ -167,7 +310,7 @@ func (s *SupermicroX) post(endpoint string, urlValues *url.Values, form []byte,
 	*/
 	req.AddCookie(s.sid)
 
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
 	s.log.V(2).Info("trace", "url", bmcURL, "requestDump", string(reqDump))
 
 	resp, err := s.httpClient.Do(req)
@@ -176,7 +319,7 @@ func (s *SupermicroX) post(endpoint string, urlValues *url.Values, form []byte,
 	}
 	defer resp.Body.Close()
 
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	s.log.V(2).Info("", "responseDump", string(respDump))
 
 	statusCode = resp.StatusCode
@@ -211,7 +354,7 @@ func (s *SupermicroX) query(requestType string) (ipmi *supermicro.IPMI, err erro
 		}
 	}
 
-	reqDump, _ := httputil.DumpRequestOut(req, true)
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
 	s.log.V(2).Info("trace", "url", bmcURL, "requestDump", string(reqDump))
 
 	resp, err := s.httpClient.Do(req)
@@ -224,7 +367,7 @@ func (s *SupermicroX) query(requestType string) (ipmi *supermicro.IPMI, err erro
 	if err != nil {
 		return ipmi, err
 	}
-	respDump, _ := httputil.DumpResponse(resp, true)
+	respDump, _ := httpclient.DumpResponse(resp, true)
 	s.log.V(2).Info("", "responseDump", string(respDump))
 
 	ipmi = &supermicro.IPMI{}
@@ -238,6 +381,8 @@ func (s *SupermicroX) query(requestType string) (ipmi *supermicro.IPMI, err erro
 
 // Serial returns the device serial
 func (s *SupermicroX) Serial() (serial string, err error) {
+	defer s.wrapError("Serial", &err)
+
 	ipmi, err := s.query("op=FRU_INFO.XML&r=(0,0)")
 	if err != nil {
 		return "", err
@@ -247,11 +392,13 @@ func (s *SupermicroX) Serial() (serial string, err error) {
 		return "", errors.ErrInvalidSerial
 	}
 
-	return strings.ToLower(ipmi.FruInfo.Board.SerialNum), nil
+	return devices.NormalizeSerial(ipmi.FruInfo.Board.SerialNum), nil
 }
 
 // ChassisSerial returns the serial number of the chassis where the blade is attached
 func (s *SupermicroX) ChassisSerial() (serial string, err error) {
+	defer s.wrapError("ChassisSerial", &err)
+
 	ipmi, err := s.query("op=FRU_INFO.XML&r=(0,0)")
 	if err != nil {
 		return serial, err
@@ -261,7 +408,7 @@ func (s *SupermicroX) ChassisSerial() (serial string, err error) {
 		return serial, errors.ErrInvalidSerial
 	}
 
-	return strings.ToLower(strings.TrimSpace(ipmi.FruInfo.Chassis.SerialNum)), err
+	return devices.NormalizeSerial(ipmi.FruInfo.Chassis.SerialNum), err
 }
 
 // HardwareType returns just Model id string - supermicrox
@@ -277,6 +424,8 @@ func (s *SupermicroX) HardwareType() (model string) {
 
 // Model returns the device model
 func (s *SupermicroX) Model() (model string, err error) {
+	defer s.wrapError("Model", &err)
+
 	ipmi, err := s.query("op=FRU_INFO.XML&r=(0,0)")
 	if err != nil {
 		return model, err
@@ -291,6 +440,8 @@ func (s *SupermicroX) Model() (model string, err error) {
 
 // Version returns the version of the bmc we are running
 func (s *SupermicroX) Version() (bmcVersion string, err error) {
+	defer s.wrapError("Version", &err)
+
 	ipmi, err := s.query("op=GENERIC_INFO.XML&r=(0,0)")
 	if err != nil {
 		return bmcVersion, err
@@ -308,11 +459,15 @@ func (s *SupermicroX) Version() (bmcVersion string, err error) {
 }
 
 func (s *SupermicroX) Class() (class string, err error) {
+	defer s.wrapError("Class", &err)
+
 	return "SupermicroX", nil
 }
 
 // Name returns the hostname of the machine
 func (s *SupermicroX) Name() (name string, err error) {
+	defer s.wrapError("Name", &err)
+
 	ipmi, err := s.query("op=CONFIG_INFO.XML&r=(0,0)")
 	if err != nil {
 		return name, err
@@ -327,6 +482,8 @@ func (s *SupermicroX) Name() (name string, err error) {
 
 // Status returns health string status from the bmc
 func (s *SupermicroX) Status() (health string, err error) {
+	defer s.wrapError("Status", &err)
+
 	// TODO x11 returns status codes, need to find where those are documented
 	/*
 			<?xml version="1.0"?>
@@ -346,8 +503,22 @@ func (s *SupermicroX) Status() (health string, err error) {
 	return "Unhealthy", err
 }
 
+// Health returns the normalized health from the bmc
+func (s *SupermicroX) Health() (health devices.Health, err error) {
+	defer s.wrapError("Health", &err)
+
+	status, err := s.Status()
+	if err != nil {
+		return devices.HealthUnknown, err
+	}
+
+	return devices.NormalizeHealth(status), err
+}
+
 // Memory returns the total amount of memory of the server
 func (s *SupermicroX) Memory() (mem int, err error) {
+	defer s.wrapError("Memory", &err)
+
 	ipmi, err := s.query("op=SMBIOS_INFO.XML&r=(0,0)")
 
 	for _, dimm := range ipmi.Dimm {
@@ -364,6 +535,8 @@ func (s *SupermicroX) Memory() (mem int, err error) {
 
 // CPU returns the cpu, cores and hyperthreads of the server
 func (s *SupermicroX) CPU() (cpu string, cpuCount int, coreCount int, hyperthreadCount int, err error) {
+	defer s.wrapError("CPU", &err)
+
 	ipmi, err := s.query("op=SMBIOS_INFO.XML&r=(0,0)")
 	if err != nil {
 		return "", 0, 0, 0, err
@@ -388,6 +561,8 @@ func (s *SupermicroX) CPU() (cpu string, cpuCount int, coreCount int, hyperthrea
 
 // BiosVersion returns the current version of the bios
 func (s *SupermicroX) BiosVersion() (version string, err error) {
+	defer s.wrapError("BiosVersion", &err)
+
 	ipmi, err := s.query("op=SMBIOS_INFO.XML&r=(0,0)")
 	if err != nil {
 		return version, err
@@ -403,6 +578,8 @@ func (s *SupermicroX) BiosVersion() (version string, err error) {
 // PowerKw returns the current power usage in Kw
 // TODO update for x11, getting all zeros with this
 func (s *SupermicroX) PowerKw() (power float64, err error) {
+	defer s.wrapError("PowerKw", &err)
+
 	ipmi, err := s.query("op=POWER_CONSUMPTION.XML&r=(0,0)")
 	if err != nil {
 		return power, err
@@ -423,6 +600,8 @@ func (s *SupermicroX) PowerKw() (power float64, err error) {
 
 // PowerState returns the current power state of the machine
 func (s *SupermicroX) PowerState() (state string, err error) {
+	defer s.wrapError("PowerState", &err)
+
 	ipmi, err := s.query("op=POWER_INFO.XML&r=(0,0)")
 	if err != nil {
 		return state, err
@@ -437,6 +616,8 @@ func (s *SupermicroX) PowerState() (state string, err error) {
 
 // TempC returns the current temperature of the machine
 func (s *SupermicroX) TempC() (temp int, err error) {
+	defer s.wrapError("TempC", &err)
+
 	ipmi, err := s.query("op=SENSOR_INFO.XML&r=(1,ff)")
 	if err != nil {
 		return temp, err
@@ -463,6 +644,8 @@ func (s *SupermicroX) TempC() (temp int, err error) {
 
 // IsBlade returns if the current hardware is a blade or not
 func (s *SupermicroX) IsBlade() (isBlade bool, err error) {
+	defer s.wrapError("IsBlade", &err)
+
 	ipmi, err := s.query("op=Get_NodeInfoReadings.XML&r=(0,0)")
 	if err != nil {
 		return isBlade, err
@@ -481,6 +664,8 @@ func (s *SupermicroX) IsBlade() (isBlade bool, err error) {
 
 // Slot returns the current slot within the chassis
 func (s *SupermicroX) Slot() (slot int, err error) {
+	defer s.wrapError("Slot", &err)
+
 	slot = 1
 	ipmi, err := s.query("op=Get_NodeInfoReadings.XML&r=(0,0)")
 	if err != nil {
@@ -495,7 +680,7 @@ func (s *SupermicroX) Slot() (slot int, err error) {
 		return slot, err
 	}
 	for _, node := range ipmi.NodeInfo.Nodes {
-		if strings.ToLower(node.NodeSerial) == serial {
+		if devices.NormalizeSerial(node.NodeSerial) == serial {
 			slot = node.ID + 1
 		}
 	}
@@ -505,6 +690,8 @@ func (s *SupermicroX) Slot() (slot int, err error) {
 
 // Nics returns all found Nics in the device
 func (s *SupermicroX) Nics() (nics []*devices.Nic, err error) {
+	defer s.wrapError("Nics", &err)
+
 	ipmi, err := s.query("op=GENERIC_INFO.XML&r=(0,0)")
 	if err != nil {
 		return nics, err
@@ -571,6 +758,8 @@ func (s *SupermicroX) Nics() (nics []*devices.Nic, err error) {
 
 // License returns the iLO's license information
 func (s *SupermicroX) License() (name string, licType string, err error) {
+	defer s.wrapError("License", &err)
+
 	ipmi, err := s.query("op=BIOS_LINCENSE_ACTIVATE.XML&r=(0,0)")
 	if err != nil {
 		return name, licType, err
@@ -596,8 +785,10 @@ func (s *SupermicroX) Vendor() (vendor string) {
 // ServerSnapshot do best effort to populate the server data and returns a blade or discrete
 // nolint: gocyclo
 func (s *SupermicroX) ServerSnapshot() (server interface{}, err error) {
+	defer s.wrapError("ServerSnapshot", &err)
+
 	if isBlade, _ := s.IsBlade(); isBlade {
-		blade := &devices.Blade{}
+		blade := &devices.Blade{CollectedAt: time.Now().UTC()}
 		blade.Vendor = s.Vendor()
 		blade.BmcAddress = s.ip
 		blade.BmcType = s.HardwareType()
@@ -668,7 +859,7 @@ func (s *SupermicroX) ServerSnapshot() (server interface{}, err error) {
 		}
 		server = blade
 	} else {
-		discrete := &devices.Discrete{}
+		discrete := &devices.Discrete{CollectedAt: time.Now().UTC()}
 		discrete.Vendor = s.Vendor()
 		discrete.BmcAddress = s.ip
 		discrete.BmcType = s.HardwareType()
@@ -737,6 +928,8 @@ func (s *SupermicroX) ServerSnapshot() (server interface{}, err error) {
 
 // Disks returns a list of disks installed on the device
 func (s *SupermicroX) Disks() (disks []*devices.Disk, err error) {
+	defer s.wrapError("Disks", &err)
+
 	return disks, err
 }
 
@@ -747,16 +940,15 @@ func (s *SupermicroX) UpdateCredentials(username string, password string) {
 }
 
 // BiosVersion returns the BIOS version from the BMC, implements the Firmware interface
-func (s *SupermicroX) GetBIOSVersion(ctx context.Context) (string, error) {
+func (s *SupermicroX) GetBIOSVersion(ctx context.Context) (version string, err error) {
+	defer s.wrapError("GetBIOSVersion", &err)
+
 	return "", errors.ErrNotImplemented
 }
 
 // BMCVersion returns the BMC version, implements the Firmware interface
-func (s *SupermicroX) GetBMCVersion(ctx context.Context) (string, error) {
-	return "", errors.ErrNotImplemented
-}
+func (s *SupermicroX) GetBMCVersion(ctx context.Context) (version string, err error) {
+	defer s.wrapError("GetBMCVersion", &err)
 
-// Updates the BMC firmware, implements the Firmware interface
-func (s *SupermicroX) FirmwareUpdateBMC(ctx context.Context, filePath string) error {
-	return errors.ErrNotImplemented
+	return "", errors.ErrNotImplemented
 }
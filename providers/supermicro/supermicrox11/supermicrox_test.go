@@ -1,22 +1,32 @@
 package supermicrox11
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bombsimon/logrusr/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
 var (
-	mux     *http.ServeMux
-	server  *httptest.Server
+	mux    *http.ServeMux
+	server *httptest.Server
+	// queries records the ipmi.cgi queries answered by the test server
+	queries []string
+	// onQuery lets a test change the answers when the test server receives a query
+	onQuery func(query string)
 	Answers = map[string][]byte{
 		"op=FRU_INFO.XML&r=(0,0)": []byte(`<?xml version="1.0"?>
 			<IPMI>
@@ -61,6 +71,14 @@ var (
 				<IP_PROTOCOL_STATUS IP4_STATUS="1" IP6_STATUS="1"/>
 			</CONFIG_INFO>
 			</IPMI>`),
+		"op=SEL_INFO.XML&r=(1,c0)": []byte(`<?xml version="1.0"?>
+			<IPMI>
+			  <SEL_INFO>
+				<SEL TOTAL_NUMBER="0002"/>
+				<SEL SEL_RD="01000200105e5f20000401010159ffff"/>
+				<SEL SEL_RD="02000200105e5f2000040c08ef01ffff"/>
+			  </SEL_INFO>
+			</IPMI>`),
 		"op=SMBIOS_INFO.XML&r=(0,0)": []byte(`<?xml version="1.0"?>
 			<IPMI>
 			  <BIOS VENDOR="American Megatrends Inc." VER="1.4" REL_DATE="05/26/2020"/>
@@ -173,6 +191,19 @@ var (
 			<Node ID="4" Present="0" PowerStatus="0" Power="0" Current="0" IP="0.0.0.0" NodePartNo="" NodeSerialNo="" CPU1Temp="0" CPU2Temp="0" SystemTemp="0" FanSpeed="0"/>
 		  </NodeInfo>
 		</IPMI>`),
+
+		"op=FW_UPDATE_ENTER.XML&r=(0,0)":    []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="OK"/> </IPMI>`),
+		"op=FW_UPDATE_CHECK.XML&r=(0,0)":    []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="OK" VERSION="01.73"/> </IPMI>`),
+		"op=FW_UPDATE_PROGRESS.XML&r=(0,0)": []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="OK" PROGRESS="100"/> </IPMI>`),
+		"op=FW_UPDATE_EXIT.XML&r=(0,0)":     []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="OK"/> </IPMI>`),
+
+		"op=BIOS_UPDATE_ENTER.XML&r=(0,0)":    []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="OK"/> </IPMI>`),
+		"op=BIOS_UPDATE_CHECK.XML&r=(0,0)":    []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="OK" VERSION="1.5"/> </IPMI>`),
+		"op=BIOS_UPDATE_PROGRESS.XML&r=(0,0)": []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="OK" PROGRESS="100"/> </IPMI>`),
+		"op=BIOS_UPDATE_EXIT.XML&r=(0,0)":     []byte(`<?xml version="1.0"?> <IPMI> <FW_UPDATE STATUS="OK"/> </IPMI>`),
+
+		"op=POWER_INFO.XML&r=(1,0)": []byte(`<?xml version="1.0"?>  <IPMI>  <POWER_INFO>  <POWER STATUS="OFF"/>  </POWER_INFO>  </IPMI>`),
+		"op=POWER_INFO.XML&r=(1,1)": []byte(`<?xml version="1.0"?>  <IPMI>  <POWER_INFO>  <POWER STATUS="ON"/>  </POWER_INFO>  </IPMI>`),
 	}
 )
 
@@ -183,6 +214,8 @@ func init() {
 }
 
 func setup() (r *SupermicroX, err error) {
+	queries = nil
+	onQuery = nil
 	mux = http.NewServeMux()
 	server = httptest.NewTLSServer(mux)
 	ip := strings.TrimPrefix(server.URL, "https://")
@@ -195,15 +228,20 @@ func setup() (r *SupermicroX, err error) {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		queries = append(queries, string(query))
+		if onQuery != nil {
+			onQuery(string(query))
+		}
 		_, _ = w.Write(Answers[string(query)])
 	})
 
 	mux.HandleFunc("/cgi/login.cgi", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "SID", Value: "session"})
 		_, _ = w.Write([]byte("../cgi/url_redirect.cgi?url_name=mainmenu"))
 	})
 
 	testLog := logrus.New()
-	r, err = New(context.TODO(), ip, username, password, logrusr.New(testLog))
+	r, err = NewWithOptions(context.TODO(), ip, username, password, logrusr.New(testLog), WithInsecureTLS())
 	if err != nil {
 		return r, err
 	}
@@ -618,3 +656,413 @@ func TestUpdateCredentials(t *testing.T) {
 
 	tearDown()
 }
+
+func TestListUsers(t *testing.T) {
+	expectedAnswer := []devices.User{
+		{ID: 1, Name: "ADMIN", Role: devices.UserRoleAdmin, Enabled: true},
+		{ID: 2, Name: "test", Role: devices.UserRoleAdmin, Enabled: true},
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	answer, err := bmc.ListUsers()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.ListUsers %v", err)
+	}
+
+	if len(answer) != len(expectedAnswer) {
+		t.Fatalf("Expected %v users: found %v", len(expectedAnswer), len(answer))
+	}
+
+	for pos, user := range answer {
+		if user != expectedAnswer[pos] {
+			t.Errorf("Expected answer %v: found %v", expectedAnswer[pos], user)
+		}
+	}
+}
+
+func TestUserManager(t *testing.T) {
+	tests := []struct {
+		name         string
+		call         func(bmc *SupermicroX) error
+		response     string
+		expectedForm map[string]string
+		expectedErr  error
+	}{
+		{
+			name: "create",
+			call: func(bmc *SupermicroX) error {
+				return bmc.CreateUser(devices.User{Name: "readonly", Password: "S3cretPass", Role: devices.UserRoleReadOnly})
+			},
+			response:     "result=LANG_CONFUSR_RESULT_OK",
+			expectedForm: map[string]string{"op": "config_user", "username": "readonly", "original_username": "3", "password": "S3cretPass", "new_privilege": "2"},
+		},
+		{
+			name: "modify",
+			call: func(bmc *SupermicroX) error {
+				return bmc.ModifyUser(devices.User{Name: "test", Role: devices.UserRoleOperator})
+			},
+			response:     "result=LANG_CONFUSR_RESULT_OK",
+			expectedForm: map[string]string{"op": "config_user", "username": "test", "original_username": "2", "new_privilege": "3"},
+		},
+		{
+			name: "modify with a password",
+			call: func(bmc *SupermicroX) error {
+				return bmc.ModifyUser(devices.User{Name: "test", Password: "N3wSecretPass", Role: devices.UserRoleReadOnly})
+			},
+			response:     "result=LANG_CONFUSR_RESULT_OK",
+			expectedForm: map[string]string{"op": "config_user", "username": "test", "original_username": "2", "password": "N3wSecretPass", "new_privilege": "2"},
+		},
+		{
+			name: "modify missing",
+			call: func(bmc *SupermicroX) error {
+				return bmc.ModifyUser(devices.User{Name: "missing", Role: devices.UserRoleAdmin})
+			},
+			expectedErr: bmclibErrs.ErrUserAccountNotFound,
+		},
+		{
+			name:         "delete",
+			call:         func(bmc *SupermicroX) error { return bmc.DeleteUser("test") },
+			response:     "result=LANG_CONFUSR_RESULT_OK",
+			expectedForm: map[string]string{"op": "config_user", "username": "", "original_username": "2"},
+		},
+		{
+			name:         "change password",
+			call:         func(bmc *SupermicroX) error { return bmc.ChangePassword("test", "N3wSecretPass") },
+			response:     "result=LANG_CONFUSR_RESULT_OK",
+			expectedForm: map[string]string{"op": "config_user", "username": "test", "original_username": "2", "password": "N3wSecretPass", "new_privilege": "4"},
+		},
+		{
+			name:         "change password rejected by the bmc",
+			call:         func(bmc *SupermicroX) error { return bmc.ChangePassword("test", "Passw0rd") },
+			response:     "result=LANG_CONFUSER_COMMON_ERR7",
+			expectedForm: map[string]string{"op": "config_user", "username": "test", "original_username": "2", "password": "Passw0rd", "new_privilege": "4"},
+			expectedErr:  bmclibErrs.ErrUserAccountUpdate,
+		},
+		{
+			name:        "change password too weak",
+			call:        func(bmc *SupermicroX) error { return bmc.ChangePassword("test", "weak") },
+			expectedErr: bmclibErrs.ErrWeakPassword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bmc, err := setup()
+			if err != nil {
+				t.Fatalf("Found errors during the test setup %v", err)
+			}
+			defer tearDown()
+
+			var form map[string]string
+			mux.HandleFunc("/cgi/op.cgi", func(w http.ResponseWriter, r *http.Request) {
+				_ = r.ParseForm()
+				form = map[string]string{}
+				for key := range r.PostForm {
+					form[key] = r.PostForm.Get(key)
+				}
+				_, _ = w.Write([]byte(tt.response))
+			})
+
+			err = tt.call(bmc)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Expected error %v: found %v", tt.expectedErr, err)
+			}
+
+			if len(form) != len(tt.expectedForm) {
+				t.Fatalf("Expected answer %v: found %v", tt.expectedForm, form)
+			}
+			for key, value := range tt.expectedForm {
+				if form[key] != value {
+					t.Errorf("Expected answer %v: found %v", value, form[key])
+				}
+			}
+		})
+	}
+}
+
+func TestGetEventLog(t *testing.T) {
+	timestamp := time.Unix(1600000000, 0).UTC()
+	expectedAnswer := []devices.EventLogEntry{
+		{ID: "1", Timestamp: timestamp, Sensor: "Temperature #0x01", EventType: "Asserted", Severity: devices.SeverityCritical, Message: "Temperature: Upper Critical going high"},
+		{ID: "2", Timestamp: timestamp, Sensor: "Memory #0x08", EventType: "Deasserted", Severity: devices.SeverityOK, Message: "Memory: event offset 0x01"},
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	answer, err := bmc.GetEventLog()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.GetEventLog %v", err)
+	}
+
+	if len(answer) != len(expectedAnswer) {
+		t.Fatalf("Expected %v entries: found %v", len(expectedAnswer), len(answer))
+	}
+
+	for pos, entry := range answer {
+		if entry != expectedAnswer[pos] {
+			t.Errorf("Expected answer %v: found %v", expectedAnswer[pos], entry)
+		}
+	}
+}
+
+func TestTemperatures(t *testing.T) {
+	expectedAnswer := map[string]float64{
+		"CPU Temp":        30,
+		"PCH Temp":        34,
+		"System Temp":     25,
+		"Peripheral Temp": 29,
+		"VcpuVRM Temp":    31,
+		"DIMMA2 Temp":     29,
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	answer, err := bmc.Temperatures()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.Temperatures %v", err)
+	}
+
+	if len(answer) != len(expectedAnswer) {
+		t.Fatalf("Expected %v sensors: found %v", len(expectedAnswer), len(answer))
+	}
+
+	for _, sensor := range answer {
+		if sensor.Reading != expectedAnswer[sensor.Name] {
+			t.Errorf("Expected answer %v: found %v", expectedAnswer[sensor.Name], sensor.Reading)
+		}
+		if sensor.Status != string(devices.HealthOK) {
+			t.Errorf("Expected answer %v: found %v", devices.HealthOK, sensor.Status)
+		}
+	}
+}
+
+func TestFirmwareUpdateBMC(t *testing.T) {
+	defer func(size int, interval time.Duration) {
+		firmwareChunkSize, firmwarePollInterval = size, interval
+	}(firmwareChunkSize, firmwarePollInterval)
+	firmwareChunkSize, firmwarePollInterval = 4, time.Millisecond
+
+	image := []byte("BMC_FIRMWARE")
+	images := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/firmware/BMC_X11AST2500_173.bin" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(image)
+	}))
+	defer images.Close()
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	var uploaded []byte
+	mux.HandleFunc("/cgi/upload_firmware.cgi", func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("fw_image")
+		if err != nil || r.FormValue("offset") != strconv.Itoa(len(uploaded)) || r.FormValue("total_size") != strconv.Itoa(len(image)) {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		chunk, _ := ioutil.ReadAll(file)
+		uploaded = append(uploaded, chunk...)
+	})
+
+	var ops []string
+	var preserve string
+	var rebooted bool
+	mux.HandleFunc("/cgi/op.cgi", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		ops = append(ops, r.PostForm.Get("op"))
+		switch r.PostForm.Get("op") {
+		case "main_fwupdate":
+			preserve = r.PostForm.Get("preserve_config")
+		case "main_fwupdate_reboot":
+			rebooted = true
+		}
+	})
+
+	// the old firmware still answers once after the restart is requested
+	defer func(answer []byte) { Answers["op=GENERIC_INFO.XML&r=(0,0)"] = answer }(Answers["op=GENERIC_INFO.XML&r=(0,0)"])
+	var oldAnswers int
+	onQuery = func(query string) {
+		if query != "op=GENERIC_INFO.XML&r=(0,0)" || !rebooted {
+			return
+		}
+		oldAnswers++
+		if oldAnswers > 1 {
+			Answers[query] = []byte(strings.Replace(string(Answers[query]), `IPMIFW_VERSION="0325"`, `IPMIFW_VERSION="0173"`, 1))
+		}
+	}
+
+	var phases []bmclibErrs.FirmwareUpdatePhase
+	status, output, err := bmc.UpdateFirmwareWithProgress(images.URL+"/firmware", "BMC_X11AST2500_173.bin", func(p devices.FirmwareProgress) {
+		if len(phases) == 0 || phases[len(phases)-1] != p.Phase {
+			phases = append(phases, p.Phase)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.UpdateFirmwareWithProgress %v", err)
+	}
+
+	if !status || output != "bmc firmware updated to 0173" {
+		t.Errorf("Expected answer %v %v: found %v %v", true, "bmc firmware updated to 0173", status, output)
+	}
+
+	if oldAnswers < 2 {
+		t.Errorf("Expected answer %v: found %v version queries after the restart", 2, oldAnswers)
+	}
+
+	if string(uploaded) != string(image) {
+		t.Errorf("Expected answer %s: found %s", image, uploaded)
+	}
+
+	expectedOps := []string{"main_fwupdate", "main_fwupdate_reboot"}
+	if !reflect.DeepEqual(ops, expectedOps) || preserve != "1" {
+		t.Errorf("Expected answer %v %v: found %v %v", expectedOps, "1", ops, preserve)
+	}
+
+	expectedPhases := []bmclibErrs.FirmwareUpdatePhase{
+		bmclibErrs.FirmwareUpdatePhasePrepare,
+		bmclibErrs.FirmwareUpdatePhaseUpload,
+		bmclibErrs.FirmwareUpdatePhaseVerify,
+		bmclibErrs.FirmwareUpdatePhaseFlash,
+		bmclibErrs.FirmwareUpdatePhaseReboot,
+	}
+	if !reflect.DeepEqual(phases, expectedPhases) {
+		t.Errorf("Expected answer %v: found %v", expectedPhases, phases)
+	}
+}
+
+func TestFirmwareUpdateBMCUploadRejected(t *testing.T) {
+	path := t.TempDir() + "/BMC_X11AST2500_173.bin"
+	if err := ioutil.WriteFile(path, []byte("BMC_FIRMWARE"), 0o600); err != nil {
+		t.Fatalf("Found errors writing the firmware image %v", err)
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	mux.HandleFunc("/cgi/upload_firmware.cgi", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no space left", http.StatusInternalServerError)
+	})
+
+	err = bmc.FirmwareUpdateBMC(context.TODO(), path)
+
+	var updateErr *bmclibErrs.FirmwareUpdateError
+	if !errors.As(err, &updateErr) || updateErr.Phase != bmclibErrs.FirmwareUpdatePhaseUpload {
+		t.Fatalf("Expected answer %v: found %v", bmclibErrs.FirmwareUpdatePhaseUpload, err)
+	}
+
+	var httpErr *bmclibErrs.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected answer %v: found %v", http.StatusInternalServerError, err)
+	}
+
+	var bmcErr *bmclibErrs.BMCError
+	if !errors.As(err, &bmcErr) || bmcErr.Vendor != BmcType || bmcErr.Operation != "FirmwareUpdateBMC" {
+		t.Errorf("Expected the error to carry the bmc identity: found %v", err)
+	}
+
+	// the BMC is taken out of the update mode
+	if queries[len(queries)-1] != "op=FW_UPDATE_EXIT.XML&r=(0,0)" {
+		t.Errorf("Expected answer %v: found %v", "op=FW_UPDATE_EXIT.XML&r=(0,0)", queries[len(queries)-1])
+	}
+}
+
+func TestFirmwareUpdateBIOS(t *testing.T) {
+	defer func(interval time.Duration) { firmwarePollInterval = interval }(firmwarePollInterval)
+	firmwarePollInterval = time.Millisecond
+	defer func(power, smbios []byte) {
+		Answers["op=POWER_INFO.XML&r=(0,0)"], Answers["op=SMBIOS_INFO.XML&r=(0,0)"] = power, smbios
+	}(Answers["op=POWER_INFO.XML&r=(0,0)"], Answers["op=SMBIOS_INFO.XML&r=(0,0)"])
+
+	image := []byte("BIOS_IMAGE")
+	path := t.TempDir() + "/X11SCM9_B30.bin"
+	if err := ioutil.WriteFile(path, image, 0o600); err != nil {
+		t.Fatalf("Found errors writing the firmware image %v", err)
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	// the host powers off and on with the queries, reporting the new BIOS version once it's back on
+	onQuery = func(query string) {
+		switch query {
+		case "op=POWER_INFO.XML&r=(1,0)":
+			Answers["op=POWER_INFO.XML&r=(0,0)"] = Answers[query]
+		case "op=POWER_INFO.XML&r=(1,1)":
+			Answers["op=POWER_INFO.XML&r=(0,0)"] = Answers[query]
+			Answers["op=SMBIOS_INFO.XML&r=(0,0)"] = bytes.Replace(Answers["op=SMBIOS_INFO.XML&r=(0,0)"], []byte(`VER="1.4"`), []byte(`VER="1.5"`), 1)
+		}
+	}
+
+	var uploaded []byte
+	mux.HandleFunc("/cgi/upload_bios.cgi", func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("fw_image")
+		if err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		chunk, _ := ioutil.ReadAll(file)
+		uploaded = append(uploaded, chunk...)
+	})
+
+	var ops []string
+	mux.HandleFunc("/cgi/op.cgi", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		ops = append(ops, r.PostForm.Get("op"))
+	})
+
+	err = bmc.FirmwareUpdateBIOS(context.TODO(), path)
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.FirmwareUpdateBIOS %v", err)
+	}
+
+	if string(uploaded) != string(image) {
+		t.Errorf("Expected answer %s: found %s", image, uploaded)
+	}
+
+	expectedOps := []string{"main_biosupdate"}
+	if !reflect.DeepEqual(ops, expectedOps) {
+		t.Errorf("Expected answer %v: found %v", expectedOps, ops)
+	}
+
+	// the host is powered off before the update mode and back on once the image is flashed
+	var power []string
+	for _, query := range queries {
+		switch query {
+		case "op=POWER_INFO.XML&r=(1,0)", "op=POWER_INFO.XML&r=(1,1)", "op=BIOS_UPDATE_ENTER.XML&r=(0,0)", "op=BIOS_UPDATE_PROGRESS.XML&r=(0,0)":
+			power = append(power, query)
+		}
+	}
+	expectedPower := []string{"op=POWER_INFO.XML&r=(1,0)", "op=BIOS_UPDATE_ENTER.XML&r=(0,0)", "op=BIOS_UPDATE_PROGRESS.XML&r=(0,0)", "op=POWER_INFO.XML&r=(1,1)"}
+	if !reflect.DeepEqual(power, expectedPower) {
+		t.Errorf("Expected answer %v: found %v", expectedPower, power)
+	}
+
+	version, err := bmc.BiosVersion()
+	if err != nil || version != "1.5" {
+		t.Errorf("Expected answer %v: found %v %v", "1.5", version, err)
+	}
+}
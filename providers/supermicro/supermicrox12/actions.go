@@ -0,0 +1,146 @@
+package supermicrox12
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the PowerController interface.
+var _ devices.PowerController = (*SupermicroX)(nil)
+
+// reset calls the ComputerSystem.Reset action of the server with the given ResetType
+func (s *SupermicroX) reset(resetType string) (status bool, err error) {
+	uri, err := s.systemURI()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = s.post(uri+"/Actions/ComputerSystem.Reset", &ResetRequest{ResetType: resetType})
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", resetType, err)
+	}
+
+	return true, nil
+}
+
+// PowerState returns the current power state of the machine
+func (s *SupermicroX) PowerState() (state string, err error) {
+	defer s.wrapError("PowerState", &err)
+
+	system, err := s.system()
+	if err != nil {
+		return state, err
+	}
+
+	return strings.ToLower(system.PowerState), nil
+}
+
+// IsOn tells if a machine is currently powered on
+func (s *SupermicroX) IsOn() (status bool, err error) {
+	state, err := s.PowerState()
+	if err != nil {
+		return false, err
+	}
+
+	return state == "on", nil
+}
+
+// PowerOn power on the machine via bmc
+func (s *SupermicroX) PowerOn() (status bool, err error) {
+	defer s.wrapError("PowerOn", &err)
+
+	return s.reset("On")
+}
+
+// PowerOff power off the machine via bmc
+func (s *SupermicroX) PowerOff() (status bool, err error) {
+	defer s.wrapError("PowerOff", &err)
+
+	return s.reset("ForceOff")
+}
+
+// PowerCycle reboots the machine via bmc
+func (s *SupermicroX) PowerCycle() (status bool, err error) {
+	defer s.wrapError("PowerCycle", &err)
+
+	return s.reset("PowerCycle")
+}
+
+// PowerReset resets the machine via bmc without cutting its power
+func (s *SupermicroX) PowerReset() (status bool, err error) {
+	defer s.wrapError("PowerReset", &err)
+
+	return s.reset("ForceRestart")
+}
+
+// PowerCycleBmc reboots the bmc we are connected to
+func (s *SupermicroX) PowerCycleBmc() (status bool, err error) {
+	defer s.wrapError("PowerCycleBmc", &err)
+
+	uri, err := s.managerURI()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = s.post(uri+"/Actions/Manager.Reset", &ResetRequest{ResetType: "GracefulRestart"})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// PxeOnce makes the machine to boot via pxe once, restarting it or powering it on
+func (s *SupermicroX) PxeOnce() (status bool, err error) {
+	defer s.wrapError("PxeOnce", &err)
+
+	override := &BootOverrideRequest{}
+	override.Boot.BootSourceOverrideEnabled = "Once"
+	override.Boot.BootSourceOverrideTarget = "Pxe"
+
+	uri, err := s.systemURI()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = s.patch(uri, override)
+	if err != nil {
+		return false, err
+	}
+
+	isOn, err := s.IsOn()
+	if err != nil {
+		return false, err
+	}
+
+	if isOn {
+		return s.reset("ForceRestart")
+	}
+
+	return s.reset("On")
+}
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the FirmwareUpdater interface.
+var _ devices.FirmwareUpdater = (*SupermicroX)(nil)
+
+// UpdateFirmware updates the bmc firmware
+func (s *SupermicroX) UpdateFirmware(source, file string) (status bool, output string, err error) {
+	return s.UpdateFirmwareWithProgress(source, file, nil)
+}
+
+// UpdateFirmwareWithProgress isn't supported on the X12 and X13 bmcs yet
+func (s *SupermicroX) UpdateFirmwareWithProgress(source, file string, progress func(devices.FirmwareProgress)) (status bool, output string, err error) {
+	return false, "", errors.NewFeatureUnsupportedError("firmware update", s.Vendor(), s.HardwareType())
+}
+
+// CheckFirmwareVersion returns the version of the bmc firmware
+func (s *SupermicroX) CheckFirmwareVersion() (version string, err error) {
+	defer s.wrapError("CheckFirmwareVersion", &err)
+
+	return s.Version()
+}
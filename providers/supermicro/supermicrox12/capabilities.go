@@ -0,0 +1,40 @@
+package supermicrox12
+
+import (
+	"strings"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/providers"
+	"github.com/jacobweinstock/registrar"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the CapabilityReporter interface.
+var _ devices.CapabilityReporter = (*SupermicroX)(nil)
+
+// generation returns the generation of a board, X12 or X13, from its model, or an empty string
+func generation(model string) string {
+	model = strings.ToLower(model)
+	for _, g := range []string{X12, X13} {
+		if strings.HasPrefix(model, g) {
+			return g
+		}
+	}
+
+	return ""
+}
+
+// Capabilities returns the operations supported by the bmc, all driven through its Redfish service
+func (s *SupermicroX) Capabilities() registrar.Features {
+	return registrar.Features{
+		providers.FeaturePowerState,
+		providers.FeaturePowerSet,
+		providers.FeatureBootDeviceSet,
+		providers.FeatureBmcReset,
+		providers.FeatureFirmwareInventory,
+		providers.FeatureUserCreate,
+		providers.FeatureUserDelete,
+		providers.FeatureUserUpdate,
+		providers.FeatureUserRead,
+	}
+}
@@ -0,0 +1,101 @@
+package supermicrox12
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/cfgresources"
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the Configure interface.
+var _ devices.Configure = (*SupermicroX)(nil)
+
+// Resources returns a slice of supported resources and
+// the order they are to be applied in, none is supported on the X12 and X13 bmcs yet.
+func (s *SupermicroX) Resources() []string {
+	return []string{}
+}
+
+// ApplyCfg implements the Bmc interface
+// this is to be deprecated.
+func (s *SupermicroX) ApplyCfg(config *cfgresources.ResourcesConfig) (err error) {
+	return err
+}
+
+// User isn't supported on the X12 and X13 bmcs yet
+func (s *SupermicroX) User(users []*cfgresources.User) error {
+	return errors.NewFeatureUnsupportedError("user configuration", s.Vendor(), s.HardwareType())
+}
+
+// Syslog isn't supported on the X12 and X13 bmcs yet
+func (s *SupermicroX) Syslog(cfg *cfgresources.Syslog) error {
+	return errors.NewFeatureUnsupportedError("syslog configuration", s.Vendor(), s.HardwareType())
+}
+
+// Ntp isn't supported on the X12 and X13 bmcs yet
+func (s *SupermicroX) Ntp(cfg *cfgresources.Ntp) error {
+	return errors.NewFeatureUnsupportedError("ntp configuration", s.Vendor(), s.HardwareType())
+}
+
+// Ldap isn't supported on the X12 and X13 bmcs yet
+func (s *SupermicroX) Ldap(cfg *cfgresources.Ldap) error {
+	return errors.NewFeatureUnsupportedError("ldap configuration", s.Vendor(), s.HardwareType())
+}
+
+// LdapGroups isn't supported on the X12 and X13 bmcs yet
+func (s *SupermicroX) LdapGroups(cfgGroups []*cfgresources.LdapGroup, cfgLdap *cfgresources.Ldap) error {
+	return errors.NewFeatureUnsupportedError("ldap configuration", s.Vendor(), s.HardwareType())
+}
+
+// Network isn't supported on the X12 and X13 bmcs yet
+func (s *SupermicroX) Network(cfg *cfgresources.Network) (bool, error) {
+	return false, errors.NewFeatureUnsupportedError("network configuration", s.Vendor(), s.HardwareType())
+}
+
+// SetLicense isn't supported on the X12 and X13 bmcs yet
+func (s *SupermicroX) SetLicense(cfg *cfgresources.License) error {
+	return errors.NewFeatureUnsupportedError("license", s.Vendor(), s.HardwareType())
+}
+
+// Bios isn't supported on the X12 and X13 bmcs yet
+func (s *SupermicroX) Bios(cfg *cfgresources.Bios) error {
+	return errors.NewFeatureUnsupportedError("BIOS settings", s.Vendor(), s.HardwareType())
+}
+
+// Power isn't supported on the X12 and X13 bmcs yet
+func (s *SupermicroX) Power(cfg *cfgresources.Power) error {
+	return errors.NewFeatureUnsupportedError("power configuration", s.Vendor(), s.HardwareType())
+}
+
+// CurrentHTTPSCert returns the current x509 certficates configured on the BMC
+// the bool value returned is set to true if the BMC support CSR generation.
+// CurrentHTTPSCert implements the Configure interface.
+func (s *SupermicroX) CurrentHTTPSCert() ([]*x509.Certificate, bool, error) {
+	dialer := &net.Dialer{
+		Timeout: time.Duration(10) * time.Second,
+	}
+
+	conn, err := tls.DialWithDialer(dialer, "tcp", s.ip+":"+"443", &tls.Config{InsecureSkipVerify: true})
+	if err != nil {
+		return []*x509.Certificate{{}}, false, err
+	}
+
+	defer conn.Close()
+
+	return conn.ConnectionState().PeerCertificates, false, nil
+}
+
+// GenerateCSR isn't supported on the X12 and X13 bmcs yet
+func (s *SupermicroX) GenerateCSR(cert *cfgresources.HTTPSCertAttributes) ([]byte, error) {
+	return nil, errors.NewFeatureUnsupportedError("CSR generation", s.Vendor(), s.HardwareType())
+}
+
+// UploadHTTPSCert isn't supported on the X12 and X13 bmcs yet
+func (s *SupermicroX) UploadHTTPSCert(cert []byte, certFileName string, key []byte, keyFileName string) (bool, error) {
+	return false, errors.NewFeatureUnsupportedError("HTTPS certificate upload", s.Vendor(), s.HardwareType())
+}
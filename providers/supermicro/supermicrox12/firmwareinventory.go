@@ -0,0 +1,29 @@
+package supermicrox12
+
+import (
+	"github.com/bmc-toolbox/bmclib/devices"
+)
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the FirmwareInventorier interface.
+var _ devices.FirmwareInventorier = (*SupermicroX)(nil)
+
+// GetFirmwareInventory returns the firmware of the bmc and the BIOS of the server
+func (s *SupermicroX) GetFirmwareInventory() (inventory []devices.Firmware, err error) {
+	defer s.wrapError("GetFirmwareInventory", &err)
+
+	bmcVersion, err := s.Version()
+	if err != nil {
+		return inventory, err
+	}
+
+	biosVersion, err := s.BiosVersion()
+	if err != nil {
+		return inventory, err
+	}
+
+	return []devices.Firmware{
+		{Component: "BMC", Installed: bmcVersion},
+		{Component: "BIOS", Installed: biosVersion},
+	}, nil
+}
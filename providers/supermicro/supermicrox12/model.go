@@ -0,0 +1,119 @@
+package supermicrox12
+
+// Status is the Redfish status of a resource
+type Status struct {
+	Health string `json:"Health"`
+	State  string `json:"State"`
+}
+
+// Link is a reference to another Redfish resource
+type Link struct {
+	ODataID string `json:"@odata.id"`
+}
+
+// Collection is a Redfish resource collection
+type Collection struct {
+	Members []Link `json:"Members"`
+}
+
+// System is the Redfish ComputerSystem resource of the server, a member of /redfish/v1/Systems
+type System struct {
+	Manufacturer     string `json:"Manufacturer"`
+	Model            string `json:"Model"`
+	SerialNumber     string `json:"SerialNumber"`
+	HostName         string `json:"HostName"`
+	BiosVersion      string `json:"BiosVersion"`
+	PowerState       string `json:"PowerState"`
+	Status           Status `json:"Status"`
+	ProcessorSummary struct {
+		Count int    `json:"Count"`
+		Model string `json:"Model"`
+	} `json:"ProcessorSummary"`
+	MemorySummary struct {
+		TotalSystemMemoryGiB float64 `json:"TotalSystemMemoryGiB"`
+	} `json:"MemorySummary"`
+}
+
+// Chassis is the Redfish Chassis resource of the board, a member of /redfish/v1/Chassis
+type Chassis struct {
+	Model        string `json:"Model"`
+	SerialNumber string `json:"SerialNumber"`
+	PartNumber   string `json:"PartNumber"`
+	ChassisType  string `json:"ChassisType"`
+	Status       Status `json:"Status"`
+}
+
+// Manager is the Redfish Manager resource of the BMC, a member of /redfish/v1/Managers
+type Manager struct {
+	DateTime        string `json:"DateTime"`
+	FirmwareVersion string `json:"FirmwareVersion"`
+	Model           string `json:"Model"`
+	Status          Status `json:"Status"`
+}
+
+// EthernetInterface is a Redfish EthernetInterface of the server or of the BMC
+type EthernetInterface struct {
+	ID         string `json:"Id"`
+	Name       string `json:"Name"`
+	MACAddress string `json:"MACAddress"`
+	SpeedMbps  int    `json:"SpeedMbps"`
+	MTUSize    int    `json:"MTUSize"`
+	LinkStatus string `json:"LinkStatus"`
+}
+
+// Processor is a Redfish Processor of the server
+type Processor struct {
+	ID            string `json:"Id"`
+	Socket        string `json:"Socket"`
+	ProcessorType string `json:"ProcessorType"`
+	Manufacturer  string `json:"Manufacturer"`
+	Model         string `json:"Model"`
+	MaxSpeedMHz   int64  `json:"MaxSpeedMHz"`
+	TotalCores    int    `json:"TotalCores"`
+	TotalThreads  int    `json:"TotalThreads"`
+	Status        Status `json:"Status"`
+}
+
+// Power is the Redfish Power resource of the chassis, /redfish/v1/Chassis/{id}/Power
+type Power struct {
+	PowerControl []struct {
+		PowerConsumedWatts float64 `json:"PowerConsumedWatts"`
+	} `json:"PowerControl"`
+	PowerSupplies []struct {
+		MemberID           string  `json:"MemberId"`
+		Name               string  `json:"Name"`
+		SerialNumber       string  `json:"SerialNumber"`
+		PartNumber         string  `json:"PartNumber"`
+		PowerCapacityWatts float64 `json:"PowerCapacityWatts"`
+		PowerOutputWatts   float64 `json:"PowerOutputWatts"`
+		Status             Status  `json:"Status"`
+	} `json:"PowerSupplies"`
+}
+
+// Thermal is the Redfish Thermal resource of the chassis, /redfish/v1/Chassis/{id}/Thermal
+type Thermal struct {
+	Temperatures []struct {
+		Name            string  `json:"Name"`
+		PhysicalContext string  `json:"PhysicalContext"`
+		ReadingCelsius  float64 `json:"ReadingCelsius"`
+	} `json:"Temperatures"`
+}
+
+// ResetRequest is the payload of the Reset actions
+type ResetRequest struct {
+	ResetType string `json:"ResetType"`
+}
+
+// BootOverrideRequest is the payload overriding the boot source of the server
+type BootOverrideRequest struct {
+	Boot struct {
+		BootSourceOverrideEnabled string `json:"BootSourceOverrideEnabled"`
+		BootSourceOverrideTarget  string `json:"BootSourceOverrideTarget"`
+	} `json:"Boot"`
+}
+
+// SessionRequest is the payload creating a Redfish session
+type SessionRequest struct {
+	UserName string `json:"UserName"`
+	Password string `json:"Password"`
+}
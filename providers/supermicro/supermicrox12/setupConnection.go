@@ -0,0 +1,231 @@
+package supermicrox12
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/bmc-toolbox/bmclib/errors"
+	"github.com/bmc-toolbox/bmclib/internal/httpclient"
+	"github.com/bmc-toolbox/bmclib/providers/supermicro"
+)
+
+const (
+	// sessionHeader is the header holding the Redfish session token
+	sessionHeader = "X-Auth-Token"
+	// sessionsURI is the Redfish collection where the sessions are created
+	sessionsURI = "redfish/v1/SessionService/Sessions"
+)
+
+// httpLogin initiates the connection to a supermicro bmc with a Redfish session
+func (s *SupermicroX) httpLogin() (err error) {
+	if s.httpClient != nil {
+		return
+	}
+
+	httpClient, err := httpclient.Build(s.httpClientSetupFuncs...)
+	if err != nil {
+		return err
+	}
+
+	s.log.V(1).Info("connecting to bmc", "step", "bmc connection", "vendor", supermicro.VendorID, "ip", s.ip)
+
+	data, err := json.Marshal(&SessionRequest{UserName: s.username, Password: s.password})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(s.context(), "POST", fmt.Sprintf("https://%s/%s", s.ip, sessionsURI), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return errors.WrapRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	payload, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode {
+	case 200, 201:
+	case 401:
+		return fmt.Errorf("login to %s rejected: %w", s.ip, errors.ErrInvalidCredentials)
+	case 404:
+		return errors.ErrPageNotFound
+	default:
+		return fmt.Errorf("login to %s: %w", s.ip, errors.NewHTTPErrorFromResponse(resp, payload))
+	}
+
+	s.sessionToken = resp.Header.Get(sessionHeader)
+	if s.sessionToken == "" {
+		return fmt.Errorf("login to %s returned no session token: %w", s.ip, errors.ErrLoginFailed)
+	}
+	s.sessionURI = resp.Header.Get("Location")
+	s.httpClient = httpClient
+
+	return err
+}
+
+// context returns the context the SupermicroX was created with, for the calls not taking one
+func (s *SupermicroX) context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+
+	return s.ctx
+}
+
+// sessionURL returns the absolute URL of the session, the Location header is usually a path
+func (s *SupermicroX) sessionURL() string {
+	if strings.HasPrefix(s.sessionURI, "https://") {
+		return s.sessionURI
+	}
+
+	return fmt.Sprintf("https://%s/%s", s.ip, strings.TrimPrefix(s.sessionURI, "/"))
+}
+
+// get calls a given Redfish endpoint of the bmc and returns the data
+func (s *SupermicroX) get(endpoint string) (payload []byte, err error) {
+	err = s.httpLogin()
+	if err != nil {
+		return nil, err
+	}
+
+	bmcURL := fmt.Sprintf("https://%s/%s", s.ip, strings.TrimPrefix(endpoint, "/"))
+	req, err := http.NewRequestWithContext(s.context(), "GET", bmcURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s.sessionAuth.Authorize(req, s.sessionToken)
+
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
+	s.log.V(2).Info("", "request", bmcURL, "requestDump", string(reqDump))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.WrapRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	respDump, _ := httpclient.DumpResponse(resp, true)
+	s.log.V(2).Info("", "responseDump", string(respDump))
+
+	payload, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.NewHTTPErrorFromResponse(resp, payload)
+	}
+
+	return payload, nil
+}
+
+// post sends the json encoding of data to the given Redfish endpoint of the bmc
+func (s *SupermicroX) post(endpoint string, data interface{}) (statusCode int, err error) {
+	return s.send("POST", endpoint, data)
+}
+
+// patch updates the given Redfish resource of the bmc with the json encoding of data
+func (s *SupermicroX) patch(endpoint string, data interface{}) (statusCode int, err error) {
+	return s.send("PATCH", endpoint, data)
+}
+
+// delete removes the given Redfish resource of the bmc
+func (s *SupermicroX) delete(endpoint string) (statusCode int, err error) {
+	return s.send("DELETE", endpoint, nil)
+}
+
+// send sends the json encoding of data to the given Redfish endpoint of the bmc with method,
+// the request has no body when data is nil.
+func (s *SupermicroX) send(method, endpoint string, data interface{}) (statusCode int, err error) {
+	err = s.httpLogin()
+	if err != nil {
+		return statusCode, err
+	}
+
+	var body io.Reader
+	if data != nil {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return statusCode, err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	bmcURL := fmt.Sprintf("https://%s/%s", s.ip, strings.TrimPrefix(endpoint, "/"))
+	req, err := http.NewRequestWithContext(s.context(), method, bmcURL, body)
+	if err != nil {
+		return statusCode, err
+	}
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+	s.sessionAuth.Authorize(req, s.sessionToken)
+
+	reqDump, _ := httpclient.DumpRequestOut(req, true)
+	s.log.V(2).Info("", "url", bmcURL, "requestDump", string(reqDump))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return statusCode, errors.WrapRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	respDump, _ := httpclient.DumpResponse(resp, true)
+	s.log.V(2).Info("", "responseDump", string(respDump))
+
+	statusCode = resp.StatusCode
+	payload, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return statusCode, err
+	}
+
+	// the Redfish actions, creates and updates answer any 2xx, e.g. 201 or 202 when accepted as a task
+	if statusCode < 200 || statusCode > 299 {
+		return statusCode, errors.NewHTTPErrorFromResponse(resp, payload)
+	}
+
+	return statusCode, nil
+}
+
+// Close closes the connection properly by deleting the Redfish session
+func (s *SupermicroX) Close(ctx context.Context) (err error) {
+	if s.httpClient == nil || s.sessionURI == "" {
+		return err
+	}
+
+	s.log.V(1).Info("logout from bmc", "step", "bmc connection", "vendor", supermicro.VendorID, "ip", s.ip)
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", s.sessionURL(), nil)
+	if err != nil {
+		return err
+	}
+	s.sessionAuth.Authorize(req, s.sessionToken)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return errors.WrapRequestError(err)
+	}
+	defer resp.Body.Close()
+	defer io.Copy(ioutil.Discard, resp.Body) // nolint
+
+	s.httpClient = nil
+	s.sessionToken = ""
+	s.sessionURI = ""
+
+	return err
+}
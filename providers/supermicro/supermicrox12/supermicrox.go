@@ -0,0 +1,741 @@
+package supermicrox12
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+	"github.com/bmc-toolbox/bmclib/internal/httpclient"
+	"github.com/bmc-toolbox/bmclib/providers"
+	"github.com/go-logr/logr"
+
+	"github.com/bmc-toolbox/bmclib/providers/supermicro"
+)
+
+const (
+	// BmcType defines the bmc model that is supported by this package
+	BmcType = "supermicrox12"
+
+	// X12 is the constant for x12 servers
+	X12 = "x12"
+	// X13 is the constant for x13 servers
+	X13 = "x13"
+
+	// the collections the system, manager and chassis are resolved from
+	systemsURI  = "redfish/v1/Systems"
+	managersURI = "redfish/v1/Managers"
+	chassisURI  = "redfish/v1/Chassis"
+)
+
+// SupermicroX holds the status and properties of a connection to a supermicro X12 or X13 bmc,
+// which is driven through its Redfish service rather than the cgi endpoints of the older generations.
+type SupermicroX struct {
+	ip                   string
+	username             string
+	password             string
+	httpClient           *http.Client
+	ctx                  context.Context
+	log                  logr.Logger
+	httpClientSetupFuncs []func(*http.Client)
+	// sessionAuth places the Redfish session token on the requests
+	sessionAuth  httpclient.SessionAuth
+	sessionToken string
+	// sessionURI is the location of the Redfish session, deleted on Close
+	sessionURI string
+	// systemPath, managerPath and chassisPath are the members resolved from the Redfish collections
+	systemPath  string
+	managerPath string
+	chassisPath string
+	// passwordPolicy is checked by the user management before a password is sent to the BMC
+	passwordPolicy devices.PasswordPolicy
+}
+
+// SupermicroXOption is a type that can configure a *SupermicroX
+type SupermicroXOption func(*SupermicroX)
+
+// WithSecureTLS enforces trusted TLS connections, with an optional CA certificate pool.
+// Using this option with an nil pool uses the system CAs.
+func WithSecureTLS(rootCAs *x509.CertPool) SupermicroXOption {
+	return func(s *SupermicroX) {
+		s.httpClientSetupFuncs = append(s.httpClientSetupFuncs, httpclient.SecureTLSOption(rootCAs))
+	}
+}
+
+// WithInsecureTLS skips the verification of the BMC certificate, which is otherwise
+// verified against the system CAs. Use it for BMCs serving self-signed certificates.
+func WithInsecureTLS() SupermicroXOption {
+	return func(s *SupermicroX) {
+		s.httpClientSetupFuncs = append(s.httpClientSetupFuncs, httpclient.WithInsecureTLS())
+	}
+}
+
+// WithSecureTLSFromFile enforces trusted TLS connections using the CA certificates of a PEM file.
+// Requests fail with a loading error when the file is missing or holds no valid certificate.
+func WithSecureTLSFromFile(caCertFile string) SupermicroXOption {
+	return func(s *SupermicroX) {
+		s.httpClientSetupFuncs = append(s.httpClientSetupFuncs, httpclient.WithCACertFile(caCertFile))
+	}
+}
+
+// WithClientCert authenticates to the BMC with the given PEM encoded client certificate and key.
+func WithClientCert(certPEM, keyPEM []byte) SupermicroXOption {
+	return func(s *SupermicroX) {
+		s.httpClientSetupFuncs = append(s.httpClientSetupFuncs, httpclient.WithClientCert(certPEM, keyPEM))
+	}
+}
+
+// WithObserver calls observer after each HTTP round-trip made to the BMC,
+// to collect metrics or logs.
+func WithObserver(observer func(providers.RequestInfo)) SupermicroXOption {
+	return func(s *SupermicroX) {
+		s.httpClientSetupFuncs = append(s.httpClientSetupFuncs, httpclient.WithObserver(observer))
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the HTTP requests made to the BMC,
+// it defaults to bmclib/<version>.
+func WithUserAgent(ua string) SupermicroXOption {
+	return func(s *SupermicroX) {
+		s.httpClientSetupFuncs = append(s.httpClientSetupFuncs, httpclient.WithUserAgent(ua))
+	}
+}
+
+// WithForceHTTP1 restricts the HTTP requests made to the BMC to HTTP/1.1,
+// for BMC web servers returning garbled responses over HTTP/2.
+func WithForceHTTP1() SupermicroXOption {
+	return func(s *SupermicroX) {
+		s.httpClientSetupFuncs = append(s.httpClientSetupFuncs, httpclient.WithForceHTTP1())
+	}
+}
+
+// WithMaxResponseBytes bounds the size of the responses read from the BMC,
+// it defaults to httpclient.DefaultMaxResponseBytes.
+func WithMaxResponseBytes(n int64) SupermicroXOption {
+	return func(s *SupermicroX) {
+		s.httpClientSetupFuncs = append(s.httpClientSetupFuncs, httpclient.WithMaxResponseBytes(n))
+	}
+}
+
+// WithRequestLogging logs the method, host, path, status and duration of each HTTP request
+// made to the BMC at V(1) with the logger of the SupermicroX, lighter than the dumps logged at V(2).
+func WithRequestLogging() SupermicroXOption {
+	return func(s *SupermicroX) {
+		s.httpClientSetupFuncs = append(s.httpClientSetupFuncs, httpclient.WithRequestLogging(s.log))
+	}
+}
+
+// WithTimeout sets the overall timeout of the HTTP requests made to the BMC,
+// it defaults to httpclient.DefaultTimeout.
+func WithTimeout(d time.Duration) SupermicroXOption {
+	return func(s *SupermicroX) {
+		s.httpClientSetupFuncs = append(s.httpClientSetupFuncs, httpclient.WithTimeout(d))
+	}
+}
+
+// WithProxy routes the HTTP requests made to the BMC through the given proxy,
+// by default the proxy settings of the environment are honored.
+func WithProxy(proxyURL string) SupermicroXOption {
+	return func(s *SupermicroX) {
+		s.httpClientSetupFuncs = append(s.httpClientSetupFuncs, httpclient.WithProxy(proxyURL))
+	}
+}
+
+// WithRetry retries the idempotent HTTP requests made to the BMC on transient failures,
+// making up to attempts attempts with an exponential backoff.
+func WithRetry(attempts int, backoff time.Duration) SupermicroXOption {
+	return func(s *SupermicroX) {
+		s.httpClientSetupFuncs = append(s.httpClientSetupFuncs, httpclient.WithRetry(attempts, backoff))
+	}
+}
+
+// WithRateLimit paces the HTTP requests made to the BMC to requestsPerSecond,
+// allowing bursts of up to burst requests.
+func WithRateLimit(requestsPerSecond float64, burst int) SupermicroXOption {
+	return func(s *SupermicroX) {
+		s.httpClientSetupFuncs = append(s.httpClientSetupFuncs, httpclient.WithRateLimit(requestsPerSecond, burst))
+	}
+}
+
+// WithPasswordPolicy sets the policy the passwords are checked against before they're sent to the BMC,
+// devices.DefaultPasswordPolicy() by default.
+func WithPasswordPolicy(policy devices.PasswordPolicy) SupermicroXOption {
+	return func(s *SupermicroX) {
+		s.passwordPolicy = policy
+	}
+}
+
+// New returns a new SupermicroX instance ready to be used
+func New(ctx context.Context, ip string, username string, password string, log logr.Logger) (sm *SupermicroX, err error) {
+	return NewWithOptions(ctx, ip, username, password, log)
+}
+
+// NewWithOptions returns a new SupermicroX with options ready to be used
+func NewWithOptions(ctx context.Context, ip string, username string, password string, log logr.Logger, opts ...SupermicroXOption) (*SupermicroX, error) {
+	sm := &SupermicroX{
+		ip:             ip,
+		username:       username,
+		password:       password,
+		ctx:            ctx,
+		log:            log,
+		sessionAuth:    httpclient.HeaderAuth{Name: sessionHeader},
+		passwordPolicy: devices.DefaultPasswordPolicy(),
+	}
+	for _, opt := range opts {
+		opt(sm)
+	}
+	return sm, nil
+}
+
+// CheckCredentials verify whether the credentials are valid or not
+func (s *SupermicroX) CheckCredentials() (err error) {
+	defer s.wrapError("CheckCredentials", &err)
+
+	return s.httpLogin()
+}
+
+// wrapError attaches the bmc identity to the error returned by a public method,
+// it's meant to be deferred with the named error result.
+func (s *SupermicroX) wrapError(operation string, err *error) {
+	*err = errors.NewBMCError(BmcType, s.ip, operation, *err)
+}
+
+// getJSON calls a given Redfish endpoint of the bmc and decodes the answer into v
+func (s *SupermicroX) getJSON(endpoint string, v interface{}) (err error) {
+	payload, err := s.get(endpoint)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(payload, v)
+}
+
+// member returns the first member of a Redfish collection, the X12 and X13 bmcs list a single
+// system and manager and the board as the first chassis. The member is kept in path once resolved.
+func (s *SupermicroX) member(collection string, path *string) (string, error) {
+	if *path != "" {
+		return *path, nil
+	}
+
+	members, err := s.members(collection)
+	if err != nil {
+		return "", err
+	}
+
+	if len(members) == 0 || members[0].ODataID == "" {
+		return "", fmt.Errorf("%s lists no member", collection)
+	}
+
+	*path = members[0].ODataID
+	return *path, nil
+}
+
+// systemURI returns the location of the Redfish ComputerSystem of the server
+func (s *SupermicroX) systemURI() (string, error) {
+	return s.member(systemsURI, &s.systemPath)
+}
+
+// managerURI returns the location of the Redfish Manager of the bmc
+func (s *SupermicroX) managerURI() (string, error) {
+	return s.member(managersURI, &s.managerPath)
+}
+
+// chassisURI returns the location of the Redfish Chassis of the board
+func (s *SupermicroX) chassisURI() (string, error) {
+	return s.member(chassisURI, &s.chassisPath)
+}
+
+// system returns the Redfish ComputerSystem of the server
+func (s *SupermicroX) system() (system *System, err error) {
+	uri, err := s.systemURI()
+	if err != nil {
+		return nil, err
+	}
+
+	system = &System{}
+	return system, s.getJSON(uri, system)
+}
+
+// chassis returns the Redfish Chassis of the board
+func (s *SupermicroX) chassis() (chassis *Chassis, err error) {
+	uri, err := s.chassisURI()
+	if err != nil {
+		return nil, err
+	}
+
+	chassis = &Chassis{}
+	return chassis, s.getJSON(uri, chassis)
+}
+
+// manager returns the Redfish Manager of the bmc
+func (s *SupermicroX) manager() (manager *Manager, err error) {
+	uri, err := s.managerURI()
+	if err != nil {
+		return nil, err
+	}
+
+	manager = &Manager{}
+	return manager, s.getJSON(uri, manager)
+}
+
+// power returns the Redfish Power resource of the chassis
+func (s *SupermicroX) power() (power *Power, err error) {
+	uri, err := s.chassisURI()
+	if err != nil {
+		return nil, err
+	}
+
+	power = &Power{}
+	return power, s.getJSON(uri+"/Power", power)
+}
+
+// thermal returns the Redfish Thermal resource of the chassis
+func (s *SupermicroX) thermal() (thermal *Thermal, err error) {
+	uri, err := s.chassisURI()
+	if err != nil {
+		return nil, err
+	}
+
+	thermal = &Thermal{}
+	return thermal, s.getJSON(uri+"/Thermal", thermal)
+}
+
+// members returns the links of the members of a Redfish collection
+func (s *SupermicroX) members(endpoint string) (members []Link, err error) {
+	collection := &Collection{}
+	err = s.getJSON(endpoint, collection)
+	if err != nil {
+		return members, err
+	}
+
+	return collection.Members, nil
+}
+
+// processors returns the Redfish Processors of the server, the accelerators listed along are left out
+func (s *SupermicroX) processors() (processors []*Processor, err error) {
+	uri, err := s.systemURI()
+	if err != nil {
+		return processors, err
+	}
+
+	members, err := s.members(uri + "/Processors")
+	if err != nil {
+		return processors, err
+	}
+
+	for _, member := range members {
+		processor := &Processor{}
+		err = s.getJSON(member.ODataID, processor)
+		if err != nil {
+			return processors, err
+		}
+
+		// the empty sockets are listed with an Absent state
+		if processor.Status.State == "Absent" || (processor.ProcessorType != "" && processor.ProcessorType != "CPU") {
+			continue
+		}
+		processors = append(processors, processor)
+	}
+
+	return processors, nil
+}
+
+// Serial returns the device serial
+func (s *SupermicroX) Serial() (serial string, err error) {
+	system, err := s.system()
+	if err != nil {
+		return serial, err
+	}
+
+	if system.SerialNumber == "" {
+		return serial, errors.ErrInvalidSerial
+	}
+
+	return devices.NormalizeSerial(system.SerialNumber), nil
+}
+
+// ChassisSerial returns the serial number of the chassis where the blade is attached
+func (s *SupermicroX) ChassisSerial() (serial string, err error) {
+	chassis, err := s.chassis()
+	if err != nil {
+		return serial, err
+	}
+
+	return devices.NormalizeSerial(chassis.SerialNumber), nil
+}
+
+// HardwareType returns the type of bmc we are talking to
+func (s *SupermicroX) HardwareType() (bmcType string) {
+	return BmcType
+}
+
+// Model returns the model of the board, the system model is often just Super Server
+func (s *SupermicroX) Model() (model string, err error) {
+	chassis, err := s.chassis()
+	if err != nil {
+		return model, err
+	}
+
+	return chassis.Model, nil
+}
+
+// Generation returns the generation of the board, X12 or X13, or an empty string for the other boards
+func (s *SupermicroX) Generation() (gen string, err error) {
+	model, err := s.Model()
+	if err != nil {
+		return gen, err
+	}
+
+	return generation(model), nil
+}
+
+// Version returns the version of the bmc we are running
+func (s *SupermicroX) Version() (bmcVersion string, err error) {
+	manager, err := s.manager()
+	if err != nil {
+		return bmcVersion, err
+	}
+
+	return manager.FirmwareVersion, nil
+}
+
+// Name returns the hostname of the machine
+func (s *SupermicroX) Name() (name string, err error) {
+	system, err := s.system()
+	if err != nil {
+		return name, err
+	}
+
+	return system.HostName, nil
+}
+
+// Status returns health string status from the bmc
+func (s *SupermicroX) Status() (health string, err error) {
+	system, err := s.system()
+	if err != nil {
+		return health, err
+	}
+
+	return system.Status.Health, nil
+}
+
+// Health returns the normalized health from the bmc
+func (s *SupermicroX) Health() (health devices.Health, err error) {
+	status, err := s.Status()
+	if err != nil {
+		return devices.HealthUnknown, err
+	}
+
+	return devices.NormalizeHealth(status), err
+}
+
+// Memory returns the total amount of memory of the server
+func (s *SupermicroX) Memory() (mem int, err error) {
+	system, err := s.system()
+	if err != nil {
+		return mem, err
+	}
+
+	return int(system.MemorySummary.TotalSystemMemoryGiB), nil
+}
+
+// CPU returns the cpu, cores and hyperthreads of the server
+func (s *SupermicroX) CPU() (cpu string, cpuCount int, coreCount int, hyperthreadCount int, err error) {
+	processors, err := s.processors()
+	if err != nil {
+		return "", 0, 0, 0, err
+	}
+
+	if len(processors) == 0 {
+		return "", 0, 0, 0, nil
+	}
+
+	entry := processors[0]
+	return httpclient.StandardizeProcessorName(entry.Model), len(processors), entry.TotalCores, entry.TotalThreads, nil
+}
+
+// CPUs returns the processors installed on the server
+func (s *SupermicroX) CPUs() (cpus []*devices.CPU, err error) {
+	processors, err := s.processors()
+	if err != nil {
+		return cpus, err
+	}
+
+	for _, processor := range processors {
+		cpus = append(cpus, &devices.CPU{
+			ID:           processor.ID,
+			Vendor:       processor.Manufacturer,
+			Model:        httpclient.StandardizeProcessorName(processor.Model),
+			Slot:         processor.Socket,
+			ClockSpeedHz: processor.MaxSpeedMHz * 1000 * 1000,
+			Cores:        processor.TotalCores,
+			Threads:      processor.TotalThreads,
+			Status:       &devices.Status{Health: processor.Status.Health, State: processor.Status.State},
+		})
+	}
+
+	return cpus, nil
+}
+
+// BiosVersion returns the current version of the bios
+func (s *SupermicroX) BiosVersion() (version string, err error) {
+	system, err := s.system()
+	if err != nil {
+		return version, err
+	}
+
+	return system.BiosVersion, nil
+}
+
+// PowerKw returns the current power usage in Kw
+func (s *SupermicroX) PowerKw() (power float64, err error) {
+	p, err := s.power()
+	if err != nil {
+		return power, err
+	}
+
+	for _, control := range p.PowerControl {
+		power += control.PowerConsumedWatts / 1000.00
+	}
+
+	return power, nil
+}
+
+// TempC returns the current inlet temperature of the machine
+func (s *SupermicroX) TempC() (temp int, err error) {
+	thermal, err := s.thermal()
+	if err != nil {
+		return temp, err
+	}
+
+	for _, t := range thermal.Temperatures {
+		if t.PhysicalContext == "Intake" || strings.Contains(strings.ToLower(t.Name), "inlet") {
+			return int(t.ReadingCelsius), nil
+		}
+	}
+
+	return temp, nil
+}
+
+// Psus returns a list of psus installed on the device
+func (s *SupermicroX) Psus() (psus []*devices.Psu, err error) {
+	serial, err := s.Serial()
+	if err != nil {
+		return psus, err
+	}
+
+	p, err := s.power()
+	if err != nil {
+		return psus, err
+	}
+
+	for _, psu := range p.PowerSupplies {
+		// the empty bays are listed with an Absent state
+		if psu.Status.State == "Absent" {
+			continue
+		}
+
+		psuSerial := devices.NormalizeSerial(psu.SerialNumber)
+		if psuSerial == "" {
+			psuSerial = fmt.Sprintf("%s_%s", serial, strings.ToLower(psu.MemberID))
+		}
+
+		psus = append(psus, &devices.Psu{
+			Serial:     psuSerial,
+			CapacityKw: psu.PowerCapacityWatts / 1000.00,
+			PowerKw:    psu.PowerOutputWatts / 1000.00,
+			Status:     psu.Status.Health,
+			PartNumber: devices.NormalizeSerial(psu.PartNumber),
+			Position:   len(psus) + 1,
+		})
+	}
+
+	return psus, nil
+}
+
+// IsBlade returns if the current hardware is a blade or not
+func (s *SupermicroX) IsBlade() (isBlade bool, err error) {
+	chassis, err := s.chassis()
+	if err != nil {
+		return isBlade, err
+	}
+
+	return chassis.ChassisType == "Blade", nil
+}
+
+// Slot returns the current slot within the chassis, the Redfish service doesn't expose it
+func (s *SupermicroX) Slot() (slot int, err error) {
+	return -1, nil
+}
+
+// Nics returns all found Nics in the device, the nic of the bmc first
+func (s *SupermicroX) Nics() (nics []*devices.Nic, err error) {
+	managerURI, err := s.managerURI()
+	if err != nil {
+		return nics, err
+	}
+
+	members, err := s.members(managerURI + "/EthernetInterfaces")
+	if err != nil {
+		return nics, err
+	}
+
+	for _, member := range members {
+		iface := &EthernetInterface{}
+		err = s.getJSON(member.ODataID, iface)
+		if err != nil {
+			return nics, err
+		}
+
+		nics = append(nics, &devices.Nic{
+			Name:       "bmc",
+			MacAddress: strings.ToLower(iface.MACAddress),
+			Up:         iface.LinkStatus == "LinkUp",
+			SpeedMbps:  iface.SpeedMbps,
+			MTU:        iface.MTUSize,
+			BMC:        true,
+		})
+	}
+
+	systemURI, err := s.systemURI()
+	if err != nil {
+		return nics, err
+	}
+
+	members, err = s.members(systemURI + "/EthernetInterfaces")
+	if err != nil {
+		return nics, err
+	}
+
+	for _, member := range members {
+		iface := &EthernetInterface{}
+		err = s.getJSON(member.ODataID, iface)
+		if err != nil {
+			return nics, err
+		}
+
+		nics = append(nics, &devices.Nic{
+			Name:       iface.ID,
+			MacAddress: strings.ToLower(iface.MACAddress),
+			Up:         iface.LinkStatus == "LinkUp",
+			SpeedMbps:  iface.SpeedMbps,
+			MTU:        iface.MTUSize,
+		})
+	}
+
+	return nics, nil
+}
+
+// Disks returns a list of disks installed on the device, read from the drives of the Redfish storage subsystems
+func (s *SupermicroX) Disks() (disks []*devices.Disk, err error) {
+	uri, err := s.systemURI()
+	if err != nil {
+		return disks, err
+	}
+
+	return supermicro.RedfishDisks(s.getJSON, uri+"/Storage")
+}
+
+// License isn't exposed by the Redfish service of the X12 and X13 bmcs
+func (s *SupermicroX) License() (name string, licType string, err error) {
+	return name, licType, errors.NewFeatureUnsupportedError("license", s.Vendor(), s.HardwareType())
+}
+
+// Screenshot isn't supported on the X12 and X13 bmcs yet
+func (s *SupermicroX) Screenshot() (response []byte, extension string, err error) {
+	return response, extension, errors.NewFeatureUnsupportedError("screenshot", s.Vendor(), s.HardwareType())
+}
+
+// Vendor returns bmc's vendor
+func (s *SupermicroX) Vendor() (vendor string) {
+	return supermicro.VendorID
+}
+
+// ServerSnapshot do best effort to populate the server data and returns a discrete,
+// the license isn't collected as the Redfish service doesn't expose it.
+func (s *SupermicroX) ServerSnapshot() (server interface{}, err error) {
+	defer s.wrapError("ServerSnapshot", &err)
+
+	system, err := s.system()
+	if err != nil {
+		return nil, err
+	}
+
+	discrete := &devices.Discrete{
+		CollectedAt: time.Now().UTC(),
+		Vendor:      s.Vendor(),
+		BmcAddress:  s.ip,
+		BmcType:     s.HardwareType(),
+		BmcAuth:     true,
+		Serial:      devices.NormalizeSerial(system.SerialNumber),
+		Name:        system.HostName,
+		BiosVersion: system.BiosVersion,
+		PowerState:  strings.ToLower(system.PowerState),
+		Status:      system.Status.Health,
+		Memory:      int(system.MemorySummary.TotalSystemMemoryGiB),
+	}
+
+	discrete.Model, err = s.Model()
+	if err != nil {
+		return nil, err
+	}
+	discrete.BmcVersion, err = s.Version()
+	if err != nil {
+		return nil, err
+	}
+	discrete.Nics, err = s.Nics()
+	if err != nil {
+		return nil, err
+	}
+	discrete.Disks, err = s.Disks()
+	if err != nil {
+		return nil, err
+	}
+	discrete.Processor, discrete.ProcessorCount, discrete.ProcessorCoreCount, discrete.ProcessorThreadCount, err = s.CPU()
+	if err != nil {
+		return nil, err
+	}
+	discrete.CPUs, err = s.CPUs()
+	if err != nil {
+		return nil, err
+	}
+	discrete.PowerKw, err = s.PowerKw()
+	if err != nil {
+		return nil, err
+	}
+	discrete.Psus, err = s.Psus()
+	if err != nil {
+		return nil, err
+	}
+	discrete.TempC, err = s.TempC()
+	if err != nil {
+		return nil, err
+	}
+
+	return discrete, nil
+}
+
+// UpdateCredentials updates login credentials
+func (s *SupermicroX) UpdateCredentials(username string, password string) {
+	s.username = username
+	s.password = password
+}
+
+// GetBIOSVersion returns the BIOS version from the BMC, implements the Firmware interface
+func (s *SupermicroX) GetBIOSVersion(ctx context.Context) (version string, err error) {
+	defer s.wrapError("GetBIOSVersion", &err)
+
+	return s.BiosVersion()
+}
+
+// GetBMCVersion returns the BMC version, implements the Firmware interface
+func (s *SupermicroX) GetBMCVersion(ctx context.Context) (version string, err error) {
+	defer s.wrapError("GetBMCVersion", &err)
+
+	return s.Version()
+}
@@ -0,0 +1,550 @@
+package supermicrox12
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+	"github.com/bombsimon/logrusr/v2"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	testToken   = "2c6e9a3b71d04f85"
+	testSession = "/redfish/v1/SessionService/Sessions/7"
+)
+
+var (
+	mux    *http.ServeMux
+	server *httptest.Server
+	// fixtures maps the Redfish endpoints to the files of the fixtures directory
+	fixtures = map[string]string{
+		"/redfish/v1/Systems":                            "fixtures/systems.json",
+		"/redfish/v1/Managers":                           "fixtures/managers.json",
+		"/redfish/v1/Chassis":                            "fixtures/chassis.json",
+		"/redfish/v1/Systems/1":                          "fixtures/systems.1.json",
+		"/redfish/v1/Chassis/1":                          "fixtures/chassis.1.json",
+		"/redfish/v1/Managers/1":                         "fixtures/managers.1.json",
+		"/redfish/v1/Chassis/1/Power":                    "fixtures/chassis.1.power.json",
+		"/redfish/v1/Chassis/1/Thermal":                  "fixtures/chassis.1.thermal.json",
+		"/redfish/v1/Managers/1/EthernetInterfaces":      "fixtures/managers.1.ethernetinterfaces.json",
+		"/redfish/v1/Managers/1/EthernetInterfaces/1":    "fixtures/managers.1.ethernetinterfaces.1.json",
+		"/redfish/v1/Systems/1/EthernetInterfaces":       "fixtures/systems.1.ethernetinterfaces.json",
+		"/redfish/v1/Systems/1/EthernetInterfaces/1":     "fixtures/systems.1.ethernetinterfaces.1.json",
+		"/redfish/v1/Systems/1/EthernetInterfaces/2":     "fixtures/systems.1.ethernetinterfaces.2.json",
+		"/redfish/v1/Systems/1/Processors":               "fixtures/systems.1.processors.json",
+		"/redfish/v1/Systems/1/Processors/1":             "fixtures/systems.1.processors.1.json",
+		"/redfish/v1/Systems/1/Processors/2":             "fixtures/systems.1.processors.2.json",
+		"/redfish/v1/Systems/1/Storage":                  "fixtures/systems.1.storage.json",
+		"/redfish/v1/Systems/1/Storage/NVMeSSD":          "fixtures/systems.1.storage.nvmessd.json",
+		"/redfish/v1/Systems/1/Storage/NVMeSSD/Drives/0": "fixtures/systems.1.storage.nvmessd.drives.0.json",
+		"/redfish/v1/Systems/1/Storage/NVMeSSD/Drives/1": "fixtures/systems.1.storage.nvmessd.drives.1.json",
+		"/redfish/v1/AccountService/Accounts":            "fixtures/accountservice.accounts.json",
+		"/redfish/v1/AccountService/Accounts/2":          "fixtures/accountservice.accounts.2.json",
+		"/redfish/v1/AccountService/Accounts/3":          "fixtures/accountservice.accounts.3.json",
+	}
+	// sent records the payloads posted or patched to the resources
+	sent = map[string]string{}
+	// sessionDeleted is set once the session has been deleted
+	sessionDeleted bool
+	// sendStatus is the status code answered to the posted or patched payloads
+	sendStatus int
+)
+
+func setup() (s *SupermicroX, err error) {
+	sent = map[string]string{}
+	sessionDeleted = false
+	sendStatus = http.StatusNoContent
+
+	mux = http.NewServeMux()
+	server = httptest.NewTLSServer(mux)
+	ip := strings.TrimPrefix(server.URL, "https://")
+
+	mux.HandleFunc("/redfish/v1/SessionService/Sessions", func(w http.ResponseWriter, r *http.Request) {
+		login := &SessionRequest{}
+		if err := json.NewDecoder(r.Body).Decode(login); err != nil || r.Method != "POST" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if login.UserName != "ADMIN" || login.Password != "Sup3rM1cr0" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("X-Auth-Token", testToken)
+		w.Header().Set("Location", testSession)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	mux.HandleFunc(testSession, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" && r.Header.Get("X-Auth-Token") == testToken {
+			sessionDeleted = true
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("/redfish/v1/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Auth-Token") != testToken {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		if r.Method == "POST" || r.Method == "PATCH" || r.Method == "DELETE" {
+			payload, _ := ioutil.ReadAll(r.Body)
+			sent[r.Method+" "+r.URL.Path] = string(payload)
+			w.WriteHeader(sendStatus)
+			return
+		}
+
+		fixture, ok := fixtures[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		payload, err := ioutil.ReadFile(fixture)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(payload)
+	})
+
+	testLog := logrus.New()
+	return NewWithOptions(context.TODO(), ip, "ADMIN", "Sup3rM1cr0", logrusr.New(testLog), WithInsecureTLS())
+}
+
+func tearDown() {
+	server.Close()
+}
+
+func TestInterfaces(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	_ = devices.Bmc(bmc)
+	_ = devices.Configure(bmc)
+}
+
+func TestServerSnapshot(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	answer, err := bmc.ServerSnapshot()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.ServerSnapshot %v", err)
+	}
+
+	discrete, ok := answer.(*devices.Discrete)
+	if !ok {
+		t.Fatalf("Expected a *devices.Discrete: found %T", answer)
+	}
+
+	cpuStatus := &devices.Status{Health: "OK", State: "Enabled"}
+	expected := &devices.Discrete{
+		Serial:               "s452937x2a18157",
+		Name:                 "x12-test",
+		Vendor:               devices.Supermicro,
+		Model:                "X12DPU-6",
+		BiosVersion:          "1.4a",
+		BmcType:              BmcType,
+		BmcAddress:           bmc.ip,
+		BmcVersion:           "01.01.06",
+		BmcAuth:              true,
+		PowerState:           "on",
+		PowerKw:              0.348,
+		TempC:                24,
+		Status:               "OK",
+		Processor:            "intel(r) xeon(r) gold 6338 cpu",
+		ProcessorCount:       2,
+		ProcessorCoreCount:   32,
+		ProcessorThreadCount: 64,
+		Memory:               512,
+		CPUs: []*devices.CPU{
+			{ID: "1", Vendor: "Intel(R) Corporation", Model: "intel(r) xeon(r) gold 6338 cpu", Slot: "CPU1", ClockSpeedHz: 2000000000, Cores: 32, Threads: 64, Status: cpuStatus},
+			{ID: "2", Vendor: "Intel(R) Corporation", Model: "intel(r) xeon(r) gold 6338 cpu", Slot: "CPU2", ClockSpeedHz: 2000000000, Cores: 32, Threads: 64, Status: cpuStatus},
+		},
+		Nics: []*devices.Nic{
+			{Name: "bmc", MacAddress: "3c:ec:ef:12:34:56", Up: true, SpeedMbps: 1000, MTU: 1500, BMC: true},
+			{Name: "1", MacAddress: "3c:ec:ef:ab:cd:01", Up: true, SpeedMbps: 25000, MTU: 9000},
+			{Name: "2", MacAddress: "3c:ec:ef:ab:cd:02", Up: false, MTU: 1500},
+		},
+		Disks: []*devices.Disk{
+			{Status: "OK", Serial: "s64hne0r812345", Type: "SSD", Size: "3840 GB", Model: "SAMSUNG MZQL23T8HCLS-00A07", Location: "Disk.Bay.0", FwVersion: "GDC5602Q", Interface: "NVMe"},
+			{Status: "Warning", Serial: "s64hne0r812346", Type: "SSD", Size: "3840 GB", Model: "SAMSUNG MZQL23T8HCLS-00A07", Location: "Disk.Bay.1", FwVersion: "GDC5602Q", Interface: "NVMe"},
+		},
+		Psus: []*devices.Psu{
+			{Serial: "p1k0bck12ab3456", CapacityKw: 1, PowerKw: 0.18, Status: "OK", PartNumber: "pws-1k02a-1r", Position: 1},
+			{Serial: "s452937x2a18157_1", CapacityKw: 1, PowerKw: 0.168, Status: "OK", PartNumber: "pws-1k02a-1r", Position: 2},
+		},
+	}
+	expected.CollectedAt = discrete.CollectedAt
+
+	expectedJSON, _ := json.Marshal(expected)
+	answerJSON, _ := json.Marshal(discrete)
+	if string(expectedJSON) != string(answerJSON) {
+		t.Errorf("Expected answer %s: found %s", expectedJSON, answerJSON)
+	}
+}
+
+func TestGeneration(t *testing.T) {
+	tests := []struct {
+		model string
+		want  string
+	}{
+		{"X12DPU-6", X12},
+		{"X12STH-SYS", X12},
+		{"X13SEW-TF", X13},
+		{"X11DPU", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if answer := generation(tt.model); answer != tt.want {
+			t.Errorf("Expected answer %v for %q: found %v", tt.want, tt.model, answer)
+		}
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	answer, err := bmc.Generation()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.Generation %v", err)
+	}
+
+	if answer != X12 {
+		t.Errorf("Expected answer %v: found %v", X12, answer)
+	}
+}
+
+func TestPowerActions(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	tests := []struct {
+		name      string
+		action    func() (bool, error)
+		endpoint  string
+		resetType string
+	}{
+		{"PowerOn", bmc.PowerOn, "POST /redfish/v1/Systems/1/Actions/ComputerSystem.Reset", "On"},
+		{"PowerOff", bmc.PowerOff, "POST /redfish/v1/Systems/1/Actions/ComputerSystem.Reset", "ForceOff"},
+		{"PowerCycle", bmc.PowerCycle, "POST /redfish/v1/Systems/1/Actions/ComputerSystem.Reset", "PowerCycle"},
+		{"PowerReset", bmc.PowerReset, "POST /redfish/v1/Systems/1/Actions/ComputerSystem.Reset", "ForceRestart"},
+		{"PowerCycleBmc", bmc.PowerCycleBmc, "POST /redfish/v1/Managers/1/Actions/Manager.Reset", "GracefulRestart"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := tt.action()
+			if err != nil {
+				t.Fatalf("Found errors calling bmc.%s %v", tt.name, err)
+			}
+			if !status {
+				t.Errorf("Expected answer %v: found %v", true, status)
+			}
+
+			expected := `{"ResetType":"` + tt.resetType + `"}`
+			if sent[tt.endpoint] != expected {
+				t.Errorf("Expected answer %v: found %v", expected, sent[tt.endpoint])
+			}
+		})
+	}
+}
+
+func TestPowerActionStatus(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	// any 2xx is an accepted action, 201 and 202 included
+	for _, status := range []int{http.StatusOK, http.StatusCreated, http.StatusAccepted} {
+		sendStatus = status
+		ok, err := bmc.PowerOn()
+		if err != nil || !ok {
+			t.Errorf("Expected answer %v for a %d: found %v %v", true, status, ok, err)
+		}
+	}
+
+	sendStatus = http.StatusBadRequest
+	_, err = bmc.PowerOn()
+	if err == nil {
+		t.Errorf("Expected an error for a %d", http.StatusBadRequest)
+	}
+}
+
+func TestResolveMembers(t *testing.T) {
+	// some firmware names the members after the board rather than 1
+	collection := t.TempDir() + "/systems.json"
+	err := ioutil.WriteFile(collection, []byte(`{"Members": [{"@odata.id": "/redfish/v1/Systems/Self"}]}`), 0o600)
+	if err != nil {
+		t.Fatalf("Found errors writing the fixture %v", err)
+	}
+
+	defer func(systems string) {
+		fixtures["/redfish/v1/Systems"] = systems
+		delete(fixtures, "/redfish/v1/Systems/Self")
+	}(fixtures["/redfish/v1/Systems"])
+	fixtures["/redfish/v1/Systems"] = collection
+	fixtures["/redfish/v1/Systems/Self"] = fixtures["/redfish/v1/Systems/1"]
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	_, err = bmc.Serial()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.Serial %v", err)
+	}
+
+	_, err = bmc.PowerOn()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.PowerOn %v", err)
+	}
+
+	expected := `{"ResetType":"On"}`
+	if sent["POST /redfish/v1/Systems/Self/Actions/ComputerSystem.Reset"] != expected {
+		t.Errorf("Expected answer %v: found %v", expected, sent)
+	}
+}
+
+func TestPxeOnce(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	status, err := bmc.PxeOnce()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.PxeOnce %v", err)
+	}
+
+	if !status {
+		t.Errorf("Expected answer %v: found %v", true, status)
+	}
+
+	expected := `{"Boot":{"BootSourceOverrideEnabled":"Once","BootSourceOverrideTarget":"Pxe"}}`
+	if sent["PATCH /redfish/v1/Systems/1"] != expected {
+		t.Errorf("Expected answer %v: found %v", expected, sent["PATCH /redfish/v1/Systems/1"])
+	}
+
+	// the fixture reports the server powered on, it's restarted into pxe
+	expected = `{"ResetType":"ForceRestart"}`
+	if sent["POST /redfish/v1/Systems/1/Actions/ComputerSystem.Reset"] != expected {
+		t.Errorf("Expected answer %v: found %v", expected, sent["POST /redfish/v1/Systems/1/Actions/ComputerSystem.Reset"])
+	}
+}
+
+func TestGetFirmwareInventory(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	inventory, err := bmc.GetFirmwareInventory()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.GetFirmwareInventory %v", err)
+	}
+
+	expected := []devices.Firmware{
+		{Component: "BMC", Installed: "01.01.06"},
+		{Component: "BIOS", Installed: "1.4a"},
+	}
+	if !reflect.DeepEqual(inventory, expected) {
+		t.Errorf("Expected answer %v: found %v", expected, inventory)
+	}
+}
+
+func TestLicense(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	_, _, err = bmc.License()
+
+	var featureErr *bmclibErrs.FeatureUnsupportedError
+	if !errors.As(err, &featureErr) {
+		t.Fatalf("Expected a FeatureUnsupportedError: found %v", err)
+	}
+
+	if featureErr.Model != BmcType {
+		t.Errorf("Expected answer %v: found %v", BmcType, featureErr.Model)
+	}
+}
+
+func TestSessionLogout(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	err = bmc.CheckCredentials()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.CheckCredentials %v", err)
+	}
+
+	err = bmc.Close(context.TODO())
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.Close %v", err)
+	}
+
+	if !sessionDeleted {
+		t.Errorf("Expected the session %s to be deleted", testSession)
+	}
+}
+
+func TestLoginUnauthorized(t *testing.T) {
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	bmc.UpdateCredentials("ADMIN", "wrong")
+
+	err = bmc.CheckCredentials()
+	if !errors.Is(err, bmclibErrs.ErrInvalidCredentials) {
+		t.Errorf("Expected answer %v: found %v", bmclibErrs.ErrInvalidCredentials, err)
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	expectedAnswer := []devices.User{
+		{ID: 2, Name: "ADMIN", Role: devices.UserRoleAdmin, Enabled: true},
+		{ID: 3, Name: "monitoring", Role: devices.UserRoleReadOnly, Enabled: true},
+	}
+
+	bmc, err := setup()
+	if err != nil {
+		t.Fatalf("Found errors during the test setup %v", err)
+	}
+	defer tearDown()
+
+	answer, err := bmc.ListUsers()
+	if err != nil {
+		t.Fatalf("Found errors calling bmc.ListUsers %v", err)
+	}
+
+	if !reflect.DeepEqual(answer, expectedAnswer) {
+		t.Errorf("Expected answer %v: found %v", expectedAnswer, answer)
+	}
+}
+
+func TestUserManager(t *testing.T) {
+	tests := []struct {
+		name        string
+		call        func(bmc *SupermicroX) error
+		endpoint    string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name: "create",
+			call: func(bmc *SupermicroX) error {
+				return bmc.CreateUser(devices.User{Name: "operator", Password: "S3cretPass", Role: devices.UserRoleOperator})
+			},
+			endpoint: "POST /redfish/v1/AccountService/Accounts",
+			expected: `{"UserName":"operator","Password":"S3cretPass","RoleId":"Operator","Enabled":true}`,
+		},
+		{
+			name: "create existing",
+			call: func(bmc *SupermicroX) error {
+				return bmc.CreateUser(devices.User{Name: "monitoring", Password: "S3cretPass", Role: devices.UserRoleReadOnly})
+			},
+			expectedErr: bmclibErrs.ErrUserAccountExists,
+		},
+		{
+			name: "create with an OEM role",
+			call: func(bmc *SupermicroX) error {
+				return bmc.CreateUser(devices.User{Name: "operator", Password: "S3cretPass", Role: devices.UserRoleOEM})
+			},
+			expectedErr: bmclibErrs.ErrInvalidUserRole,
+		},
+		{
+			name: "modify",
+			call: func(bmc *SupermicroX) error {
+				return bmc.ModifyUser(devices.User{Name: "monitoring", Role: devices.UserRoleOperator})
+			},
+			endpoint: "PATCH /redfish/v1/AccountService/Accounts/3",
+			expected: `{"RoleId":"Operator"}`,
+		},
+		{
+			name: "modify missing",
+			call: func(bmc *SupermicroX) error {
+				return bmc.ModifyUser(devices.User{Name: "missing", Role: devices.UserRoleAdmin})
+			},
+			expectedErr: bmclibErrs.ErrUserAccountNotFound,
+		},
+		{
+			name:     "delete",
+			call:     func(bmc *SupermicroX) error { return bmc.DeleteUser("monitoring") },
+			endpoint: "DELETE /redfish/v1/AccountService/Accounts/3",
+		},
+		{
+			name:     "change password",
+			call:     func(bmc *SupermicroX) error { return bmc.ChangePassword("monitoring", "N3wSecretPass") },
+			endpoint: "PATCH /redfish/v1/AccountService/Accounts/3",
+			expected: `{"Password":"N3wSecretPass"}`,
+		},
+		{
+			name:        "change password too weak",
+			call:        func(bmc *SupermicroX) error { return bmc.ChangePassword("monitoring", "weak") },
+			expectedErr: bmclibErrs.ErrWeakPassword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bmc, err := setup()
+			if err != nil {
+				t.Fatalf("Found errors during the test setup %v", err)
+			}
+			defer tearDown()
+
+			err = tt.call(bmc)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Expected error %v: found %v", tt.expectedErr, err)
+			}
+
+			if tt.endpoint == "" {
+				if len(sent) != 0 {
+					t.Errorf("Expected nothing sent to the bmc: found %v", sent)
+				}
+				return
+			}
+
+			payload, ok := sent[tt.endpoint]
+			if !ok || payload != tt.expected {
+				t.Errorf("Expected answer %v: found %v", tt.expected, sent)
+			}
+		})
+	}
+}
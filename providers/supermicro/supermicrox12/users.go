@@ -0,0 +1,199 @@
+package supermicrox12
+
+import (
+	"fmt"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/errors"
+)
+
+// accountsURI is the Redfish collection of the user accounts of the bmc
+const accountsURI = "redfish/v1/AccountService/Accounts"
+
+// This ensures the compiler errors if this type is missing
+// a method that should be implemented to satisfy the UserManager interface.
+var _ devices.UserManager = (*SupermicroX)(nil)
+
+// accounts returns the user accounts of the Redfish AccountService in the order the bmc lists them,
+// uris holds the location of each account.
+func (s *SupermicroX) accounts() (uris []string, accounts []*devices.RedfishManagerAccount, err error) {
+	members, err := s.members(accountsURI)
+	if err != nil {
+		return uris, accounts, fmt.Errorf("%w: %s", errors.ErrRetrievingUserAccounts, err)
+	}
+
+	for _, member := range members {
+		account := &devices.RedfishManagerAccount{}
+		err = s.getJSON(member.ODataID, account)
+		if err != nil {
+			return uris, accounts, fmt.Errorf("%w: %s", errors.ErrRetrievingUserAccounts, err)
+		}
+		uris = append(uris, member.ODataID)
+		accounts = append(accounts, account)
+	}
+
+	return uris, accounts, nil
+}
+
+// findUser returns the location of the user account with the given name
+func (s *SupermicroX) findUser(name string) (uri string, err error) {
+	uris, accounts, err := s.accounts()
+	if err != nil {
+		return uri, err
+	}
+
+	for idx, account := range accounts {
+		if account.UserName == name {
+			return uris[idx], nil
+		}
+	}
+
+	return uri, fmt.Errorf("%w: %s", errors.ErrUserAccountNotFound, name)
+}
+
+// ListUsers returns the user accounts configured on the bmc,
+// ListUsers implements the UserManager interface.
+func (s *SupermicroX) ListUsers() (users []devices.User, err error) {
+	defer s.wrapError("ListUsers", &err)
+
+	_, accounts, err := s.accounts()
+	if err != nil {
+		return users, err
+	}
+
+	for _, account := range accounts {
+		if account.UserName == "" {
+			continue
+		}
+		users = append(users, account.ToUser())
+	}
+
+	return users, nil
+}
+
+// CreateUser creates a user account by posting it to the Redfish AccountService,
+// CreateUser implements the UserManager interface.
+func (s *SupermicroX) CreateUser(user devices.User) (err error) {
+	defer s.wrapError("CreateUser", &err)
+
+	if user.Name == "" || user.Password == "" {
+		return errors.ErrUserParamsRequired
+	}
+
+	err = devices.ValidatePassword(user.Password, s.passwordPolicy)
+	if err != nil {
+		return err
+	}
+
+	roleID, ok := devices.RedfishRoleID(user.Role)
+	if !ok {
+		return fmt.Errorf("%w: %s", errors.ErrInvalidUserRole, user.Role)
+	}
+
+	_, accounts, err := s.accounts()
+	if err != nil {
+		return err
+	}
+
+	for _, account := range accounts {
+		if account.UserName == user.Name {
+			return fmt.Errorf("%w: %s", errors.ErrUserAccountExists, user.Name)
+		}
+	}
+
+	_, err = s.post(accountsURI, &devices.RedfishManagerAccount{
+		UserName: user.Name,
+		Password: user.Password,
+		RoleID:   roleID,
+		Enabled:  true,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %s", errors.ErrUserAccountUpdate, err)
+	}
+
+	return nil
+}
+
+// ModifyUser sets the role of an existing user account, its password is changed as well
+// when user.Password is set and kept otherwise, ModifyUser implements the UserManager interface.
+func (s *SupermicroX) ModifyUser(user devices.User) (err error) {
+	defer s.wrapError("ModifyUser", &err)
+
+	if user.Name == "" {
+		return errors.ErrUserParamsRequired
+	}
+
+	if user.Password != "" {
+		err = devices.ValidatePassword(user.Password, s.passwordPolicy)
+		if err != nil {
+			return err
+		}
+	}
+
+	roleID, ok := devices.RedfishRoleID(user.Role)
+	if !ok {
+		return fmt.Errorf("%w: %s", errors.ErrInvalidUserRole, user.Role)
+	}
+
+	uri, err := s.findUser(user.Name)
+	if err != nil {
+		return err
+	}
+
+	update := map[string]interface{}{"RoleId": roleID}
+	if user.Password != "" {
+		update["Password"] = user.Password
+	}
+
+	_, err = s.patch(uri, update)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errors.ErrUserAccountUpdate, err)
+	}
+
+	return nil
+}
+
+// DeleteUser removes a user account from the Redfish AccountService,
+// DeleteUser implements the UserManager interface.
+func (s *SupermicroX) DeleteUser(name string) (err error) {
+	defer s.wrapError("DeleteUser", &err)
+
+	uri, err := s.findUser(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.delete(uri)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errors.ErrUserAccountUpdate, err)
+	}
+
+	return nil
+}
+
+// ChangePassword sets the password of a user account, keeping its role,
+// ChangePassword implements the UserManager interface.
+func (s *SupermicroX) ChangePassword(name string, password string) (err error) {
+	defer s.wrapError("ChangePassword", &err)
+
+	if name == "" || password == "" {
+		return errors.ErrUserParamsRequired
+	}
+
+	err = devices.ValidatePassword(password, s.passwordPolicy)
+	if err != nil {
+		return err
+	}
+
+	uri, err := s.findUser(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.patch(uri, map[string]string{"Password": password})
+	if err != nil {
+		return fmt.Errorf("%w: %s", errors.ErrUserAccountUpdate, err)
+	}
+
+	return nil
+}
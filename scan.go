@@ -0,0 +1,163 @@
+package bmclib
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/bmc-toolbox/bmclib/bmc"
+	"github.com/bmc-toolbox/bmclib/devices"
+	"github.com/bmc-toolbox/bmclib/discover"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+	"github.com/pkg/errors"
+)
+
+// Target is a BMC to snapshot with ScanHosts
+type Target struct {
+	Host     string
+	Username string
+	Password string
+	// Options are passed to discover.ScanAndConnect, the context is set by ScanHosts
+	Options []discover.Option
+	// Provider is an already instantiated provider, used instead of discovering the device
+	Provider interface{}
+}
+
+// Result is the outcome of the snapshot of a Target
+type Result struct {
+	Host string
+	// Snapshot is the *devices.Discrete, *devices.Blade or *devices.Chassis returned by ServerSnapshot,
+	// a provider implementing devices.PartialSnapshotter returns it along with an *errors.MultiError
+	// of the fields it couldn't read.
+	Snapshot interface{}
+	Err      error
+}
+
+// snapshotter is implemented by the devices.Bmc and devices.Cmc providers
+type snapshotter interface {
+	ServerSnapshot() (interface{}, error)
+}
+
+// ScanHosts snapshots the hosts with a pool of concurrency workers and returns a Result per host,
+// in the order of hosts. The hosts left when ctx is done are reported with the context error, which
+// is also returned, and a snapshot still running then is abandoned so it doesn't hold back the caller.
+func ScanHosts(ctx context.Context, hosts []Target, concurrency int) ([]Result, error) {
+	results := make([]Result, len(hosts))
+	err := forEachHost(ctx, len(hosts), concurrency,
+		func(i int) {
+			results[i], _ = scanHost(ctx, hosts[i])
+		},
+		func(i int) {
+			results[i] = Result{Host: hosts[i].Host, Err: ctx.Err()}
+		},
+	)
+
+	return results, err
+}
+
+// forEachHost calls work for the hosts 0 to count-1 from a pool of concurrency workers,
+// the hosts left once ctx is done are passed to skip instead. It returns the context error.
+func forEachHost(ctx context.Context, count, concurrency int, work, skip func(i int)) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				work(i)
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			skip(i)
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// scanHost connects to the target and takes its snapshot, giving up once ctx is done. The snapshot
+// keeps running on the BMC after that, the returned channel is closed once it exits.
+func scanHost(ctx context.Context, target Target) (Result, <-chan struct{}) {
+	done := make(chan Result, 1)
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		snapshot, err := snapshotTarget(ctx, target)
+		done <- Result{Host: target.Host, Snapshot: snapshot, Err: err}
+	}()
+
+	select {
+	case result := <-done:
+		return result, exited
+	case <-ctx.Done():
+		return Result{Host: target.Host, Err: ctx.Err()}, exited
+	}
+}
+
+// snapshotTarget returns the ServerSnapshot of the provider of the target,
+// discovering and closing it when the target has none.
+func snapshotTarget(ctx context.Context, target Target) (snapshot interface{}, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	provider := target.Provider
+	if provider == nil {
+		options := append(append([]discover.Option{}, target.Options...), discover.WithContext(ctx))
+		provider, err = discover.ScanAndConnect(target.Host, target.Username, target.Password, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		// the session is closed even when ctx is done, not to leak it on the BMC
+		switch closer := provider.(type) {
+		case bmc.Closer:
+			defer closer.Close(context.Background()) // nolint
+		case legacyCloser:
+			defer closer.Close() // nolint
+		}
+	}
+
+	if p, ok := provider.(devices.PartialSnapshotter); ok {
+		return partialSnapshot(p)
+	}
+
+	s, ok := provider.(snapshotter)
+	if !ok {
+		return nil, bmclibErrs.ErrProviderImplementation
+	}
+
+	return s.ServerSnapshot()
+}
+
+// partialSnapshot returns the best effort snapshot of the provider with an *errors.MultiError
+// of the fields it couldn't read, in field name order.
+func partialSnapshot(p devices.PartialSnapshotter) (interface{}, error) {
+	snapshot, fieldErrs := p.ServerSnapshotPartial()
+
+	fields := make([]string, 0, len(fieldErrs))
+	for field := range fieldErrs {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	errs := &bmclibErrs.MultiError{}
+	for _, field := range fields {
+		errs.Append(errors.WithMessage(fieldErrs[field], field))
+	}
+
+	return snapshot, errs.ErrorOrNil()
+}
@@ -0,0 +1,143 @@
+package bmclib
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type snapshotTester struct {
+	serial  string
+	delay   time.Duration
+	err     error
+	running *int32
+	peak    *int32
+}
+
+func (s *snapshotTester) ServerSnapshot() (interface{}, error) {
+	if s.running != nil {
+		n := atomic.AddInt32(s.running, 1)
+		defer atomic.AddInt32(s.running, -1)
+		for {
+			peak := atomic.LoadInt32(s.peak)
+			if n <= peak || atomic.CompareAndSwapInt32(s.peak, peak, n) {
+				break
+			}
+		}
+	}
+
+	time.Sleep(s.delay)
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &devices.Discrete{Serial: s.serial}, nil
+}
+
+func TestScanHosts(t *testing.T) {
+	errUnreachable := errors.New("unreachable")
+	var running, peak int32
+
+	hosts := []Target{
+		{Host: "fast1", Provider: &snapshotTester{serial: "fast1", running: &running, peak: &peak}},
+		{Host: "slow", Provider: &snapshotTester{serial: "slow", delay: 200 * time.Millisecond, running: &running, peak: &peak}},
+		{Host: "failing", Provider: &snapshotTester{err: errUnreachable, running: &running, peak: &peak}},
+		{Host: "fast2", Provider: &snapshotTester{serial: "fast2", running: &running, peak: &peak}},
+		{Host: "fast3", Provider: &snapshotTester{serial: "fast3", running: &running, peak: &peak}},
+		{Host: "unsupported", Provider: struct{}{}},
+	}
+
+	start := time.Now()
+	results, err := ScanHosts(context.Background(), hosts, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the slow host ties a single worker, the other one goes through the rest of the hosts
+	assert.Less(t, int64(time.Since(start)), int64(400*time.Millisecond))
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+
+	assert.Equal(t, len(hosts), len(results))
+	for i, result := range results {
+		assert.Equal(t, hosts[i].Host, result.Host)
+	}
+
+	for _, i := range []int{0, 1, 3, 4} {
+		assert.Nil(t, results[i].Err)
+		assert.Equal(t, &devices.Discrete{Serial: hosts[i].Host}, results[i].Snapshot)
+	}
+	assert.ErrorIs(t, results[2].Err, errUnreachable)
+	assert.ErrorIs(t, results[5].Err, bmclibErrs.ErrProviderImplementation)
+}
+
+func TestScanHostsContextDone(t *testing.T) {
+	hosts := []Target{
+		{Host: "fast", Provider: &snapshotTester{serial: "fast"}},
+		{Host: "slow1", Provider: &snapshotTester{serial: "slow1", delay: 5 * time.Second}},
+		{Host: "slow2", Provider: &snapshotTester{serial: "slow2", delay: 5 * time.Second}},
+		{Host: "pending", Provider: &snapshotTester{serial: "pending"}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	results, err := ScanHosts(ctx, hosts, 2)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+
+	assert.Nil(t, results[0].Err)
+	for _, result := range results[1:] {
+		assert.ErrorIs(t, result.Err, context.DeadlineExceeded)
+		assert.Nil(t, result.Snapshot)
+	}
+}
+
+// partialSnapshotTester implements devices.PartialSnapshotter
+type partialSnapshotTester struct {
+	snapshotTester
+	fieldErrs map[string]error
+}
+
+func (p *partialSnapshotTester) ServerSnapshotPartial() (interface{}, map[string]error) {
+	return &devices.Discrete{Serial: p.serial}, p.fieldErrs
+}
+
+func TestScanHostsPartial(t *testing.T) {
+	errLicense := errors.New("license")
+	errPsus := errors.New("psus")
+
+	hosts := []Target{
+		{Host: "partial", Provider: &partialSnapshotTester{
+			snapshotTester: snapshotTester{serial: "partial", err: errLicense},
+			fieldErrs:      map[string]error{"Psus": errPsus, "BmcLicence": errLicense},
+		}},
+		{Host: "clean", Provider: &partialSnapshotTester{snapshotTester: snapshotTester{serial: "clean"}, fieldErrs: map[string]error{}}},
+	}
+
+	results, err := ScanHosts(context.Background(), hosts, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the partial snapshot is preferred, the errors are sorted by field name
+	assert.Equal(t, &devices.Discrete{Serial: "partial"}, results[0].Snapshot)
+	var fieldErrs *bmclibErrs.MultiError
+	if assert.ErrorAs(t, results[0].Err, &fieldErrs) {
+		assert.Equal(t, 2, len(fieldErrs.Errors))
+		assert.EqualError(t, fieldErrs.Errors[0], "BmcLicence: license")
+		assert.ErrorIs(t, fieldErrs.Errors[1], errPsus)
+	}
+
+	assert.Equal(t, &devices.Discrete{Serial: "clean"}, results[1].Snapshot)
+	assert.Nil(t, results[1].Err)
+
+	bulk := newBulkResult(results[0], time.Now())
+	assert.Equal(t, SnapshotPartial, bulk.Status)
+}
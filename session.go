@@ -0,0 +1,195 @@
+package bmclib
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bmc-toolbox/bmclib/bmc"
+	"github.com/bmc-toolbox/bmclib/discover"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+	"github.com/pkg/errors"
+)
+
+// Session is a connection to a single BMC, it logs in once and reuses the session of the provider
+// for every call until Close. The calls are serialized so they don't race on the session.
+type Session struct {
+	Host string
+
+	mu       sync.Mutex
+	provider interface{}
+	closed   bool
+}
+
+// credentialsChecker is implemented by the devices.Bmc and devices.Cmc providers,
+// checking the credentials logs in and keeps the session for the next calls.
+type credentialsChecker interface {
+	CheckCredentials() error
+}
+
+// legacyCloser is implemented by the devices.Cmc providers
+type legacyCloser interface {
+	Close() error
+}
+
+// legacyPowerStateGetter is implemented by the devices.Bmc and devices.Cmc providers
+type legacyPowerStateGetter interface {
+	PowerState() (string, error)
+}
+
+// OpenSession discovers the BMC of host with discover.ScanAndConnect and returns a Session logged in to it.
+func OpenSession(ctx context.Context, host, user, pass string, options ...discover.Option) (*Session, error) {
+	options = append(append([]discover.Option{}, options...), discover.WithContext(ctx))
+	provider, err := discover.ScanAndConnect(host, user, pass, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSession(ctx, host, provider)
+}
+
+// NewSession logs in to the BMC with an already instantiated provider and returns a Session over it,
+// the provider is opened when it implements bmc.Opener, its credentials are checked otherwise.
+func NewSession(ctx context.Context, host string, provider interface{}) (*Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var err error
+	switch p := provider.(type) {
+	case bmc.Opener:
+		err = p.Open(ctx)
+	case credentialsChecker:
+		err = p.CheckCredentials()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Session{Host: host, provider: provider}, nil
+}
+
+// Do calls fn with the provider of the session, fn asserts the capability interface of the devices
+// or bmc packages it requires. No other call is made on the session until fn returns.
+func (s *Session) Do(ctx context.Context, fn func(provider interface{}) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return bmclibErrs.ErrSessionClosed
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return fn(s.provider)
+}
+
+// ServerSnapshot returns the *devices.Discrete, *devices.Blade or *devices.Chassis of the BMC
+func (s *Session) ServerSnapshot(ctx context.Context) (snapshot interface{}, err error) {
+	err = s.Do(ctx, func(provider interface{}) error {
+		p, ok := provider.(snapshotter)
+		if !ok {
+			return errors.Wrapf(bmclibErrs.ErrProviderImplementation, "%T doesn't implement ServerSnapshot", provider)
+		}
+
+		snapshot, err = p.ServerSnapshot()
+		return err
+	})
+
+	return snapshot, err
+}
+
+// PowerState returns the power state of the machine
+func (s *Session) PowerState(ctx context.Context) (state string, err error) {
+	err = s.Do(ctx, func(provider interface{}) error {
+		switch p := provider.(type) {
+		case bmc.PowerStateGetter:
+			state, err = p.PowerStateGet(ctx)
+		case legacyPowerStateGetter:
+			state, err = p.PowerState()
+		default:
+			err = errors.Wrapf(bmclibErrs.ErrProviderImplementation, "%T doesn't implement PowerStateGet", provider)
+		}
+		return err
+	})
+
+	return state, err
+}
+
+// SetPowerState sets the power state of the machine, the states are the ones of bmc.PowerSetter,
+// the providers without it support on, off and cycle.
+func (s *Session) SetPowerState(ctx context.Context, state string) (ok bool, err error) {
+	err = s.Do(ctx, func(provider interface{}) error {
+		if p, isSetter := provider.(bmc.PowerSetter); isSetter {
+			ok, err = p.PowerSet(ctx, state)
+			return err
+		}
+
+		var set func() (bool, error)
+		switch state {
+		case "on":
+			if p, isSetter := provider.(interface{ PowerOn() (bool, error) }); isSetter {
+				set = p.PowerOn
+			}
+		case "off":
+			if p, isSetter := provider.(interface{ PowerOff() (bool, error) }); isSetter {
+				set = p.PowerOff
+			}
+		case "cycle":
+			if p, isSetter := provider.(interface{ PowerCycle() (bool, error) }); isSetter {
+				set = p.PowerCycle
+			}
+		}
+		if set == nil {
+			return errors.Wrapf(bmclibErrs.ErrProviderImplementation, "%T doesn't implement power state %s", provider, state)
+		}
+
+		ok, err = set()
+		return err
+	})
+
+	return ok, err
+}
+
+// SetBootDevice sets the next boot device of the machine, the providers without bmc.BootDeviceSetter
+// only support booting once from pxe.
+func (s *Session) SetBootDevice(ctx context.Context, bootDevice string, setPersistent, efiBoot bool) (ok bool, err error) {
+	err = s.Do(ctx, func(provider interface{}) error {
+		if p, isSetter := provider.(bmc.BootDeviceSetter); isSetter {
+			ok, err = p.BootDeviceSet(ctx, bootDevice, setPersistent, efiBoot)
+			return err
+		}
+
+		p, isSetter := provider.(interface{ PxeOnce() (bool, error) })
+		if !isSetter || bootDevice != "pxe" || setPersistent {
+			return errors.Wrapf(bmclibErrs.ErrProviderImplementation, "%T doesn't implement boot device %s", provider, bootDevice)
+		}
+
+		ok, err = p.PxeOnce()
+		return err
+	})
+
+	return ok, err
+}
+
+// Close logs out of the BMC, the calls made on the session afterwards return errors.ErrSessionClosed.
+// Closing a closed session does nothing.
+func (s *Session) Close(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	switch p := s.provider.(type) {
+	case bmc.Closer:
+		return p.Close(ctx)
+	case legacyCloser:
+		return p.Close()
+	}
+
+	return nil
+}
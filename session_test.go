@@ -0,0 +1,139 @@
+package bmclib
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/bmc-toolbox/bmclib/devices"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// sessionTester is a provider counting its logins, it fails the calls made without a session
+type sessionTester struct {
+	logins   int
+	logouts  int
+	loggedIn bool
+	calls    []string
+}
+
+func (s *sessionTester) login() error {
+	if !s.loggedIn {
+		s.logins++
+		s.loggedIn = true
+	}
+	return nil
+}
+
+func (s *sessionTester) call(name string) error {
+	if !s.loggedIn {
+		return bmclibErrs.ErrSessionExpired
+	}
+	s.calls = append(s.calls, name)
+	return nil
+}
+
+func (s *sessionTester) CheckCredentials() error {
+	return s.login()
+}
+
+func (s *sessionTester) ServerSnapshot() (interface{}, error) {
+	if err := s.call("snapshot"); err != nil {
+		return nil, err
+	}
+	return &devices.Discrete{Serial: "serial"}, nil
+}
+
+func (s *sessionTester) PowerState() (string, error) {
+	return "on", s.call("powerstate")
+}
+
+func (s *sessionTester) PxeOnce() (bool, error) {
+	return true, s.call("pxeonce")
+}
+
+func (s *sessionTester) PowerCycle() (bool, error) {
+	return true, s.call("powercycle")
+}
+
+func (s *sessionTester) Close(ctx context.Context) error {
+	s.logouts++
+	s.loggedIn = false
+	return nil
+}
+
+func TestSession(t *testing.T) {
+	ctx := context.Background()
+	provider := &sessionTester{}
+
+	session, err := NewSession(ctx, "127.0.0.1", provider)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot, err := session.ServerSnapshot(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, &devices.Discrete{Serial: "serial"}, snapshot)
+
+	ok, err := session.SetBootDevice(ctx, "pxe", false, false)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = session.SetPowerState(ctx, "cycle")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	state, err := session.PowerState(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "on", state)
+
+	// the calls are serialized over the session
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := session.ServerSnapshot(ctx)
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	_, err = session.SetBootDevice(ctx, "disk", true, false)
+	assert.ErrorIs(t, err, bmclibErrs.ErrProviderImplementation)
+
+	_, err = session.SetPowerState(ctx, "soft")
+	assert.ErrorIs(t, err, bmclibErrs.ErrProviderImplementation)
+
+	assert.Nil(t, session.Close(ctx))
+	assert.Nil(t, session.Close(ctx))
+
+	_, err = session.ServerSnapshot(ctx)
+	assert.ErrorIs(t, err, bmclibErrs.ErrSessionClosed)
+
+	assert.Equal(t, 1, provider.logins)
+	assert.Equal(t, 1, provider.logouts)
+	assert.Equal(t, []string{"snapshot", "pxeonce", "powercycle", "powerstate"}, provider.calls[:4])
+	assert.Equal(t, 14, len(provider.calls))
+}
+
+func TestSessionLoginFailed(t *testing.T) {
+	errLogin := errors.New("login failed")
+	_, err := NewSession(context.Background(), "127.0.0.1", &openerTester{err: errLogin})
+	assert.ErrorIs(t, err, errLogin)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = NewSession(ctx, "127.0.0.1", &sessionTester{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+type openerTester struct {
+	err error
+}
+
+func (o *openerTester) Open(ctx context.Context) error {
+	return o.err
+}